@@ -0,0 +1,272 @@
+package gogo
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/oculus-core/gogo/internal/component"
+)
+
+var addProjectDir string
+var addForce bool
+var resourceFields string
+var clientSpec string
+var protoSkipGenerate bool
+var tapDir string
+
+// addCmd represents the add command
+var addCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Generate code in an existing project",
+	Long: `Generate components such as CRUD resources, middleware, HTTP clients,
+background jobs, gRPC services, CLI commands, and Homebrew taps for projects
+created by gogo.
+
+Generators refuse to run when their files, declarations, or routes already
+exist, and suggest a free name instead. --force overwrites existing files,
+but never code declared in other files.`,
+	// Once the arguments are valid, an error is about the project rather
+	// than the command line, so it is reported without the usage
+	PersistentPreRun: func(cmd *cobra.Command, _ []string) {
+		cmd.SilenceUsage = true
+	},
+}
+
+// addResourceCmd represents the add resource command
+var addResourceCmd = &cobra.Command{
+	Use:   "resource <name>",
+	Short: "Generate a CRUD resource in an API project",
+	Long: `Generate a model, an in-memory repository, handlers for the list, get,
+create, update, and delete routes with request validation, and handler tests,
+and register the routes in internal/api/server.go. The handlers use Gin, or
+http.ServeMux in projects without Gin.
+
+Fields are given as name:type pairs; supported types are string, int, int64,
+float, bool, and time:
+
+  gogo add resource user --fields "name:string,email:string,age:int"`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		fields, err := component.ParseFields(resourceFields)
+		if err != nil {
+			return err
+		}
+
+		p, err := loadAddProject()
+		if err != nil {
+			return err
+		}
+		result, err := component.AddResource(p, args[0], fields)
+		if err != nil {
+			return err
+		}
+
+		printComponentResult(result)
+		return nil
+	},
+}
+
+// addMiddlewareCmd represents the add middleware command
+var addMiddlewareCmd = &cobra.Command{
+	Use:   "middleware <name>",
+	Short: "Generate a middleware in an API project",
+	Long: `Generate a middleware and its httptest test in internal/middleware, and
+append it to the middleware chain in internal/api/middleware.go. The middleware
+is a gin.HandlerFunc, or a func(http.Handler) http.Handler in projects without
+Gin:
+
+  gogo add middleware audit-log`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		p, err := loadAddProject()
+		if err != nil {
+			return err
+		}
+		result, err := component.AddMiddleware(p, args[0])
+		if err != nil {
+			return err
+		}
+
+		printComponentResult(result)
+		return nil
+	},
+}
+
+// addClientCmd represents the add client command
+var addClientCmd = &cobra.Command{
+	Use:   "client <service>",
+	Short: "Generate a typed HTTP client for a service",
+	Long: `Generate pkg/client/<service> with an HTTP client that supports a base URL,
+an auth header, retries, and contexts, along with httptest tests.
+
+With --openapi, a method is generated for every operation of an OpenAPI 3
+spec and the first server URL becomes DefaultBaseURL:
+
+  gogo add client billing --openapi api/billing.yaml`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		p, err := loadAddProject()
+		if err != nil {
+			return err
+		}
+		result, err := component.AddClient(p, args[0], clientSpec)
+		if err != nil {
+			return err
+		}
+
+		printComponentResult(result)
+		return nil
+	},
+}
+
+// addJobCmd represents the add job command
+var addJobCmd = &cobra.Command{
+	Use:   "job <name>",
+	Short: "Generate a background job",
+	Long: `Generate a job in internal/jobs with a config struct, run metrics, and a
+unit test that uses a fake clock, and add it to the registry in
+internal/jobs/jobs.go. The first job also creates the registry and scheduler:
+
+  gogo add job cleanup-sessions`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		p, err := loadAddProject()
+		if err != nil {
+			return err
+		}
+		result, err := component.AddJob(p, args[0])
+		if err != nil {
+			return err
+		}
+
+		printComponentResult(result)
+		return nil
+	},
+}
+
+// addProtoCmd represents the add proto command
+var addProtoCmd = &cobra.Command{
+	Use:   "proto <service>",
+	Short: "Generate a gRPC service from a new .proto file",
+	Long: `Generate api/proto/<service>/v1/<service>.proto, buf.yaml and buf.gen.yaml when
+missing, a make proto target, a server skeleton in internal/rpc/<service>, and
+a test that calls it over bufconn. The Go stubs are then generated with
+buf generate when buf is installed:
+
+  gogo add proto inventory`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		p, err := loadAddProject()
+		if err != nil {
+			return err
+		}
+		generate := !protoSkipGenerate && !isOffline()
+		result, err := component.AddProto(p, args[0], generate)
+		if err != nil {
+			return err
+		}
+
+		printComponentResult(result)
+		return nil
+	},
+}
+
+// addCommandCmd represents the add command command
+var addCommandCmd = &cobra.Command{
+	Use:   "command <name>",
+	Short: "Generate a subcommand in a CLI project",
+	Long: `Generate a Cobra command and its test in the cmd package of a CLI project, and
+add it to rootCmd in root.go:
+
+  gogo add command serve`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		p, err := loadAddProject()
+		if err != nil {
+			return err
+		}
+		result, err := component.AddCommand(p, args[0])
+		if err != nil {
+			return err
+		}
+
+		printComponentResult(result)
+		return nil
+	},
+}
+
+// addTapCmd represents the add tap command
+var addTapCmd = &cobra.Command{
+	Use:   "tap",
+	Short: "Scaffold a Homebrew tap for a CLI project",
+	Long: `Scaffold a Homebrew tap repository with a formula that installs the
+GoReleaser archives of a CLI project on GitHub, a script and a workflow that
+update the formula to new releases, and a workflow that tests it.
+
+The tap is a separate repository, created next to the project by default:
+
+  gogo add tap --tap-dir ../homebrew-tools`,
+	Args: cobra.NoArgs,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		p, err := loadAddProject()
+		if err != nil {
+			return err
+		}
+		result, err := component.AddTap(p, tapDir)
+		if err != nil {
+			return err
+		}
+
+		printComponentResult(result)
+		return nil
+	},
+}
+
+// loadAddProject loads the project the add commands generate into
+func loadAddProject() (*component.Project, error) {
+	if _, err := loadTemplates(viper.GetString("template")); err != nil {
+		return nil, err
+	}
+	p, err := component.LoadProject(addProjectDir)
+	if err != nil {
+		return nil, err
+	}
+	p.Force = addForce
+	return p, nil
+}
+
+// printComponentResult lists the files a generator changed
+func printComponentResult(result *component.Result) {
+	for _, p := range result.Created {
+		fmt.Printf("  created: %s\n", p)
+	}
+	for _, p := range result.Updated {
+		fmt.Printf("  updated: %s\n", p)
+	}
+	if len(result.Manual) > 0 {
+		fmt.Println("\nTo finish, manually:")
+		for _, step := range result.Manual {
+			fmt.Printf("  - %s\n", step)
+		}
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(addCmd)
+	addCmd.AddCommand(addResourceCmd)
+	addCmd.AddCommand(addMiddlewareCmd)
+	addCmd.AddCommand(addClientCmd)
+	addCmd.AddCommand(addJobCmd)
+	addCmd.AddCommand(addProtoCmd)
+	addCmd.AddCommand(addCommandCmd)
+	addCmd.AddCommand(addTapCmd)
+
+	addCmd.PersistentFlags().StringVarP(&addProjectDir, "dir", "d", ".", "project directory")
+	addCmd.PersistentFlags().BoolVar(&addForce, "force", false, "overwrite generated files that already exist")
+	addResourceCmd.Flags().StringVar(&resourceFields, "fields", "", `resource fields, e.g. "name:string,age:int"`)
+	addProtoCmd.Flags().BoolVar(&protoSkipGenerate, "skip-generate", false, "do not run buf generate")
+	addClientCmd.Flags().StringVar(&clientSpec, "openapi", "", "OpenAPI 3 spec (YAML or JSON) to generate operation methods from")
+	addTapCmd.Flags().StringVar(&tapDir, "tap-dir", "", "directory of the tap repository, named homebrew-<tap> (default ../homebrew-tap)")
+}
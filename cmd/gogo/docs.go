@@ -0,0 +1,67 @@
+package gogo
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/oculus-core/gogo/internal/feature"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+var docsEngine string
+var docsForce bool
+
+// docsCmd represents the docs command
+var docsCmd = &cobra.Command{
+	Use:   "docs [project-dir]",
+	Short: "Add a documentation site to a generated project",
+	Long: `Add a documentation site skeleton to a generated project: index and getting
+started pages, make docs-serve and docs-build targets, and a workflow that
+deploys the site to GitHub Pages. The navigation lists the starter docs of
+use_docs and the decision records of use_adr.
+
+The site uses MkDocs with the Material theme, or Hugo with --engine hugo.
+gogo.yaml records the choice as docs_site. Existing files with different
+content are not overwritten unless --force is given.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		if docsEngine == config.DocsSiteNone || !config.IsValidDocsSite(docsEngine) {
+			return fmt.Errorf("unknown docs engine %q: use %s or %s", docsEngine, config.DocsSiteMkDocs, config.DocsSiteHugo)
+		}
+
+		projectDir := "."
+		if len(args) > 0 {
+			projectDir = args[0]
+		}
+
+		f, err := feature.Lookup("docs-site")
+		if err != nil {
+			return err
+		}
+		// Turn the site on with the chosen engine
+		site := *f
+		site.Set = func(cfg *config.ProjectConfig, _ bool) { cfg.DocsSite = docsEngine }
+
+		result, err := feature.Enable(projectDir, &site, docsForce)
+		if err != nil {
+			return err
+		}
+
+		for _, p := range result.Written {
+			fmt.Printf("  created:  %s\n", p)
+		}
+		for _, t := range result.MakeTargets {
+			fmt.Printf("  added:    Makefile target %s\n", t)
+		}
+		fmt.Printf("Added a %s documentation site and updated gogo.yaml\n", docsEngine)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(docsCmd)
+
+	docsCmd.Flags().StringVarP(&docsEngine, "engine", "e", config.DocsSiteMkDocs, "site generator: mkdocs or hugo")
+	docsCmd.Flags().BoolVarP(&docsForce, "force", "f", false, "overwrite existing files")
+}
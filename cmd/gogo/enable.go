@@ -0,0 +1,73 @@
+package gogo
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/oculus-core/gogo/internal/feature"
+)
+
+var enableForce bool
+var disableForce bool
+
+// enableCmd represents the enable command
+var enableCmd = &cobra.Command{
+	Use:   "enable <feature> [project-dir]",
+	Short: "Turn on a feature in a generated project",
+	Long: `Turn a feature on in gogo.yaml and write the files gogo generates for it,
+including its Makefile targets.
+
+Existing files with different content are not overwritten unless --force is given.
+
+Features: ` + strings.Join(feature.Names(), ", "),
+	Args:      cobra.RangeArgs(1, 2),
+	ValidArgs: feature.Names(),
+	RunE: func(_ *cobra.Command, args []string) error {
+		f, err := feature.Lookup(args[0])
+		if err != nil {
+			return err
+		}
+
+		result, err := feature.Enable(featureProjectDir(args), f, enableForce)
+		if err != nil {
+			return err
+		}
+
+		for _, p := range result.Written {
+			fmt.Printf("  created:  %s\n", p)
+		}
+		for _, t := range result.MakeTargets {
+			fmt.Printf("  added:    Makefile target %s\n", t)
+		}
+		for _, p := range result.Updated {
+			fmt.Printf("  updated:  %s\n", p)
+		}
+		fmt.Printf("Enabled %s and updated gogo.yaml\n", f.Name)
+		return nil
+	},
+}
+
+// disableCmd represents the disable command
+var disableCmd = &cobra.Command{
+	Use:   "disable <feature> [project-dir]",
+	Short: "Turn off a feature in a generated project",
+	Long: `Turn a feature off in gogo.yaml and remove its generated files and
+Makefile targets. This is the same as gogo remove.
+
+Features: ` + strings.Join(feature.Names(), ", "),
+	Args:      cobra.RangeArgs(1, 2),
+	ValidArgs: feature.Names(),
+	RunE: func(_ *cobra.Command, args []string) error {
+		return removeFeature(args, disableForce)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(enableCmd)
+	rootCmd.AddCommand(disableCmd)
+
+	enableCmd.Flags().BoolVarP(&enableForce, "force", "f", false, "overwrite existing files")
+	disableCmd.Flags().BoolVarP(&disableForce, "force", "f", false, "remove files even if they have been modified")
+}
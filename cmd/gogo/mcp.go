@@ -0,0 +1,60 @@
+package gogo
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/oculus-core/gogo/internal/mcp"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// mcpCmd represents the mcp command
+var mcpCmd = &cobra.Command{
+	Use:   "mcp",
+	Short: "Run a Model Context Protocol server on stdio",
+	Long: `Run gogo as a Model Context Protocol (MCP) server over stdin/stdout so
+IDE assistants can scaffold projects through gogo.
+
+Tools:
+  list_project_types  list the available project types
+  validate_config     validate a configuration against the policy
+  generate_project    generate a project and list the created files
+  add_component       add a component to a project, like gogo add
+
+Register it with an MCP client, for example:
+
+  {"mcpServers": {"gogo": {"command": "gogo", "args": ["mcp"]}}}`,
+	Args: cobra.NoArgs,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		pol, err := loadPolicy()
+		if err != nil {
+			return fmt.Errorf("error loading policy: %w", err)
+		}
+		if _, err := loadTemplates(viper.GetString("template")); err != nil {
+			return err
+		}
+
+		// stdout carries the protocol; warnings go to stderr
+		srv := mcp.New(mcp.Options{
+			Policy:  pol,
+			Version: Version,
+			Offline: isOffline(),
+			OnGenerated: func(cfg *config.ProjectConfig, projectDir string) {
+				if err := recordAudit(cfg, projectDir); err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: failed to write audit record: %v\n", err)
+				}
+				if err := sendNotifications(cfg, projectDir); err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: failed to send notifications: %v\n", err)
+				}
+			},
+		})
+		return srv.Serve(os.Stdin, os.Stdout)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(mcpCmd)
+}
@@ -2,10 +2,18 @@ package gogo
 
 import (
 	"fmt"
+	"os"
+	"os/exec"
+	"path"
 	"path/filepath"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
+	"github.com/oculus-core/gogo/internal/audit"
+	"github.com/oculus-core/gogo/internal/notify"
+	"github.com/oculus-core/gogo/internal/templates"
 	"github.com/oculus-core/gogo/internal/wizard"
 	"github.com/oculus-core/gogo/pkg/config"
 )
@@ -17,6 +25,10 @@ var configFile string
 var appType string
 var useWizard bool
 var moduleName string
+var profileName string
+var openAPISpec string
+var inPlace bool
+var syncName string
 
 // newCmd represents the new command
 var newCmd = &cobra.Command{
@@ -27,14 +39,34 @@ Launches an interactive wizard to configure your project,
 or uses default settings if you skip the wizard.
 
 You can also specify a configuration file with --config
-or a project type with --type (cli, api, library).`,
+or a project type with --type (cli, api, library, github-action, operator, grpc, event-driven, batch, crawler, worker, tui, sdk, script).
+
+An sdk project is a client library generated from the OpenAPI 3 spec given
+with --from-openapi:
+
+  gogo new petstore-go --type sdk --from-openapi petstore.yaml
+
+To scaffold into the current directory, such as a freshly cloned repository,
+instead of a new subdirectory, pass . as the project name or use --in-place.
+The directory must be empty apart from .git. The project and its module are
+named after the origin remote of the repository, or else after the directory:
+
+  git clone git@github.com:acme/billing.git && cd billing
+  gogo new .
+
+Without a project name, gogo new scaffolds in place into the directory given
+with --output, or into the current directory when it is an empty clone.`,
 	Args: cobra.MaximumNArgs(1),
-	Run: func(_ *cobra.Command, args []string) {
+	Run: func(cmd *cobra.Command, args []string) {
 		// Initialize config based on provided options
 		if configFile != "" {
 			// Load config from file
 			var err error
-			projectConfig, err = config.LoadConfigFromFile(configFile)
+			load := config.LoadConfigFromFile
+			if isOffline() {
+				load = config.LoadConfigFromFileCached
+			}
+			projectConfig, err = load(configFile)
 			if err != nil {
 				fmt.Printf("Error loading config file: %v\n", err)
 				return
@@ -42,14 +74,9 @@ or a project type with --type (cli, api, library).`,
 			fmt.Printf("Loaded configuration from %s\n", configFile)
 		} else if appType != "" {
 			// Initialize config based on project type
-			switch appType {
-			case string(config.TypeCLI):
-				projectConfig = config.NewCLIProjectConfig()
-			case string(config.TypeAPI):
-				projectConfig = config.NewAPIProjectConfig()
-			case string(config.TypeLibrary):
-				projectConfig = config.NewLibraryProjectConfig()
-			default:
+			if config.IsValidProjectType(config.ProjectType(appType)) {
+				projectConfig = config.GetProjectConfigForType(config.ProjectType(appType))
+			} else {
 				fmt.Printf("Unknown project type: %s. Using default.\n", appType)
 				projectConfig = config.NewDefaultProjectConfig()
 			}
@@ -59,38 +86,181 @@ or a project type with --type (cli, api, library).`,
 			projectConfig = config.NewDefaultProjectConfig()
 		}
 
+		// Without a project name, the project is generated in place in the
+		// directory given with --output, or in the current directory when it
+		// is an empty clone
+		place := inPlace
+		if len(args) > 0 && args[0] == "." {
+			place, args = true, nil
+		}
+		if len(args) == 0 && !place && (configFile == "" || projectConfig.Name == "") {
+			place = cmd.Flags().Changed("output") || isEmptyClone(outputDir)
+		}
+
+		// In place, the project is generated in the output directory itself
+		// and named after its git remote or the directory unless a name is
+		// given. The module defaults to the remote too.
+		projectDir := ""
+		if place {
+			name, err := inPlaceProject(outputDir)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+			projectDir = outputDir
+			if module := config.ModuleFromRemote(gitRemote(outputDir)); module != "" && configFile == "" {
+				projectConfig.Module = module
+				name = path.Base(module)
+			}
+			if len(args) == 0 {
+				args = []string{name}
+			}
+		}
+
 		// If a project name is provided, use it
 		if len(args) > 0 {
 			projectConfig.Name = args[0]
 		}
+		if moduleName != "" {
+			projectConfig.Module = moduleName
+		}
+		if openAPISpec != "" {
+			projectConfig.OpenAPISpec = openAPISpec
+		}
+
+		// Default the authors to the git user, before the profile, which
+		// only fills in the authors when git has no user
+		if len(projectConfig.Authors) == 0 {
+			projectConfig.Authors = gitAuthors(outputDir)
+		}
+
+		// Fill in the ownership fields from the selected profile
+		rules, err := applyProfile(projectConfig)
+		if err != nil {
+			fmt.Printf("Error applying profile: %v\n", err)
+			return
+		}
+
+		// The template given with --template replaces the one of the
+		// configuration file, which replaces those of GOGO_TEMPLATE and the
+		// template key of the gogo config file
+		if cmd.Flags().Changed("template") || projectConfig.Template == "" {
+			projectConfig.Template = viper.GetString("template")
+		}
+		if projectConfig.Template, err = loadTemplates(projectConfig.Template); err != nil {
+			fmt.Printf("Error loading templates: %v\n", err)
+			return
+		}
+
+		// Load the organization policy
+		pol, err := loadPolicy()
+		if err != nil {
+			fmt.Printf("Error loading policy: %v\n", err)
+			return
+		}
+
+		// Apply the default rules of the profile and policy to the fields
+		// the configuration file leaves out
+		rules = append(rules, pol.DefaultRules()...)
+		var keep map[string]bool
+		if configFile != "" && len(rules) > 0 {
+			if keep, err = config.ConfigFileFields(configFile); err != nil {
+				fmt.Printf("Error loading config file: %v\n", err)
+				return
+			}
+		}
+		defaulted, err := config.ApplyRules(projectConfig, rules, keep)
+		if err != nil {
+			fmt.Printf("Error applying rules: %v\n", err)
+			return
+		}
+		if verbose && len(defaulted) > 0 {
+			fmt.Println("Rules set:", strings.Join(defaulted, ", "))
+		}
+
+		// Enforce values locked by the organization policy
+		changed, err := pol.Apply(projectConfig)
+		if err != nil {
+			fmt.Printf("Error applying policy: %v\n", err)
+			return
+		}
+		for _, field := range changed {
+			v, _ := pol.LockedValue(field)
+			fmt.Printf("Policy %s enforces %s=%v\n", pol.Name, field, v)
+		}
 
 		if !skipWizard {
 			// Run the interactive wizard
-			if err := wizard.RunWizard(projectConfig); err != nil {
+			if err := wizard.RunWizard(projectConfig, pol, rules); err != nil {
 				fmt.Printf("Error in wizard: %v\n", err)
 				return
 			}
 		}
 
+		// Make the name and module ASCII, keeping a unicode name for display
+		name, module := projectConfig.Name, projectConfig.Module
+		if err := projectConfig.NormalizeNames(); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		if projectConfig.Name != name {
+			fmt.Printf("Using project name %s for %q\n", projectConfig.Name, name)
+		}
+		if projectConfig.Module != module {
+			fmt.Printf("Using module %s for %q\n", projectConfig.Module, module)
+		}
+
+		// Reconcile a project name that differs from its module
+		if err := wizard.ResolveNameMismatch(projectConfig, pol, viper.GetString("sync_name"), !skipWizard); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		// Validate the final configuration against the policy
+		if err := pol.Validate(projectConfig); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
 		// Generate the project
-		if err := wizard.GenerateProject(projectConfig, outputDir); err != nil {
+		if projectDir == "" {
+			projectDir = filepath.Join(outputDir, projectConfig.Name)
+		}
+		// buf and kubebuilder may fetch plugins and modules, so they only run online
+		opts := wizard.Options{RunTools: !isOffline()}
+		if err := wizard.GenerateProjectWithOptions(projectConfig, projectDir, opts); err != nil {
 			fmt.Printf("Error generating project: %v\n", err)
 			return
 		}
 
+		// Run the hooks of the user templates that the user confirms, none
+		// without the wizard
+		if err := runTemplateHooks(projectConfig.Template, projectDir); err != nil {
+			fmt.Printf("Warning: %v\n", err)
+		}
+
+		// Record the generation in the audit log if one is configured
+		if err := recordAudit(projectConfig, projectDir); err != nil {
+			fmt.Printf("Warning: failed to write audit record: %v\n", err)
+		}
+
+		// Notify configured webhooks about the new project
+		if err := sendNotifications(projectConfig, projectDir); err != nil {
+			fmt.Printf("Warning: failed to send notifications: %v\n", err)
+		}
+
 		// Get absolute path for display
-		absPath, err := filepath.Abs(outputDir)
+		absPath, err := filepath.Abs(projectDir)
 		if err != nil {
 			// Fallback to the relative path if there's an error
-			absPath = outputDir
+			absPath = projectDir
 		}
 
 		fmt.Printf("\nSuccessfully created project %s in %s\n", projectConfig.Name, absPath)
 		fmt.Println("\nNext steps:")
-		fmt.Println("  1. cd", outputDir)
-		fmt.Println("  2. git init")
-		fmt.Println("  3. go mod tidy")
-		fmt.Println("  4. make build")
+		for i, step := range nextSteps(projectConfig, projectDir) {
+			fmt.Printf("  %d. %s\n", i+1, step)
+		}
 	},
 }
 
@@ -101,7 +271,252 @@ func init() {
 	newCmd.Flags().StringVarP(&outputDir, "output", "o", ".", "output directory for the project")
 	newCmd.Flags().BoolVarP(&skipWizard, "skip-wizard", "s", false, "skip the interactive wizard and use defaults")
 	newCmd.Flags().StringVarP(&configFile, "config", "c", "", "path to configuration file")
-	newCmd.Flags().StringVarP(&appType, "type", "t", "", "project type (cli, api, library)")
+	newCmd.Flags().StringVarP(&appType, "type", "t", "", "project type (cli, api, library, github-action, operator, grpc, event-driven, batch, crawler, worker, tui, sdk, script)")
 	newCmd.Flags().BoolVarP(&useWizard, "wizard", "w", true, "use interactive wizard")
 	newCmd.Flags().StringVarP(&moduleName, "module", "m", "", "Go module name")
+	newCmd.Flags().StringVar(&openAPISpec, "from-openapi", "", "OpenAPI 3 spec (YAML or JSON) to generate an sdk project from")
+	newCmd.Flags().BoolVar(&inPlace, "in-place", false, "generate into the output directory itself, named after it, instead of a subdirectory")
+	newCmd.Flags().StringVar(&syncName, "sync-name", "", "when the project name differs from the end of the module: ask, name (rename the project), module (change the module), or keep (env GOGO_SYNC_NAME)")
+	newCmd.Flags().StringVar(&profileName, "profile", "", "profile from the config file with team, Slack channel, and on-call defaults (env GOGO_PROFILE)")
+
+	_ = viper.BindPFlag("profile", newCmd.Flags().Lookup("profile"))
+	_ = viper.BindEnv("profile", "GOGO_PROFILE")
+	_ = viper.BindPFlag("sync_name", newCmd.Flags().Lookup("sync-name"))
+	_ = viper.BindEnv("sync_name", "GOGO_SYNC_NAME")
+}
+
+// runTemplateHooks runs the hooks of the user templates in dir in the
+// generated project, asking before each one. Without the wizard, no hook runs.
+func runTemplateHooks(dir, projectDir string) error {
+	if dir == "" {
+		return nil
+	}
+	set, err := templates.OpenSet(dir)
+	if err != nil {
+		return err
+	}
+	var confirm templates.ConfirmFunc
+	if !skipWizard {
+		confirm = wizard.ConfirmHook
+	}
+	return templates.RunHooks(set.Manifest.Hooks, projectDir, confirm, os.Stdout)
+}
+
+// inPlaceProject checks that a project can be generated in dir itself and
+// returns the project name derived from it. dir may not exist yet; otherwise
+// it must be empty, apart from the .git directory of a fresh clone.
+func inPlaceProject(dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", dir, err)
+	}
+	name := filepath.Base(abs)
+	if name == string(filepath.Separator) || name == "." {
+		return "", fmt.Errorf("cannot name a project after %s", abs)
+	}
+
+	entries, err := os.ReadDir(abs)
+	if os.IsNotExist(err) {
+		return name, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", abs, err)
+	}
+	var existing []string
+	for _, e := range entries {
+		if e.Name() != ".git" {
+			existing = append(existing, e.Name())
+		}
+	}
+	if len(existing) > 0 {
+		return "", fmt.Errorf("%s is not empty (%s); generate in place only into an empty directory or a fresh clone",
+			abs, strings.Join(existing, ", "))
+	}
+	return name, nil
+}
+
+// isEmptyClone reports whether dir is a git repository without files yet
+func isEmptyClone(dir string) bool {
+	entries, err := os.ReadDir(dir)
+	return err == nil && len(entries) == 1 && entries[0].Name() == ".git"
+}
+
+// gitRemote returns the URL of the origin remote of the repository at the
+// root of dir, or of its only remote, or an empty string. Repositories that
+// merely contain dir are ignored.
+func gitRemote(dir string) string {
+	if _, err := os.Stat(filepath.Join(dir, ".git")); err != nil {
+		return ""
+	}
+	out, err := exec.Command("git", "-C", dir, "remote").Output()
+	if err != nil {
+		return ""
+	}
+	remotes := strings.Fields(string(out))
+	name := ""
+	for _, r := range remotes {
+		if r == "origin" {
+			name = r
+		}
+	}
+	if name == "" && len(remotes) == 1 {
+		name = remotes[0]
+	}
+	if name == "" {
+		return ""
+	}
+	out, err = exec.Command("git", "-C", dir, "remote", "get-url", name).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// gitAuthors returns the git user of dir, or of the working directory when
+// dir does not exist yet, as the only author, or nil when git has no user.
+// The repository config of dir takes precedence over the global one.
+func gitAuthors(dir string) []config.Author {
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		dir = "."
+	}
+	get := func(key string) string {
+		out, err := exec.Command("git", "-C", dir, "config", "--get", key).Output()
+		if err != nil {
+			return ""
+		}
+		return strings.TrimSpace(string(out))
+	}
+	author := config.Author{Name: get("user.name"), Email: get("user.email")}
+	if author.Name == "" && author.Email == "" {
+		return nil
+	}
+	return []config.Author{author}
+}
+
+// nextSteps lists what to do after generating the project in projectDir:
+// entering it, installing the pinned tools and dependencies, generating code,
+// installing the hooks, and building and starting what the project needs
+func nextSteps(cfg *config.ProjectConfig, projectDir string) []string {
+	var steps []string
+	if abs, err := filepath.Abs(projectDir); err != nil || !isWorkingDir(abs) {
+		steps = append(steps, "cd "+projectDir)
+	}
+	if _, err := os.Stat(filepath.Join(projectDir, ".git")); err != nil {
+		steps = append(steps, "git init -b "+cfg.Branch())
+	}
+	switch cfg.ToolVersionManager {
+	case config.ToolVersionAsdf:
+		steps = append(steps, "asdf install")
+	case config.ToolVersionMise:
+		steps = append(steps, "mise install")
+	}
+	// go mod tidy downloads the modules required in go.mod and writes go.sum
+	if len(wizard.Dependencies(cfg)) > 0 {
+		steps = append(steps, "go mod tidy")
+	}
+	// The code of gRPC services is generated from the protos, not committed
+	if cfg.Type == config.TypeGRPC {
+		steps = append(steps, makeOr(cfg, "make proto", "buf dep update && buf generate"))
+	}
+	// .pre-commit-config.yaml may also check commit messages in the commit-msg stage
+	if cfg.ConventionalCommits() {
+		steps = append(steps, "pre-commit install --hook-type pre-commit --hook-type commit-msg")
+	} else if cfg.UsePreCommitHooks {
+		steps = append(steps, "pre-commit install")
+	}
+	if cfg.SecretsManager == config.SecretsManagerSops && cfg.CreateMakefile {
+		steps = append(steps, "make secrets-init")
+	}
+	steps = append(steps, makeOr(cfg, "make build", "go build ./..."))
+	if cfg.Type == config.TypeEventDriven {
+		steps = append(steps, makeOr(cfg, "make up migrate", "docker compose up -d --wait && go run ./"+cfg.LayoutPath("cmd/"+cfg.Name)+" migrate"))
+	}
+	return steps
+}
+
+// makeOr returns the make command when the project has a Makefile and the
+// command it runs otherwise
+func makeOr(cfg *config.ProjectConfig, makeCommand, command string) string {
+	if cfg.CreateMakefile {
+		return makeCommand
+	}
+	return command
+}
+
+// isWorkingDir reports whether dir is the current working directory
+func isWorkingDir(dir string) bool {
+	wd, err := os.Getwd()
+	return err == nil && wd == dir
+}
+
+// applyProfile fills in the empty ownership fields of cfg from the profile
+// selected via --profile, GOGO_PROFILE, or the profile key of the config file,
+// and returns the rules of the profile. Profiles are defined under the
+// profiles key.
+func applyProfile(cfg *config.ProjectConfig) ([]config.Rule, error) {
+	name := viper.GetString("profile")
+	if name == "" {
+		return nil, nil
+	}
+
+	var profiles map[string]config.Profile
+	if err := viper.UnmarshalKey("profiles", &profiles); err != nil {
+		return nil, fmt.Errorf("invalid profiles: %w", err)
+	}
+	p, err := config.ResolveProfile(profiles, name)
+	if err != nil {
+		return nil, err
+	}
+	if err := config.ValidateRules(p.Rules); err != nil {
+		return nil, fmt.Errorf("invalid rules in profile %s: %w", name, err)
+	}
+	p.Apply(cfg)
+	if verbose {
+		fmt.Println("Using profile:", name)
+	}
+	return p.Rules, nil
+}
+
+// recordAudit writes an audit record for a generated project when the audit
+// section of the config file enables a file or webhook destination
+func recordAudit(cfg *config.ProjectConfig, projectDir string) error {
+	var settings audit.Settings
+	if err := viper.UnmarshalKey("audit", &settings); err != nil {
+		return fmt.Errorf("invalid audit settings: %w", err)
+	}
+	if !settings.Enabled() {
+		return nil
+	}
+
+	var skipped error
+	if settings.Webhook != "" && isOffline() {
+		settings.Webhook = ""
+		skipped = fmt.Errorf("audit webhook skipped: %w", errOffline)
+	}
+
+	rec, err := audit.NewRecord(cfg, projectDir, Version)
+	if err != nil {
+		return err
+	}
+	if err := audit.Write(settings, rec); err != nil {
+		return err
+	}
+	return skipped
+}
+
+// sendNotifications posts a project.generated event to the webhooks in the
+// notify section of the config file
+func sendNotifications(cfg *config.ProjectConfig, projectDir string) error {
+	var settings notify.Settings
+	if err := viper.UnmarshalKey("notify", &settings); err != nil {
+		return fmt.Errorf("invalid notify settings: %w", err)
+	}
+	if len(settings.Webhooks) == 0 {
+		return nil
+	}
+	if isOffline() {
+		return fmt.Errorf("%d webhook(s) skipped: %w", len(settings.Webhooks), errOffline)
+	}
+
+	return notify.Send(settings, notify.NewProjectGeneratedEvent(cfg, projectDir, Version))
 }
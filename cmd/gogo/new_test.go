@@ -2,6 +2,7 @@ package gogo
 
 import (
 	"os"
+	"os/exec"
 	"path/filepath"
 	"testing"
 
@@ -135,7 +136,7 @@ func TestNewCommandFlags(t *testing.T) {
 
 			// Create new command for testing
 			cmd := &cobra.Command{Use: "gogo"}
-			config := config.NewDefaultProjectConfig()
+			cfg := config.NewDefaultProjectConfig()
 
 			// In a real test, the addNewCommand function would be called here
 			// For this test, we're simulating the creation of the new command with flags
@@ -145,39 +146,23 @@ func TestNewCommandFlags(t *testing.T) {
 				Args:  cobra.MinimumNArgs(1),
 				Run: func(cmd *cobra.Command, args []string) {
 					if len(args) > 0 {
-						config.Name = args[0]
-						config.Module = "github.com/user/" + config.Name
+						cfg.Name = args[0]
+						cfg.Module = "github.com/user/" + cfg.Name
 					}
 
 					// Update module from flag
 					moduleFlag, _ := cmd.Flags().GetString("module")
 					if moduleFlag != "" {
-						config.Module = moduleFlag
+						cfg.Module = moduleFlag
 					}
 
 					// Update type from flag
 					typeFlag, _ := cmd.Flags().GetString("type")
 					if typeFlag != "" {
-						switch typeFlag {
-						case "cli":
-							config.Type = "cli"
-							config.UseCobra = true
-							config.UseViper = true
-							config.UseGin = false
-							config.UseCmd = true
-						case "api":
-							config.Type = "api"
-							config.UseCobra = false
-							config.UseViper = false
-							config.UseGin = true
-							config.UseCmd = true
-						case "library":
-							config.Type = "library"
-							config.UseCobra = false
-							config.UseViper = false
-							config.UseGin = false
-							config.UseCmd = false
-						default:
+						if projType := config.ProjectType(typeFlag); config.IsValidProjectType(projType) {
+							cfg.Type = projType
+							config.ApplyTypeDefaults(cfg)
+						} else {
 							// This would be handled by the real command with an error
 							cmd.PrintErrf("invalid project type: %s\n", typeFlag)
 						}
@@ -205,15 +190,15 @@ func TestNewCommandFlags(t *testing.T) {
 			// For non-error cases, verify the configuration matches expectations
 			assert.NoError(t, err)
 			if !tc.expectErr {
-				assert.Equal(t, tc.expect.Name, config.Name)
-				assert.Equal(t, tc.expect.Type, config.Type)
-				assert.Equal(t, tc.expect.Module, config.Module)
-				assert.Equal(t, tc.expect.UseCmd, config.UseCmd)
-				assert.Equal(t, tc.expect.UseCobra, config.UseCobra)
-				assert.Equal(t, tc.expect.UseViper, config.UseViper)
-				assert.Equal(t, tc.expect.UseInternal, config.UseInternal)
-				assert.Equal(t, tc.expect.UsePkg, config.UsePkg)
-				assert.Equal(t, tc.expect.UseGin, config.UseGin)
+				assert.Equal(t, tc.expect.Name, cfg.Name)
+				assert.Equal(t, tc.expect.Type, cfg.Type)
+				assert.Equal(t, tc.expect.Module, cfg.Module)
+				assert.Equal(t, tc.expect.UseCmd, cfg.UseCmd)
+				assert.Equal(t, tc.expect.UseCobra, cfg.UseCobra)
+				assert.Equal(t, tc.expect.UseViper, cfg.UseViper)
+				assert.Equal(t, tc.expect.UseInternal, cfg.UseInternal)
+				assert.Equal(t, tc.expect.UsePkg, cfg.UsePkg)
+				assert.Equal(t, tc.expect.UseGin, cfg.UseGin)
 			}
 		})
 	}
@@ -288,3 +273,129 @@ func TestNewCommandConfigGeneration(t *testing.T) {
 	assert.Equal(t, cfg.UsePkg, loadedCfg.UsePkg)
 	assert.Equal(t, cfg.UseGin, loadedCfg.UseGin)
 }
+
+// TestNewOfflineSkipsTools tests that gogo new --offline does not run buf,
+// whose remote plugins need the network
+func TestNewOfflineSkipsTools(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	binDir := t.TempDir()
+	script := "#!/bin/sh\necho \"$@\" >> " + filepath.Join(binDir, "args") + "\n"
+	assert.NoError(t, os.WriteFile(filepath.Join(binDir, "buf"), []byte(script), 0700))
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	dir := t.TempDir()
+	rootCmd.SetArgs([]string{"new", "orders", "--offline", "--skip-wizard", "--type", "grpc", "--output", dir})
+	assert.NoError(t, rootCmd.Execute())
+	assert.FileExists(t, filepath.Join(dir, "orders", "buf.gen.yaml"))
+	assert.NoFileExists(t, filepath.Join(binDir, "args"))
+}
+
+// TestInPlaceProject tests which directories a project can be generated in
+// place in, and the name derived from them
+func TestInPlaceProject(t *testing.T) {
+	clone := filepath.Join(t.TempDir(), "billing")
+	assert.NoError(t, os.MkdirAll(filepath.Join(clone, ".git"), 0755))
+	name, err := inPlaceProject(clone)
+	assert.NoError(t, err)
+	assert.Equal(t, "billing", name)
+
+	// A directory that does not exist yet is created
+	name, err = inPlaceProject(filepath.Join(t.TempDir(), "new-tool"))
+	assert.NoError(t, err)
+	assert.Equal(t, "new-tool", name)
+
+	// Existing files are never overwritten
+	assert.NoError(t, os.WriteFile(filepath.Join(clone, "README.md"), []byte("# billing\n"), 0600))
+	_, err = inPlaceProject(clone)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "is not empty (README.md)")
+}
+
+// TestNextSteps tests that the next steps skip what is already done
+func TestNextSteps(t *testing.T) {
+	hooks := "pre-commit install --hook-type pre-commit --hook-type commit-msg"
+	cfg := config.NewCLIProjectConfig()
+	dir := t.TempDir()
+	assert.Equal(t, []string{"cd " + dir, "git init -b main", "go mod tidy", hooks, "make build"}, nextSteps(cfg, dir))
+
+	// Without dependencies, there is nothing to tidy
+	assert.Equal(t, []string{"cd " + dir, "git init -b main", hooks, "make build"}, nextSteps(config.NewLibraryProjectConfig(), dir))
+
+	// The steps follow the tools and services the project uses
+	grpc := config.GetProjectConfigForType(config.TypeGRPC)
+	grpc.UsePreCommitHooks = false
+	grpc.ToolVersionManager = config.ToolVersionMise
+	assert.Equal(t, []string{"cd " + dir, "git init -b main", "mise install", "go mod tidy", "make proto", "make build"}, nextSteps(grpc, dir))
+	events := config.GetProjectConfigForType(config.TypeEventDriven)
+	events.UsePreCommitHooks = false
+	events.SecretsManager = config.SecretsManagerSops
+	assert.Equal(t, []string{"cd " + dir, "git init -b main", "go mod tidy", "make secrets-init", "make build", "make up migrate"}, nextSteps(events, dir))
+	events.CreateMakefile = false
+	events.SecretsManager = config.SecretsManagerNone
+	assert.Equal(t, []string{"cd " + dir, "git init -b main", "go mod tidy", "go build ./...", "docker compose up -d --wait && go run ./cmd/my-project migrate"}, nextSteps(events, dir))
+
+	oldWd, err := os.Getwd()
+	assert.NoError(t, err)
+	defer func() { _ = os.Chdir(oldWd) }()
+	assert.NoError(t, os.Chdir(dir))
+	wd, err := os.Getwd()
+	assert.NoError(t, err)
+	assert.NoError(t, os.Mkdir(filepath.Join(wd, ".git"), 0755))
+	cfg.CreateMakefile = false
+	cfg.UsePreCommitHooks = false
+	assert.Equal(t, []string{"go mod tidy", "go build ./..."}, nextSteps(cfg, wd))
+}
+
+// TestGitRemote tests that the remote of a clone names its module
+func TestGitRemote(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir := t.TempDir()
+	assert.Equal(t, "", gitRemote(dir))
+
+	git := func(args ...string) {
+		t.Helper()
+		out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput()
+		assert.NoError(t, err, string(out))
+	}
+	git("init", "-q")
+	assert.True(t, isEmptyClone(dir))
+	assert.Equal(t, "", gitRemote(dir))
+
+	git("remote", "add", "upstream", "https://github.com/acme/upstream.git")
+	assert.Equal(t, "https://github.com/acme/upstream.git", gitRemote(dir))
+	git("remote", "add", "origin", "git@github.com:acme/billing.git")
+	assert.Equal(t, "git@github.com:acme/billing.git", gitRemote(dir))
+
+	// The remote of a repository that only contains the directory is not its own
+	sub := filepath.Join(dir, "tools", "rotate-keys")
+	assert.NoError(t, os.MkdirAll(sub, 0755))
+	assert.Equal(t, "", gitRemote(sub))
+	assert.False(t, isEmptyClone(dir))
+}
+
+// TestGitAuthors tests that the git user of the repository is the author
+func TestGitAuthors(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", home)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+
+	dir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput()
+		assert.NoError(t, err, string(out))
+	}
+	git("init", "-q")
+	assert.Nil(t, gitAuthors(dir))
+
+	git("config", "user.name", "Jane Doe")
+	assert.Equal(t, []config.Author{{Name: "Jane Doe"}}, gitAuthors(dir))
+	git("config", "user.email", "jane@acme.test")
+	assert.Equal(t, []config.Author{{Name: "Jane Doe", Email: "jane@acme.test"}}, gitAuthors(dir))
+}
@@ -0,0 +1,69 @@
+package gogo
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/oculus-core/gogo/internal/feature"
+)
+
+var removeForce bool
+
+// removeCmd represents the remove command
+var removeCmd = &cobra.Command{
+	Use:   "remove <feature> [project-dir]",
+	Short: "Remove a feature from a generated project",
+	Long: `Remove the files gogo generated for a feature, strip its Makefile targets,
+and turn it off in gogo.yaml.
+
+Files that have been modified since generation (see gogo status) are not
+removed unless --force is given.
+
+Features: ` + strings.Join(feature.Names(), ", "),
+	Args:      cobra.RangeArgs(1, 2),
+	ValidArgs: feature.Names(),
+	RunE: func(_ *cobra.Command, args []string) error {
+		return removeFeature(args, removeForce)
+	},
+}
+
+// removeFeature removes the feature named by args[0] from the project in args[1]
+func removeFeature(args []string, force bool) error {
+	f, err := feature.Lookup(args[0])
+	if err != nil {
+		return err
+	}
+
+	result, err := feature.Remove(featureProjectDir(args), f, force)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range result.Removed {
+		fmt.Printf("  removed:  %s\n", p)
+	}
+	for _, t := range result.MakeTargets {
+		fmt.Printf("  stripped: Makefile target %s\n", t)
+	}
+	for _, p := range result.Updated {
+		fmt.Printf("  updated:  %s\n", p)
+	}
+	fmt.Printf("Removed %s and updated gogo.yaml\n", f.Name)
+	return nil
+}
+
+// featureProjectDir returns the optional project directory argument of the feature commands
+func featureProjectDir(args []string) string {
+	if len(args) > 1 {
+		return args[1]
+	}
+	return "."
+}
+
+func init() {
+	rootCmd.AddCommand(removeCmd)
+
+	removeCmd.Flags().BoolVarP(&removeForce, "force", "f", false, "remove files even if they have been modified")
+}
@@ -1,15 +1,27 @@
 package gogo
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+
+	"github.com/oculus-core/gogo/internal/policy"
+	"github.com/oculus-core/gogo/internal/templates"
+	"github.com/oculus-core/gogo/internal/templates/builtin"
 )
 
 var cfgFile string
 var verbose bool
+var policySource string
+var offline bool
+var templateSource string
+
+// errOffline is returned when an operation would need the network in offline mode
+var errOffline = errors.New("network access is disabled in offline mode")
 
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
@@ -40,6 +52,16 @@ func init() {
 	// Global flags
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.gogo/config.yaml)")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "enable verbose output")
+	rootCmd.PersistentFlags().StringVar(&policySource, "policy", "", "organization policy file, directory, or git repository (env GOGO_POLICY)")
+	rootCmd.PersistentFlags().StringVar(&templateSource, "template", "", "directory or git repository (host/owner/repo@ref) of templates layered over new projects, whose builtin directory replaces built-in templates (env GOGO_TEMPLATE)")
+	rootCmd.PersistentFlags().BoolVar(&offline, "offline", false, "never access the network; use only cached policies and templates (env GOGO_OFFLINE)")
+
+	_ = viper.BindPFlag("policy", rootCmd.PersistentFlags().Lookup("policy"))
+	_ = viper.BindEnv("policy", "GOGO_POLICY")
+	_ = viper.BindPFlag("offline", rootCmd.PersistentFlags().Lookup("offline"))
+	_ = viper.BindEnv("offline", "GOGO_OFFLINE")
+	_ = viper.BindPFlag("template", rootCmd.PersistentFlags().Lookup("template"))
+	_ = viper.BindEnv("template", "GOGO_TEMPLATE")
 }
 
 // initConfig reads in config file and ENV variables if set.
@@ -67,3 +89,87 @@ func initConfig() {
 		}
 	}
 }
+
+// isOffline reports whether network access is disabled via --offline, GOGO_OFFLINE,
+// or the offline key of the config file
+func isOffline() bool {
+	return viper.GetBool("offline")
+}
+
+// loadTemplates fetches the user templates at source, a directory or a git
+// repository, and loads the exported templates in their builtin directory, if
+// any, in place of the built-in ones. It returns the directory of the user
+// templates, or an empty string without a source.
+func loadTemplates(source string) (string, error) {
+	if source == "" {
+		return "", nil
+	}
+	dir, err := fetchTemplates(source)
+	if err != nil {
+		return "", err
+	}
+	if _, err := templates.OpenSet(dir); err != nil {
+		return "", err
+	}
+
+	overrides := filepath.Join(dir, templates.OverridesDir)
+	if _, err := os.Stat(overrides); err != nil {
+		return dir, nil
+	}
+	if err := builtin.Override(overrides); err != nil {
+		return "", err
+	}
+	if verbose {
+		fmt.Fprintln(os.Stderr, "Using built-in templates from", overrides)
+	}
+	return dir, nil
+}
+
+// fetchTemplates returns the directory of the user templates at source,
+// fetching git repositories into the cache unless gogo is offline
+func fetchTemplates(source string) (string, error) {
+	fetch := templates.Fetch
+	if isOffline() {
+		fetch = templates.FetchCached
+	}
+
+	dir, err := fetch(source)
+	if errors.Is(err, templates.ErrNotCached) {
+		return "", fmt.Errorf("%w; run once without --offline to cache it", err)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if verbose && dir != source {
+		fmt.Fprintln(os.Stderr, "Using templates", source, "from", dir)
+	}
+	return dir, nil
+}
+
+// loadPolicy loads the organization policy configured via --policy, GOGO_POLICY,
+// or the policy key of the config file. It returns nil when no policy is configured.
+func loadPolicy() (*policy.Policy, error) {
+	source := viper.GetString("policy")
+	if source == "" {
+		return nil, nil
+	}
+
+	load := policy.Load
+	if isOffline() {
+		load = policy.LoadCached
+	}
+
+	pol, err := load(source)
+	if errors.Is(err, policy.ErrNotCached) {
+		return nil, fmt.Errorf("%w; run once without --offline to cache it", err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if verbose {
+		fmt.Fprintln(os.Stderr, "Using policy:", pol.Name)
+	}
+	return pol, nil
+}
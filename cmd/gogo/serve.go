@@ -0,0 +1,102 @@
+package gogo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/oculus-core/gogo/internal/server"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+var serveAddr string
+var serveOutputDir string
+var serveGRPC bool
+
+// serveCmd represents the serve command
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve the project generation HTTP API",
+	Long: `Expose project generation as an HTTP service.
+
+POST /v1/projects with a JSON project configuration returns a tar.gz
+archive of the generated project. With --output-dir the project is
+written to that directory instead and its path is returned.
+
+GET /healthz reports whether the server is running.
+
+With --grpc the same operations are also available as the gogo.v1.GogoService
+gRPC service (see api/gogo/v1/gogo.proto) over plaintext HTTP/2 on the same
+address.`,
+	Args: cobra.NoArgs,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		pol, err := loadPolicy()
+		if err != nil {
+			return fmt.Errorf("error loading policy: %w", err)
+		}
+
+		handler := server.New(server.Options{
+			OutputDir: serveOutputDir,
+			Policy:    pol,
+			OnGenerated: func(cfg *config.ProjectConfig, projectDir string) {
+				if err := recordAudit(cfg, projectDir); err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: failed to write audit record: %v\n", err)
+				}
+				if err := sendNotifications(cfg, projectDir); err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: failed to send notifications: %v\n", err)
+				}
+			},
+			EnableGRPC: serveGRPC,
+		})
+
+		srv := &http.Server{
+			Addr:              serveAddr,
+			Handler:           handler,
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+		if serveGRPC {
+			// gRPC clients connect with HTTP/2 without TLS
+			srv.Protocols = new(http.Protocols)
+			srv.Protocols.SetHTTP1(true)
+			srv.Protocols.SetUnencryptedHTTP2(true)
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		errCh := make(chan error, 1)
+		go func() {
+			fmt.Printf("Serving project generation API on %s\n", serveAddr)
+			errCh <- srv.ListenAndServe()
+		}()
+
+		select {
+		case err := <-errCh:
+			if !errors.Is(err, http.ErrServerClosed) {
+				return err
+			}
+			return nil
+		case <-ctx.Done():
+		}
+
+		fmt.Println("Shutting down...")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		return srv.Shutdown(shutdownCtx)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+
+	serveCmd.Flags().StringVar(&serveAddr, "addr", ":8080", "address to listen on")
+	serveCmd.Flags().StringVar(&serveOutputDir, "output-dir", "", "write projects to this directory instead of returning archives")
+	serveCmd.Flags().BoolVar(&serveGRPC, "grpc", false, "also serve the gRPC API over h2c")
+}
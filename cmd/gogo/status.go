@@ -0,0 +1,55 @@
+package gogo
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/oculus-core/gogo/internal/manifest"
+)
+
+// statusCmd represents the status command
+var statusCmd = &cobra.Command{
+	Use:   "status [project-dir]",
+	Short: "Show which generated files have been modified",
+	Long: `Compare the files gogo generated with the hashes recorded in
+.gogo/manifest.json and list the ones that have been modified or deleted.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		projectDir := "."
+		if len(args) > 0 {
+			projectDir = args[0]
+		}
+
+		m, err := manifest.Load(projectDir)
+		if err != nil {
+			return err
+		}
+		statuses, err := m.Status(projectDir)
+		if err != nil {
+			return fmt.Errorf("failed to check files: %w", err)
+		}
+
+		var changed []manifest.FileStatus
+		for _, s := range statuses {
+			if s.State != manifest.Unmodified {
+				changed = append(changed, s)
+			}
+		}
+
+		if len(changed) == 0 {
+			fmt.Printf("All %d generated files are unmodified.\n", len(statuses))
+			return nil
+		}
+
+		fmt.Printf("%d of %d generated files changed:\n\n", len(changed), len(statuses))
+		for _, s := range changed {
+			fmt.Printf("  %-9s %s\n", s.State+":", s.Path)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(statusCmd)
+}
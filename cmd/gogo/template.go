@@ -0,0 +1,142 @@
+package gogo
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/oculus-core/gogo/internal/templates"
+	"github.com/oculus-core/gogo/internal/templates/builtin"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+var templateExportForce bool
+var templateExportType string
+
+// templateCmd represents the template command
+var templateCmd = &cobra.Command{
+	Use:   "template",
+	Short: "Work with project templates",
+	Long:  `Commands for template authors.`,
+}
+
+// templateFunctionsCmd represents the template functions command
+var templateFunctionsCmd = &cobra.Command{
+	Use:   "functions",
+	Short: "List the functions available to templates",
+	Long: `List every function templates can call, grouped by category.
+
+Templates can only call these functions; see docs/templates.md for details.`,
+	Args: cobra.NoArgs,
+	Run: func(_ *cobra.Command, _ []string) {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		category := ""
+		for _, f := range templates.Funcs() {
+			if f.Category != category {
+				if category != "" {
+					fmt.Fprintln(w)
+				}
+				category = f.Category
+				fmt.Fprintf(w, "%s:\n", category)
+			}
+			fmt.Fprintf(w, "  %s\t%s\n", f.Signature, f.Description)
+		}
+		_ = w.Flush()
+	},
+}
+
+// templateExportCmd represents the template export command
+var templateExportCmd = &cobra.Command{
+	Use:   "export [dir]",
+	Short: "Write the built-in templates to a directory",
+	Long: `Write the templates built into gogo to the builtin directory of a template
+directory, gogo-templates by default, to customize them. With --type, only the
+templates of that project type and of the gogo add commands it supports are
+written. The templates keep the names they have in the binary, such as
+builtin/wizard/crawler/main.tmpl.
+
+builtin/builtin.yaml records the template schema version. Edit the templates
+and pass the directory to gogo new or gogo add with --template to use them in
+place of the built-in ones:
+
+  gogo template export --type crawler
+  gogo new --type crawler --template gogo-templates
+
+Nothing is written if any of the files exist, unless --force is given.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		dir := "gogo-templates"
+		if len(args) > 0 {
+			dir = args[0]
+		}
+
+		var dirs []string
+		if templateExportType != "" {
+			t := config.ProjectType(templateExportType)
+			if !config.IsValidProjectType(t) {
+				return fmt.Errorf("unknown project type %q", templateExportType)
+			}
+			dirs = append(append(dirs, commonTemplateDirs...), typeTemplateDirs[t]...)
+		}
+		names, err := builtin.Names(dirs...)
+		if err != nil {
+			return err
+		}
+
+		note := builtin.Note{SchemaVersion: builtin.SchemaVersion, GogoVersion: Version, Type: templateExportType}
+		written, err := builtin.Export(filepath.Join(dir, templates.OverridesDir), names, note, templateExportForce)
+		if err != nil {
+			return err
+		}
+		for _, p := range written {
+			fmt.Printf("  created:  %s\n", p)
+		}
+		fmt.Printf("Exported %d built-in templates with schema version %d to %s\n",
+			len(names), builtin.SchemaVersion, dir)
+		return nil
+	},
+}
+
+// commonTemplateDirs hold the built-in templates every project type uses
+var commonTemplateDirs = []string{
+	"wizard/project", "wizard/testhelpers", "wizard/docssite", "wizard/starterdocs", "wizard/adr",
+	"wizard/secrets", "wizard/ownership", "wizard/crash", "wizard/notifier", "wizard/docker",
+	"wizard/benchmarks", "wizard/examples", "component/client", "component/job", "component/proto",
+}
+
+// typeTemplateDirs hold the built-in templates of each project type, besides
+// the common ones
+var typeTemplateDirs = map[config.ProjectType][]string{
+	config.TypeCLI: {
+		"wizard/plugins", "wizard/selfupdate", "wizard/telemetry", "wizard/configcmd", "wizard/output",
+		"wizard/prompt", "component/command", "component/tap",
+	},
+	config.TypeAPI: {
+		"wizard/httpcache", "wizard/plugins", "wizard/validation", "wizard/pagination", "wizard/tasks",
+		"wizard/configreload", "wizard/pprof", "wizard/livereload", "wizard/featureflags", "wizard/i18n",
+		"wizard/multitenancy", "component/middleware", "component/resource",
+	},
+	config.TypeLibrary:      {"wizard/packagedocs"},
+	config.TypeGitHubAction: {"wizard/githubaction"},
+	config.TypeOperator:     {"wizard/operator"},
+	config.TypeGRPC:         {"wizard/grpc"},
+	config.TypeEventDriven:  {"wizard/eventdriven"},
+	config.TypeBatch:        {"wizard/batchjob"},
+	config.TypeCrawler:      {"wizard/crawler"},
+	config.TypeWorker:       {"wizard/worker"},
+	config.TypeTUI:          {"wizard/tui"},
+	config.TypeSDK:          {"wizard/sdk"},
+	config.TypeScript:       {"wizard/script"},
+}
+
+func init() {
+	rootCmd.AddCommand(templateCmd)
+	templateCmd.AddCommand(templateFunctionsCmd)
+	templateCmd.AddCommand(templateExportCmd)
+
+	templateExportCmd.Flags().StringVarP(&templateExportType, "type", "t", "", "only export the templates of this project type")
+	templateExportCmd.Flags().BoolVarP(&templateExportForce, "force", "f", false, "overwrite existing files")
+}
@@ -0,0 +1,76 @@
+package gogo
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oculus-core/gogo/internal/manifest"
+	"github.com/oculus-core/gogo/internal/templates/builtin"
+)
+
+// readTree returns the files below dir by their slash-separated paths
+func readTree(t *testing.T, dir string) map[string]string {
+	t.Helper()
+	files := map[string]string{}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, path)
+		if d.IsDir() {
+			// Only projects with user templates keep their originals
+			if filepath.ToSlash(rel) == manifest.OriginalsDir {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = string(data)
+		return nil
+	})
+	require.NoError(t, err)
+	return files
+}
+
+// TestTemplateExportRoundTrip checks that exported templates can be passed to
+// gogo new with --template as they are, and generate the same project
+func TestTemplateExportRoundTrip(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Cleanup(func() { _ = builtin.Override() })
+	run := func(args ...string) {
+		t.Helper()
+		rootCmd.SetArgs(args)
+		require.NoError(t, rootCmd.Execute())
+	}
+
+	// The flags of earlier runs keep their values, so the run without
+	// --template comes first
+	dir := t.TempDir()
+	run("new", "svc", "--offline", "--skip-wizard", "--type", "api", "--output", filepath.Join(dir, "builtin"))
+	run("template", "export", filepath.Join(dir, "gogo-templates"))
+	run("new", "svc", "--offline", "--skip-wizard", "--type", "api", "--output", filepath.Join(dir, "exported"),
+		"--template", filepath.Join(dir, "gogo-templates"))
+
+	assert.FileExists(t, filepath.Join(dir, "gogo-templates", "builtin", builtin.NoteFile))
+	want := readTree(t, filepath.Join(dir, "builtin", "svc"))
+	got := readTree(t, filepath.Join(dir, "exported", "svc"))
+	require.NotEmpty(t, want)
+	assert.Equal(t, want, got)
+
+	// Edited templates change the project
+	gitignore := filepath.Join(dir, "gogo-templates", "builtin", "wizard", "project", "gitignore.tmpl")
+	require.NoError(t, os.WriteFile(gitignore, []byte("/edited\n"), 0644))
+	run("new", "svc", "--offline", "--skip-wizard", "--type", "api", "--output", filepath.Join(dir, "edited"),
+		"--template", filepath.Join(dir, "gogo-templates"))
+	edited, err := os.ReadFile(filepath.Join(dir, "edited", "svc", ".gitignore"))
+	require.NoError(t, err)
+	assert.Equal(t, "/edited\n", string(edited))
+}
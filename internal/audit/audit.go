@@ -0,0 +1,140 @@
+package audit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/oculus-core/gogo/internal/notify"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// Settings configures where audit records are written.
+// Both destinations are optional; when neither is set auditing is disabled.
+type Settings struct {
+	// File is a local file that records are appended to as JSON lines
+	File string `mapstructure:"file" yaml:"file"`
+	// Webhook is a URL that each record is POSTed to as JSON
+	Webhook string `mapstructure:"webhook" yaml:"webhook"`
+}
+
+// Enabled reports whether any audit destination is configured
+func (s Settings) Enabled() bool {
+	return s.File != "" || s.Webhook != ""
+}
+
+// Record describes a single project generation
+type Record struct {
+	Timestamp  time.Time `json:"timestamp"`
+	User       string    `json:"user"`
+	Project    string    `json:"project"`
+	Module     string    `json:"module"`
+	Type       string    `json:"type"`
+	ConfigHash string    `json:"config_hash"`
+	OutputPath string    `json:"output_path"`
+	Version    string    `json:"gogo_version"`
+}
+
+// NewRecord builds an audit record for a generated project
+func NewRecord(cfg *config.ProjectConfig, outputPath, version string) (Record, error) {
+	hash, err := ConfigHash(cfg)
+	if err != nil {
+		return Record{}, err
+	}
+
+	if abs, err := filepath.Abs(outputPath); outputPath != "" && err == nil {
+		outputPath = abs
+	}
+
+	return Record{
+		Timestamp:  time.Now().UTC(),
+		User:       currentUser(),
+		Project:    cfg.Name,
+		Module:     cfg.Module,
+		Type:       string(cfg.Type),
+		ConfigHash: hash,
+		OutputPath: outputPath,
+		Version:    version,
+	}, nil
+}
+
+// ConfigHash returns a stable SHA-256 hash of the project configuration
+func ConfigHash(cfg *config.ProjectConfig) (string, error) {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
+
+// Write sends the record to every configured destination
+func Write(settings Settings, rec Record) error {
+	if settings.File != "" {
+		if err := appendToFile(expandHome(settings.File), rec); err != nil {
+			return err
+		}
+	}
+
+	if settings.Webhook != "" {
+		if err := notify.PostJSON(settings.Webhook, nil, rec); err != nil {
+			return fmt.Errorf("failed to send audit record: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// appendToFile appends the record as a single JSON line
+func appendToFile(path string, rec Record) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit record: %w", err)
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return nil
+}
+
+// currentUser returns the name of the user running gogo
+func currentUser() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	if name := os.Getenv("USER"); name != "" {
+		return name
+	}
+	return "unknown"
+}
+
+// expandHome expands a leading ~ to the user's home directory
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~"))
+}
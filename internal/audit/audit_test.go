@@ -0,0 +1,99 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+func TestNewRecord(t *testing.T) {
+	cfg := config.NewCLIProjectConfig()
+	cfg.Name = "audited"
+
+	rec, err := NewRecord(cfg, "out/audited", "v1.2.3")
+	require.NoError(t, err)
+
+	assert.Equal(t, "audited", rec.Project)
+	assert.Equal(t, "cli", rec.Type)
+	assert.Equal(t, "v1.2.3", rec.Version)
+	assert.True(t, filepath.IsAbs(rec.OutputPath))
+	assert.NotEmpty(t, rec.User)
+	assert.Contains(t, rec.ConfigHash, "sha256:")
+
+	// The hash is stable for identical configs and changes with the config
+	again, err := ConfigHash(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, rec.ConfigHash, again)
+
+	cfg.License = "Apache-2.0"
+	changed, err := ConfigHash(cfg)
+	require.NoError(t, err)
+	assert.NotEqual(t, rec.ConfigHash, changed)
+}
+
+func TestWriteFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "audit.log")
+	settings := Settings{File: path}
+	assert.True(t, settings.Enabled())
+
+	for _, name := range []string{"first", "second"} {
+		cfg := config.NewDefaultProjectConfig()
+		cfg.Name = name
+		rec, err := NewRecord(cfg, name, "dev")
+		require.NoError(t, err)
+		require.NoError(t, Write(settings, rec))
+	}
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var projects []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var rec Record
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &rec))
+		projects = append(projects, rec.Project)
+	}
+	assert.Equal(t, []string{"first", "second"}, projects)
+}
+
+func TestWriteWebhook(t *testing.T) {
+	var received Record
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	rec, err := NewRecord(config.NewAPIProjectConfig(), "out", "dev")
+	require.NoError(t, err)
+
+	require.NoError(t, Write(Settings{Webhook: server.URL}, rec))
+	assert.Equal(t, rec.ConfigHash, received.ConfigHash)
+	assert.Equal(t, "api", received.Type)
+
+	// Non-2xx responses are reported as errors
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+
+	assert.Error(t, Write(Settings{Webhook: failing.URL}, rec))
+}
+
+func TestSettingsDisabled(t *testing.T) {
+	assert.False(t, Settings{}.Enabled())
+	assert.NoError(t, Write(Settings{}, Record{}))
+}
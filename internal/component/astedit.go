@@ -0,0 +1,292 @@
+package component
+
+import (
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"strings"
+)
+
+// The registration edits below find their target with go/ast and insert the
+// new code as text at the offset of that node, so comments and the layout of
+// the rest of the file survive. The result is formatted with go/format. An
+// edit reports false when the file does not parse or the target is missing,
+// and the generator then leaves the step to the user.
+
+// goSource is a parsed Go file that edits are applied to
+type goSource struct {
+	src  string
+	fset *token.FileSet
+	file *ast.File
+}
+
+// parseSource parses Go source, reporting false on syntax errors
+func parseSource(src string) (*goSource, bool) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.ParseComments|parser.SkipObjectResolution)
+	if err != nil {
+		return nil, false
+	}
+	return &goSource{src: src, fset: fset, file: file}, true
+}
+
+// offset returns the byte offset of pos in the source
+func (g *goSource) offset(pos token.Pos) int {
+	return g.fset.Position(pos).Offset
+}
+
+// text returns the source of a node
+func (g *goSource) text(n ast.Node) string {
+	return g.src[g.offset(n.Pos()):g.offset(n.End())]
+}
+
+// function returns the declaration of a function, or of a method when recv
+// names its receiver type
+func (g *goSource) function(recv, name string) *ast.FuncDecl {
+	for _, decl := range g.file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if ok && fn.Body != nil && fn.Name.Name == name && receiverType(fn) == recv {
+			return fn
+		}
+	}
+	return nil
+}
+
+// declares reports whether the file declares a top-level name
+func (g *goSource) declares(name string) bool {
+	for _, n := range declarations(g.file) {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// insert adds code at an offset and formats the result
+func (g *goSource) insert(at int, code string) (string, bool) {
+	return g.replace(at, at, code)
+}
+
+// replace replaces the source between two offsets and formats the result
+func (g *goSource) replace(from, to int, code string) (string, bool) {
+	out, err := format.Source([]byte(g.src[:from] + code + g.src[to:]))
+	if err != nil {
+		return g.src, false
+	}
+	return string(out), true
+}
+
+// insertBeforeLine adds code on its own line before the token at pos. When
+// only whitespace precedes the token on its line, the code goes above that
+// line; otherwise the line is split.
+func (g *goSource) insertBeforeLine(pos token.Pos, code string) (string, bool) {
+	at := g.offset(pos)
+	lineStart := strings.LastIndex(g.src[:at], "\n") + 1
+	if strings.TrimSpace(g.src[lineStart:at]) == "" {
+		return g.insert(lineStart, code+"\n")
+	}
+	return g.insert(at, "\n"+code+"\n")
+}
+
+// insertAfterLine adds code on its own line after the line that holds pos
+func (g *goSource) insertAfterLine(pos token.Pos, code string) (string, bool) {
+	at := g.offset(pos)
+	end := strings.Index(g.src[at:], "\n")
+	if end < 0 {
+		return g.insert(len(g.src), "\n"+code+"\n")
+	}
+	return g.insert(at+end+1, code+"\n")
+}
+
+// appendToBlock adds a statement at the end of a block
+func (g *goSource) appendToBlock(block *ast.BlockStmt, stmt string) (string, bool) {
+	return g.insertBeforeLine(block.Rbrace, stmt)
+}
+
+// isCall reports whether a statement is a call whose function is fun, such as
+// rootCmd.AddCommand
+func (g *goSource) isCall(stmt ast.Stmt, fun string) (*ast.CallExpr, bool) {
+	expr, ok := stmt.(*ast.ExprStmt)
+	if !ok {
+		return nil, false
+	}
+	call, ok := expr.X.(*ast.CallExpr)
+	if !ok || g.text(call.Fun) != fun {
+		return nil, false
+	}
+	return call, true
+}
+
+// addRoute adds stmt to registerRoutes of the API server: at the end of the
+// block that follows the definition of the group variable, such as
+// v1 := s.router.Group("/api/v1"), or at the end of the function when the
+// group has no block of its own
+func addRoute(src, group, stmt string) (string, bool) {
+	g, ok := parseSource(src)
+	if !ok {
+		return src, false
+	}
+	fn := g.function("Server", "registerRoutes")
+	if fn == nil {
+		return src, false
+	}
+
+	for i, s := range fn.Body.List {
+		assign, ok := s.(*ast.AssignStmt)
+		if !ok || assign.Tok != token.DEFINE || len(assign.Lhs) != 1 {
+			continue
+		}
+		if id, ok := assign.Lhs[0].(*ast.Ident); !ok || id.Name != group {
+			continue
+		}
+		if i+1 < len(fn.Body.List) {
+			if block, ok := fn.Body.List[i+1].(*ast.BlockStmt); ok {
+				return g.appendToBlock(block, stmt)
+			}
+		}
+		return g.appendToBlock(fn.Body, stmt)
+	}
+	return src, false
+}
+
+// addCommand adds stmt, such as rootCmd.AddCommand(serveCmd), to the init
+// function of the file that declares rootCmd: after its last AddCommand call,
+// or at the end of init. An init function is created when there is none.
+func addCommand(src, stmt string) (string, bool) {
+	g, ok := parseSource(src)
+	if !ok || !g.declares("rootCmd") {
+		return src, false
+	}
+	fn := g.function("", "init")
+	if fn == nil {
+		return g.insert(len(g.src), "\nfunc init() {\n"+stmt+"\n}\n")
+	}
+
+	var last ast.Stmt
+	for _, s := range fn.Body.List {
+		if _, ok := g.isCall(s, "rootCmd.AddCommand"); ok {
+			last = s
+		}
+	}
+	if last != nil {
+		return g.insertAfterLine(last.End(), stmt)
+	}
+	return g.appendToBlock(fn.Body, stmt)
+}
+
+// addJob adds an element, such as NewCleanup(DefaultCleanupConfig(), clock),
+// to the slice literal returned by Registry
+func addJob(src, elem string) (string, bool) {
+	g, ok := parseSource(src)
+	if !ok {
+		return src, false
+	}
+	fn := g.function("", "Registry")
+	if fn == nil {
+		return src, false
+	}
+
+	var lit *ast.CompositeLit
+	for _, s := range fn.Body.List {
+		if ret, ok := s.(*ast.ReturnStmt); ok && len(ret.Results) == 1 {
+			lit, _ = ret.Results[0].(*ast.CompositeLit)
+		}
+	}
+	if lit == nil {
+		return src, false
+	}
+	return g.appendElement(lit, elem)
+}
+
+// addChainElement adds an element, such as middleware.AuditLog, to the slice
+// literal assigned to chain in registerMiddleware of the API server
+func addChainElement(src, elem string) (string, bool) {
+	g, ok := parseSource(src)
+	if !ok {
+		return src, false
+	}
+	fn := g.function("Server", "registerMiddleware")
+	if fn == nil {
+		return src, false
+	}
+	for _, s := range fn.Body.List {
+		assign, ok := s.(*ast.AssignStmt)
+		if !ok || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+			continue
+		}
+		if id, ok := assign.Lhs[0].(*ast.Ident); !ok || id.Name != "chain" {
+			continue
+		}
+		if lit, ok := assign.Rhs[0].(*ast.CompositeLit); ok {
+			return g.appendElement(lit, elem)
+		}
+	}
+	return src, false
+}
+
+// appendElement adds an element at the end of a composite literal
+func (g *goSource) appendElement(lit *ast.CompositeLit, elem string) (string, bool) {
+	elem = strings.TrimSuffix(elem, ",") + ","
+	if n := len(lit.Elts); n > 0 {
+		// A literal written on one line needs a comma before the new element
+		last, rbrace := g.offset(lit.Elts[n-1].End()), g.offset(lit.Rbrace)
+		if !strings.HasPrefix(strings.TrimSpace(g.src[last:rbrace]), ",") {
+			return g.replace(last, rbrace, ","+g.src[last:rbrace]+"\n"+elem+"\n")
+		}
+	}
+	return g.insertBeforeLine(lit.Rbrace, elem)
+}
+
+// appendToMethod adds stmt at the end of a method of recv
+func appendToMethod(src, recv, name, stmt string) (string, bool) {
+	g, ok := parseSource(src)
+	if !ok {
+		return src, false
+	}
+	fn := g.function(recv, name)
+	if fn == nil {
+		return src, false
+	}
+	return g.appendToBlock(fn.Body, stmt)
+}
+
+// insertCallBefore adds stmt to a function right before the first statement
+// that calls anchor, such as server.registerRoutes
+func insertCallBefore(src, recv, name, anchor, stmt string) (string, bool) {
+	g, ok := parseSource(src)
+	if !ok {
+		return src, false
+	}
+	if s := g.callStmt(recv, name, anchor); s != nil {
+		return g.insertBeforeLine(s.Pos(), stmt)
+	}
+	return src, false
+}
+
+// insertCallAfter adds stmt to a function right after the first statement
+// that calls anchor
+func insertCallAfter(src, recv, name, anchor, stmt string) (string, bool) {
+	g, ok := parseSource(src)
+	if !ok {
+		return src, false
+	}
+	if s := g.callStmt(recv, name, anchor); s != nil {
+		return g.insertAfterLine(s.End(), stmt)
+	}
+	return src, false
+}
+
+// callStmt returns the first statement of a function that calls anchor
+func (g *goSource) callStmt(recv, name, anchor string) ast.Stmt {
+	fn := g.function(recv, name)
+	if fn == nil {
+		return nil
+	}
+	for _, s := range fn.Body.List {
+		if _, ok := g.isCall(s, anchor); ok {
+			return s
+		}
+	}
+	return nil
+}
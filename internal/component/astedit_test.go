@@ -0,0 +1,144 @@
+package component
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddRoute(t *testing.T) {
+	// A user-edited server: comments, an extra group, and no block after v1
+	src := `package api
+
+func (s *Server) registerRoutes() {
+	// Public routes
+	s.router.GET("/health", s.healthCheck)
+
+	v1 := s.router.Group("/api/v1")
+	v1.GET("/hello", s.helloWorld) // greeting
+
+	admin := s.router.Group("/admin")
+	admin.GET("/stats", s.stats)
+}
+`
+	got, ok := addRoute(src, "v1", "s.registerUserRoutes(v1)")
+	assert.True(t, ok)
+	assert.Contains(t, got, "\tadmin.GET(\"/stats\", s.stats)\n\ts.registerUserRoutes(v1)\n}\n")
+	assert.Contains(t, got, "v1.GET(\"/hello\", s.helloWorld) // greeting\n")
+
+	// The generated layout keeps the call inside the group block
+	src = `package api
+
+func (s *Server) registerRoutes() {
+	v1 := s.router.Group("/api/v1")
+	{
+		v1.GET("/hello", s.helloWorld)
+	}
+}
+`
+	got, ok = addRoute(src, "v1", "s.registerUserRoutes(v1)")
+	assert.True(t, ok)
+	assert.Contains(t, got, "\t\tv1.GET(\"/hello\", s.helloWorld)\n\t\ts.registerUserRoutes(v1)\n\t}\n}\n")
+
+	for _, src := range []string{
+		"package api\n\nfunc (s *Server) registerRoutes() {}\n",
+		"package api\n\nfunc registerRoutes() {\n\tv1 := 1\n}\n",
+		"package api\n\nfunc (s *Server) registerRoutes() {\n",
+	} {
+		_, ok := addRoute(src, "v1", "s.registerUserRoutes(v1)")
+		assert.False(t, ok, src)
+	}
+}
+
+func TestAddCommandToRoot(t *testing.T) {
+	src := `package cmd
+
+var rootCmd = &cobra.Command{Use: "app"}
+
+func init() {
+	rootCmd.AddCommand(versionCmd)
+
+	// Flags
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file")
+}
+`
+	got, ok := addCommand(src, "rootCmd.AddCommand(serveCmd)")
+	assert.True(t, ok)
+	assert.Contains(t, got, "\trootCmd.AddCommand(versionCmd)\n\trootCmd.AddCommand(serveCmd)\n\n\t// Flags\n")
+
+	got, ok = addCommand("package cmd\n\nvar rootCmd = &cobra.Command{}\n", "rootCmd.AddCommand(serveCmd)")
+	assert.True(t, ok)
+	assert.Contains(t, got, "func init() {\n\trootCmd.AddCommand(serveCmd)\n}\n")
+
+	_, ok = addCommand("package cmd\n\nfunc init() {}\n", "rootCmd.AddCommand(serveCmd)")
+	assert.False(t, ok)
+}
+
+func TestAddJobToRegistry(t *testing.T) {
+	got, ok := addJob(`package jobs
+
+// Registry returns every job
+func Registry(clock Clock) []Job {
+	return []Job{NewCleanup(DefaultCleanupConfig(), clock)}
+}
+`, "NewReport(DefaultReportConfig(), clock),")
+	assert.True(t, ok)
+	assert.Contains(t, got, "NewCleanup(DefaultCleanupConfig(), clock),\n\t\tNewReport(DefaultReportConfig(), clock),\n\t}\n")
+
+	got, ok = addJob("package jobs\n\nfunc Registry(clock Clock) []Job {\n\treturn []Job{}\n}\n", "NewReport(clock)")
+	assert.True(t, ok)
+	assert.Contains(t, got, "return []Job{\n\t\tNewReport(clock),\n\t}\n")
+
+	_, ok = addJob("package jobs\n\nfunc Registry(clock Clock) []Job {\n\treturn jobs\n}\n", "NewReport(clock),")
+	assert.False(t, ok)
+}
+
+func TestInsertCallBefore(t *testing.T) {
+	src := `package api
+
+func NewServer() *Server {
+	server := &Server{}
+	server.registerRoutes()
+	return server
+}
+`
+	got, ok := insertCallBefore(src, "", "NewServer", "server.registerRoutes", "server.registerMiddleware()")
+	assert.True(t, ok)
+	assert.Contains(t, got, "\tserver.registerMiddleware()\n\tserver.registerRoutes()\n")
+
+	_, ok = insertCallBefore(src, "", "NewServer", "server.registerGRPC", "server.registerMiddleware()")
+	assert.False(t, ok)
+}
+
+func TestInsertCallAfter(t *testing.T) {
+	src := `package api
+
+func NewServer() *Server {
+	server := &Server{}
+	server.registerRoutes()
+	return server
+}
+`
+	got, ok := insertCallAfter(src, "", "NewServer", "server.registerRoutes", "server.registerMiddleware()")
+	assert.True(t, ok)
+	assert.Contains(t, got, "\tserver.registerRoutes()\n\tserver.registerMiddleware()\n\treturn server\n")
+
+	_, ok = insertCallAfter(src, "", "NewServer", "server.registerGRPC", "server.registerMiddleware()")
+	assert.False(t, ok)
+}
+
+func TestAddChainElement(t *testing.T) {
+	src := `package api
+
+func (s *Server) registerMiddleware() {
+	chain := []func(http.Handler) http.Handler{middleware.AuditLog}
+	s.wrap(chain)
+}
+`
+	got, ok := addChainElement(src, "middleware.RateLimit")
+	assert.True(t, ok)
+	assert.Contains(t, got, "middleware.AuditLog,\n\t\tmiddleware.RateLimit,\n")
+
+	_, ok = addChainElement("package api\n\nfunc (s *Server) registerMiddleware() {}\n", "middleware.RateLimit")
+	assert.False(t, ok)
+}
@@ -0,0 +1,247 @@
+package component
+
+import (
+	"fmt"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/oculus-core/gogo/internal/templates"
+	"github.com/oculus-core/gogo/internal/templates/builtin"
+)
+
+// clientData is the data the client templates are rendered with
+type clientData struct {
+	Name    string
+	Package string
+	BaseURL string
+	// Spec is the OpenAPI file the operations were generated from
+	Spec       string
+	Operations []clientOperation
+}
+
+// HasParams reports whether any operation has path parameters
+func (d clientData) HasParams() bool {
+	for _, o := range d.Operations {
+		if len(o.Params) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// clientOperation is a client method generated from an OpenAPI operation
+type clientOperation struct {
+	Name     string
+	Method   string
+	Path     string
+	Summary  string
+	Params   []string
+	Segments []pathSegment
+	HasBody  bool
+}
+
+// MethodConst returns the net/http constant of the operation method
+func (o clientOperation) MethodConst() string {
+	return "http.Method" + casing("pascalCase", strings.ToLower(o.Method))
+}
+
+// pathSegment is a literal part of a path or a path parameter
+type pathSegment struct {
+	Literal string
+	Param   string
+}
+
+// clientMethods are the methods of the generated client that operations must not shadow
+var clientMethods = map[string]bool{"Do": true, "Get": true, "Post": true, "Put": true, "Patch": true, "Delete": true}
+
+// openAPIMethods are the operation keys of an OpenAPI path item, in output order
+var openAPIMethods = []string{"get", "post", "put", "patch", "delete", "head", "options"}
+
+// openAPISpec is the part of an OpenAPI 3 document used to generate clients
+type openAPISpec struct {
+	Servers []struct {
+		URL string `yaml:"url"`
+	} `yaml:"servers"`
+	Paths map[string]map[string]yaml.Node `yaml:"paths"`
+}
+
+// openAPIOperation is the part of an OpenAPI operation used to generate clients
+type openAPIOperation struct {
+	OperationID string      `yaml:"operationId"`
+	Summary     string      `yaml:"summary"`
+	RequestBody interface{} `yaml:"requestBody"`
+}
+
+// AddClient generates a typed HTTP client for a service in pkg/client, with
+// options for the base URL, authentication, and retries, and httptest tests.
+// When specPath names an OpenAPI 3 document (YAML or JSON), a method is
+// generated for each of its operations.
+func AddClient(p *Project, name, specPath string) (*Result, error) {
+	if !namePattern.MatchString(name) {
+		return nil, fmt.Errorf("invalid client name %q: use lower-case letters, digits, hyphens, and underscores", name)
+	}
+
+	data := clientData{
+		Name:    name,
+		Package: strings.NewReplacer("-", "", "_", "").Replace(name),
+	}
+	if token.IsKeyword(data.Package) {
+		return nil, fmt.Errorf("invalid client name %q: %s is a Go keyword", name, data.Package)
+	}
+	if specPath != "" {
+		if err := data.loadSpec(specPath); err != nil {
+			return nil, err
+		}
+	}
+
+	pl, err := p.prepare("client", name, func(name string) (*plan, error) {
+		return planClient(name, data)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{}
+	if err := p.create(pl.files, result); err != nil {
+		return nil, err
+	}
+	return result, p.save()
+}
+
+// planClient renders the files of a client from the operations in data
+func planClient(name string, data clientData) (*plan, error) {
+	data.Name = name
+	data.Package = strings.NewReplacer("-", "", "_", "").Replace(name)
+	sources := []struct{ path, text string }{
+		{"pkg/client/{{ .Package }}/client.go", clientTemplate},
+		{"pkg/client/{{ .Package }}/client_test.go", clientTestTemplate},
+	}
+	if len(data.Operations) > 0 {
+		sources = append(sources, struct{ path, text string }{"pkg/client/{{ .Package }}/operations.go", operationsTemplate})
+	}
+
+	pl := &plan{}
+	for _, src := range sources {
+		path, err := templates.Render("path", src.path, data)
+		if err != nil {
+			return nil, err
+		}
+		content, err := renderGo(path, src.text, data)
+		if err != nil {
+			return nil, err
+		}
+		pl.files = append(pl.files, File{Path: path, Content: content})
+	}
+	return pl, nil
+}
+
+// loadSpec reads the base URL and operations of an OpenAPI document
+func (d *clientData) loadSpec(specPath string) error {
+	raw, err := os.ReadFile(specPath)
+	if err != nil {
+		return fmt.Errorf("failed to read OpenAPI spec: %w", err)
+	}
+	var spec openAPISpec
+	if err := yaml.Unmarshal(raw, &spec); err != nil {
+		return fmt.Errorf("failed to parse OpenAPI spec %s: %w", specPath, err)
+	}
+
+	d.Spec = filepath.Base(specPath)
+	if len(spec.Servers) > 0 {
+		d.BaseURL = spec.Servers[0].URL
+	}
+
+	paths := make([]string, 0, len(spec.Paths))
+	for path := range spec.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	seen := map[string]string{}
+	for _, path := range paths {
+		for _, method := range openAPIMethods {
+			node, ok := spec.Paths[path][method]
+			if !ok {
+				continue
+			}
+			var op openAPIOperation
+			if err := node.Decode(&op); err != nil {
+				return fmt.Errorf("invalid operation %s %s: %w", strings.ToUpper(method), path, err)
+			}
+
+			o := clientOperation{
+				Method:   strings.ToUpper(method),
+				Path:     path,
+				Summary:  strings.Join(strings.Fields(op.Summary), " "),
+				Segments: parsePath(path),
+				HasBody:  op.RequestBody != nil,
+			}
+			o.Name = operationName(op.OperationID, method, path)
+			if other, dup := seen[o.Name]; dup {
+				return fmt.Errorf("operations %s and %s %s both generate method %s", other, o.Method, path, o.Name)
+			}
+			seen[o.Name] = o.Method + " " + path
+			for _, s := range o.Segments {
+				if s.Param != "" {
+					o.Params = append(o.Params, s.Param)
+				}
+			}
+			d.Operations = append(d.Operations, o)
+		}
+	}
+	return nil
+}
+
+// operationName returns the Go method name of an operation: its operationId,
+// or the method and path when it has none
+func operationName(operationID, method, path string) string {
+	name := operationID
+	if name == "" {
+		name = method + " " + strings.NewReplacer("{", "", "}", "").Replace(path)
+	}
+	name = casing("pascalCase", name)
+	if clientMethods[name] {
+		name += "Operation"
+	}
+	return name
+}
+
+// parsePath splits an OpenAPI path such as /users/{id} into literal parts and
+// parameters, whose names are turned into Go identifiers
+func parsePath(path string) []pathSegment {
+	var segments []pathSegment
+	for path != "" {
+		start := strings.Index(path, "{")
+		end := strings.Index(path, "}")
+		if start < 0 || end < start {
+			segments = append(segments, pathSegment{Literal: path})
+			break
+		}
+		if start > 0 {
+			segments = append(segments, pathSegment{Literal: path[:start]})
+		}
+		param := casing("camelCase", path[start+1:end])
+		if token.IsKeyword(param) || param == "ctx" || param == "body" || param == "out" {
+			param += "Param"
+		}
+		segments = append(segments, pathSegment{Param: param})
+		path = path[end+1:]
+	}
+	return segments
+}
+
+// casing applies one of the casing functions available to templates
+func casing(fn, s string) string {
+	return templates.FuncMap()[fn].(func(string) string)(s)
+}
+
+var (
+	clientTemplate     = builtin.MustTemplate("component/client/client.tmpl")
+	clientTestTemplate = builtin.MustTemplate("component/client/test.tmpl")
+	operationsTemplate = builtin.MustTemplate("component/client/operations.tmpl")
+)
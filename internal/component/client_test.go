@@ -0,0 +1,78 @@
+package component
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+const testSpec = `openapi: 3.0.0
+servers:
+  - url: https://billing.example.com/v1
+paths:
+  /invoices:
+    get:
+      operationId: listInvoices
+      summary: List
+        invoices
+    post:
+      operationId: createInvoice
+      requestBody:
+        content:
+          application/json: {}
+  /invoices/{invoice_id}:
+    parameters:
+      - name: invoice_id
+        in: path
+    delete: {}
+`
+
+func TestAddClient(t *testing.T) {
+	p := generate(t, config.NewDefaultProjectConfig())
+
+	result, err := AddClient(p, "billing-api", "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pkg/client/billingapi/client.go", "pkg/client/billingapi/client_test.go"}, result.Created)
+
+	client := read(t, p, "pkg/client/billingapi/client.go")
+	assert.Contains(t, client, "package billingapi")
+	assert.Contains(t, client, "func WithRetries(n int, backoff time.Duration) Option {")
+	assert.NotContains(t, client, "DefaultBaseURL")
+
+	_, err = AddClient(p, "billing-api", "")
+	assert.ErrorContains(t, err, "collides with existing code")
+	_, err = AddClient(p, "type", "")
+	assert.ErrorContains(t, err, "keyword")
+}
+
+func TestAddClientFromOpenAPI(t *testing.T) {
+	p := generate(t, config.NewDefaultProjectConfig())
+	spec := filepath.Join(t.TempDir(), "billing.yaml")
+	require.NoError(t, os.WriteFile(spec, []byte(testSpec), 0600))
+
+	result, err := AddClient(p, "billing", spec)
+	require.NoError(t, err)
+	assert.Contains(t, result.Created, "pkg/client/billing/operations.go")
+
+	assert.Contains(t, read(t, p, "pkg/client/billing/client.go"), `const DefaultBaseURL = "https://billing.example.com/v1"`)
+
+	ops := read(t, p, "pkg/client/billing/operations.go")
+	assert.Contains(t, ops, "// Operations of the billing service, generated from billing.yaml")
+	assert.Contains(t, ops, "// ListInvoices calls GET /invoices: List invoices\n"+
+		"func (c *Client) ListInvoices(ctx context.Context, out interface{}) error {")
+	assert.Contains(t, ops, "func (c *Client) CreateInvoice(ctx context.Context, body interface{}, out interface{}) error {")
+	assert.Contains(t, ops, "func (c *Client) DeleteInvoicesInvoiceId(ctx context.Context, invoiceId string, out interface{}) error {\n"+
+		"\tpath := \"/invoices/\" + url.PathEscape(invoiceId)\n"+
+		"\treturn c.Do(ctx, http.MethodDelete, path, nil, out)\n")
+}
+
+func TestOperationName(t *testing.T) {
+	assert.Equal(t, "GetUser", operationName("getUser", "get", "/users/{id}"))
+	assert.Equal(t, "GetUsersId", operationName("", "get", "/users/{id}"))
+	assert.Equal(t, "DeleteOperation", operationName("delete", "delete", "/"))
+}
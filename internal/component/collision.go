@@ -0,0 +1,298 @@
+package component
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// plan is the code a generator adds to a project for one component
+type plan struct {
+	files []File
+	// routes are the HTTP paths the generated handlers register
+	routes []string
+}
+
+// planFunc plans the component with the given name
+type planFunc func(name string) (*plan, error)
+
+// CollisionError is returned when a component would collide with code that
+// already exists in the project
+type CollisionError struct {
+	// Kind is the kind of component, such as resource or job
+	Kind string
+	Name string
+	// Conflicts describe the existing files, declarations, and routes
+	Conflicts []string
+	// Forceable is set when every conflict is a file that --force may overwrite
+	Forceable bool
+	// Suggestion is a free name for the component, if one was found
+	Suggestion string
+}
+
+func (e *CollisionError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %q collides with existing code:", e.Kind, e.Name)
+	for _, c := range e.Conflicts {
+		fmt.Fprintf(&b, "\n  - %s", c)
+	}
+
+	var hints []string
+	if e.Suggestion != "" {
+		hints = append(hints, fmt.Sprintf("choose another name, such as %q", e.Suggestion))
+	}
+	if e.Forceable {
+		hints = append(hints, "rerun with --force to overwrite the existing files")
+	}
+	if len(hints) > 0 {
+		fmt.Fprintf(&b, "\n%s", strings.Join(hints, ", or "))
+	}
+	return b.String()
+}
+
+// prepare plans a component and checks it against the project. Existing files
+// are only allowed when p.Force is set; declarations and routes that already
+// exist elsewhere are always an error, since the generated code would not compile.
+func (p *Project) prepare(kind, name string, planFor planFunc) (*plan, error) {
+	pl, err := planFor(name)
+	if err != nil {
+		return nil, err
+	}
+	files, others, err := p.collisions(pl)
+	if err != nil {
+		return nil, err
+	}
+	if len(others) == 0 && (len(files) == 0 || p.Force) {
+		return pl, nil
+	}
+
+	return nil, &CollisionError{
+		Kind:       kind,
+		Name:       name,
+		Conflicts:  append(files, others...),
+		Forceable:  len(others) == 0,
+		Suggestion: p.suggest(name, planFor),
+	}
+}
+
+// suggest returns the first numbered variant of name that collides with nothing
+func (p *Project) suggest(name string, planFor planFunc) string {
+	for i := 2; i < 10; i++ {
+		candidate := name + strconv.Itoa(i)
+		pl, err := planFor(candidate)
+		if err != nil {
+			return ""
+		}
+		files, others, err := p.collisions(pl)
+		if err == nil && len(files) == 0 && len(others) == 0 {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// collisions lists the planned files that already exist, and the planned Go
+// declarations and routes that are already taken by other files
+func (p *Project) collisions(pl *plan) (files, others []string, err error) {
+	planned := make(map[string]bool, len(pl.files))
+	declared := make(map[string][]string)
+	seen := make(map[string]string)
+	for _, f := range pl.files {
+		planned[f.Path] = true
+		if p.exists(f.Path) {
+			files = append(files, p.rel(f.Path)+" already exists")
+		}
+		if !strings.HasSuffix(f.Path, ".go") {
+			continue
+		}
+		dir := path.Dir(f.Path)
+		for _, name := range declarations(parseGo(f.Path, f.Content)) {
+			if file, ok := seen[dir+"."+name]; ok {
+				others = append(others, fmt.Sprintf("%s is already declared in %s", name, file))
+				continue
+			}
+			seen[dir+"."+name] = p.rel(f.Path)
+			declared[dir] = append(declared[dir], name)
+		}
+	}
+
+	dirs := make([]string, 0, len(declared))
+	for dir := range declared {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+	for _, dir := range dirs {
+		pkg, err := p.scanPackage(dir, planned)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, name := range declared[dir] {
+			if file, ok := pkg.decls[name]; ok {
+				others = append(others, fmt.Sprintf("%s is already declared in %s", name, file))
+			}
+		}
+	}
+
+	if len(pl.routes) > 0 {
+		pkg, err := p.scanPackage(path.Dir(apiServerFile), planned)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, route := range pl.routes {
+			if file, ok := pkg.routes[route]; ok {
+				others = append(others, fmt.Sprintf("route %s is already registered in %s", route, file))
+			}
+		}
+	}
+	return files, others, nil
+}
+
+// packageIndex maps the top-level declarations and route paths of a Go
+// package directory to the files they appear in
+type packageIndex struct {
+	decls  map[string]string
+	routes map[string]string
+}
+
+// scanPackage indexes the Go files of a project directory, skipping the
+// files in skip. A missing directory is an empty package.
+func (p *Project) scanPackage(dir string, skip map[string]bool) (*packageIndex, error) {
+	idx := &packageIndex{decls: map[string]string{}, routes: map[string]string{}}
+	entries, err := os.ReadDir(p.path(dir))
+	if errors.Is(err, fs.ErrNotExist) {
+		return idx, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", p.rel(dir), err)
+	}
+
+	for _, entry := range entries {
+		conventional := path.Join(dir, entry.Name())
+		rel := p.rel(conventional)
+		if entry.IsDir() || !strings.HasSuffix(rel, ".go") || skip[conventional] {
+			continue
+		}
+		src, err := os.ReadFile(filepath.Join(p.path(dir), entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", rel, err)
+		}
+		file := parseGo(rel, src)
+		for _, name := range declarations(file) {
+			idx.decls[name] = rel
+		}
+		for _, route := range routePaths(file) {
+			idx.routes[route] = rel
+		}
+	}
+	return idx, nil
+}
+
+// parseGo parses a Go file. Files with syntax errors yield what could be
+// parsed, so a half-edited file does not block the generators.
+func parseGo(name string, src []byte) *ast.File {
+	file, _ := parser.ParseFile(token.NewFileSet(), name, src, parser.SkipObjectResolution)
+	return file
+}
+
+// declarations returns the top-level names declared by a file. Methods are
+// named Type.Method.
+func declarations(file *ast.File) []string {
+	if file == nil {
+		return nil
+	}
+	var names []string
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if d.Name.Name == "init" || d.Name.Name == "_" {
+				continue
+			}
+			if recv := receiverType(d); recv != "" {
+				names = append(names, recv+"."+d.Name.Name)
+			} else if d.Recv == nil {
+				names = append(names, d.Name.Name)
+			}
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					names = append(names, s.Name.Name)
+				case *ast.ValueSpec:
+					for _, n := range s.Names {
+						if n.Name != "_" {
+							names = append(names, n.Name)
+						}
+					}
+				}
+			}
+		}
+	}
+	return names
+}
+
+// receiverType returns the type name of a method receiver
+func receiverType(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return ""
+	}
+	expr := fn.Recv.List[0].Type
+	for {
+		switch t := expr.(type) {
+		case *ast.StarExpr:
+			expr = t.X
+		case *ast.IndexExpr:
+			expr = t.X
+		case *ast.IndexListExpr:
+			expr = t.X
+		case *ast.Ident:
+			return t.Name
+		default:
+			return ""
+		}
+	}
+}
+
+// routeMethods are the router methods whose first argument is a route path,
+// those of Gin and the HandleFunc and Handle of http.ServeMux
+var routeMethods = map[string]bool{
+	"GET": true, "POST": true, "PUT": true, "PATCH": true, "DELETE": true,
+	"HEAD": true, "OPTIONS": true, "Any": true, "Group": true,
+	"HandleFunc": true, "Handle": true,
+}
+
+// routePaths returns the paths passed to router methods such as GET, Group,
+// and HandleFunc
+func routePaths(file *ast.File) []string {
+	if file == nil {
+		return nil
+	}
+	var paths []string
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) == 0 {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || !routeMethods[sel.Sel.Name] {
+			return true
+		}
+		lit, ok := call.Args[0].(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			return true
+		}
+		if s, err := strconv.Unquote(lit.Value); err == nil {
+			paths = append(paths, s)
+		}
+		return true
+	})
+	return paths
+}
@@ -0,0 +1,84 @@
+package component
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+func write(t *testing.T, p *Project, rel, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(p.path(rel)), 0755))
+	require.NoError(t, os.WriteFile(p.path(rel), []byte(content), 0600))
+}
+
+func TestCollisionExistingFiles(t *testing.T) {
+	p := generate(t, config.NewAPIProjectConfig())
+	_, err := AddResource(p, "user", nil)
+	require.NoError(t, err)
+
+	_, err = AddResource(p, "user", nil)
+	var collision *CollisionError
+	require.True(t, errors.As(err, &collision))
+	assert.True(t, collision.Forceable)
+	assert.Equal(t, "user2", collision.Suggestion)
+	assert.Contains(t, collision.Conflicts, "internal/model/user.go already exists")
+	assert.Contains(t, err.Error(), "--force")
+
+	// --force overwrites the files without registering the routes twice
+	p.Force = true
+	result, err := AddResource(p, "user", nil)
+	require.NoError(t, err)
+	assert.Empty(t, result.Created)
+	assert.Contains(t, result.Updated, "internal/model/user.go")
+	assert.NotContains(t, result.Updated, apiServerFile)
+	assert.Equal(t, 1, strings.Count(read(t, p, apiServerFile), "s.registerUserRoutes(v1)"))
+}
+
+func TestCollisionDeclarations(t *testing.T) {
+	p := generate(t, config.NewAPIProjectConfig())
+	write(t, p, "internal/middleware/auth.go", "package middleware\n\nfunc AuditLog() {}\n")
+
+	// The declaration lives in a file gogo does not own, so --force cannot help
+	p.Force = true
+	_, err := AddMiddleware(p, "audit-log")
+	var collision *CollisionError
+	require.True(t, errors.As(err, &collision))
+	assert.False(t, collision.Forceable)
+	assert.Equal(t, []string{"AuditLog is already declared in internal/middleware/auth.go"}, collision.Conflicts)
+	assert.NotContains(t, err.Error(), "--force")
+	assert.False(t, p.exists("internal/middleware/audit_log.go"))
+}
+
+func TestCollisionRoutes(t *testing.T) {
+	p := generate(t, config.NewAPIProjectConfig())
+	write(t, p, "internal/api/orders.go", `package api
+
+func (s *Server) registerOrders() {
+	s.router.Group("/api/v1").GET("/orders/:id", nil)
+}
+`)
+
+	_, err := AddResource(p, "order", nil)
+	var collision *CollisionError
+	require.True(t, errors.As(err, &collision))
+	assert.Equal(t, []string{"route /orders/:id is already registered in internal/api/orders.go"}, collision.Conflicts)
+	assert.Equal(t, "order2", collision.Suggestion)
+}
+
+func TestCollisionWithinPlan(t *testing.T) {
+	p := generate(t, config.NewAPIProjectConfig())
+
+	// The first job creates the registry, which declares Registry itself
+	_, err := AddJob(p, "registry")
+	var collision *CollisionError
+	require.True(t, errors.As(err, &collision))
+	assert.Contains(t, collision.Conflicts[0], "Registry is already declared in internal/jobs/")
+}
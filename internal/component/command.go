@@ -0,0 +1,79 @@
+package component
+
+import (
+	"fmt"
+
+	"github.com/oculus-core/gogo/internal/templates"
+	"github.com/oculus-core/gogo/internal/templates/builtin"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// commandData is the data the command templates are rendered with
+type commandData struct {
+	Name string
+}
+
+// AddCommand generates a Cobra subcommand with a test in the cmd package of a
+// CLI project and adds it to rootCmd
+func AddCommand(p *Project, name string) (*Result, error) {
+	rootFile := "cmd/" + p.Config.Name + "/cmd/root.go"
+	if p.Config.Type != config.TypeCLI || !p.exists(rootFile) {
+		return nil, fmt.Errorf("gogo add command needs a CLI project with %s", p.rel(rootFile))
+	}
+	if !p.Config.UseCobra {
+		return nil, fmt.Errorf("gogo add command supports Cobra projects only")
+	}
+	if !namePattern.MatchString(name) {
+		return nil, fmt.Errorf("invalid command name %q: use lower-case letters, digits, hyphens, and underscores", name)
+	}
+
+	pl, err := p.prepare("command", name, func(name string) (*plan, error) {
+		return planCommand(rootFile, name)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{}
+	if err := p.create(pl.files, result); err != nil {
+		return nil, err
+	}
+
+	call := "rootCmd.AddCommand(" + casing("camelCase", name) + "Cmd)"
+	err = p.register(result, rootFile, call,
+		fmt.Sprintf("call %s in init (%s)", call, p.rel(rootFile)),
+		func(src string) (string, bool) { return addCommand(src, call) })
+	if err != nil {
+		return nil, err
+	}
+	return result, p.save()
+}
+
+// planCommand renders the files of a command next to rootFile
+func planCommand(rootFile, name string) (*plan, error) {
+	data := commandData{Name: name}
+	dir := rootFile[:len(rootFile)-len("root.go")]
+	sources := []struct{ path, text string }{
+		{dir + "{{ snakeCase .Name }}.go", commandTemplate},
+		{dir + "{{ snakeCase .Name }}_test.go", commandTestTemplate},
+	}
+
+	pl := &plan{}
+	for _, src := range sources {
+		path, err := templates.Render("path", src.path, data)
+		if err != nil {
+			return nil, err
+		}
+		content, err := renderGo(path, src.text, data)
+		if err != nil {
+			return nil, err
+		}
+		pl.files = append(pl.files, File{Path: path, Content: content})
+	}
+	return pl, nil
+}
+
+var (
+	commandTemplate     = builtin.MustTemplate("component/command/command.tmpl")
+	commandTestTemplate = builtin.MustTemplate("component/command/test.tmpl")
+)
@@ -0,0 +1,48 @@
+package component
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+func TestAddCommand(t *testing.T) {
+	p := generate(t, config.NewCLIProjectConfig())
+
+	result, err := AddCommand(p, "serve-docs")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"cmd/svc/cmd/serve_docs.go",
+		"cmd/svc/cmd/serve_docs_test.go",
+	}, result.Created)
+	assert.Equal(t, []string{"cmd/svc/cmd/root.go"}, result.Updated)
+
+	assert.Contains(t, read(t, p, "cmd/svc/cmd/serve_docs.go"), "var serveDocsCmd = &cobra.Command{\n\tUse:   \"serve-docs\",")
+	assert.Contains(t, read(t, p, "cmd/svc/cmd/root.go"), "func init() {\n\tcobra.OnInitialize(initConfig)\n")
+	assert.Contains(t, read(t, p, "cmd/svc/cmd/root.go"), "\trootCmd.Flags().BoolP(\"toggle\", \"t\", false, \"Help message for toggle\")\n\trootCmd.AddCommand(serveDocsCmd)\n}\n")
+
+	_, err = AddCommand(p, "version")
+	assert.ErrorContains(t, err, "cmd/svc/cmd/version.go already exists")
+
+	write(t, p, "cmd/svc/cmd/db.go", "package cmd\n\nvar initDbCmd = rootCmd\n")
+	_, err = AddCommand(p, "init-db")
+	assert.ErrorContains(t, err, "initDbCmd is already declared in cmd/svc/cmd/db.go")
+}
+
+func TestAddCommandRequiresCLIProject(t *testing.T) {
+	p := generate(t, config.NewAPIProjectConfig())
+	_, err := AddCommand(p, "serve")
+	assert.ErrorContains(t, err, "CLI project")
+}
+
+func TestAddCommandRequiresCobra(t *testing.T) {
+	cfg := config.NewCLIProjectConfig()
+	cfg.UseCobra = false
+	p := generate(t, cfg)
+
+	_, err := AddCommand(p, "serve")
+	assert.ErrorContains(t, err, "Cobra")
+}
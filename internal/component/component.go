@@ -0,0 +1,175 @@
+// Package component generates code into existing gogo projects, such as the
+// resources created by gogo add.
+package component
+
+import (
+	"errors"
+	"fmt"
+	"go/format"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/oculus-core/gogo/internal/manifest"
+	"github.com/oculus-core/gogo/internal/templates"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// Project is an existing project generated by gogo
+type Project struct {
+	Dir      string
+	Config   *config.ProjectConfig
+	Manifest *manifest.Manifest
+	// Force lets generators overwrite files that already exist
+	Force bool
+}
+
+// LoadProject reads the gogo.yaml and manifest of a project
+func LoadProject(dir string) (*Project, error) {
+	m, err := manifest.Load(dir)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := config.LoadConfigFromFile(filepath.Join(dir, config.ProjectFileName))
+	if err != nil {
+		return nil, err
+	}
+	return &Project{Dir: dir, Config: cfg, Manifest: m}, nil
+}
+
+// File is a file written by a generator
+type File struct {
+	// Path is relative to the project directory, using forward slashes
+	Path    string
+	Content []byte
+}
+
+// Result reports what a generator changed
+type Result struct {
+	// Created are the new files
+	Created []string
+	// Updated are existing files the generator edited, such as route registrations
+	Updated []string
+	// Manual lists registration steps the generator could not apply itself
+	Manual []string
+}
+
+// rel returns the path of a project file given in the conventional layout,
+// such as internal/api/server.go, in the layout of the project
+func (p *Project) rel(conventional string) string {
+	return p.Config.LayoutPath(conventional)
+}
+
+// path returns the absolute path of a project file given in the conventional
+// layout
+func (p *Project) path(rel string) string {
+	return filepath.Join(p.Dir, filepath.FromSlash(p.rel(rel)))
+}
+
+// exists reports whether a project file exists
+func (p *Project) exists(rel string) bool {
+	_, err := os.Stat(p.path(rel))
+	return err == nil
+}
+
+// create writes the files of a plan and records them in the manifest. The
+// files and their references to other files move to the layout of the
+// project. Files that already exist are overwritten, so callers check the
+// plan with prepare first.
+func (p *Project) create(files []File, result *Result) error {
+	for _, f := range files {
+		rel := p.rel(f.Path)
+		existed := p.exists(f.Path)
+		target := p.path(f.Path)
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", rel, err)
+		}
+		if err := os.WriteFile(target, []byte(p.Config.RelayoutText(string(f.Content))), 0600); err != nil {
+			return fmt.Errorf("failed to write %s: %w", rel, err)
+		}
+		if err := p.Manifest.Record(p.Dir, rel); err != nil {
+			return err
+		}
+		if existed {
+			result.Updated = append(result.Updated, rel)
+		} else {
+			result.Created = append(result.Created, rel)
+		}
+	}
+	return nil
+}
+
+// edit rewrites an existing file with fn. The manifest hash is refreshed only
+// when the file was unmodified, so user edits keep showing up in gogo status.
+// It reports false when the file does not exist or fn leaves it unchanged.
+func (p *Project) edit(rel string, fn func(string) (string, bool)) (bool, error) {
+	data, err := os.ReadFile(p.path(rel))
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read %s: %w", p.rel(rel), err)
+	}
+
+	edited, ok := fn(string(data))
+	if !ok {
+		return false, nil
+	}
+
+	modified, err := p.Manifest.IsModified(p.Dir, p.rel(rel))
+	if err != nil {
+		return false, err
+	}
+	if err := os.WriteFile(p.path(rel), []byte(edited), 0600); err != nil {
+		return false, fmt.Errorf("failed to write %s: %w", p.rel(rel), err)
+	}
+	if !modified {
+		if err := p.Manifest.Record(p.Dir, p.rel(rel)); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// register adds a registration line, such as a route or job, to a file with
+// insert. Nothing changes when the file already contains the line; when
+// insert cannot place it, manual is added to the steps left to the user.
+func (p *Project) register(result *Result, rel, line, manual string, insert func(string) (string, bool)) error {
+	data, err := os.ReadFile(p.path(rel))
+	if err == nil && strings.Contains(string(data), line) {
+		return nil
+	}
+
+	done, err := p.edit(rel, insert)
+	if err != nil {
+		return err
+	}
+	if done {
+		result.Updated = append(result.Updated, p.rel(rel))
+	} else {
+		result.Manual = append(result.Manual, manual)
+	}
+	return nil
+}
+
+// save writes the updated manifest
+func (p *Project) save() error {
+	if err := p.Manifest.Write(p.Dir); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	return nil
+}
+
+// renderGo renders a Go source template and formats the result
+func renderGo(name, text string, data interface{}) ([]byte, error) {
+	rendered, err := templates.Render(name, text, data)
+	if err != nil {
+		return nil, err
+	}
+	src, err := format.Source([]byte(rendered))
+	if err != nil {
+		return nil, fmt.Errorf("template %s produced invalid Go code: %w", name, err)
+	}
+	return src, nil
+}
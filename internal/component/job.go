@@ -0,0 +1,88 @@
+package component
+
+import (
+	"fmt"
+
+	"github.com/oculus-core/gogo/internal/templates"
+	"github.com/oculus-core/gogo/internal/templates/builtin"
+)
+
+// jobsRegistryFile lists the jobs of a project and runs them
+const jobsRegistryFile = "internal/jobs/jobs.go"
+
+// jobData is the data the job templates are rendered with
+type jobData struct {
+	Name string
+}
+
+// AddJob generates a background job in internal/jobs with a config struct,
+// metrics, and a unit test that uses a fake clock, and adds it to the jobs
+// registry. The registry, scheduler, and clocks are created with the first job.
+func AddJob(p *Project, name string) (*Result, error) {
+	if !namePattern.MatchString(name) {
+		return nil, fmt.Errorf("invalid job name %q: use lower-case letters, digits, hyphens, and underscores", name)
+	}
+
+	newRegistry := !p.exists(jobsRegistryFile)
+	pl, err := p.prepare("job", name, func(name string) (*plan, error) {
+		return planJob(name, newRegistry)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{}
+	if err := p.create(pl.files, result); err != nil {
+		return nil, err
+	}
+
+	if newRegistry {
+		result.Manual = append(result.Manual, "start the jobs from main with jobs.Schedule(ctx, jobs.Registry(jobs.SystemClock))")
+		return result, p.save()
+	}
+
+	job := casing("pascalCase", name)
+	entry := fmt.Sprintf("New%s(Default%sConfig(), clock),", job, job)
+	err = p.register(result, jobsRegistryFile, entry,
+		fmt.Sprintf("add %s to Registry (%s)", entry, p.rel(jobsRegistryFile)),
+		func(src string) (string, bool) {
+			return addJob(src, entry)
+		})
+	if err != nil {
+		return nil, err
+	}
+	return result, p.save()
+}
+
+// planJob renders the files of a job, and the registry when the project has
+// no jobs yet
+func planJob(name string, newRegistry bool) (*plan, error) {
+	data := jobData{Name: name}
+	sources := []struct{ path, text string }{
+		{"internal/jobs/{{ snakeCase .Name }}.go", jobTemplate},
+		{"internal/jobs/{{ snakeCase .Name }}_test.go", jobTestTemplate},
+	}
+	if newRegistry {
+		sources = append(sources, struct{ path, text string }{jobsRegistryFile, jobsRegistryTemplate})
+	}
+
+	pl := &plan{}
+	for _, src := range sources {
+		path, err := templates.Render("path", src.path, data)
+		if err != nil {
+			return nil, err
+		}
+		content, err := renderGo(path, src.text, data)
+		if err != nil {
+			return nil, err
+		}
+		pl.files = append(pl.files, File{Path: path, Content: content})
+	}
+	return pl, nil
+}
+
+var (
+	jobsRegistryTemplate = builtin.MustTemplate("component/job/jobs-registry.tmpl")
+	jobTemplate          = builtin.MustTemplate("component/job/job.tmpl")
+	jobTestTemplate      = builtin.MustTemplate("component/job/test.tmpl")
+)
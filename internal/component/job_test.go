@@ -0,0 +1,37 @@
+package component
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+func TestAddJob(t *testing.T) {
+	p := generate(t, config.NewDefaultProjectConfig())
+
+	result, err := AddJob(p, "cleanup-sessions")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"internal/jobs/cleanup_sessions.go",
+		"internal/jobs/cleanup_sessions_test.go",
+		jobsRegistryFile,
+	}, result.Created)
+	assert.Len(t, result.Manual, 1)
+
+	job := read(t, p, "internal/jobs/cleanup_sessions.go")
+	assert.Contains(t, job, "type CleanupSessionsConfig struct {")
+	assert.Contains(t, job, `func (j *CleanupSessions) Name() string { return "cleanup-sessions" }`)
+	assert.Contains(t, read(t, p, "internal/jobs/cleanup_sessions_test.go"), "clock.Advance(job.Interval())")
+
+	result, err = AddJob(p, "send_digest")
+	require.NoError(t, err)
+	assert.Equal(t, []string{jobsRegistryFile}, result.Updated)
+	assert.Empty(t, result.Manual)
+	assert.Contains(t, read(t, p, jobsRegistryFile), "\treturn []Job{\n"+
+		"\t\tNewCleanupSessions(DefaultCleanupSessionsConfig(), clock),\n"+
+		"\t\tNewSendDigest(DefaultSendDigestConfig(), clock),\n"+
+		"\t}\n")
+}
@@ -0,0 +1,129 @@
+package component
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/oculus-core/gogo/internal/templates"
+	"github.com/oculus-core/gogo/internal/templates/builtin"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// namePattern matches component names such as audit-log or rate_limit
+var namePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+
+// middlewareChainFile installs the middleware of API projects in order
+const middlewareChainFile = "internal/api/middleware.go"
+
+// middlewareData is the data the middleware templates are rendered with
+type middlewareData struct {
+	Module string
+	Name   string
+}
+
+// AddMiddleware generates a middleware with an httptest test in
+// internal/middleware and appends it to the middleware chain of the server:
+// a gin.HandlerFunc in Gin projects, and a func(http.Handler) http.Handler
+// otherwise. The chain file and its call in NewServer are created with the
+// first middleware.
+func AddMiddleware(p *Project, name string) (*Result, error) {
+	if p.Config.Type != config.TypeAPI || !p.exists(apiServerFile) {
+		return nil, fmt.Errorf("gogo add middleware needs an API project with %s", p.rel(apiServerFile))
+	}
+	if !namePattern.MatchString(name) {
+		return nil, fmt.Errorf("invalid middleware name %q: use lower-case letters, digits, hyphens, and underscores", name)
+	}
+
+	gin := p.Config.UseGin
+	newChain := !p.exists(middlewareChainFile)
+	pl, err := p.prepare("middleware", name, func(name string) (*plan, error) {
+		return planMiddleware(p.Config.Module, name, gin, newChain)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{}
+	if err := p.create(pl.files, result); err != nil {
+		return nil, err
+	}
+
+	if newChain {
+		// Gin installs middleware before the routes; the standard library
+		// chain wraps the mux, so it goes in front of the routes after they
+		// are registered
+		const call = "server.registerMiddleware()"
+		err := p.register(result, apiServerFile, call,
+			fmt.Sprintf("call %s in NewServer (%s)", call, p.rel(apiServerFile)),
+			func(src string) (string, bool) {
+				if gin {
+					return insertCallBefore(src, "", "NewServer", "server.registerRoutes", call)
+				}
+				return insertCallAfter(src, "", "NewServer", "server.registerRoutes", call)
+			})
+		if err != nil {
+			return nil, err
+		}
+		return result, p.save()
+	}
+
+	fn := "middleware." + casing("pascalCase", name)
+	if gin {
+		use := "s.router.Use(" + fn + "())"
+		err = p.register(result, middlewareChainFile, use,
+			fmt.Sprintf("call %s in registerMiddleware (%s)", use, p.rel(middlewareChainFile)),
+			func(src string) (string, bool) {
+				return appendToMethod(src, "Server", "registerMiddleware", use)
+			})
+	} else {
+		err = p.register(result, middlewareChainFile, fn,
+			fmt.Sprintf("add %s to the chain in registerMiddleware (%s)", fn, p.rel(middlewareChainFile)),
+			func(src string) (string, bool) {
+				return addChainElement(src, fn)
+			})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result, p.save()
+}
+
+// planMiddleware renders the files of a middleware, and the chain file when
+// the project has none yet
+func planMiddleware(module, name string, gin, newChain bool) (*plan, error) {
+	data := middlewareData{Module: module, Name: name}
+	middleware, test, chain := middlewareTemplate, middlewareTestTemplate, middlewareChainTemplate
+	if !gin {
+		middleware, test, chain = stdlibMiddlewareTemplate, stdlibMiddlewareTestTemplate, stdlibMiddlewareChainTemplate
+	}
+	sources := []struct{ path, text string }{
+		{"internal/middleware/{{ snakeCase .Name }}.go", middleware},
+		{"internal/middleware/{{ snakeCase .Name }}_test.go", test},
+	}
+	if newChain {
+		sources = append(sources, struct{ path, text string }{middlewareChainFile, chain})
+	}
+
+	pl := &plan{}
+	for _, src := range sources {
+		path, err := templates.Render("path", src.path, data)
+		if err != nil {
+			return nil, err
+		}
+		content, err := renderGo(path, src.text, data)
+		if err != nil {
+			return nil, err
+		}
+		pl.files = append(pl.files, File{Path: path, Content: content})
+	}
+	return pl, nil
+}
+
+var (
+	middlewareTemplate            = builtin.MustTemplate("component/middleware/middleware.tmpl")
+	middlewareTestTemplate        = builtin.MustTemplate("component/middleware/test.tmpl")
+	middlewareChainTemplate       = builtin.MustTemplate("component/middleware/chain.tmpl")
+	stdlibMiddlewareTemplate      = builtin.MustTemplate("component/middleware/stdlib-middleware.tmpl")
+	stdlibMiddlewareTestTemplate  = builtin.MustTemplate("component/middleware/stdlib-test.tmpl")
+	stdlibMiddlewareChainTemplate = builtin.MustTemplate("component/middleware/stdlib-chain.tmpl")
+)
@@ -0,0 +1,66 @@
+package component
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+func TestAddMiddleware(t *testing.T) {
+	p := generate(t, config.NewAPIProjectConfig())
+
+	result, err := AddMiddleware(p, "audit-log")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"internal/middleware/audit_log.go",
+		"internal/middleware/audit_log_test.go",
+		middlewareChainFile,
+	}, result.Created)
+	assert.Equal(t, []string{apiServerFile}, result.Updated)
+
+	assert.Contains(t, read(t, p, "internal/middleware/audit_log.go"), "func AuditLog() gin.HandlerFunc {")
+	assert.Contains(t, read(t, p, apiServerFile), "\tserver.registerMiddleware()\n\tserver.registerRoutes()\n")
+	assert.Contains(t, read(t, p, middlewareChainFile), `"github.com/acme/svc/internal/middleware"`)
+
+	// Later middleware is appended to the chain
+	result, err = AddMiddleware(p, "rate_limit")
+	require.NoError(t, err)
+	assert.Equal(t, []string{middlewareChainFile}, result.Updated)
+	assert.Contains(t, read(t, p, middlewareChainFile),
+		"\ts.router.Use(middleware.AuditLog())\n\ts.router.Use(middleware.RateLimit())\n}\n")
+
+	_, err = AddMiddleware(p, "audit-log")
+	assert.ErrorContains(t, err, "collides with existing code")
+	_, err = AddMiddleware(p, "Audit")
+	assert.ErrorContains(t, err, "invalid middleware name")
+}
+
+func TestAddMiddlewareStdlib(t *testing.T) {
+	cfg := config.NewAPIProjectConfig()
+	cfg.UseGin = false
+	p := generate(t, cfg)
+
+	result, err := AddMiddleware(p, "audit-log")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"internal/middleware/audit_log.go",
+		"internal/middleware/audit_log_test.go",
+		middlewareChainFile,
+	}, result.Created)
+	assert.Equal(t, []string{apiServerFile}, result.Updated)
+	assert.Empty(t, result.Manual)
+
+	assert.Contains(t, read(t, p, "internal/middleware/audit_log.go"), "func AuditLog(next http.Handler) http.Handler {")
+	assert.NotContains(t, read(t, p, "internal/middleware/audit_log_test.go"), "gin")
+	// The chain wraps the mux, so it is installed after the routes
+	assert.Contains(t, read(t, p, apiServerFile), "\tserver.registerRoutes()\n\tserver.registerMiddleware()\n")
+
+	result, err = AddMiddleware(p, "rate_limit")
+	require.NoError(t, err)
+	assert.Equal(t, []string{middlewareChainFile}, result.Updated)
+	assert.Contains(t, read(t, p, middlewareChainFile),
+		"\t\tmiddleware.AuditLog,\n\t\tmiddleware.RateLimit,\n\t}\n")
+}
@@ -0,0 +1,157 @@
+package component
+
+import (
+	"bytes"
+	"fmt"
+	"go/token"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/oculus-core/gogo/internal/templates"
+	"github.com/oculus-core/gogo/internal/templates/builtin"
+)
+
+// Buf configuration files at the project root
+const (
+	bufConfigFile    = "buf.yaml"
+	bufGenConfigFile = "buf.gen.yaml"
+)
+
+// protoRoot is the buf module that holds the .proto files
+const protoRoot = "api/proto"
+
+// protoData is the data the proto templates are rendered with
+type protoData struct {
+	Module  string
+	Name    string
+	Package string
+}
+
+// AddProto generates a versioned .proto service, the buf configuration when
+// the project has none, a server skeleton in internal/rpc, and a test that
+// serves it over bufconn. When generate is set and buf is installed, the Go
+// stubs are regenerated with buf generate.
+func AddProto(p *Project, name string, generate bool) (*Result, error) {
+	if !namePattern.MatchString(name) {
+		return nil, fmt.Errorf("invalid service name %q: use lower-case letters, digits, hyphens, and underscores", name)
+	}
+
+	if pkg := strings.NewReplacer("-", "", "_", "").Replace(name); token.IsKeyword(pkg) {
+		return nil, fmt.Errorf("invalid service name %q: %s is a Go keyword", name, pkg)
+	}
+
+	newBufConfig := !p.exists(bufConfigFile)
+	newBufGenConfig := !p.exists(bufGenConfigFile)
+	pl, err := p.prepare("proto", name, func(name string) (*plan, error) {
+		return planProto(p.Config.Module, name, newBufConfig, newBufGenConfig)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{}
+	if err := p.create(pl.files, result); err != nil {
+		return nil, err
+	}
+
+	if !newBufConfig {
+		content, err := os.ReadFile(p.path(bufConfigFile))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", bufConfigFile, err)
+		}
+		if !bytes.Contains(content, []byte("path: "+protoRoot)) {
+			result.Manual = append(result.Manual, fmt.Sprintf("add the %s module to %s", protoRoot, bufConfigFile))
+		}
+	}
+
+	added, err := p.edit("Makefile", func(src string) (string, bool) {
+		if strings.Contains(src, "\nproto:") {
+			return src, false
+		}
+		return strings.TrimRight(src, "\n") + "\n\n" + protoMakeTarget, true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if added {
+		result.Updated = append(result.Updated, "Makefile")
+	}
+
+	if err := p.save(); err != nil {
+		return nil, err
+	}
+
+	if !generate {
+		result.Manual = append(result.Manual, "generate the Go stubs with buf generate")
+		return result, nil
+	}
+	if _, err := exec.LookPath("buf"); err != nil {
+		result.Manual = append(result.Manual, "install buf (https://buf.build/docs/installation) and run buf generate")
+		return result, nil
+	}
+	cmd := exec.Command("buf", "generate")
+	cmd.Dir = p.Dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return result, fmt.Errorf("buf generate failed: %w\n%s", err, out)
+	}
+	result.Updated = append(result.Updated, "gen/")
+	return result, nil
+}
+
+// planProto renders the files of a service, and the buf configuration files
+// the project does not have yet
+func planProto(module, name string, newBufConfig, newBufGenConfig bool) (*plan, error) {
+	data := protoData{
+		Module:  module,
+		Name:    name,
+		Package: strings.NewReplacer("-", "", "_", "").Replace(name),
+	}
+	sources := []struct{ path, text string }{
+		{protoRoot + "/{{ .Package }}/v1/{{ .Package }}.proto", protoTemplate},
+		{"internal/rpc/{{ .Package }}/server.go", protoServerTemplate},
+		{"internal/rpc/{{ .Package }}/server_test.go", protoServerTestTemplate},
+	}
+	if newBufConfig {
+		sources = append(sources, struct{ path, text string }{bufConfigFile, bufConfigTemplate})
+	}
+	if newBufGenConfig {
+		sources = append(sources, struct{ path, text string }{bufGenConfigFile, bufGenConfigTemplate})
+	}
+
+	pl := &plan{}
+	for _, src := range sources {
+		path, err := templates.Render("path", src.path, data)
+		if err != nil {
+			return nil, err
+		}
+		var content []byte
+		if strings.HasSuffix(path, ".go") {
+			content, err = renderGo(path, src.text, data)
+		} else {
+			var text string
+			text, err = templates.Render(path, src.text, data)
+			content = []byte(text)
+		}
+		if err != nil {
+			return nil, err
+		}
+		pl.files = append(pl.files, File{Path: path, Content: content})
+	}
+	return pl, nil
+}
+
+// protoMakeTarget regenerates the stubs of every .proto file
+const protoMakeTarget = `# Generate Go code from the .proto files
+proto:
+	buf lint
+	buf generate
+`
+
+var (
+	bufConfigTemplate       = builtin.MustTemplate("component/proto/buf-config.tmpl")
+	bufGenConfigTemplate    = builtin.MustTemplate("component/proto/buf-gen-config.tmpl")
+	protoTemplate           = builtin.MustTemplate("component/proto/proto.tmpl")
+	protoServerTemplate     = builtin.MustTemplate("component/proto/server.tmpl")
+	protoServerTestTemplate = builtin.MustTemplate("component/proto/server-test.tmpl")
+)
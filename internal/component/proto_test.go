@@ -0,0 +1,49 @@
+package component
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+func TestAddProto(t *testing.T) {
+	p := generate(t, config.NewDefaultProjectConfig())
+
+	result, err := AddProto(p, "inventory", false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"api/proto/inventory/v1/inventory.proto",
+		"internal/rpc/inventory/server.go",
+		"internal/rpc/inventory/server_test.go",
+		bufConfigFile,
+		bufGenConfigFile,
+	}, result.Created)
+	assert.Equal(t, []string{"Makefile"}, result.Updated)
+	assert.Equal(t, []string{"generate the Go stubs with buf generate"}, result.Manual)
+
+	proto := read(t, p, "api/proto/inventory/v1/inventory.proto")
+	assert.Contains(t, proto, "package inventory.v1;")
+	assert.Contains(t, proto, `option go_package = "github.com/acme/svc/gen/inventory/v1;inventoryv1";`)
+	assert.Contains(t, proto, "rpc GetInventory(GetInventoryRequest) returns (GetInventoryResponse);")
+	assert.Contains(t, read(t, p, "internal/rpc/inventory/server_test.go"), "bufconn.Listen(1 << 20)")
+	assert.Contains(t, read(t, p, "Makefile"), "\nproto:\n\tbuf lint\n\tbuf generate\n")
+
+	// A second service reuses the buf configuration and make target
+	result, err = AddProto(p, "order-history", false)
+	require.NoError(t, err)
+	assert.Len(t, result.Created, 3)
+	assert.Empty(t, result.Updated)
+}
+
+func TestAddProtoForeignBufConfig(t *testing.T) {
+	p := generate(t, config.NewDefaultProjectConfig())
+	require.NoError(t, os.WriteFile(p.path(bufConfigFile), []byte("version: v2\n"), 0600))
+
+	result, err := AddProto(p, "inventory", false)
+	require.NoError(t, err)
+	assert.Contains(t, result.Manual, "add the api/proto module to buf.yaml")
+}
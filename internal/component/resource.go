@@ -0,0 +1,266 @@
+package component
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/oculus-core/gogo/internal/templates"
+	"github.com/oculus-core/gogo/internal/templates/builtin"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// identPattern matches resource and field names
+var identPattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// FieldTypes maps the field types accepted by ParseFields to Go types
+var FieldTypes = map[string]string{
+	"string":  "string",
+	"int":     "int",
+	"int64":   "int64",
+	"float":   "float64",
+	"float64": "float64",
+	"bool":    "bool",
+	"time":    "time.Time",
+}
+
+// Field is a field of a generated resource
+type Field struct {
+	// Name is the snake_case name, used as the JSON key
+	Name string
+	// Type is the Go type
+	Type string
+}
+
+// Required reports whether creating a resource requires the field: string
+// and time fields are required
+func (f Field) Required() bool {
+	return f.Type == "string" || f.Type == "time.Time"
+}
+
+// Email reports whether the field must be an email address
+func (f Field) Email() bool {
+	return f.Type == "string" && strings.Contains(f.Name, "email")
+}
+
+// Tag returns the struct tag of the field, including the validation rules of
+// Gin's binding
+func (f Field) Tag() string {
+	var binding []string
+	if f.Required() {
+		binding = append(binding, "required")
+	}
+	if f.Email() {
+		binding = append(binding, "email")
+	}
+	if len(binding) == 0 {
+		return f.JSONTag()
+	}
+	return fmt.Sprintf("`json:%q binding:%q`", f.Name, strings.Join(binding, ","))
+}
+
+// JSONTag returns the struct tag of the field without validation rules, for
+// projects that validate in the handlers
+func (f Field) JSONTag() string {
+	return fmt.Sprintf("`json:%q`", f.Name)
+}
+
+// sample returns an example value of the field that passes validation
+func (f Field) sample() interface{} {
+	switch f.Type {
+	case "int", "int64":
+		return 42
+	case "float64":
+		return 1.5
+	case "bool":
+		return true
+	case "time.Time":
+		return "2024-01-01T00:00:00Z"
+	}
+	if strings.Contains(f.Name, "email") {
+		return "user@example.com"
+	}
+	return "example"
+}
+
+// ParseFields parses a field list such as "name:string,email:string,age:int"
+func ParseFields(spec string) ([]Field, error) {
+	var fields []Field
+	seen := map[string]bool{}
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, typ, ok := strings.Cut(part, ":")
+		if !ok {
+			return nil, fmt.Errorf("field %q must have the form name:type", part)
+		}
+		name, typ = strings.TrimSpace(name), strings.TrimSpace(typ)
+		if !identPattern.MatchString(name) {
+			return nil, fmt.Errorf("invalid field name %q: use lower-case letters, digits, and underscores", name)
+		}
+		if name == "id" {
+			return nil, fmt.Errorf("field id is generated for every resource")
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate field %q", name)
+		}
+		goType, ok := FieldTypes[typ]
+		if !ok {
+			return nil, fmt.Errorf("unsupported type %q for field %s (supported: string, int, int64, float, bool, time)", typ, name)
+		}
+		seen[name] = true
+		fields = append(fields, Field{Name: name, Type: goType})
+	}
+	return fields, nil
+}
+
+// resourceData is the data the resource templates are rendered with
+type resourceData struct {
+	Module string
+	Name   string
+	Fields []Field
+	// Gin is set in Gin projects, whose models carry the validation rules
+	Gin bool
+}
+
+// HasTime reports whether a field uses time.Time
+func (d resourceData) HasTime() bool {
+	for _, f := range d.Fields {
+		if f.Type == "time.Time" {
+			return true
+		}
+	}
+	return false
+}
+
+// HasRequired reports whether creating the resource requires any field
+func (d resourceData) HasRequired() bool {
+	for _, f := range d.Fields {
+		if f.Required() {
+			return true
+		}
+	}
+	return false
+}
+
+// HasEmail reports whether a field must be an email address
+func (d resourceData) HasEmail() bool {
+	for _, f := range d.Fields {
+		if f.Email() {
+			return true
+		}
+	}
+	return false
+}
+
+// SampleJSON returns a quoted Go string with a valid JSON request body
+func (d resourceData) SampleJSON() (string, error) {
+	body := map[string]interface{}{}
+	for _, f := range d.Fields {
+		body[f.Name] = f.sample()
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return "", err
+	}
+	return strconv.Quote(string(data)), nil
+}
+
+// apiServerFile is the file of API projects that registers routes
+const apiServerFile = "internal/api/server.go"
+
+// AddResource generates a model, an in-memory repository, CRUD handlers with
+// validation, and handler tests for a resource of an API project, and
+// registers its routes with the server: on the v1 group in Gin projects, and
+// on the http.ServeMux otherwise
+func AddResource(p *Project, name string, fields []Field) (*Result, error) {
+	if p.Config.Type != config.TypeAPI || !p.exists(apiServerFile) {
+		return nil, fmt.Errorf("gogo add resource needs an API project with %s", p.rel(apiServerFile))
+	}
+	if !identPattern.MatchString(name) {
+		return nil, fmt.Errorf("invalid resource name %q: use lower-case letters, digits, and underscores", name)
+	}
+
+	gin := p.Config.UseGin
+	pl, err := p.prepare("resource", name, func(name string) (*plan, error) {
+		return planResource(p.Config.Module, name, fields, gin)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{}
+	if err := p.create(pl.files, result); err != nil {
+		return nil, err
+	}
+
+	register := "s.register" + casing("pascalCase", name) + "Routes"
+	call := register + "()"
+	if gin {
+		call = register + "(v1)"
+	}
+	err = p.register(result, apiServerFile, call,
+		fmt.Sprintf("call %s in registerRoutes (%s)", call, p.rel(apiServerFile)),
+		func(src string) (string, bool) {
+			if gin {
+				return addRoute(src, "v1", call)
+			}
+			return appendToMethod(src, "Server", "registerRoutes", call)
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, p.save()
+}
+
+// planResource renders the files of a resource
+func planResource(module, name string, fields []Field, gin bool) (*plan, error) {
+	data := resourceData{Module: module, Name: name, Fields: fields, Gin: gin}
+	handler, handlerTest := handlerTemplate, handlerTestTemplate
+	if !gin {
+		handler, handlerTest = stdlibHandlerTemplate, stdlibHandlerTestTemplate
+	}
+	sources := []struct{ path, text string }{
+		{"internal/model/{{ snakeCase .Name }}.go", modelTemplate},
+		{"internal/repository/{{ snakeCase .Name }}.go", repositoryTemplate},
+		{"internal/api/{{ snakeCase .Name }}_handler.go", handler},
+		{"internal/api/{{ snakeCase .Name }}_handler_test.go", handlerTest},
+	}
+
+	pl := &plan{}
+	for _, src := range sources {
+		path, err := templates.Render("path", src.path, data)
+		if err != nil {
+			return nil, err
+		}
+		content, err := renderGo(path, src.text, data)
+		if err != nil {
+			return nil, err
+		}
+		pl.files = append(pl.files, File{Path: path, Content: content})
+	}
+
+	// Gin routes are relative to the v1 group; the ServeMux serves the
+	// collection and, under its subtree, the items
+	route := "/" + casing("kebabCase", casing("pluralize", name))
+	if gin {
+		pl.routes = []string{route, route + "/:id"}
+	} else {
+		pl.routes = []string{"/api/v1" + route, "/api/v1" + route + "/"}
+	}
+	return pl, nil
+}
+
+var (
+	modelTemplate             = builtin.MustTemplate("component/resource/model.tmpl")
+	repositoryTemplate        = builtin.MustTemplate("component/resource/repository.tmpl")
+	handlerTemplate           = builtin.MustTemplate("component/resource/handler.tmpl")
+	handlerTestTemplate       = builtin.MustTemplate("component/resource/handler-test.tmpl")
+	stdlibHandlerTemplate     = builtin.MustTemplate("component/resource/stdlib-handler.tmpl")
+	stdlibHandlerTestTemplate = builtin.MustTemplate("component/resource/stdlib-handler-test.tmpl")
+)
@@ -0,0 +1,158 @@
+package component
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oculus-core/gogo/internal/manifest"
+	"github.com/oculus-core/gogo/internal/wizard"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+func generate(t *testing.T, cfg *config.ProjectConfig) *Project {
+	t.Helper()
+	cfg.Name = "svc"
+	cfg.Module = "github.com/acme/svc"
+
+	dir := t.TempDir()
+	require.NoError(t, wizard.GenerateProject(cfg, dir))
+	p, err := LoadProject(filepath.Join(dir, cfg.Name))
+	require.NoError(t, err)
+	return p
+}
+
+func read(t *testing.T, p *Project, rel string) string {
+	t.Helper()
+	data, err := os.ReadFile(p.path(rel))
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestParseFields(t *testing.T) {
+	fields, err := ParseFields("name:string, email:string,age:int,born:time")
+	require.NoError(t, err)
+	assert.Equal(t, []Field{
+		{Name: "name", Type: "string"},
+		{Name: "email", Type: "string"},
+		{Name: "age", Type: "int"},
+		{Name: "born", Type: "time.Time"},
+	}, fields)
+	assert.Equal(t, "`json:\"email\" binding:\"required,email\"`", fields[1].Tag())
+	assert.Equal(t, "`json:\"age\"`", fields[2].Tag())
+
+	for _, spec := range []string{"name", "Name:string", "id:int", "a:string,a:int", "x:uuid"} {
+		_, err := ParseFields(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestAddResource(t *testing.T) {
+	p := generate(t, config.NewAPIProjectConfig())
+	fields, err := ParseFields("name:string,email:string,age:int")
+	require.NoError(t, err)
+
+	result, err := AddResource(p, "user", fields)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"internal/model/user.go",
+		"internal/repository/user.go",
+		"internal/api/user_handler.go",
+		"internal/api/user_handler_test.go",
+	}, result.Created)
+	assert.Equal(t, []string{apiServerFile}, result.Updated)
+	assert.Empty(t, result.Manual)
+
+	assert.Contains(t, read(t, p, "internal/model/user.go"), "Email string `json:\"email\" binding:\"required,email\"`")
+	assert.Contains(t, read(t, p, "internal/repository/user.go"), "type UserRepository interface")
+	handler := read(t, p, "internal/api/user_handler.go")
+	assert.Contains(t, handler, `rg.DELETE("/users/:id", h.delete)`)
+	assert.Contains(t, handler, `"github.com/acme/svc/internal/repository"`)
+	assert.Contains(t, read(t, p, apiServerFile), "\t\tv1.POST(\"/greetings\", s.createGreeting)\n\t\ts.registerUserRoutes(v1)\n\t}\n")
+
+	// The new and updated files are recorded as generated
+	m, err := manifest.Load(p.Dir)
+	require.NoError(t, err)
+	statuses, err := m.Status(p.Dir)
+	require.NoError(t, err)
+	for _, s := range statuses {
+		assert.Equal(t, manifest.Unmodified, s.State, s.Path)
+	}
+	assert.Contains(t, m.Files, "internal/api/user_handler_test.go")
+
+	_, err = AddResource(p, "user", fields)
+	assert.ErrorContains(t, err, "collides with existing code")
+}
+
+func TestAddResourceManualRegistration(t *testing.T) {
+	p := generate(t, config.NewAPIProjectConfig())
+	require.NoError(t, os.WriteFile(p.path(apiServerFile), []byte("package api\n"), 0600))
+
+	result, err := AddResource(p, "order_item", nil)
+	require.NoError(t, err)
+	assert.Contains(t, result.Created, "internal/api/order_item_handler.go")
+	assert.Empty(t, result.Updated)
+	assert.Equal(t, []string{"call s.registerOrderItemRoutes(v1) in registerRoutes (internal/api/server.go)"}, result.Manual)
+	assert.Contains(t, read(t, p, "internal/api/order_item_handler.go"), `rg.GET("/order-items", h.list)`)
+}
+
+func TestAddResourceLayout(t *testing.T) {
+	cfg := config.NewAPIProjectConfig()
+	cfg.Layout = map[string]string{"internal": "app"}
+	p := generate(t, cfg)
+
+	result, err := AddResource(p, "user", nil)
+	require.NoError(t, err)
+	assert.Contains(t, result.Created, "app/api/user_handler.go")
+	assert.Equal(t, []string{"app/api/server.go"}, result.Updated)
+	assert.Contains(t, read(t, p, "internal/api/user_handler.go"), `"github.com/acme/svc/app/repository"`)
+	assert.Contains(t, read(t, p, apiServerFile), "s.registerUserRoutes(v1)")
+	assert.NoDirExists(t, filepath.Join(p.Dir, "internal"))
+
+	m, err := manifest.Load(p.Dir)
+	require.NoError(t, err)
+	assert.Contains(t, m.Files, "app/model/user.go")
+}
+
+func TestAddResourceRequiresAPIProject(t *testing.T) {
+	p := generate(t, config.NewCLIProjectConfig())
+	_, err := AddResource(p, "user", nil)
+	assert.ErrorContains(t, err, "API project")
+}
+
+func TestAddResourceStdlib(t *testing.T) {
+	cfg := config.NewAPIProjectConfig()
+	cfg.UseGin = false
+	p := generate(t, cfg)
+	fields, err := ParseFields("name:string,email:string,age:int")
+	require.NoError(t, err)
+
+	result, err := AddResource(p, "user", fields)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"internal/model/user.go",
+		"internal/repository/user.go",
+		"internal/api/user_handler.go",
+		"internal/api/user_handler_test.go",
+	}, result.Created)
+	assert.Equal(t, []string{apiServerFile}, result.Updated)
+	assert.Empty(t, result.Manual)
+
+	assert.Contains(t, read(t, p, "internal/model/user.go"), "Email string `json:\"email\"`")
+	handler := read(t, p, "internal/api/user_handler.go")
+	assert.Contains(t, handler, `s.mux.HandleFunc("/api/v1/users/", h.item)`)
+	assert.Contains(t, handler, `errors.New("name is required")`)
+	assert.Contains(t, handler, "mail.ParseAddress(item.Email)")
+	assert.NotContains(t, handler, "gin")
+	assert.Contains(t, read(t, p, apiServerFile), "\ts.registerUserRoutes()\n}\n")
+
+	// The routes of the mux collide like those of Gin
+	_, err = AddResource(p, "user", fields)
+	assert.ErrorContains(t, err, "collides with existing code")
+	result, err = AddResource(p, "book", nil)
+	require.NoError(t, err)
+	assert.NotContains(t, read(t, p, "internal/api/book_handler.go"), "net/mail")
+}
@@ -0,0 +1,177 @@
+package component
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/oculus-core/gogo/internal/templates"
+	"github.com/oculus-core/gogo/internal/templates/builtin"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// placeholderSHA256 stands in for the checksums until the formula is updated
+// to the first release
+var placeholderSHA256 = strings.Repeat("0", 64)
+
+// tapSystem is an operating system of Homebrew and the archives of the CPUs
+// it runs on
+type tapSystem struct {
+	// OS names the on_<os> block of the formula and GOOS the archives
+	OS, GOOS string
+	CPUs     []tapCPU
+}
+
+// tapCPU names the on_<cpu> block of the formula and the GOARCH of its archive
+type tapCPU struct {
+	CPU, GOARCH string
+}
+
+// tapSystems are the platforms of Homebrew, which GoReleaser builds by default
+var tapSystems = []tapSystem{
+	{OS: "macos", GOOS: "darwin", CPUs: []tapCPU{{"arm", "arm64"}, {"intel", "amd64"}}},
+	{OS: "linux", GOOS: "linux", CPUs: []tapCPU{{"arm", "arm64"}, {"intel", "amd64"}}},
+}
+
+// tapData is the data the tap templates are rendered with
+type tapData struct {
+	Name        string
+	Description string
+	License     string
+	Version     string
+	// Repository is the GitHub repository of the CLI, such as acme/todo
+	Repository string
+	// Project prefixes the archive names, which GoReleaser names after the repository
+	Project string
+	// Tap is the name users tap, such as acme/tap for acme/homebrew-tap
+	Tap     string
+	SHA256  string
+	Systems []tapSystem
+}
+
+// AddTap scaffolds a Homebrew tap repository in dir with a formula that
+// installs the GoReleaser archives of a CLI project from its GitHub releases.
+// The tap is a separate repository, so it is not recorded in the manifest of
+// the project. An empty dir means homebrew-tap next to the project.
+func AddTap(p *Project, dir string) (*Result, error) {
+	if p.Config.Type != config.TypeCLI {
+		return nil, fmt.Errorf("gogo add tap needs a CLI project")
+	}
+	parts := strings.Split(p.Config.Module, "/")
+	if len(parts) < 3 || parts[0] != "github.com" {
+		return nil, fmt.Errorf("gogo add tap needs a project on GitHub, not %s", p.Config.Module)
+	}
+	owner, repo := parts[1], parts[2]
+	if !p.exists(".goreleaser.yaml") {
+		return nil, fmt.Errorf("gogo add tap needs the .goreleaser.yaml that publishes the archives; enable create_version_file")
+	}
+	if dir == "" {
+		dir = filepath.Join(p.Dir, "..", "homebrew-tap")
+	}
+	tap := strings.TrimPrefix(filepath.Base(dir), "homebrew-")
+	if tap == filepath.Base(dir) || tap == "" {
+		return nil, fmt.Errorf("invalid tap directory %s: Homebrew needs the repository to be named homebrew-<tap>", dir)
+	}
+
+	version := "0.1.0"
+	if data, err := os.ReadFile(p.path("VERSION")); err == nil && strings.TrimSpace(string(data)) != "" {
+		version = strings.TrimSpace(string(data))
+	}
+	license := p.Config.License
+	if license == "None" {
+		license = ""
+	}
+	description := p.Config.Description
+	if description == "" {
+		description = "Command-line application"
+	}
+	data := tapData{
+		Name:        p.Config.Name,
+		Description: description,
+		License:     license,
+		Version:     version,
+		Repository:  owner + "/" + repo,
+		Project:     repo,
+		Tap:         owner + "/" + tap,
+		SHA256:      placeholderSHA256,
+		Systems:     tapSystems,
+	}
+
+	files, err := planTap(data)
+	if err != nil {
+		return nil, err
+	}
+	var conflicts []string
+	for _, f := range files {
+		if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(f.Path))); err == nil {
+			conflicts = append(conflicts, filepath.ToSlash(filepath.Join(dir, f.Path))+" already exists")
+		}
+	}
+	if len(conflicts) > 0 && !p.Force {
+		return nil, fmt.Errorf("cannot scaffold the tap: %s; use --force to overwrite", strings.Join(conflicts, ", "))
+	}
+
+	result := &Result{}
+	for _, f := range files {
+		target := filepath.Join(dir, filepath.FromSlash(f.Path))
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return nil, fmt.Errorf("failed to create directory for %s: %w", target, err)
+		}
+		mode := os.FileMode(0644)
+		if strings.HasSuffix(f.Path, ".sh") {
+			mode = 0755
+		}
+		if err := os.WriteFile(target, f.Content, mode); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", target, err)
+		}
+		result.Created = append(result.Created, filepath.ToSlash(target))
+	}
+	result.Manual = []string{
+		fmt.Sprintf("create the GitHub repository %s/homebrew-%s and push %s to it", owner, tap, dir),
+		fmt.Sprintf("after each release of %s, run the Update formula workflow of the tap, or scripts/update-formula.sh", data.Repository),
+		fmt.Sprintf("users then install with: brew install %s/%s", data.Tap, data.Name),
+	}
+	return result, nil
+}
+
+// planTap renders the files of the tap, relative to its directory
+func planTap(data tapData) ([]File, error) {
+	formula, err := templates.Render("formula", formulaTemplate, data)
+	if err != nil {
+		return nil, err
+	}
+	readme, err := templates.Render("README.md", tapReadmeTemplate, data)
+	if err != nil {
+		return nil, err
+	}
+	files := []File{
+		{Path: "Formula/" + data.Name + ".rb", Content: []byte(formula)},
+		{Path: "README.md", Content: []byte(readme)},
+	}
+	for _, f := range tapFiles {
+		content, err := templates.Render(f.path, f.text, data)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, File{Path: f.path, Content: []byte(content)})
+	}
+	return files, nil
+}
+
+// tapFiles are the script that sets the version and checksums of the formula
+// from a release, the workflow that runs it and commits the formula, and the
+// workflow that installs and tests the formula
+var tapFiles = []struct{ path, text string }{
+	{"scripts/update-formula.sh", updateFormulaTemplate},
+	{".github/workflows/update-formula.yml", updateWorkflowTemplate},
+	{".github/workflows/test.yml", tapTestWorkflowTemplate},
+}
+
+var (
+	formulaTemplate         = builtin.MustTemplate("component/tap/formula.tmpl")
+	tapReadmeTemplate       = builtin.MustTemplate("component/tap/readme.tmpl")
+	updateFormulaTemplate   = builtin.MustTemplate("component/tap/update-formula.tmpl")
+	updateWorkflowTemplate  = builtin.MustTemplate("component/tap/update-workflow.tmpl")
+	tapTestWorkflowTemplate = builtin.MustTemplate("component/tap/test-workflow.tmpl")
+)
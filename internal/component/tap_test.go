@@ -0,0 +1,62 @@
+package component
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+func TestAddTap(t *testing.T) {
+	cfg := config.NewCLIProjectConfig()
+	cfg.CreateVersionFile = true
+	cfg.Description = "Manage todo lists"
+	p := generate(t, cfg)
+	dir := filepath.Join(t.TempDir(), "homebrew-tools")
+
+	result, err := AddTap(p, dir)
+	require.NoError(t, err)
+	assert.Len(t, result.Created, 5)
+	assert.Contains(t, result.Manual, "users then install with: brew install acme/tools/svc")
+
+	formula, err := os.ReadFile(filepath.Join(dir, "Formula", "svc.rb"))
+	require.NoError(t, err)
+	assert.Contains(t, string(formula), "class Svc < Formula\n  desc \"Manage todo lists\"\n")
+	assert.Contains(t, string(formula), "  license \"MIT\"\n\n  on_macos do\n    on_arm do\n"+
+		"      url \"https://github.com/acme/svc/releases/download/v0.1.0/svc_0.1.0_darwin_arm64.tar.gz\"\n")
+	assert.Contains(t, string(formula), "      url \"https://github.com/acme/svc/releases/download/v0.1.0/svc_0.1.0_linux_amd64.tar.gz\"\n")
+	assert.Contains(t, string(formula), "shell_output(\"#{bin}/svc version\")")
+
+	script, err := os.Stat(filepath.Join(dir, "scripts", "update-formula.sh"))
+	require.NoError(t, err)
+	assert.NotZero(t, script.Mode()&0100, "update-formula.sh is not executable")
+
+	// The tap is a separate repository, so the project manifest is untouched
+	assert.NotContains(t, read(t, p, ".gogo/manifest.json"), "homebrew")
+
+	_, err = AddTap(p, dir)
+	assert.ErrorContains(t, err, "Formula/svc.rb already exists")
+	p.Force = true
+	_, err = AddTap(p, dir)
+	assert.NoError(t, err)
+}
+
+func TestAddTapRequirements(t *testing.T) {
+	p := generate(t, config.NewCLIProjectConfig())
+	_, err := AddTap(p, filepath.Join(t.TempDir(), "homebrew-tap"))
+	assert.ErrorContains(t, err, ".goreleaser.yaml")
+
+	cfg := config.NewCLIProjectConfig()
+	cfg.CreateVersionFile = true
+	p = generate(t, cfg)
+	_, err = AddTap(p, filepath.Join(t.TempDir(), "tools"))
+	assert.ErrorContains(t, err, "homebrew-<tap>")
+
+	p = generate(t, config.NewAPIProjectConfig())
+	_, err = AddTap(p, "")
+	assert.ErrorContains(t, err, "CLI project")
+}
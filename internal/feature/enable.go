@@ -0,0 +1,276 @@
+package feature
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/oculus-core/gogo/internal/manifest"
+	"github.com/oculus-core/gogo/internal/wizard"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// ExistsError is returned by Enable when a file it would write already exists
+// with different content
+type ExistsError struct {
+	Feature string
+	Paths   []string
+}
+
+func (e *ExistsError) Error() string {
+	return fmt.Sprintf("not enabling %s: files already exist: %s (use --force to overwrite)",
+		e.Feature, strings.Join(e.Paths, ", "))
+}
+
+// EnableResult reports what Enable changed
+type EnableResult struct {
+	// Written are the created or overwritten files
+	Written []string
+	// MakeTargets are the targets appended to the Makefile
+	MakeTargets []string
+	// Updated are the regenerated Updates of the feature
+	Updated []string
+}
+
+// Enable turns a feature on in gogo.yaml and writes the files gogo generates
+// for it, as a new project with the same configuration would have them. Files
+// that already exist with other content are only overwritten when force is set.
+func Enable(projectDir string, f *Feature, force bool) (*EnableResult, error) {
+	m, err := manifest.Load(projectDir)
+	if err != nil {
+		return nil, err
+	}
+
+	configPath := filepath.Join(projectDir, config.ProjectFileName)
+	cfg, err := config.LoadConfigFromFile(configPath)
+	if err != nil {
+		return nil, err
+	}
+	if f.Enabled(cfg) {
+		return nil, fmt.Errorf("feature %s is already enabled in this project", f.Name)
+	}
+	f.Set(cfg, true)
+
+	// Generate a scratch project with the feature turned on and take its files
+	generatedDir, cleanup, err := generateScratch(cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	files, err := f.readGenerated(generatedDir, cfg)
+	if err != nil {
+		return nil, err
+	}
+	updates, err := readUpdates(projectDir, generatedDir, f.existingUpdates(projectDir, cfg))
+	if err != nil {
+		return nil, err
+	}
+
+	var conflicts []string
+	for _, p := range sortedKeys(files) {
+		current, err := os.ReadFile(filepath.Join(projectDir, filepath.FromSlash(p)))
+		if err == nil && !bytes.Equal(current, files[p]) {
+			conflicts = append(conflicts, p)
+		}
+	}
+	if len(conflicts) > 0 && !force {
+		return nil, &ExistsError{Feature: f.Name, Paths: conflicts}
+	}
+
+	makefile, targets, err := f.extendedMakefile(projectDir, generatedDir)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &EnableResult{MakeTargets: targets}
+	for _, p := range sortedKeys(files) {
+		path := filepath.Join(projectDir, filepath.FromSlash(p))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return nil, fmt.Errorf("failed to create directory for %s: %w", p, err)
+		}
+		if err := os.WriteFile(path, files[p], 0600); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", p, err)
+		}
+		if err := m.Record(projectDir, p); err != nil {
+			return nil, err
+		}
+		result.Written = append(result.Written, p)
+	}
+
+	if len(targets) > 0 {
+		if err := os.WriteFile(filepath.Join(projectDir, "Makefile"), makefile, 0600); err != nil {
+			return nil, fmt.Errorf("failed to update Makefile: %w", err)
+		}
+		if err := m.Record(projectDir, "Makefile"); err != nil {
+			return nil, err
+		}
+	}
+
+	// Modified updates are left alone: they only miss the new pages
+	for _, p := range sortedKeys(updates) {
+		modified, err := m.IsModified(projectDir, p)
+		if err != nil {
+			return nil, err
+		}
+		if modified {
+			continue
+		}
+		if err := writeUpdate(projectDir, p, updates[p], m); err != nil {
+			return nil, err
+		}
+		result.Updated = append(result.Updated, p)
+	}
+
+	if err := config.SaveProjectFile(cfg, configPath); err != nil {
+		return nil, err
+	}
+	if err := m.Record(projectDir, config.ProjectFileName); err != nil {
+		return nil, err
+	}
+
+	if err := m.Write(projectDir); err != nil {
+		return nil, fmt.Errorf("failed to write manifest: %w", err)
+	}
+	return result, nil
+}
+
+// readGenerated reads the files of the feature from a generated project
+func (f *Feature) readGenerated(generatedDir string, cfg *config.ProjectConfig) (map[string][]byte, error) {
+	files := map[string][]byte{}
+	for _, p := range f.paths(cfg) {
+		root := filepath.Join(generatedDir, filepath.FromSlash(strings.TrimSuffix(p, "/")))
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			rel, err := filepath.Rel(generatedDir, path)
+			if err != nil {
+				return err
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			files[filepath.ToSlash(rel)] = data
+			return nil
+		})
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("failed to read generated %s: %w", p, err)
+		}
+	}
+	return files, nil
+}
+
+// generateScratch generates a project with cfg in a temporary directory, and
+// returns its directory and a function that removes it
+func generateScratch(cfg *config.ProjectConfig) (string, func(), error) {
+	tmpDir, err := os.MkdirTemp("", "gogo-feature-")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	cleanup := func() { _ = os.RemoveAll(tmpDir) }
+
+	if err := wizard.GenerateProject(cfg, tmpDir); err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	return filepath.Join(tmpDir, cfg.Name), cleanup, nil
+}
+
+// existingUpdates returns the Updates of the feature that the project has
+func (f *Feature) existingUpdates(projectDir string, cfg *config.ProjectConfig) []string {
+	var paths []string
+	for _, p := range f.Updates {
+		p = cfg.LayoutPath(p)
+		if _, err := os.Stat(filepath.Join(projectDir, filepath.FromSlash(p))); err == nil {
+			paths = append(paths, p)
+		}
+	}
+	return paths
+}
+
+// readUpdates returns the files of a generated project that differ from the
+// same files of the project
+func readUpdates(projectDir, generatedDir string, paths []string) (map[string][]byte, error) {
+	updates := map[string][]byte{}
+	for _, p := range paths {
+		generated, err := os.ReadFile(filepath.Join(generatedDir, filepath.FromSlash(p)))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read generated %s: %w", p, err)
+		}
+		current, err := os.ReadFile(filepath.Join(projectDir, filepath.FromSlash(p)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", p, err)
+		}
+		if !bytes.Equal(current, generated) {
+			updates[p] = generated
+		}
+	}
+	return updates, nil
+}
+
+// writeUpdate writes a regenerated file and records it in the manifest
+func writeUpdate(projectDir, p string, content []byte, m *manifest.Manifest) error {
+	if err := os.WriteFile(filepath.Join(projectDir, filepath.FromSlash(p)), content, 0600); err != nil {
+		return fmt.Errorf("failed to update %s: %w", p, err)
+	}
+	return m.Record(projectDir, p)
+}
+
+// extendedMakefile returns the project Makefile with the feature targets of
+// the generated Makefile appended, and the targets that were added
+func (f *Feature) extendedMakefile(projectDir, generatedDir string) ([]byte, []string, error) {
+	if len(f.MakeTargets) == 0 {
+		return nil, nil, nil
+	}
+	current, err := os.ReadFile(filepath.Join(projectDir, "Makefile"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read Makefile: %w", err)
+	}
+	generated, err := os.ReadFile(filepath.Join(generatedDir, "Makefile"))
+	if err != nil {
+		return nil, nil, nil
+	}
+
+	// Skip targets the Makefile already has, and targets the configuration of
+	// the project does not generate
+	var missing []string
+	for _, t := range f.MakeTargets {
+		if _, found := StripMakeTargets(string(current), []string{t}); len(found) > 0 {
+			continue
+		}
+		if _, found := StripMakeTargets(string(generated), []string{t}); len(found) > 0 {
+			missing = append(missing, t)
+		}
+	}
+	rules := ExtractMakeTargets(string(generated), missing)
+	if rules == "" {
+		return nil, nil, nil
+	}
+
+	extended := append(bytes.TrimRight(current, "\n"), '\n', '\n')
+	extended = append(extended, rules...)
+	extended = append(extended, '\n')
+	return extended, missing, nil
+}
+
+func sortedKeys(files map[string][]byte) []string {
+	keys := make([]string, 0, len(files))
+	for k := range files {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
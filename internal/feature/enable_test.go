@@ -0,0 +1,108 @@
+package feature
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oculus-core/gogo/internal/manifest"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+func TestEnableAfterRemove(t *testing.T) {
+	projectDir := generate(t)
+	f, err := Lookup("lint")
+	require.NoError(t, err)
+
+	original, err := os.ReadFile(filepath.Join(projectDir, ".golangci.yml"))
+	require.NoError(t, err)
+
+	_, err = Remove(projectDir, f, false)
+	require.NoError(t, err)
+
+	result, err := Enable(projectDir, f, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{".github/workflows/lint.yml", ".golangci.yml"}, result.Written)
+	assert.Equal(t, []string{"lint"}, result.MakeTargets)
+
+	restored, err := os.ReadFile(filepath.Join(projectDir, ".golangci.yml"))
+	require.NoError(t, err)
+	assert.Equal(t, string(original), string(restored))
+
+	makefile, err := os.ReadFile(filepath.Join(projectDir, "Makefile"))
+	require.NoError(t, err)
+	assert.Contains(t, string(makefile), "\n\n# Lint the code\nlint:\n")
+
+	cfg, err := config.LoadConfigFromFile(filepath.Join(projectDir, config.ProjectFileName))
+	require.NoError(t, err)
+	assert.True(t, cfg.UseLinters)
+
+	m, err := manifest.Load(projectDir)
+	require.NoError(t, err)
+	assert.Contains(t, m.Files, ".golangci.yml")
+
+	_, err = Enable(projectDir, f, false)
+	assert.ErrorContains(t, err, "already enabled")
+}
+
+func TestEnableKeepsExistingFiles(t *testing.T) {
+	projectDir := generate(t)
+	f, err := Lookup("catalog-info")
+	require.NoError(t, err)
+
+	path := filepath.Join(projectDir, "catalog-info.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("kind: Component\n"), 0600))
+
+	_, err = Enable(projectDir, f, false)
+	var existsErr *ExistsError
+	require.True(t, errors.As(err, &existsErr))
+	assert.Equal(t, []string{"catalog-info.yaml"}, existsErr.Paths)
+
+	_, err = Enable(projectDir, f, true)
+	require.NoError(t, err)
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "name: demo")
+}
+
+func TestEnableDocsSite(t *testing.T) {
+	projectDir := generate(t)
+	f, err := Lookup("docs-site")
+	require.NoError(t, err)
+
+	result, err := Enable(projectDir, f, false)
+	require.NoError(t, err)
+	assert.Contains(t, result.Written, "mkdocs.yml")
+	assert.NotContains(t, result.Written, "docs/architecture.md", "the starter docs own the architecture page")
+	assert.Equal(t, []string{"docs-serve", "docs-build"}, result.MakeTargets)
+
+	cfg, err := config.LoadConfigFromFile(filepath.Join(projectDir, config.ProjectFileName))
+	require.NoError(t, err)
+	assert.Equal(t, config.DocsSiteMkDocs, cfg.DocsSite)
+
+	removed, err := Remove(projectDir, f, false)
+	require.NoError(t, err)
+	assert.Contains(t, removed.Removed, "docs/index.md")
+	assert.FileExists(t, filepath.Join(projectDir, "docs", "architecture.md"))
+	assert.FileExists(t, filepath.Join(projectDir, "docs", "development.md"))
+}
+
+func TestEnableSecrets(t *testing.T) {
+	projectDir := generate(t)
+	f, err := Lookup("sops")
+	require.NoError(t, err)
+
+	result, err := Enable(projectDir, f, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{".sops.yaml", "secrets/secrets.example.yaml"}, result.Written)
+	// Only the targets of sops are added, not those of Vault
+	assert.Equal(t, []string{"secrets-init", "secrets-edit", "secrets-decrypt"}, result.MakeTargets)
+
+	cfg, err := config.LoadConfigFromFile(filepath.Join(projectDir, config.ProjectFileName))
+	require.NoError(t, err)
+	assert.Equal(t, config.SecretsManagerSops, cfg.SecretsManager)
+}
@@ -0,0 +1,472 @@
+// Package feature describes the optional parts of a generated project, such as
+// linters or GitHub Actions, so they can be enabled or removed after generation.
+package feature
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/oculus-core/gogo/internal/manifest"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// Feature is an optional part of a generated project
+type Feature struct {
+	Name        string
+	Description string
+
+	// Aliases are alternative names accepted on the command line
+	Aliases []string
+
+	// Paths are the files the feature generates, relative to the project.
+	// Paths ending in "/" cover every generated file below that directory.
+	Paths []string
+
+	// MakeTargets are the Makefile targets that belong to the feature
+	MakeTargets []string
+
+	// Updates are files of other features that change with the feature, such
+	// as the navigation of the docs site. Enable and Remove regenerate those
+	// the project has.
+	Updates []string
+
+	// Enabled reports whether the feature is turned on in a configuration
+	Enabled func(cfg *config.ProjectConfig) bool
+
+	// Set turns the feature on or off in a configuration
+	Set func(cfg *config.ProjectConfig, on bool)
+}
+
+// Features lists the features that can be toggled in a project, sorted by name
+var Features = []*Feature{
+	{
+		Name:        "adr",
+		Description: "architecture decision records and make adr target",
+		Paths: []string{
+			"docs/adr/index.md", "docs/adr/template.md", "docs/adr/0001-record-architecture-decisions.md",
+			"docs/content/adr/_index.md", "docs/content/adr/template.md",
+			"docs/content/adr/0001-record-architecture-decisions.md", "scripts/new-adr.sh",
+		},
+		MakeTargets: []string{"adr"},
+		Updates:     docsSiteUpdates,
+		Enabled:     func(cfg *config.ProjectConfig) bool { return cfg.UseADR },
+		Set:         func(cfg *config.ProjectConfig, on bool) { cfg.UseADR = on },
+	},
+	{
+		Name:        "apidiff",
+		Description: "make apidiff target and API compatibility workflow",
+		Aliases:     []string{"gorelease"},
+		Paths:       []string{".github/workflows/apidiff.yml"},
+		MakeTargets: []string{"apidiff"},
+		Enabled:     func(cfg *config.ProjectConfig) bool { return cfg.UseAPIDiff },
+		Set:         func(cfg *config.ProjectConfig, on bool) { cfg.UseAPIDiff = on },
+	},
+	{
+		Name:        "benchmarks",
+		Description: "make bench target and benchstat comparison workflow",
+		Aliases:     []string{"bench"},
+		Paths:       []string{".github/workflows/bench.yml"},
+		MakeTargets: []string{"bench"},
+		Enabled:     func(cfg *config.ProjectConfig) bool { return cfg.UseBenchmarks },
+		Set:         func(cfg *config.ProjectConfig, on bool) { cfg.UseBenchmarks = on },
+	},
+	{
+		Name:        "catalog-info",
+		Description: "Backstage catalog-info.yaml",
+		Paths:       []string{"catalog-info.yaml"},
+		Enabled:     func(cfg *config.ProjectConfig) bool { return cfg.CreateCatalogInfo },
+		Set:         func(cfg *config.ProjectConfig, on bool) { cfg.CreateCatalogInfo = on },
+	},
+	{
+		Name:        "docker",
+		Description: "Dockerfile, image publishing workflow, and make docker-build and docker-push targets",
+		Aliases:     []string{"dockerfile"},
+		Paths:       []string{"Dockerfile", ".dockerignore", ".github/workflows/image.yml"},
+		MakeTargets: []string{"docker-build", "docker-push"},
+		Enabled:     func(cfg *config.ProjectConfig) bool { return cfg.UseDocker },
+		Set:         func(cfg *config.ProjectConfig, on bool) { cfg.UseDocker = on },
+	},
+	{
+		Name:        "docs",
+		Description: "architecture, development, and deployment pages in docs",
+		Paths: []string{
+			"docs/architecture.md", "docs/development.md", "docs/deployment.md",
+			"docs/content/architecture.md", "docs/content/development.md", "docs/content/deployment.md",
+		},
+		Updates: docsSiteUpdates,
+		Enabled: func(cfg *config.ProjectConfig) bool { return cfg.UseDocs },
+		Set:     func(cfg *config.ProjectConfig, on bool) { cfg.UseDocs = on },
+	},
+	{
+		Name:        "docs-site",
+		Description: "MkDocs or Hugo documentation site and GitHub Pages workflow",
+		Aliases:     []string{"site"},
+		Paths: []string{
+			"mkdocs.yml", "docs/hugo.toml", "docs/layouts/", "docs/index.md", "docs/getting-started.md",
+			"docs/content/_index.md", "docs/content/getting-started.md", ".github/workflows/docs.yml",
+		},
+		MakeTargets: []string{"docs-serve", "docs-build"},
+		Enabled: func(cfg *config.ProjectConfig) bool {
+			return cfg.DocsSite != "" && cfg.DocsSite != config.DocsSiteNone
+		},
+		Set: func(cfg *config.ProjectConfig, on bool) {
+			switch {
+			case !on:
+				cfg.DocsSite = config.DocsSiteNone
+			case cfg.DocsSite == "" || cfg.DocsSite == config.DocsSiteNone:
+				cfg.DocsSite = config.DocsSiteMkDocs
+			}
+		},
+	},
+	{
+		Name:        "examples",
+		Description: "example programs in examples/",
+		Paths:       []string{"examples/"},
+		MakeTargets: []string{"examples"},
+		Enabled:     func(cfg *config.ProjectConfig) bool { return cfg.UseExamples },
+		Set:         func(cfg *config.ProjectConfig, on bool) { cfg.UseExamples = on },
+	},
+	{
+		Name:        "github-actions",
+		Description: "GitHub Actions workflows",
+		Aliases:     []string{"actions"},
+		Paths:       []string{".github/workflows/ci.yml"},
+		Enabled:     func(cfg *config.ProjectConfig) bool { return cfg.UseGitHubActions },
+		Set:         func(cfg *config.ProjectConfig, on bool) { cfg.UseGitHubActions = on },
+	},
+	{
+		Name:        "license",
+		Description: "LICENSE file",
+		Paths:       []string{"LICENSE"},
+		Enabled:     func(cfg *config.ProjectConfig) bool { return cfg.CreateLicense },
+		Set:         func(cfg *config.ProjectConfig, on bool) { cfg.CreateLicense = on },
+	},
+	{
+		Name:        "linters",
+		Description: "golangci-lint configuration, lint workflow, and make lint",
+		Aliases:     []string{"lint"},
+		Paths:       []string{".golangci.yml", ".github/workflows/lint.yml"},
+		MakeTargets: []string{"lint"},
+		Enabled:     func(cfg *config.ProjectConfig) bool { return cfg.UseLinters },
+		Set:         func(cfg *config.ProjectConfig, on bool) { cfg.UseLinters = on },
+	},
+	{
+		Name:        "live-reload",
+		Description: "air configuration and make dev for API projects",
+		Aliases:     []string{"air", "dev"},
+		Paths:       []string{".air.toml"},
+		MakeTargets: []string{"dev"},
+		Enabled:     func(cfg *config.ProjectConfig) bool { return cfg.UseLiveReload },
+		Set:         func(cfg *config.ProjectConfig, on bool) { cfg.UseLiveReload = on },
+	},
+	{
+		Name:        "makefile",
+		Description: "Makefile",
+		Paths:       []string{"Makefile"},
+		Enabled:     func(cfg *config.ProjectConfig) bool { return cfg.CreateMakefile },
+		Set:         func(cfg *config.ProjectConfig, on bool) { cfg.CreateMakefile = on },
+	},
+	{
+		Name:        "notify",
+		Description: "internal/notify package with SMTP and Slack webhook notifiers",
+		Paths:       []string{"internal/notify/"},
+		Enabled:     func(cfg *config.ProjectConfig) bool { return cfg.UseNotify },
+		Set:         func(cfg *config.ProjectConfig, on bool) { cfg.UseNotify = on },
+	},
+	{
+		Name:        "pre-commit",
+		Description: "pre-commit hooks and commitlint configuration",
+		Aliases:     []string{"precommit"},
+		Paths:       []string{".pre-commit-config.yaml", ".commitlintrc.yaml"},
+		Enabled:     func(cfg *config.ProjectConfig) bool { return cfg.UsePreCommitHooks },
+		Set:         func(cfg *config.ProjectConfig, on bool) { cfg.UsePreCommitHooks = on },
+	},
+	{
+		Name:        "readme",
+		Description: "README.md",
+		Paths:       []string{"README.md"},
+		Enabled:     func(cfg *config.ProjectConfig) bool { return cfg.CreateReadme },
+		Set:         func(cfg *config.ProjectConfig, on bool) { cfg.CreateReadme = on },
+	},
+	{
+		Name:        "secrets",
+		Description: "sops and age or Vault Agent secrets setup",
+		Aliases:     []string{"sops", "vault"},
+		Paths: []string{
+			".sops.yaml", "secrets/secrets.example.yaml",
+			"vault/agent.hcl", "vault/secrets.env.ctmpl",
+		},
+		MakeTargets: []string{"secrets-init", "secrets-edit", "secrets-decrypt", "secrets-render", "secrets-agent"},
+		Enabled: func(cfg *config.ProjectConfig) bool {
+			return cfg.SecretsManager != "" && cfg.SecretsManager != config.SecretsManagerNone
+		},
+		Set: func(cfg *config.ProjectConfig, on bool) {
+			switch {
+			case !on:
+				cfg.SecretsManager = config.SecretsManagerNone
+			case cfg.SecretsManager == "" || cfg.SecretsManager == config.SecretsManagerNone:
+				cfg.SecretsManager = config.SecretsManagerSops
+			}
+		},
+	},
+	{
+		Name:        "test",
+		Description: "test helpers, integration tests, and make test-unit and test-integration targets",
+		Paths:       []string{"test/"},
+		MakeTargets: []string{"test-unit", "test-integration"},
+		Enabled:     func(cfg *config.ProjectConfig) bool { return cfg.UseTest },
+		Set:         func(cfg *config.ProjectConfig, on bool) { cfg.UseTest = on },
+	},
+	{
+		Name:        "version-file",
+		Description: "VERSION file, version bump targets, and GoReleaser config",
+		Aliases:     []string{"version"},
+		Paths:       []string{"VERSION", ".goreleaser.yaml"},
+		MakeTargets: []string{"bump-patch", "bump-minor", "bump-major", "tag"},
+		Enabled:     func(cfg *config.ProjectConfig) bool { return cfg.CreateVersionFile },
+		Set:         func(cfg *config.ProjectConfig, on bool) { cfg.CreateVersionFile = on },
+	},
+}
+
+// docsSiteUpdates are the navigation and the home page of the docs site, which
+// list the pages of the starter docs and the decision records
+var docsSiteUpdates = []string{"mkdocs.yml", "docs/index.md", "docs/content/_index.md"}
+
+// Lookup returns the feature with the given name or alias
+func Lookup(name string) (*Feature, error) {
+	for _, f := range Features {
+		if f.Name == name {
+			return f, nil
+		}
+		for _, alias := range f.Aliases {
+			if alias == name {
+				return f, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("unknown feature %q (available: %s)", name, strings.Join(Names(), ", "))
+}
+
+// Names returns the names of all features
+func Names() []string {
+	names := make([]string, 0, len(Features))
+	for _, f := range Features {
+		names = append(names, f.Name)
+	}
+	return names
+}
+
+// ModifiedError is returned by Remove when files it would change have been
+// modified since they were generated
+type ModifiedError struct {
+	Feature string
+	Paths   []string
+}
+
+func (e *ModifiedError) Error() string {
+	return fmt.Sprintf("not removing %s: modified since generation: %s (use --force to remove anyway)",
+		e.Feature, strings.Join(e.Paths, ", "))
+}
+
+// RemoveResult reports what Remove changed
+type RemoveResult struct {
+	// Removed are the deleted files
+	Removed []string
+	// MakeTargets are the targets stripped from the Makefile
+	MakeTargets []string
+	// Updated are the regenerated Updates of the feature
+	Updated []string
+}
+
+// Remove deletes the generated files of a feature from a project, strips its
+// Makefile targets, and turns it off in gogo.yaml. Files that differ from the
+// manifest are left alone unless force is set, in which case they are removed too.
+func Remove(projectDir string, f *Feature, force bool) (*RemoveResult, error) {
+	m, err := manifest.Load(projectDir)
+	if err != nil {
+		return nil, err
+	}
+
+	configPath := filepath.Join(projectDir, config.ProjectFileName)
+	cfg, err := config.LoadConfigFromFile(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	paths, err := f.generatedFiles(projectDir, cfg, m)
+	if err != nil {
+		return nil, err
+	}
+
+	makefile, targets, err := f.strippedMakefile(projectDir)
+	if err != nil {
+		return nil, err
+	}
+
+	if !f.Enabled(cfg) && len(paths) == 0 && len(targets) == 0 {
+		return nil, fmt.Errorf("feature %s is not enabled in this project", f.Name)
+	}
+	f.Set(cfg, false)
+
+	// The files that list the removed ones are regenerated without them
+	var updates map[string][]byte
+	if existing := f.existingUpdates(projectDir, cfg); len(existing) > 0 {
+		generatedDir, cleanup, err := generateScratch(cfg)
+		if err != nil {
+			return nil, err
+		}
+		defer cleanup()
+		if updates, err = readUpdates(projectDir, generatedDir, existing); err != nil {
+			return nil, err
+		}
+	}
+
+	if !force {
+		check := append([]string{}, paths...)
+		if len(targets) > 0 {
+			check = append(check, "Makefile")
+		}
+		check = append(check, sortedKeys(updates)...)
+		var modified []string
+		for _, p := range check {
+			changed, err := m.IsModified(projectDir, p)
+			if err != nil {
+				return nil, err
+			}
+			if changed {
+				modified = append(modified, p)
+			}
+		}
+		if len(modified) > 0 {
+			return nil, &ModifiedError{Feature: f.Name, Paths: modified}
+		}
+	}
+
+	result := &RemoveResult{MakeTargets: targets}
+	for _, p := range paths {
+		err := os.Remove(filepath.Join(projectDir, filepath.FromSlash(p)))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("failed to remove %s: %w", p, err)
+		}
+		m.Forget(p)
+		result.Removed = append(result.Removed, p)
+	}
+	removeEmptyDirs(projectDir, paths)
+
+	if len(targets) > 0 {
+		if err := os.WriteFile(filepath.Join(projectDir, "Makefile"), makefile, 0600); err != nil {
+			return nil, fmt.Errorf("failed to update Makefile: %w", err)
+		}
+		if err := m.Record(projectDir, "Makefile"); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, p := range sortedKeys(updates) {
+		if err := writeUpdate(projectDir, p, updates[p], m); err != nil {
+			return nil, err
+		}
+		result.Updated = append(result.Updated, p)
+	}
+
+	if err := config.SaveProjectFile(cfg, configPath); err != nil {
+		return nil, err
+	}
+	if err := m.Record(projectDir, config.ProjectFileName); err != nil {
+		return nil, err
+	}
+
+	if err := m.Write(projectDir); err != nil {
+		return nil, fmt.Errorf("failed to write manifest: %w", err)
+	}
+	return result, nil
+}
+
+// paths returns the Paths of the feature in the layout of the project
+func (f *Feature) paths(cfg *config.ProjectConfig) []string {
+	paths := make([]string, 0, len(f.Paths))
+	for _, p := range f.Paths {
+		paths = append(paths, cfg.LayoutPath(p))
+	}
+	return paths
+}
+
+// generatedFiles returns the existing or recorded files of the feature, sorted
+func (f *Feature) generatedFiles(projectDir string, cfg *config.ProjectConfig, m *manifest.Manifest) ([]string, error) {
+	seen := map[string]bool{}
+	for _, p := range f.paths(cfg) {
+		if strings.HasSuffix(p, "/") {
+			for recorded := range m.Files {
+				if strings.HasPrefix(recorded, p) {
+					seen[recorded] = true
+				}
+			}
+			continue
+		}
+
+		_, recorded := m.Files[p]
+		_, err := os.Stat(filepath.Join(projectDir, filepath.FromSlash(p)))
+		switch {
+		case err == nil, recorded:
+			seen[p] = true
+		case !errors.Is(err, fs.ErrNotExist):
+			return nil, err
+		}
+	}
+
+	paths := make([]string, 0, len(seen))
+	for p := range seen {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// strippedMakefile returns the project Makefile without the feature targets,
+// and the targets that were found
+func (f *Feature) strippedMakefile(projectDir string) ([]byte, []string, error) {
+	if len(f.MakeTargets) == 0 {
+		return nil, nil, nil
+	}
+	data, err := os.ReadFile(filepath.Join(projectDir, "Makefile"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read Makefile: %w", err)
+	}
+
+	stripped, found := StripMakeTargets(string(data), f.MakeTargets)
+	return []byte(stripped), found, nil
+}
+
+// removeEmptyDirs removes the directories of the removed files that are now empty
+func removeEmptyDirs(projectDir string, paths []string) {
+	dirs := map[string]bool{}
+	for _, p := range paths {
+		for dir := filepath.Dir(filepath.FromSlash(p)); dir != "."; dir = filepath.Dir(dir) {
+			dirs[dir] = true
+		}
+	}
+
+	// Remove the deepest directories first
+	sorted := make([]string, 0, len(dirs))
+	for dir := range dirs {
+		sorted = append(sorted, dir)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return len(sorted[i]) > len(sorted[j]) })
+
+	for _, dir := range sorted {
+		path := filepath.Join(projectDir, dir)
+		entries, err := os.ReadDir(path)
+		if err != nil || len(entries) > 0 {
+			continue
+		}
+		_ = os.Remove(path)
+	}
+}
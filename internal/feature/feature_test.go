@@ -0,0 +1,205 @@
+package feature
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oculus-core/gogo/internal/manifest"
+	"github.com/oculus-core/gogo/internal/wizard"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+func generate(t *testing.T) string {
+	t.Helper()
+	cfg := config.NewDefaultProjectConfig()
+	cfg.Name = "demo"
+	cfg.Module = "github.com/acme/demo"
+
+	dir := t.TempDir()
+	require.NoError(t, wizard.GenerateProject(cfg, dir))
+	return filepath.Join(dir, cfg.Name)
+}
+
+func TestRemoveLinters(t *testing.T) {
+	projectDir := generate(t)
+	f, err := Lookup("linters")
+	require.NoError(t, err)
+
+	result, err := Remove(projectDir, f, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{".github/workflows/lint.yml", ".golangci.yml"}, result.Removed)
+	assert.Equal(t, []string{"lint"}, result.MakeTargets)
+
+	assert.NoFileExists(t, filepath.Join(projectDir, ".golangci.yml"))
+	assert.FileExists(t, filepath.Join(projectDir, ".github", "workflows", "ci.yml"))
+
+	makefile, err := os.ReadFile(filepath.Join(projectDir, "Makefile"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(makefile), "lint")
+	assert.Contains(t, string(makefile), "test-coverage:")
+
+	cfg, err := config.LoadConfigFromFile(filepath.Join(projectDir, config.ProjectFileName))
+	require.NoError(t, err)
+	assert.False(t, cfg.UseLinters)
+	assert.True(t, cfg.UseGitHubActions)
+
+	// The manifest is updated, so the project reports no changes
+	m, err := manifest.Load(projectDir)
+	require.NoError(t, err)
+	statuses, err := m.Status(projectDir)
+	require.NoError(t, err)
+	for _, s := range statuses {
+		assert.Equal(t, manifest.Unmodified, s.State, s.Path)
+	}
+	assert.NotContains(t, m.Files, ".golangci.yml")
+
+	_, err = Remove(projectDir, f, false)
+	assert.ErrorContains(t, err, "not enabled")
+}
+
+// TestRemoveDocs tests that removing the starter docs leaves the decision
+// records and the docs site, whose navigation no longer lists the pages
+func TestRemoveDocs(t *testing.T) {
+	cfg := config.NewDefaultProjectConfig()
+	cfg.Name = "demo"
+	cfg.Module = "github.com/acme/demo"
+	cfg.UseADR = true
+	cfg.DocsSite = config.DocsSiteMkDocs
+	dir := t.TempDir()
+	require.NoError(t, wizard.GenerateProject(cfg, dir))
+	projectDir := filepath.Join(dir, cfg.Name)
+
+	f, err := Lookup("docs")
+	require.NoError(t, err)
+	result, err := Remove(projectDir, f, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"docs/architecture.md", "docs/deployment.md", "docs/development.md"}, result.Removed)
+	assert.Equal(t, []string{"docs/index.md", "mkdocs.yml"}, result.Updated)
+
+	for _, file := range []string{
+		"docs/adr/index.md", "docs/adr/template.md", "docs/adr/0001-record-architecture-decisions.md",
+		"docs/index.md", "docs/getting-started.md", "mkdocs.yml",
+	} {
+		assert.FileExists(t, filepath.Join(projectDir, filepath.FromSlash(file)))
+	}
+	mkdocs, err := os.ReadFile(filepath.Join(projectDir, "mkdocs.yml"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(mkdocs), "architecture.md")
+	assert.Contains(t, string(mkdocs), "adr/index.md")
+
+	saved, err := config.LoadConfigFromFile(filepath.Join(projectDir, config.ProjectFileName))
+	require.NoError(t, err)
+	assert.False(t, saved.UseDocs)
+	assert.True(t, saved.UseADR)
+
+	m, err := manifest.Load(projectDir)
+	require.NoError(t, err)
+	statuses, err := m.Status(projectDir)
+	require.NoError(t, err)
+	for _, s := range statuses {
+		assert.Equal(t, manifest.Unmodified, s.State, s.Path)
+	}
+
+	// Enabling the pages again lists them in the site
+	enabled, err := Enable(projectDir, f, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"docs/index.md", "mkdocs.yml"}, enabled.Updated)
+	mkdocs, err = os.ReadFile(filepath.Join(projectDir, "mkdocs.yml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(mkdocs), "  - Architecture: architecture.md\n")
+}
+
+// TestFeaturePathsOwnedOnce tests that no file belongs to two features
+func TestFeaturePathsOwnedOnce(t *testing.T) {
+	owners := map[string]string{}
+	for _, f := range Features {
+		for _, p := range f.Paths {
+			for other, owner := range owners {
+				if owner == f.Name {
+					continue
+				}
+				overlap := p == other ||
+					strings.HasSuffix(other, "/") && strings.HasPrefix(p, other) ||
+					strings.HasSuffix(p, "/") && strings.HasPrefix(other, p)
+				assert.False(t, overlap, "%s of %s overlaps %s of %s", p, f.Name, other, owner)
+			}
+			owners[p] = f.Name
+		}
+	}
+}
+
+func TestRemoveRemovesEmptyDirectories(t *testing.T) {
+	projectDir := generate(t)
+	for _, name := range []string{"linters", "github-actions"} {
+		f, err := Lookup(name)
+		require.NoError(t, err)
+		_, err = Remove(projectDir, f, false)
+		require.NoError(t, err)
+	}
+	assert.NoDirExists(t, filepath.Join(projectDir, ".github"))
+}
+
+func TestRemoveLayout(t *testing.T) {
+	cfg := config.NewDefaultProjectConfig()
+	cfg.Name = "demo"
+	cfg.Module = "github.com/acme/demo"
+	cfg.Layout = map[string]string{"docs": "documentation"}
+	dir := t.TempDir()
+	require.NoError(t, wizard.GenerateProject(cfg, dir))
+	projectDir := filepath.Join(dir, cfg.Name)
+
+	f, err := Lookup("docs")
+	require.NoError(t, err)
+	result, err := Remove(projectDir, f, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"documentation/architecture.md", "documentation/deployment.md", "documentation/development.md"}, result.Removed)
+	assert.NoDirExists(t, filepath.Join(projectDir, "documentation"))
+}
+
+func TestRemoveRefusesModifiedFiles(t *testing.T) {
+	projectDir := generate(t)
+	f, err := Lookup("pre-commit")
+	require.NoError(t, err)
+
+	path := filepath.Join(projectDir, ".pre-commit-config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("repos: []\n"), 0600))
+
+	_, err = Remove(projectDir, f, false)
+	var modErr *ModifiedError
+	require.True(t, errors.As(err, &modErr))
+	assert.Equal(t, []string{".pre-commit-config.yaml"}, modErr.Paths)
+	assert.FileExists(t, path)
+	assert.FileExists(t, filepath.Join(projectDir, ".commitlintrc.yaml"))
+
+	_, err = Remove(projectDir, f, true)
+	require.NoError(t, err)
+	assert.NoFileExists(t, path)
+}
+
+func TestLookupUnknown(t *testing.T) {
+	_, err := Lookup("helm")
+	assert.ErrorContains(t, err, "available: adr, apidiff, benchmarks, catalog-info")
+}
+
+func TestStripMakeTargets(t *testing.T) {
+	content := ".PHONY: build lint\n\n" +
+		"# Build\nbuild:\n\tgo build ./...\n\n" +
+		"# Lint the code\nlint:\n\tgolangci-lint run\n\n" +
+		"help:\n\t@echo \"  build   - Build\"\n\t@echo \"  lint    - Lint\"\n"
+
+	stripped, found := StripMakeTargets(content, []string{"lint", "docker"})
+	assert.Equal(t, []string{"lint"}, found)
+	assert.Equal(t, ".PHONY: build\n\n"+
+		"# Build\nbuild:\n\tgo build ./...\n\n"+
+		"help:\n\t@echo \"  build   - Build\"\n", stripped)
+
+	unchanged, found := StripMakeTargets(content, []string{"docker"})
+	assert.Empty(t, found)
+	assert.Equal(t, content, unchanged)
+}
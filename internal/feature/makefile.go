@@ -0,0 +1,106 @@
+package feature
+
+import (
+	"strings"
+)
+
+// StripMakeTargets removes the rules of the given targets from a Makefile,
+// together with the comment above each rule, their .PHONY entries, and the
+// matching lines of the help target. It returns the targets that were found.
+func StripMakeTargets(content string, targets []string) (string, []string) {
+	remove := map[string]bool{}
+	for _, t := range targets {
+		remove[t] = true
+	}
+
+	// Rules are separated by blank lines
+	blocks := strings.Split(content, "\n\n")
+	kept := make([]string, 0, len(blocks))
+	var found []string
+	for _, block := range blocks {
+		if target := ruleTarget(block); remove[target] {
+			found = append(found, target)
+			continue
+		}
+		kept = append(kept, block)
+	}
+	if len(found) == 0 {
+		return content, nil
+	}
+
+	lines := strings.Split(strings.Join(kept, "\n\n"), "\n")
+	out := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if strings.HasPrefix(line, ".PHONY:") {
+			line = stripPhony(line, remove)
+			if line == "" {
+				continue
+			}
+		}
+		if helpTarget(line) != "" && remove[helpTarget(line)] {
+			continue
+		}
+		out = append(out, line)
+	}
+	return strings.Join(out, "\n"), found
+}
+
+// ruleTarget returns the target of the first rule in a block, skipping comments
+func ruleTarget(block string) string {
+	for _, line := range strings.Split(block, "\n") {
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, _, ok := strings.Cut(line, ":")
+		if !ok || strings.ContainsAny(name, " \t=$") || strings.HasPrefix(name, ".") {
+			return ""
+		}
+		return name
+	}
+	return ""
+}
+
+// stripPhony removes targets from a .PHONY line; it returns "" when none are left
+func stripPhony(line string, remove map[string]bool) string {
+	var names []string
+	for _, name := range strings.Fields(strings.TrimPrefix(line, ".PHONY:")) {
+		if !remove[name] {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	return ".PHONY: " + strings.Join(names, " ")
+}
+
+// helpTarget returns the target described by a help line such as
+// `	@echo "  lint   - Lint the code"`
+func helpTarget(line string) string {
+	rest, ok := strings.CutPrefix(strings.TrimSpace(line), `@echo "  `)
+	if !ok {
+		return ""
+	}
+	name, _, ok := strings.Cut(rest, " ")
+	if !ok {
+		return ""
+	}
+	return name
+}
+
+// ExtractMakeTargets returns the rules of the given targets in a Makefile,
+// with the comment above each rule, separated by blank lines
+func ExtractMakeTargets(content string, targets []string) string {
+	want := map[string]bool{}
+	for _, t := range targets {
+		want[t] = true
+	}
+
+	var rules []string
+	for _, block := range strings.Split(content, "\n\n") {
+		if want[ruleTarget(block)] {
+			rules = append(rules, strings.TrimRight(block, "\n"))
+		}
+	}
+	return strings.Join(rules, "\n\n")
+}
@@ -0,0 +1,37 @@
+// Package gitsource resolves the git-hosted sources of policies and template
+// sets, written as host/owner/repo@ref or as git URLs, and where they are cached.
+package gitsource
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// URL converts a source into a clonable URL and an optional ref
+func URL(source string) (url, ref string) {
+	url = source
+
+	// Split a trailing @ref, ignoring the user part of scp-style URLs (git@host:path)
+	if i := strings.LastIndex(url, "@"); i > 0 && !strings.Contains(url[i:], ":") && strings.Contains(url[:i], "/") {
+		url, ref = url[:i], url[i+1:]
+	}
+
+	if !strings.Contains(url, "://") && !strings.HasPrefix(url, "git@") {
+		url = "https://" + url
+	}
+	return url, ref
+}
+
+// CacheDir returns the directory where a source is cached, below the kind
+// directory, such as policies or templates, of the gogo cache
+func CacheDir(kind, source string) (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate cache directory: %w", err)
+	}
+
+	replacer := strings.NewReplacer("://", "_", "/", "_", ":", "_", "@", "_")
+	return filepath.Join(base, "gogo", kind, replacer.Replace(source)), nil
+}
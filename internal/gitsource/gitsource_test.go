@@ -0,0 +1,40 @@
+package gitsource
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestURL(t *testing.T) {
+	tests := []struct {
+		source string
+		url    string
+		ref    string
+	}{
+		{"github.com/acme/gogo-policy", "https://github.com/acme/gogo-policy", ""},
+		{"github.com/acme/gogo-policy@v1.2.0", "https://github.com/acme/gogo-policy", "v1.2.0"},
+		{"https://git.example.com/policy.git", "https://git.example.com/policy.git", ""},
+		{"git@github.com:acme/gogo-policy.git", "git@github.com:acme/gogo-policy.git", ""},
+		{"git@github.com:acme/gogo-policy.git@main", "git@github.com:acme/gogo-policy.git", "main"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.source, func(t *testing.T) {
+			url, ref := URL(tc.source)
+			assert.Equal(t, tc.url, url)
+			assert.Equal(t, tc.ref, ref)
+		})
+	}
+}
+
+func TestCacheDir(t *testing.T) {
+	cache := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", cache)
+
+	dir, err := CacheDir("templates", "github.com/acme/gogo-templates@v1")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(cache, "gogo", "templates", "github.com_acme_gogo-templates_v1"), dir)
+}
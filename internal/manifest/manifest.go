@@ -0,0 +1,249 @@
+// Package manifest records the files gogo generated in a project so later
+// commands can tell which of them the user has modified.
+package manifest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Dir is the directory gogo keeps its project state in
+const Dir = ".gogo"
+
+// FileName is the path of the manifest relative to the project directory
+const FileName = Dir + "/manifest.json"
+
+// OriginalsDir holds the generated files as gogo wrote them, which the
+// three-way merges of template sets start from
+const OriginalsDir = Dir + "/original"
+
+// SchemaVersion is the version of the manifest format
+const SchemaVersion = 1
+
+// ErrNotFound is returned by Load when a project has no manifest
+var ErrNotFound = errors.New("no " + FileName + " found; was the project generated by gogo?")
+
+// Manifest lists the generated files of a project with their content hashes
+type Manifest struct {
+	Version     int       `json:"version"`
+	GeneratedAt time.Time `json:"generated_at"`
+
+	// Files maps slash-separated paths relative to the project to "sha256:<hex>"
+	Files map[string]string `json:"files"`
+}
+
+// New returns an empty manifest
+func New() *Manifest {
+	return &Manifest{
+		Version:     SchemaVersion,
+		GeneratedAt: time.Now().UTC().Truncate(time.Second),
+		Files:       map[string]string{},
+	}
+}
+
+// Build records every file below projectDir, except the .git and .gogo directories
+func Build(projectDir string) (*Manifest, error) {
+	m := New()
+	err := filepath.WalkDir(projectDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if name := d.Name(); path != projectDir && (name == ".git" || name == Dir) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(projectDir, path)
+		if err != nil {
+			return err
+		}
+		return m.Record(projectDir, filepath.ToSlash(rel))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build manifest: %w", err)
+	}
+	return m, nil
+}
+
+// Record stores the current hash of the given files
+func (m *Manifest) Record(projectDir string, paths ...string) error {
+	for _, p := range paths {
+		sum, err := HashFile(filepath.Join(projectDir, filepath.FromSlash(p)))
+		if err != nil {
+			return err
+		}
+		m.Files[p] = sum
+	}
+	return nil
+}
+
+// Forget removes files from the manifest
+func (m *Manifest) Forget(paths ...string) {
+	for _, p := range paths {
+		delete(m.Files, p)
+	}
+}
+
+// Load reads the manifest of a project
+func Load(projectDir string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(projectDir, FileName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", FileName, err)
+	}
+	if m.Version > SchemaVersion {
+		return nil, fmt.Errorf("%s has version %d; upgrade gogo to read it", FileName, m.Version)
+	}
+	if m.Files == nil {
+		m.Files = map[string]string{}
+	}
+	return &m, nil
+}
+
+// Write saves the manifest in the project
+func (m *Manifest) Write(projectDir string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+
+	path := filepath.Join(projectDir, FileName)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", Dir, err)
+	}
+	return os.WriteFile(path, append(data, '\n'), 0600)
+}
+
+// SaveOriginals copies the files of the manifest into OriginalsDir, replacing
+// the originals of an earlier generation
+func (m *Manifest) SaveOriginals(projectDir string) error {
+	root := filepath.Join(projectDir, filepath.FromSlash(OriginalsDir))
+	if err := os.RemoveAll(root); err != nil {
+		return fmt.Errorf("failed to remove %s: %w", OriginalsDir, err)
+	}
+	for p := range m.Files {
+		data, err := os.ReadFile(filepath.Join(projectDir, filepath.FromSlash(p)))
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", p, err)
+		}
+		target := filepath.Join(root, filepath.FromSlash(p))
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", target, err)
+		}
+		if err := os.WriteFile(target, data, 0600); err != nil {
+			return fmt.Errorf("failed to write %s: %w", target, err)
+		}
+	}
+	return nil
+}
+
+// Original returns a file as gogo generated it, and false when no original
+// was saved for it
+func Original(projectDir, path string) ([]byte, bool) {
+	data, err := os.ReadFile(filepath.Join(projectDir, filepath.FromSlash(OriginalsDir), filepath.FromSlash(path)))
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+// State is the state of a generated file compared to the manifest
+type State string
+
+// File states
+const (
+	Unmodified State = "unmodified"
+	Modified   State = "modified"
+	Deleted    State = "deleted"
+)
+
+// FileStatus is the state of a single generated file
+type FileStatus struct {
+	Path  string
+	State State
+}
+
+// Status compares the generated files with their recorded hashes, sorted by path
+func (m *Manifest) Status(projectDir string) ([]FileStatus, error) {
+	paths := make([]string, 0, len(m.Files))
+	for p := range m.Files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	statuses := make([]FileStatus, 0, len(paths))
+	for _, p := range paths {
+		sum, err := HashFile(filepath.Join(projectDir, filepath.FromSlash(p)))
+		state := Unmodified
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			state = Deleted
+		case err != nil:
+			return nil, err
+		case sum != m.Files[p]:
+			state = Modified
+		}
+		statuses = append(statuses, FileStatus{Path: p, State: state})
+	}
+	return statuses, nil
+}
+
+// IsModified reports whether a generated file differs from its recorded hash.
+// Files that are not in the manifest count as modified, since gogo did not write them.
+func (m *Manifest) IsModified(projectDir, path string) (bool, error) {
+	recorded, ok := m.Files[path]
+	if !ok {
+		return true, nil
+	}
+	sum, err := HashFile(filepath.Join(projectDir, filepath.FromSlash(path)))
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return sum != recorded, nil
+}
+
+// HashFile returns the "sha256:<hex>" hash of a file, reading it in chunks
+// so large files are not loaded into memory
+func HashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return "sha256:" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Hash returns the "sha256:<hex>" hash of content
+func Hash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,109 @@
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	}
+}
+
+func TestBuildAndStatus(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"go.mod":          "module x\n",
+		"Makefile":        "build:\n",
+		"cmd/x/main.go":   "package main\n",
+		".git/HEAD":       "ref: refs/heads/main\n",
+		".gogo/state.txt": "ignored",
+	})
+
+	m, err := Build(dir)
+	require.NoError(t, err)
+	assert.Len(t, m.Files, 3)
+	assert.Contains(t, m.Files, "cmd/x/main.go")
+	assert.Equal(t, Hash([]byte("module x\n")), m.Files["go.mod"])
+
+	require.NoError(t, m.Write(dir))
+	loaded, err := Load(dir)
+	require.NoError(t, err)
+	assert.Equal(t, m.Files, loaded.Files)
+
+	writeFiles(t, dir, map[string]string{"Makefile": "build:\n\tgo build\n", "extra.go": "package x\n"})
+	require.NoError(t, os.Remove(filepath.Join(dir, "go.mod")))
+
+	statuses, err := loaded.Status(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []FileStatus{
+		{Path: "Makefile", State: Modified},
+		{Path: "cmd/x/main.go", State: Unmodified},
+		{Path: "go.mod", State: Deleted},
+	}, statuses)
+
+	modified, err := loaded.IsModified(dir, "Makefile")
+	require.NoError(t, err)
+	assert.True(t, modified)
+	modified, err = loaded.IsModified(dir, "cmd/x/main.go")
+	require.NoError(t, err)
+	assert.False(t, modified)
+	modified, err = loaded.IsModified(dir, "extra.go")
+	require.NoError(t, err)
+	assert.True(t, modified, "files gogo did not write count as modified")
+
+	// Recording a file accepts its current content
+	require.NoError(t, loaded.Record(dir, "Makefile"))
+	loaded.Forget("go.mod")
+	statuses, err = loaded.Status(dir)
+	require.NoError(t, err)
+	for _, s := range statuses {
+		assert.Equal(t, Unmodified, s.State, s.Path)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	dir := t.TempDir()
+	_, err := Load(dir)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	writeFiles(t, dir, map[string]string{FileName: `{"version": 99}`})
+	_, err = Load(dir)
+	assert.ErrorContains(t, err, "upgrade gogo")
+}
+
+func TestSaveOriginals(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"README.md":                 "# x\n",
+		"cmd/x/main.go":             "package main\n",
+		OriginalsDir + "/stale.txt": "from an earlier generation",
+	})
+
+	m, err := Build(dir)
+	require.NoError(t, err)
+	require.NoError(t, m.SaveOriginals(dir))
+
+	// Edits after the generation leave the originals alone
+	writeFiles(t, dir, map[string]string{"README.md": "# x\n\nEdited.\n"})
+
+	original, ok := Original(dir, "README.md")
+	assert.True(t, ok)
+	assert.Equal(t, "# x\n", string(original))
+	original, ok = Original(dir, "cmd/x/main.go")
+	assert.True(t, ok)
+	assert.Equal(t, "package main\n", string(original))
+
+	_, ok = Original(dir, "stale.txt")
+	assert.False(t, ok, "earlier originals are replaced")
+	_, ok = Original(dir, "missing.go")
+	assert.False(t, ok)
+}
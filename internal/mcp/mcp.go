@@ -0,0 +1,365 @@
+// Package mcp serves gogo as a Model Context Protocol server over stdio, so
+// AI assistants can scaffold projects through gogo instead of guessing layouts.
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/oculus-core/gogo/internal/component"
+	"github.com/oculus-core/gogo/internal/policy"
+	"github.com/oculus-core/gogo/internal/server"
+	"github.com/oculus-core/gogo/internal/wizard"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// ProtocolVersion is the MCP revision implemented by the server
+const ProtocolVersion = "2024-11-05"
+
+// maxMessageBytes limits the size of a single JSON-RPC message
+const maxMessageBytes = 4 << 20
+
+// JSON-RPC error codes
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+)
+
+// Options configures the MCP server
+type Options struct {
+	// Policy is enforced on every validated or generated config; may be nil
+	Policy *policy.Policy
+
+	// Version is reported to clients as the server version
+	Version string
+
+	// Offline skips the steps of add_component that need the network, such
+	// as generating the stubs of a proto service with buf
+	Offline bool
+
+	// OnGenerated is called after a project has been generated
+	OnGenerated func(cfg *config.ProjectConfig, projectDir string)
+}
+
+// Server handles MCP requests
+type Server struct {
+	opts Options
+}
+
+// New creates an MCP server
+func New(opts Options) *Server {
+	return &Server{opts: opts}
+}
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return e.Message
+}
+
+// Serve reads newline-delimited JSON-RPC messages from r and writes responses
+// to w until r is exhausted
+func (s *Server) Serve(r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxMessageBytes)
+	enc := json.NewEncoder(w)
+
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		resp := s.handle(line)
+		if resp == nil {
+			continue
+		}
+		if err := enc.Encode(resp); err != nil {
+			return fmt.Errorf("failed to write response: %w", err)
+		}
+	}
+	return scanner.Err()
+}
+
+// handle processes a single message. Notifications have no response.
+func (s *Server) handle(line []byte) *response {
+	var req request
+	if err := json.Unmarshal(line, &req); err != nil {
+		return &response{JSONRPC: "2.0", ID: json.RawMessage("null"),
+			Error: &rpcError{codeParseError, "invalid JSON: " + err.Error()}}
+	}
+	if req.ID == nil {
+		return nil
+	}
+
+	resp := &response{JSONRPC: "2.0", ID: req.ID}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		resp.Error = &rpcError{codeInvalidRequest, "invalid JSON-RPC request"}
+		return resp
+	}
+
+	result, err := s.dispatch(req.Method, req.Params)
+	if err != nil {
+		var rerr *rpcError
+		if !errors.As(err, &rerr) {
+			rerr = &rpcError{codeInvalidParams, err.Error()}
+		}
+		resp.Error = rerr
+		return resp
+	}
+	resp.Result = result
+	return resp
+}
+
+func (s *Server) dispatch(method string, params json.RawMessage) (interface{}, error) {
+	switch method {
+	case "initialize":
+		return map[string]interface{}{
+			"protocolVersion": ProtocolVersion,
+			"capabilities":    map[string]interface{}{"tools": map[string]interface{}{}},
+			"serverInfo":      map[string]string{"name": "gogo", "version": s.opts.Version},
+		}, nil
+	case "ping":
+		return map[string]interface{}{}, nil
+	case "tools/list":
+		return map[string]interface{}{"tools": tools}, nil
+	case "tools/call":
+		var call struct {
+			Name      string          `json:"name"`
+			Arguments json.RawMessage `json:"arguments"`
+		}
+		if err := json.Unmarshal(params, &call); err != nil {
+			return nil, fmt.Errorf("invalid tool call: %w", err)
+		}
+		return s.callTool(call.Name, call.Arguments)
+	default:
+		return nil, &rpcError{codeMethodNotFound, "method not found: " + method}
+	}
+}
+
+// toolResult is the result of a tools/call request
+type toolResult struct {
+	Content []textContent `json:"content"`
+	IsError bool          `json:"isError,omitempty"`
+}
+
+type textContent struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+func textResult(text string) *toolResult {
+	return &toolResult{Content: []textContent{{Type: "text", Text: text}}}
+}
+
+// errorResult reports a tool failure to the model rather than as a protocol error
+func errorResult(err error) *toolResult {
+	res := textResult(err.Error())
+	res.IsError = true
+	return res
+}
+
+func (s *Server) callTool(name string, args json.RawMessage) (*toolResult, error) {
+	switch name {
+	case "list_project_types":
+		return s.listProjectTypes(), nil
+	case "validate_config":
+		return s.validateConfig(args), nil
+	case "generate_project":
+		return s.generateProject(args), nil
+	case "add_component":
+		return s.addComponent(args), nil
+	default:
+		return nil, fmt.Errorf("unknown tool %q", name)
+	}
+}
+
+func (s *Server) listProjectTypes() *toolResult {
+	var b strings.Builder
+	for _, t := range config.ProjectTypes {
+		fmt.Fprintf(&b, "%s: %s\n", t, t.Description())
+	}
+	return textResult(b.String())
+}
+
+func (s *Server) validateConfig(args json.RawMessage) *toolResult {
+	var in struct {
+		Config json.RawMessage `json:"config"`
+	}
+	if err := json.Unmarshal(args, &in); err != nil {
+		return errorResult(fmt.Errorf("invalid arguments: %w", err))
+	}
+
+	cfg, err := s.loadConfig(in.Config)
+	if err != nil {
+		return errorResult(err)
+	}
+
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return errorResult(err)
+	}
+	return textResult("Configuration is valid. Resolved configuration:\n" + string(data))
+}
+
+func (s *Server) generateProject(args json.RawMessage) *toolResult {
+	var in struct {
+		Config    json.RawMessage `json:"config"`
+		OutputDir string          `json:"output_dir"`
+	}
+	if err := json.Unmarshal(args, &in); err != nil {
+		return errorResult(fmt.Errorf("invalid arguments: %w", err))
+	}
+
+	cfg, err := s.loadConfig(in.Config)
+	if err != nil {
+		return errorResult(err)
+	}
+
+	outputDir := in.OutputDir
+	if outputDir == "" {
+		outputDir = "."
+	}
+	projectDir, err := filepath.Abs(filepath.Join(outputDir, cfg.Name))
+	if err != nil {
+		return errorResult(err)
+	}
+	if _, err := os.Stat(projectDir); err == nil {
+		return errorResult(fmt.Errorf("%s already exists", projectDir))
+	}
+
+	if err := wizard.GenerateProject(cfg, outputDir); err != nil {
+		return errorResult(fmt.Errorf("failed to generate project: %w", err))
+	}
+	if s.opts.OnGenerated != nil {
+		s.opts.OnGenerated(cfg, projectDir)
+	}
+
+	var files []string
+	_ = filepath.WalkDir(projectDir, func(path string, d os.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			rel, _ := filepath.Rel(projectDir, path)
+			files = append(files, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	return textResult(fmt.Sprintf("Generated %s project %s in %s\n\nFiles:\n%s\n",
+		cfg.Type, cfg.Name, projectDir, strings.Join(files, "\n")))
+}
+
+func (s *Server) addComponent(args json.RawMessage) *toolResult {
+	var in struct {
+		Dir          string `json:"dir"`
+		Component    string `json:"component"`
+		Name         string `json:"name"`
+		Fields       string `json:"fields"`
+		OpenAPI      string `json:"openapi"`
+		TapDir       string `json:"tap_dir"`
+		SkipGenerate bool   `json:"skip_generate"`
+		Force        bool   `json:"force"`
+	}
+	if err := json.Unmarshal(args, &in); err != nil {
+		return errorResult(fmt.Errorf("invalid arguments: %w", err))
+	}
+	if in.Name == "" && in.Component != "tap" {
+		return errorResult(fmt.Errorf("name is required for %s components", in.Component))
+	}
+
+	dir := in.Dir
+	if dir == "" {
+		dir = "."
+	}
+	p, err := component.LoadProject(dir)
+	if err != nil {
+		return errorResult(err)
+	}
+	p.Force = in.Force
+
+	var result *component.Result
+	switch in.Component {
+	case "resource":
+		fields, ferr := component.ParseFields(in.Fields)
+		if ferr != nil {
+			return errorResult(ferr)
+		}
+		result, err = component.AddResource(p, in.Name, fields)
+	case "middleware":
+		result, err = component.AddMiddleware(p, in.Name)
+	case "client":
+		result, err = component.AddClient(p, in.Name, in.OpenAPI)
+	case "job":
+		result, err = component.AddJob(p, in.Name)
+	case "proto":
+		result, err = component.AddProto(p, in.Name, !in.SkipGenerate && !s.opts.Offline)
+	case "command":
+		result, err = component.AddCommand(p, in.Name)
+	case "tap":
+		result, err = component.AddTap(p, in.TapDir)
+	default:
+		return errorResult(fmt.Errorf("unknown component %q: use one of %s", in.Component, strings.Join(components, ", ")))
+	}
+	if err != nil {
+		return errorResult(err)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Added %s %s to %s\n", in.Component, in.Name, dir)
+	for _, path := range result.Created {
+		fmt.Fprintf(&b, "\ncreated: %s", path)
+	}
+	for _, path := range result.Updated {
+		fmt.Fprintf(&b, "\nupdated: %s", path)
+	}
+	if len(result.Manual) > 0 {
+		b.WriteString("\n\nTo finish, manually:")
+		for _, step := range result.Manual {
+			fmt.Fprintf(&b, "\n- %s", step)
+		}
+	}
+	return textResult(b.String() + "\n")
+}
+
+// loadConfig decodes a config argument and enforces the policy
+func (s *Server) loadConfig(raw json.RawMessage) (*config.ProjectConfig, error) {
+	if len(raw) == 0 {
+		return nil, errors.New("config is required")
+	}
+
+	cfg, err := server.DecodeConfigWithRules(bytes.NewReader(raw), s.opts.Policy.DefaultRules())
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.opts.Policy.Apply(cfg); err != nil {
+		return nil, err
+	}
+	if err := s.opts.Policy.Validate(cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
@@ -0,0 +1,176 @@
+package mcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oculus-core/gogo/internal/policy"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// exchange sends the messages to the server and returns the decoded responses
+func exchange(t *testing.T, srv *Server, messages ...string) []map[string]interface{} {
+	t.Helper()
+	var out bytes.Buffer
+	require.NoError(t, srv.Serve(strings.NewReader(strings.Join(messages, "\n")), &out))
+
+	var responses []map[string]interface{}
+	dec := json.NewDecoder(&out)
+	for dec.More() {
+		var resp map[string]interface{}
+		require.NoError(t, dec.Decode(&resp))
+		responses = append(responses, resp)
+	}
+	return responses
+}
+
+// toolText returns the text content of a tools/call result
+func toolText(t *testing.T, resp map[string]interface{}) (string, bool) {
+	t.Helper()
+	result, ok := resp["result"].(map[string]interface{})
+	require.True(t, ok, "missing result: %v", resp)
+	content := result["content"].([]interface{})
+	require.Len(t, content, 1)
+	isError, _ := result["isError"].(bool)
+	return content[0].(map[string]interface{})["text"].(string), isError
+}
+
+func TestInitializeAndListTools(t *testing.T) {
+	responses := exchange(t, New(Options{Version: "1.2.3"}),
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2024-11-05"}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`,
+	)
+	require.Len(t, responses, 2)
+
+	initResult := responses[0]["result"].(map[string]interface{})
+	assert.Equal(t, ProtocolVersion, initResult["protocolVersion"])
+	assert.Equal(t, "1.2.3", initResult["serverInfo"].(map[string]interface{})["version"])
+
+	var names []string
+	for _, tl := range responses[1]["result"].(map[string]interface{})["tools"].([]interface{}) {
+		names = append(names, tl.(map[string]interface{})["name"].(string))
+	}
+	assert.ElementsMatch(t, []string{"list_project_types", "validate_config", "generate_project", "add_component"}, names)
+}
+
+func TestListProjectTypes(t *testing.T) {
+	responses := exchange(t, New(Options{}),
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"list_project_types"}}`)
+	text, isError := toolText(t, responses[0])
+	assert.False(t, isError)
+	assert.Contains(t, text, "cli:")
+	assert.Contains(t, text, "library:")
+}
+
+func TestValidateConfig(t *testing.T) {
+	srv := New(Options{Policy: &policy.Policy{Name: "acme", ModulePrefix: "github.com/acme/"}})
+
+	responses := exchange(t, srv,
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"validate_config","arguments":{"config":{"name":"svc","module":"github.com/other/svc"}}}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"validate_config","arguments":{"config":{"name":"svc","module":"github.com/acme/svc","type":"api"}}}}`,
+	)
+	require.Len(t, responses, 2)
+
+	text, isError := toolText(t, responses[0])
+	assert.True(t, isError)
+	assert.Contains(t, text, "module")
+
+	text, isError = toolText(t, responses[1])
+	assert.False(t, isError)
+	assert.Contains(t, text, `"use_gin": true`)
+
+	// The OpenAPI spec of sdk projects is a local path, which clients cannot set
+	responses = exchange(t, srv,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"generate_project","arguments":{"config":{"name":"sdk","module":"github.com/acme/sdk","type":"sdk","openapi_spec":"/etc/passwd"}}}}`)
+	text, isError = toolText(t, responses[0])
+	assert.True(t, isError)
+	assert.Contains(t, text, "openapi_spec")
+}
+
+func TestGenerateProject(t *testing.T) {
+	outputDir := t.TempDir()
+	var generated string
+	srv := New(Options{OnGenerated: func(_ *config.ProjectConfig, projectDir string) { generated = projectDir }})
+
+	args, err := json.Marshal(map[string]interface{}{
+		"config":     map[string]string{"name": "tool", "type": "cli"},
+		"output_dir": outputDir,
+	})
+	require.NoError(t, err)
+	call := `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"generate_project","arguments":` + string(args) + `}}`
+
+	responses := exchange(t, srv, call)
+	text, isError := toolText(t, responses[0])
+	require.False(t, isError, text)
+	assert.Contains(t, text, "cmd/tool/main.go")
+	assert.Equal(t, filepath.Join(outputDir, "tool"), generated)
+
+	_, err = os.Stat(filepath.Join(outputDir, "tool", "go.mod"))
+	assert.NoError(t, err)
+
+	// Generating into an existing directory fails
+	responses = exchange(t, srv, call)
+	_, isError = toolText(t, responses[0])
+	assert.True(t, isError)
+}
+
+func TestAddComponent(t *testing.T) {
+	outputDir := t.TempDir()
+	projectDir := filepath.Join(outputDir, "svc")
+	call := func(id int, args map[string]interface{}) string {
+		data, err := json.Marshal(map[string]interface{}{
+			"jsonrpc": "2.0", "id": id, "method": "tools/call",
+			"params": map[string]interface{}{"name": "add_component", "arguments": args},
+		})
+		require.NoError(t, err)
+		return string(data)
+	}
+	generate := `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"generate_project","arguments":` +
+		`{"config":{"name":"svc","module":"github.com/acme/svc","type":"api"},"output_dir":"` + outputDir + `"}}}`
+
+	responses := exchange(t, New(Options{}),
+		generate,
+		call(2, map[string]interface{}{"dir": projectDir, "component": "resource", "name": "user", "fields": "name:string,age:int"}),
+		call(3, map[string]interface{}{"dir": projectDir, "component": "resource", "name": "user"}),
+		call(4, map[string]interface{}{"dir": projectDir, "component": "command", "name": "serve"}),
+		call(5, map[string]interface{}{"dir": projectDir, "component": "widget", "name": "x"}),
+		call(6, map[string]interface{}{"dir": projectDir, "component": "job"}),
+	)
+	require.Len(t, responses, 6)
+
+	text, isError := toolText(t, responses[1])
+	require.False(t, isError, text)
+	assert.Contains(t, text, "created: internal/model/user.go")
+	assert.Contains(t, text, "updated: internal/api/server.go")
+	assert.FileExists(t, filepath.Join(projectDir, "internal", "model", "user.go"))
+
+	// The generators' errors are reported to the model
+	for i, want := range []string{"already exists", "CLI project", "unknown component", "name is required"} {
+		text, isError := toolText(t, responses[i+2])
+		assert.True(t, isError)
+		assert.Contains(t, text, want)
+	}
+}
+
+func TestProtocolErrors(t *testing.T) {
+	responses := exchange(t, New(Options{}),
+		`not json`,
+		`{"jsonrpc":"2.0","id":1,"method":"resources/list"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"rm_rf"}}`,
+	)
+	require.Len(t, responses, 3)
+
+	codes := make([]float64, 0, len(responses))
+	for _, resp := range responses {
+		codes = append(codes, resp["error"].(map[string]interface{})["code"].(float64))
+	}
+	assert.Equal(t, []float64{codeParseError, codeMethodNotFound, codeInvalidParams}, codes)
+}
@@ -0,0 +1,344 @@
+package mcp
+
+import "github.com/oculus-core/gogo/pkg/config"
+
+// tool describes an MCP tool in tools/list responses
+type tool struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	InputSchema map[string]interface{} `json:"inputSchema"`
+}
+
+// configSchema is the JSON schema of a project configuration argument
+var configSchema = map[string]interface{}{
+	"type":        "object",
+	"description": "Project configuration. Omitted fields take the defaults of the project type.",
+	"properties": map[string]interface{}{
+		"name":                 stringProperty("Project name, used as the directory name"),
+		"module":               stringProperty("Go module path, defaults to the name"),
+		"display_name":         stringProperty("Name shown in the README and docs, defaults to the name before it is made ASCII"),
+		"description":          stringProperty("Short project description"),
+		"license":              stringProperty("License: MIT, Apache-2.0, GPL-3.0, BSD-3-Clause, or None"),
+		"visibility":           visibilityProperty(),
+		"default_branch":       stringProperty("Branch the workflows run on and git init creates, main when omitted"),
+		"topics":               topicsProperty(),
+		"authors":              authorsProperty(),
+		"author":               stringProperty("Deprecated: use authors. Authors as Name <email> separated by commas"),
+		"type":                 typeProperty(),
+		"action_runtime":       actionRuntimeProperty(),
+		"operator_group":       stringProperty("API group of the custom resource of an operator project, such as cache.example.com"),
+		"operator_kind":        stringProperty("Kind of the custom resource of an operator project, such as Memcached"),
+		"use_kubebuilder":      boolProperty("Scaffold an operator project with kubebuilder when it is installed, instead of the built-in templates"),
+		"event_broker":         eventBrokerProperty(),
+		"use_cmd":              boolProperty("Create the cmd/ directory"),
+		"use_internal":         boolProperty("Create the internal/ directory"),
+		"use_pkg":              boolProperty("Create the pkg/ directory"),
+		"use_test":             boolProperty("Create the test/ directory"),
+		"use_docs":             boolProperty("Create the docs/ directory"),
+		"docs_site":            docsSiteProperty(),
+		"dir_placeholder":      dirPlaceholderProperty(),
+		"use_examples":         boolProperty("Create example programs in examples/ for library and API projects"),
+		"create_readme":        boolProperty("Generate README.md"),
+		"create_license":       boolProperty("Generate LICENSE"),
+		"create_makefile":      boolProperty("Generate a Makefile"),
+		"use_linters":          boolProperty("Configure golangci-lint"),
+		"formatting":           formattingProperty(),
+		"lint_preset":          lintPresetProperty(),
+		"commit_convention":    commitConventionProperty(),
+		"use_checks":           boolProperty("Add make checks and a CI step that run go vet, staticcheck, and govulncheck without golangci-lint"),
+		"use_pre_commit_hooks": boolProperty("Configure pre-commit hooks"),
+		"use_git_hooks":        boolProperty("Configure git hooks"),
+		"use_cobra":            boolProperty("Use Cobra for commands"),
+		"use_viper":            boolProperty("Use Viper for configuration"),
+		"use_gin":              boolProperty("Use Gin for HTTP"),
+		"use_github_actions":   boolProperty("Generate GitHub Actions workflows"),
+		"create_catalog_info":  boolProperty("Generate a Backstage catalog-info.yaml"),
+		"owner":                stringProperty("Owning team for the catalog entry"),
+		"lifecycle":            stringProperty("Catalog lifecycle: experimental, production, or deprecated"),
+		"team":                 stringProperty("Owning team, used for CODEOWNERS, the README, and as the catalog owner"),
+		"slack_channel":        stringProperty("Slack channel of the team, such as #payments"),
+		"on_call":              stringProperty("On-call rotation name or schedule URL"),
+		"tool_version_manager": toolVersionManagerProperty(),
+		"secrets_manager":      secretsManagerProperty(),
+		"use_live_reload":      boolProperty("Add a make dev target that restarts the server with air when the code changes (API projects)"),
+		"use_pprof":            boolProperty("Serve net/http/pprof on a loopback debug port with make targets to capture CPU and heap profiles (API projects)"),
+		"feature_flags":        featureFlagsProperty(),
+		"request_validation":   requestValidationProperty(),
+		"pagination":           paginationProperty(),
+		"task_queue":           taskQueueProperty(),
+		"use_http_cache":       boolProperty("Cache GET responses in memory with ETag and Cache-Control headers and answer If-None-Match with 304 (API projects)"),
+		"use_notify":           boolProperty("Add an internal/notify package with SMTP and Slack webhook notifiers configured from the environment (CLI and API projects)"),
+		"use_crash_handler":    boolProperty("Recover panics in main with a structured crash report sent to Sentry or a webhook configured from the environment (CLI, API, and default projects)"),
+		"use_config_reload":    boolProperty("Watch config.yaml with Viper and apply changes while the service runs, with change callbacks (API projects with use_viper)"),
+		"use_config_command":   boolProperty("Add config get/set/list commands with the config file in the XDG config directory (CLI projects)"),
+		"use_self_update":      boolProperty("Add a self-update command and a new release notification based on GitHub releases (CLI projects on GitHub)"),
+		"use_output":           boolProperty("Add a pkg/output package and a persistent --output flag for text, JSON, or YAML output (CLI projects)"),
+		"prompt_library":       promptLibraryProperty(),
+		"use_telemetry":        boolProperty("Add opt-in anonymous usage telemetry with a local queue, a telemetry command, DO_NOT_TRACK support, and a privacy page (CLI projects)"),
+		"use_i18n":             boolProperty("Add a golang.org/x/text message catalog with Accept-Language negotiation and a localized route (API projects)"),
+		"use_multi_tenancy":    boolProperty("Add tenant ID middleware, a store scoped by tenant, tenant configuration, and isolation tests (API projects)"),
+		"use_plugins":          boolProperty("Add a versioned plugin interface, a go-plugin host with plugin discovery, and a sample plugin (CLI and API projects)"),
+		"use_benchmarks":       boolProperty("Add a make bench target and a benchstat comparison workflow for pull requests"),
+		"use_apidiff":          boolProperty("Add a make apidiff target and a workflow that checks the exported API against the latest tag with gorelease"),
+		"use_docker":           boolProperty("Add a Dockerfile with cached module downloads, multi-arch builds, and OCI labels, and make docker-build and docker-push (projects with a binary)"),
+		"docker_base":          dockerBaseProperty(),
+		"container_registry":   containerRegistryProperty(),
+		"create_version_file":  boolProperty("Generate VERSION, make bump-patch/minor/major and tag targets, and a GoReleaser config"),
+		"use_adr":              boolProperty("Generate architecture decision records in docs/adr and a make adr target"),
+		"create_package_docs":  boolProperty("Generate doc.go, a runnable example, and pkg.go.dev and Go Report Card badges (library projects)"),
+		"packages":             packagesProperty(),
+		"layout":               layoutProperty(),
+	},
+	"required": []string{"name"},
+}
+
+// components are the generators of gogo add that add_component runs
+var components = []string{"resource", "middleware", "client", "job", "proto", "command", "tap"}
+
+var tools = []tool{
+	{
+		Name:        "list_project_types",
+		Description: "List the project types gogo can generate with a short description of each.",
+		InputSchema: map[string]interface{}{"type": "object", "properties": map[string]interface{}{}},
+	},
+	{
+		Name: "validate_config",
+		Description: "Validate a project configuration against the organization policy and return the " +
+			"resolved configuration, including type defaults and policy-locked values.",
+		InputSchema: map[string]interface{}{
+			"type":       "object",
+			"properties": map[string]interface{}{"config": configSchema},
+			"required":   []string{"config"},
+		},
+	},
+	{
+		Name: "generate_project",
+		Description: "Generate a new Go project with gogo's standard layout and return the list of " +
+			"created files. Prefer this over writing project scaffolding by hand.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"config":     configSchema,
+				"output_dir": stringProperty("Directory to create the project in, defaults to the current directory"),
+			},
+			"required": []string{"config"},
+		},
+	},
+	{
+		Name: "add_component",
+		Description: "Generate a component, such as a CRUD resource, middleware, or CLI command, in an " +
+			"existing gogo project and register it, like gogo add. Returns the created and updated files " +
+			"and any steps left to do by hand.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"dir": stringProperty("Directory of the project, defaults to the current directory"),
+				"component": map[string]interface{}{
+					"type": "string",
+					"description": "Component to add: resource and middleware for API projects, client, job, " +
+						"proto for a gRPC service, or command and tap for CLI projects",
+					"enum": components,
+				},
+				"name":          stringProperty("Name of the component, such as user or audit-log; not used by tap"),
+				"fields":        stringProperty(`Fields of a resource as name:type pairs, such as "name:string,age:int"`),
+				"openapi":       stringProperty("OpenAPI 3 spec, in YAML or JSON, to generate the operations of a client from"),
+				"tap_dir":       stringProperty("Directory of the Homebrew tap repository, defaults to ../homebrew-tap"),
+				"skip_generate": boolProperty("Do not run buf generate after adding a proto service"),
+				"force":         boolProperty("Overwrite generated files that already exist"),
+			},
+			"required": []string{"component"},
+		},
+	},
+}
+
+func stringProperty(description string) map[string]interface{} {
+	return map[string]interface{}{"type": "string", "description": description}
+}
+
+func boolProperty(description string) map[string]interface{} {
+	return map[string]interface{}{"type": "boolean", "description": description}
+}
+
+func typeProperty() map[string]interface{} {
+	types := make([]string, 0, len(config.ProjectTypes))
+	for _, t := range config.ProjectTypes {
+		types = append(types, string(t))
+	}
+	return map[string]interface{}{"type": "string", "description": "Project type", "enum": types}
+}
+
+func visibilityProperty() map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "string",
+		"description": "public for open source projects, or internal to skip the LICENSE and public badges and set GOPRIVATE",
+		"enum":        config.Visibilities,
+	}
+}
+
+func topicsProperty() map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "array",
+		"description": "Topics that classify the project, shown in the README and tagging the catalog entry, such as \"payments\"",
+		"items":       map[string]interface{}{"type": "string", "pattern": "^[a-z0-9][a-z0-9-]{0,49}$"},
+		"maxItems":    config.MaxTopics,
+	}
+}
+
+func authorsProperty() map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "array",
+		"description": "Authors, named in the LICENSE and README; their emails own the files in CODEOWNERS",
+		"items": map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"name":  stringProperty("Author name"),
+				"email": stringProperty("Author email"),
+			},
+		},
+	}
+}
+
+func packagesProperty() map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "array",
+		"description": "Package directories of a library project, such as \".\" or \"internal/strutil\"; defaults to pkg/<name>",
+		"items":       map[string]interface{}{"type": "string"},
+	}
+}
+
+func layoutProperty() map[string]interface{} {
+	return map[string]interface{}{
+		"type":                 "object",
+		"description":          "Directories that take the place of conventional ones, such as {\"internal\": \"app\"}",
+		"propertyNames":        map[string]interface{}{"enum": config.LayoutDirs},
+		"additionalProperties": map[string]interface{}{"type": "string"},
+	}
+}
+
+func docsSiteProperty() map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "string",
+		"description": "Documentation site in docs/ with a GitHub Pages workflow: none, mkdocs, or hugo",
+		"enum":        config.DocsSites,
+	}
+}
+
+func dockerBaseProperty() map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "string",
+		"description": "Runtime image of the Dockerfile: distroless (nonroot, default) or scratch",
+		"enum":        config.DockerBases,
+	}
+}
+
+func containerRegistryProperty() map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "string",
+		"description": "Registry a GitHub Actions workflow pushes the multi-arch image of use_docker to, with a provenance attestation: none, ghcr, ecr, or gar",
+		"enum":        config.ContainerRegistries,
+	}
+}
+
+func featureFlagsProperty() map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "string",
+		"description": "OpenFeature flags for API projects, with a typed flags package and a gated endpoint: none, memory, or flagd",
+		"enum":        config.FeatureFlagBackends,
+	}
+}
+
+func requestValidationProperty() map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "string",
+		"description": "Request validation of API projects, with an error envelope listing the invalid fields and an example route: none, validator (go-playground/validator), or binding (Gin binding, default)",
+		"enum":        config.RequestValidations,
+	}
+}
+
+func paginationProperty() map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "string",
+		"description": "Pagination, filtering, and sorting helpers in pkg/pagination for API projects, with an example list endpoint: none, cursor, or offset",
+		"enum":        config.Paginations,
+	}
+}
+
+func formattingProperty() map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "string",
+		"description": "Formatting of the make fmt target and golangci-lint, with the imports of the module grouped last: none, goimports, or gofumpt (gofumpt and gci)",
+		"enum":        config.Formattings,
+	}
+}
+
+func lintPresetProperty() map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "string",
+		"description": "Linters of .golangci.yml: standard, or strict (adds errorlint, exhaustive, gocritic, and more, and formats with gofumpt and gci)",
+		"enum":        config.LintPresets,
+	}
+}
+
+func commitConventionProperty() map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "string",
+		"description": "Commit messages the commit-msg pre-commit hook accepts: conventional (Conventional Commits, with .commitlintrc.yaml), or none (no check)",
+		"enum":        config.CommitConventions,
+	}
+}
+
+func taskQueueProperty() map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "string",
+		"description": "Background task queue of API projects, with workers drained on shutdown and an example route that enqueues a task: none, memory (in-process worker pool), or asynq (Redis)",
+		"enum":        config.TaskQueues,
+	}
+}
+
+func promptLibraryProperty() map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "string",
+		"description": "Interactive prompts for CLI projects, with an internal/prompt package and a setup command: none, survey, or bubbletea",
+		"enum":        config.PromptLibraries,
+	}
+}
+
+func actionRuntimeProperty() map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "string",
+		"description": "How a github-action project runs: docker (built from its Dockerfile) or composite (the release binary, or a build of the source)",
+		"enum":        config.ActionRuntimes,
+	}
+}
+
+func eventBrokerProperty() map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "string",
+		"description": "Broker an event-driven project relays its outbox to and consumes from: kafka, nats (JetStream), or rabbitmq",
+		"enum":        config.EventBrokers,
+	}
+}
+
+func secretsManagerProperty() map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "string",
+		"description": "Secrets management setup: none, sops (.sops.yaml with age recipients), or vault (Vault Agent templates)",
+		"enum":        config.SecretsManagers,
+	}
+}
+
+func dirPlaceholderProperty() map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "string",
+		"description": "What empty standard directories get: gitkeep (an empty .gitkeep), doc (a doc.go describing the directory), or none",
+		"enum":        config.DirPlaceholders,
+	}
+}
+
+func toolVersionManagerProperty() map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "string",
+		"description": "File that pins go and the tools: none, asdf (.tool-versions), or mise (.mise.toml)",
+		"enum":        config.ToolVersionManagers,
+	}
+}
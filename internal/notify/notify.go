@@ -0,0 +1,126 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// Webhook types
+const (
+	// TypeHTTP posts the full event as JSON
+	TypeHTTP = "http"
+	// TypeSlack posts a Slack incoming-webhook message
+	TypeSlack = "slack"
+)
+
+// EventProjectGenerated is emitted after a project has been generated
+const EventProjectGenerated = "project.generated"
+
+// requestTimeout bounds how long a single webhook delivery may take
+const requestTimeout = 10 * time.Second
+
+// Settings is the notify section of the global config file
+type Settings struct {
+	Webhooks []Webhook `mapstructure:"webhooks" yaml:"webhooks"`
+}
+
+// Webhook is a single notification destination
+type Webhook struct {
+	URL     string            `mapstructure:"url" yaml:"url"`
+	Type    string            `mapstructure:"type" yaml:"type"`
+	Headers map[string]string `mapstructure:"headers" yaml:"headers"`
+}
+
+// Event is the JSON payload sent to generic HTTP webhooks
+type Event struct {
+	Event     string                `json:"event"`
+	Timestamp time.Time             `json:"timestamp"`
+	Project   *config.ProjectConfig `json:"project"`
+	Path      string                `json:"path"`
+	Version   string                `json:"gogo_version"`
+}
+
+// NewProjectGeneratedEvent builds the event emitted for a generated project
+func NewProjectGeneratedEvent(cfg *config.ProjectConfig, projectDir, version string) Event {
+	if abs, err := filepath.Abs(projectDir); projectDir != "" && err == nil {
+		projectDir = abs
+	}
+
+	return Event{
+		Event:     EventProjectGenerated,
+		Timestamp: time.Now().UTC(),
+		Project:   cfg,
+		Path:      projectDir,
+		Version:   version,
+	}
+}
+
+// Send delivers the event to every configured webhook.
+// All webhooks are attempted; failures are returned together.
+func Send(settings Settings, event Event) error {
+	var errs []error
+	for _, hook := range settings.Webhooks {
+		if err := hook.send(event); err != nil {
+			errs = append(errs, fmt.Errorf("webhook %s: %w", hook.URL, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (w Webhook) send(event Event) error {
+	switch w.Type {
+	case "", TypeHTTP:
+		return PostJSON(w.URL, w.Headers, event)
+	case TypeSlack:
+		return PostJSON(w.URL, w.Headers, slackMessage(event))
+	default:
+		return fmt.Errorf("unknown webhook type %q", w.Type)
+	}
+}
+
+// slackMessage formats an event as a Slack incoming-webhook payload
+func slackMessage(event Event) map[string]string {
+	p := event.Project
+	text := fmt.Sprintf("New %s project *%s* (`%s`) generated with gogo %s",
+		p.Type, p.Name, p.Module, event.Version)
+	if p.Description != "" {
+		text += "\n>" + p.Description
+	}
+	return map[string]string{"text": text}
+}
+
+// PostJSON POSTs the payload as JSON and fails on non-2xx responses
+func PostJSON(url string, headers map[string]string, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	client := &http.Client{Timeout: requestTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response %s", resp.Status)
+	}
+	return nil
+}
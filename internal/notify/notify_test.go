@@ -0,0 +1,82 @@
+package notify
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+func TestSendHTTP(t *testing.T) {
+	var received Event
+	var auth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+	}))
+	defer server.Close()
+
+	cfg := config.NewAPIProjectConfig()
+	cfg.Name = "orders"
+
+	settings := Settings{Webhooks: []Webhook{{
+		URL:     server.URL,
+		Headers: map[string]string{"Authorization": "Bearer token"},
+	}}}
+
+	require.NoError(t, Send(settings, NewProjectGeneratedEvent(cfg, "out/orders", "v1.0.0")))
+	assert.Equal(t, "Bearer token", auth)
+	assert.Equal(t, EventProjectGenerated, received.Event)
+	assert.Equal(t, "orders", received.Project.Name)
+	assert.Equal(t, config.TypeAPI, received.Project.Type)
+	assert.Equal(t, "v1.0.0", received.Version)
+}
+
+func TestSendSlack(t *testing.T) {
+	var received map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+	}))
+	defer server.Close()
+
+	cfg := config.NewCLIProjectConfig()
+	cfg.Name = "tool"
+	cfg.Module = "github.com/acme/tool"
+
+	settings := Settings{Webhooks: []Webhook{{URL: server.URL, Type: TypeSlack}}}
+	require.NoError(t, Send(settings, NewProjectGeneratedEvent(cfg, "tool", "dev")))
+	assert.Contains(t, received["text"], "*tool*")
+	assert.Contains(t, received["text"], "github.com/acme/tool")
+}
+
+func TestSendErrors(t *testing.T) {
+	var calls int
+	ok := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		calls++
+	}))
+	defer ok.Close()
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer failing.Close()
+
+	settings := Settings{Webhooks: []Webhook{
+		{URL: failing.URL},
+		{URL: ok.URL},
+		{URL: ok.URL, Type: "carrier-pigeon"},
+	}}
+
+	err := Send(settings, NewProjectGeneratedEvent(config.NewDefaultProjectConfig(), ".", "dev"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "502")
+	assert.Contains(t, err.Error(), "carrier-pigeon")
+
+	// A failing webhook does not prevent delivery to the others
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,380 @@
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/oculus-core/gogo/internal/gitsource"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// ErrNotCached is returned by LoadCached when a git-hosted policy has never been fetched
+var ErrNotCached = errors.New("policy is not cached")
+
+// FileNames are the file names looked up when a policy source is a directory or repository
+var FileNames = []string{"gogo-policy.yaml", "gogo-policy.yml", "policy.yaml"}
+
+// Policy describes organization-wide constraints applied to project configurations.
+// Keys in Locked and Allowed use the YAML field names of config.ProjectConfig.
+type Policy struct {
+	Name string `yaml:"name" json:"name"`
+
+	// Locked fields are forced to the given value and are not editable in the wizard
+	Locked map[string]interface{} `yaml:"locked" json:"locked"`
+
+	// Allowed restricts a field to one of the listed values
+	Allowed map[string][]string `yaml:"allowed" json:"allowed"`
+
+	// ModulePrefix requires every module path to start with this prefix
+	ModulePrefix string `yaml:"module_prefix" json:"module_prefix"`
+
+	// Metadata files added to every generated project
+	Metadata []config.MetadataFile `yaml:"metadata" json:"metadata"`
+
+	// Rules set default values depending on other values, such as a database
+	// for API projects; locked values still take precedence
+	Rules []config.Rule `yaml:"rules" json:"rules"`
+
+	// Source is where the policy was loaded from
+	Source string `yaml:"-" json:"-"`
+}
+
+// Violation describes a single configuration value that breaks the policy
+type Violation struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationError is returned when a configuration does not satisfy a policy
+type ValidationError struct {
+	Policy     string
+	Violations []Violation
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		msgs = append(msgs, fmt.Sprintf("%s: %s", v.Field, v.Message))
+	}
+	return fmt.Sprintf("configuration violates policy %q: %s", e.Policy, strings.Join(msgs, "; "))
+}
+
+// Load reads a policy from a local file, a local directory, or a git repository.
+// Git sources such as github.com/acme/gogo-policy may be pinned with an @ref suffix.
+func Load(source string) (*Policy, error) {
+	return load(source, false)
+}
+
+// LoadCached is like Load but never accesses the network. Git sources are read
+// from the policy cache and fail with ErrNotCached when they were never fetched.
+func LoadCached(source string) (*Policy, error) {
+	return load(source, true)
+}
+
+func load(source string, offline bool) (*Policy, error) {
+	path, err := resolve(source, offline)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy file: %w", err)
+	}
+
+	var p Policy
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("failed to parse policy file: %w", err)
+	}
+	if err := p.check(); err != nil {
+		return nil, err
+	}
+
+	p.Source = source
+	if p.Name == "" {
+		p.Name = source
+	}
+	return &p, nil
+}
+
+// resolve returns the path of the policy file for the given source
+func resolve(source string, offline bool) (string, error) {
+	info, err := os.Stat(source)
+	if err == nil {
+		if !info.IsDir() {
+			return source, nil
+		}
+		return findPolicyFile(source)
+	}
+
+	fetchFn := fetch
+	if offline {
+		fetchFn = cached
+	}
+	dir, err := fetchFn(source)
+	if err != nil {
+		return "", err
+	}
+	return findPolicyFile(dir)
+}
+
+func findPolicyFile(dir string) (string, error) {
+	for _, name := range FileNames {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("no policy file (%s) found in %s", strings.Join(FileNames, ", "), dir)
+}
+
+// fetch clones a git-hosted policy into the user cache directory and returns its path.
+// When the clone fails and a cached copy exists, the cached copy is used instead.
+func fetch(source string) (string, error) {
+	url, ref := gitsource.URL(source)
+
+	dir, err := CacheDir(source)
+	if err != nil {
+		return "", err
+	}
+
+	tmp := dir + ".tmp"
+	_ = os.RemoveAll(tmp)
+
+	args := []string{"clone", "--quiet", "--depth", "1"}
+	if ref != "" {
+		args = append(args, "--branch", ref)
+	}
+	args = append(args, url, tmp)
+
+	out, cloneErr := exec.Command("git", args...).CombinedOutput()
+	if cloneErr == nil {
+		if err := os.RemoveAll(dir); err != nil {
+			return "", fmt.Errorf("failed to replace cached policy: %w", err)
+		}
+		if err := os.Rename(tmp, dir); err != nil {
+			return "", fmt.Errorf("failed to cache policy: %w", err)
+		}
+		return dir, nil
+	}
+	_ = os.RemoveAll(tmp)
+
+	if _, err := os.Stat(dir); err == nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not update policy %s, using cached copy\n", source)
+		return dir, nil
+	}
+
+	return "", fmt.Errorf("failed to fetch policy %s: %v: %s", source, cloneErr, strings.TrimSpace(string(out)))
+}
+
+// cached returns the cached copy of a git-hosted policy without fetching it
+func cached(source string) (string, error) {
+	dir, err := CacheDir(source)
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(dir); err != nil {
+		return "", fmt.Errorf("%w: %s", ErrNotCached, source)
+	}
+	return dir, nil
+}
+
+// CacheDir returns the directory where a git-hosted policy source is cached
+func CacheDir(source string) (string, error) {
+	return gitsource.CacheDir("policies", source)
+}
+
+// check verifies that the policy only references known configuration fields
+func (p *Policy) check() error {
+	known := config.FieldNames()
+	for field := range p.Locked {
+		if !known[field] {
+			return fmt.Errorf("policy locks unknown field %q", field)
+		}
+	}
+	for field := range p.Allowed {
+		if !known[field] {
+			return fmt.Errorf("policy constrains unknown field %q", field)
+		}
+	}
+	if err := config.ValidateRules(p.Rules); err != nil {
+		return fmt.Errorf("invalid policy rules: %w", err)
+	}
+	return nil
+}
+
+// DefaultRules returns the rules of the policy
+func (p *Policy) DefaultRules() []config.Rule {
+	if p == nil {
+		return nil
+	}
+	return p.Rules
+}
+
+// IsLocked reports whether the given field is locked by the policy
+func (p *Policy) IsLocked(field string) bool {
+	if p == nil {
+		return false
+	}
+	_, ok := p.Locked[field]
+	return ok
+}
+
+// LockedValue returns the value enforced for a locked field
+func (p *Policy) LockedValue(field string) (interface{}, bool) {
+	if p == nil {
+		return nil, false
+	}
+	v, ok := p.Locked[field]
+	return v, ok
+}
+
+// Apply forces all locked values onto the configuration, adds the policy's
+// metadata files, and returns the fields whose value was changed
+func (p *Policy) Apply(cfg *config.ProjectConfig) ([]string, error) {
+	if p == nil {
+		return nil, nil
+	}
+
+	p.addMetadata(cfg)
+
+	if len(p.Locked) == 0 {
+		return nil, nil
+	}
+
+	values, err := toMap(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var changed []string
+	for field, v := range p.Locked {
+		if fieldValue(values, field) != fmt.Sprint(v) {
+			changed = append(changed, field)
+		}
+		values[field] = v
+	}
+	sort.Strings(changed)
+
+	data, err := yaml.Marshal(values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply policy: %w", err)
+	}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to apply policy: %w", err)
+	}
+
+	return changed, nil
+}
+
+// addMetadata adds the policy's metadata files that the configuration does not already define
+func (p *Policy) addMetadata(cfg *config.ProjectConfig) {
+	for _, m := range p.Metadata {
+		exists := false
+		for _, existing := range cfg.MetadataFiles {
+			if existing.Path == m.Path {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			cfg.MetadataFiles = append(cfg.MetadataFiles, m)
+		}
+	}
+}
+
+// Validate checks the configuration against the policy
+func (p *Policy) Validate(cfg *config.ProjectConfig) error {
+	if p == nil {
+		return nil
+	}
+
+	values, err := toMap(cfg)
+	if err != nil {
+		return err
+	}
+
+	var violations []Violation
+
+	for _, field := range sortedKeys(p.Locked) {
+		want := fmt.Sprint(p.Locked[field])
+		if got := fieldValue(values, field); got != want {
+			violations = append(violations, Violation{
+				Field:   field,
+				Message: fmt.Sprintf("must be %s (got %s)", want, got),
+			})
+		}
+	}
+
+	for _, field := range sortedKeys(p.Allowed) {
+		allowed := p.Allowed[field]
+		got := fieldValue(values, field)
+		if !containsString(allowed, got) {
+			violations = append(violations, Violation{
+				Field:   field,
+				Message: fmt.Sprintf("must be one of %s (got %s)", strings.Join(allowed, ", "), got),
+			})
+		}
+	}
+
+	if p.ModulePrefix != "" && !strings.HasPrefix(cfg.Module, p.ModulePrefix) {
+		violations = append(violations, Violation{
+			Field:   "module",
+			Message: fmt.Sprintf("must start with %s (got %s)", p.ModulePrefix, cfg.Module),
+		})
+	}
+
+	if len(violations) > 0 {
+		return &ValidationError{Policy: p.Name, Violations: violations}
+	}
+	return nil
+}
+
+// toMap converts a project configuration into a map keyed by YAML field names
+func toMap(cfg *config.ProjectConfig) (map[string]interface{}, error) {
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	values := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+	return values, nil
+}
+
+// fieldValue returns the value of a field of a map made by toMap, with the
+// empty omitempty fields that the map leaves out as empty strings
+func fieldValue(values map[string]interface{}, field string) string {
+	v, ok := values[field]
+	if !ok {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func containsString(slice []string, item string) bool {
+	for _, s := range slice {
+		if s == item {
+			return true
+		}
+	}
+	return false
+}
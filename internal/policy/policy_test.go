@@ -0,0 +1,190 @@
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+const testPolicy = `
+name: acme
+locked:
+  license: Apache-2.0
+  use_github_actions: true
+allowed:
+  type: [cli, api]
+module_prefix: github.com/acme/
+rules:
+  - when: {type: api}
+    set: {use_pprof: true}
+`
+
+func writePolicy(t *testing.T, dir, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, "gogo-policy.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	path := writePolicy(t, dir, testPolicy)
+
+	// Load from file
+	pol, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, "acme", pol.Name)
+	assert.Equal(t, "github.com/acme/", pol.ModulePrefix)
+	assert.True(t, pol.IsLocked("license"))
+	assert.False(t, pol.IsLocked("author"))
+
+	// Load from directory
+	pol, err = Load(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cli", "api"}, pol.Allowed["type"])
+	assert.Equal(t, []config.Rule{{When: map[string]interface{}{"type": "api"}, Set: map[string]interface{}{"use_pprof": true}}}, pol.DefaultRules())
+
+	// Unknown fields are rejected
+	writePolicy(t, dir, "locked:\n  no_such_field: true\n")
+	_, err = Load(dir)
+	assert.ErrorContains(t, err, "no_such_field")
+	writePolicy(t, dir, "rules:\n  - when: {type: api}\n    set: {use_otel: true}\n")
+	_, err = Load(dir)
+	assert.ErrorContains(t, err, "use_otel")
+
+	// Missing policy file in directory
+	_, err = Load(t.TempDir())
+	assert.Error(t, err)
+}
+
+func TestApply(t *testing.T) {
+	pol := &Policy{
+		Name: "acme",
+		Locked: map[string]interface{}{
+			"license":            "Apache-2.0",
+			"use_github_actions": true,
+		},
+	}
+
+	cfg := config.NewDefaultProjectConfig()
+	cfg.UseGitHubActions = false
+
+	changed, err := pol.Apply(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"license", "use_github_actions"}, changed)
+	assert.Equal(t, "Apache-2.0", cfg.License)
+	assert.True(t, cfg.UseGitHubActions)
+
+	// Other fields are preserved
+	assert.Equal(t, "my-project", cfg.Name)
+
+	// Applying again changes nothing
+	changed, err = pol.Apply(cfg)
+	require.NoError(t, err)
+	assert.Empty(t, changed)
+
+	// A nil policy is a no-op
+	var nilPolicy *Policy
+	changed, err = nilPolicy.Apply(cfg)
+	assert.NoError(t, err)
+	assert.Empty(t, changed)
+}
+
+func TestLockOmitemptyField(t *testing.T) {
+	// visibility is empty by default, so a marshaled config leaves it out
+	dir := t.TempDir()
+	writePolicy(t, dir, "locked:\n  visibility: private\nallowed:\n  commit_convention: [\"\", conventional]\n")
+	pol, err := Load(dir)
+	require.NoError(t, err)
+
+	cfg := config.NewDefaultProjectConfig()
+	require.Empty(t, cfg.Visibility)
+	assert.Error(t, pol.Validate(cfg))
+
+	changed, err := pol.Apply(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"visibility"}, changed)
+	assert.Equal(t, "private", cfg.Visibility)
+	assert.NoError(t, pol.Validate(cfg))
+}
+
+func TestValidate(t *testing.T) {
+	pol := &Policy{
+		Name:         "acme",
+		Locked:       map[string]interface{}{"license": "Apache-2.0"},
+		Allowed:      map[string][]string{"type": {"cli", "api"}},
+		ModulePrefix: "github.com/acme/",
+	}
+
+	cfg := config.NewCLIProjectConfig()
+	cfg.License = "Apache-2.0"
+	cfg.Module = "github.com/acme/tool"
+	assert.NoError(t, pol.Validate(cfg))
+
+	cfg.License = "MIT"
+	cfg.Type = config.TypeLibrary
+	cfg.Module = "github.com/other/tool"
+
+	err := pol.Validate(cfg)
+	require.Error(t, err)
+
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr)
+	assert.Len(t, verr.Violations, 3)
+	assert.Contains(t, err.Error(), "license: must be Apache-2.0")
+	assert.Contains(t, err.Error(), "type: must be one of cli, api")
+	assert.Contains(t, err.Error(), "module: must start with github.com/acme/")
+
+	var nilPolicy *Policy
+	assert.NoError(t, nilPolicy.Validate(cfg))
+}
+
+func TestApplyMetadata(t *testing.T) {
+	dir := t.TempDir()
+	writePolicy(t, dir, `
+name: acme
+metadata:
+  - path: service.yaml
+    fields:
+      name: "{{ .Name }}"
+      owner: "{{ .Owner }}"
+`)
+
+	pol, err := Load(dir)
+	require.NoError(t, err)
+
+	cfg := config.NewDefaultProjectConfig()
+	_, err = pol.Apply(cfg)
+	require.NoError(t, err)
+	require.Len(t, cfg.MetadataFiles, 1)
+	assert.Equal(t, "service.yaml", cfg.MetadataFiles[0].Path)
+	assert.Equal(t, "{{ .Name }}", cfg.MetadataFiles[0].Fields["name"])
+
+	// Applying twice does not duplicate metadata files
+	_, err = pol.Apply(cfg)
+	require.NoError(t, err)
+	assert.Len(t, cfg.MetadataFiles, 1)
+}
+
+func TestLoadCached(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	source := "github.com/acme/gogo-policy@v1"
+
+	_, err := LoadCached(source)
+	assert.ErrorIs(t, err, ErrNotCached)
+
+	dir, err := CacheDir(source)
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	writePolicy(t, dir, "name: acme\n")
+
+	pol, err := LoadCached(source)
+	require.NoError(t, err)
+	assert.Equal(t, "acme", pol.Name)
+	assert.Equal(t, source, pol.Source)
+}
@@ -0,0 +1,330 @@
+package server
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/oculus-core/gogo/internal/policy"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// grpcService is the fully qualified name of the service in api/gogo/v1/gogo.proto
+const grpcService = "gogo.v1.GogoService"
+
+// gRPC status codes used by the service
+const (
+	codeOK                 = 0
+	codeInvalidArgument    = 3
+	codeAlreadyExists      = 6
+	codeFailedPrecondition = 9
+	codeUnimplemented      = 12
+	codeInternal           = 13
+)
+
+// grpcProjectConfig mirrors the ProjectConfig message. The JSON tags match
+// config.ProjectConfig so requests reuse the HTTP API decoding; unset optional
+// fields keep the defaults of the project type.
+type grpcProjectConfig struct {
+	Name              string `protobuf:"1" json:"name,omitempty"`
+	Module            string `protobuf:"2" json:"module,omitempty"`
+	Description       string `protobuf:"3" json:"description,omitempty"`
+	License           string `protobuf:"4" json:"license,omitempty"`
+	Author            string `protobuf:"5" json:"author,omitempty"`
+	Type              string `protobuf:"6" json:"type,omitempty"`
+	UseCmd            *bool  `protobuf:"7" json:"use_cmd,omitempty"`
+	UseInternal       *bool  `protobuf:"8" json:"use_internal,omitempty"`
+	UsePkg            *bool  `protobuf:"9" json:"use_pkg,omitempty"`
+	UseTest           *bool  `protobuf:"10" json:"use_test,omitempty"`
+	UseDocs           *bool  `protobuf:"11" json:"use_docs,omitempty"`
+	CreateReadme      *bool  `protobuf:"12" json:"create_readme,omitempty"`
+	CreateLicense     *bool  `protobuf:"13" json:"create_license,omitempty"`
+	CreateMakefile    *bool  `protobuf:"14" json:"create_makefile,omitempty"`
+	UseLinters        *bool  `protobuf:"15" json:"use_linters,omitempty"`
+	UsePreCommitHooks *bool  `protobuf:"16" json:"use_pre_commit_hooks,omitempty"`
+	UseGitHooks       *bool  `protobuf:"17" json:"use_git_hooks,omitempty"`
+	UseCobra          *bool  `protobuf:"18" json:"use_cobra,omitempty"`
+	UseViper          *bool  `protobuf:"19" json:"use_viper,omitempty"`
+	UseGin            *bool  `protobuf:"20" json:"use_gin,omitempty"`
+	UseGitHubActions  *bool  `protobuf:"21" json:"use_github_actions,omitempty"`
+	CreateCatalogInfo *bool  `protobuf:"22" json:"create_catalog_info,omitempty"`
+	Owner             string `protobuf:"23" json:"owner,omitempty"`
+	Lifecycle         string `protobuf:"24" json:"lifecycle,omitempty"`
+
+	ToolVersionManager string `protobuf:"25" json:"tool_version_manager,omitempty"`
+	CreateVersionFile  *bool  `protobuf:"26" json:"create_version_file,omitempty"`
+	UseBenchmarks      *bool  `protobuf:"27" json:"use_benchmarks,omitempty"`
+	CreatePackageDocs  *bool  `protobuf:"28" json:"create_package_docs,omitempty"`
+	UseAPIDiff         *bool  `protobuf:"29" json:"use_apidiff,omitempty"`
+	UseExamples        *bool  `protobuf:"31" json:"use_examples,omitempty"`
+	DocsSite           string `protobuf:"32" json:"docs_site,omitempty"`
+	UseADR             *bool  `protobuf:"33" json:"use_adr,omitempty"`
+	Team               string `protobuf:"34" json:"team,omitempty"`
+	SlackChannel       string `protobuf:"35" json:"slack_channel,omitempty"`
+	OnCall             string `protobuf:"36" json:"on_call,omitempty"`
+	SecretsManager     string `protobuf:"37" json:"secrets_manager,omitempty"`
+	FeatureFlags       string `protobuf:"38" json:"feature_flags,omitempty"`
+	UseI18n            *bool  `protobuf:"39" json:"use_i18n,omitempty"`
+	UseNotify          *bool  `protobuf:"40" json:"use_notify,omitempty"`
+	UseLiveReload      *bool  `protobuf:"41" json:"use_live_reload,omitempty"`
+	UsePprof           *bool  `protobuf:"42" json:"use_pprof,omitempty"`
+	UseCrashHandler    *bool  `protobuf:"43" json:"use_crash_handler,omitempty"`
+	UseConfigReload    *bool  `protobuf:"44" json:"use_config_reload,omitempty"`
+	UseConfigCommand   *bool  `protobuf:"45" json:"use_config_command,omitempty"`
+	UseSelfUpdate      *bool  `protobuf:"46" json:"use_self_update,omitempty"`
+	UseOutput          *bool  `protobuf:"47" json:"use_output,omitempty"`
+	PromptLibrary      string `protobuf:"48" json:"prompt_library,omitempty"`
+	UseTelemetry       *bool  `protobuf:"49" json:"use_telemetry,omitempty"`
+	ActionRuntime      string `protobuf:"50" json:"action_runtime,omitempty"`
+	OperatorGroup      string `protobuf:"51" json:"operator_group,omitempty"`
+	OperatorKind       string `protobuf:"52" json:"operator_kind,omitempty"`
+	UseKubebuilder     *bool  `protobuf:"53" json:"use_kubebuilder,omitempty"`
+	EventBroker        string `protobuf:"54" json:"event_broker,omitempty"`
+	UseMultiTenancy    *bool  `protobuf:"55" json:"use_multi_tenancy,omitempty"`
+	UsePlugins         *bool  `protobuf:"56" json:"use_plugins,omitempty"`
+	DisplayName        string `protobuf:"57" json:"display_name,omitempty"`
+	DirPlaceholder     string `protobuf:"58" json:"dir_placeholder,omitempty"`
+	Visibility         string `protobuf:"60" json:"visibility,omitempty"`
+	DefaultBranch      string `protobuf:"61" json:"default_branch,omitempty"`
+	UseDocker          *bool  `protobuf:"62" json:"use_docker,omitempty"`
+	DockerBase         string `protobuf:"63" json:"docker_base,omitempty"`
+	ContainerRegistry  string `protobuf:"64" json:"container_registry,omitempty"`
+	RequestValidation  string `protobuf:"65" json:"request_validation,omitempty"`
+	Pagination         string `protobuf:"66" json:"pagination,omitempty"`
+	TaskQueue          string `protobuf:"67" json:"task_queue,omitempty"`
+	UseHTTPCache       *bool  `protobuf:"68" json:"use_http_cache,omitempty"`
+	Formatting         string `protobuf:"69" json:"formatting,omitempty"`
+	LintPreset         string `protobuf:"70" json:"lint_preset,omitempty"`
+	UseChecks          *bool  `protobuf:"71" json:"use_checks,omitempty"`
+	CommitConvention   string `protobuf:"72" json:"commit_convention,omitempty"`
+
+	Packages []string `protobuf:"30" json:"packages,omitempty"`
+	Topics   []string `protobuf:"59" json:"topics,omitempty"`
+}
+
+type grpcGenerateProjectRequest struct {
+	Config *grpcProjectConfig `protobuf:"1"`
+}
+
+type grpcGenerateProjectResponse struct {
+	Name    string `protobuf:"1"`
+	Archive []byte `protobuf:"2"`
+	Path    string `protobuf:"3"`
+}
+
+type grpcListTemplatesResponse struct {
+	Templates []grpcTemplate `protobuf:"1"`
+}
+
+type grpcTemplate struct {
+	Name        string `protobuf:"1"`
+	Description string `protobuf:"2"`
+}
+
+type grpcValidateConfigRequest struct {
+	Config *grpcProjectConfig `protobuf:"1"`
+}
+
+type grpcValidateConfigResponse struct {
+	Valid      bool            `protobuf:"1"`
+	Violations []grpcViolation `protobuf:"2"`
+}
+
+type grpcViolation struct {
+	Field   string `protobuf:"1"`
+	Message string `protobuf:"2"`
+}
+
+// grpcError is a failed call with a gRPC status code
+type grpcError struct {
+	code    int
+	message string
+}
+
+func (e *grpcError) Error() string {
+	return e.message
+}
+
+// handleGRPC dispatches unary gRPC calls to the service methods
+func (s *Server) handleGRPC(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") {
+		http.Error(w, "expected application/grpc content type", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	req, err := readGRPCMessage(http.MaxBytesReader(w, r.Body, maxRequestBytes))
+	if err != nil {
+		writeGRPCError(w, &grpcError{codeInvalidArgument, err.Error()})
+		return
+	}
+
+	var resp interface{}
+	switch r.PathValue("method") {
+	case "GenerateProject":
+		resp, err = s.grpcGenerateProject(req)
+	case "ListTemplates":
+		resp, err = s.grpcListTemplates()
+	case "ValidateConfig":
+		resp, err = s.grpcValidateConfig(req)
+	default:
+		err = &grpcError{codeUnimplemented, "unknown method " + r.PathValue("method")}
+	}
+	if err != nil {
+		writeGRPCError(w, err)
+		return
+	}
+
+	data, err := marshalProto(resp)
+	if err != nil {
+		writeGRPCError(w, &grpcError{codeInternal, err.Error()})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/grpc+proto")
+	w.Header().Set("Trailer", "Grpc-Status, Grpc-Message")
+	w.WriteHeader(http.StatusOK)
+
+	frame := make([]byte, 5, 5+len(data))
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(data)))
+	if _, err := w.Write(append(frame, data...)); err != nil {
+		s.opts.Logger.Printf("failed to write gRPC response: %v", err)
+	}
+	w.Header().Set("Grpc-Status", strconv.Itoa(codeOK))
+}
+
+func (s *Server) grpcGenerateProject(data []byte) (*grpcGenerateProjectResponse, error) {
+	var req grpcGenerateProjectRequest
+	if err := unmarshalProto(data, &req); err != nil {
+		return nil, &grpcError{codeInvalidArgument, err.Error()}
+	}
+
+	cfg, err := grpcToConfig(req.Config, s.opts.Policy.DefaultRules())
+	if err != nil {
+		return nil, &grpcError{codeInvalidArgument, err.Error()}
+	}
+	if err := s.enforcePolicy(cfg); err != nil {
+		return nil, &grpcError{codeFailedPrecondition, err.Error()}
+	}
+
+	if s.opts.OutputDir != "" {
+		projectDir, err := s.writeProject(cfg)
+		switch {
+		case errors.Is(err, errProjectExists):
+			return nil, &grpcError{codeAlreadyExists, err.Error()}
+		case err != nil:
+			return nil, &grpcError{codeInternal, "failed to generate project"}
+		}
+		return &grpcGenerateProjectResponse{Name: cfg.Name, Path: projectDir}, nil
+	}
+
+	tmpDir, err := s.buildProject(cfg)
+	if err != nil {
+		return nil, &grpcError{codeInternal, "failed to generate project"}
+	}
+	defer os.RemoveAll(tmpDir)
+
+	var archive bytes.Buffer
+	if err := WriteTarGz(&archive, filepath.Join(tmpDir, cfg.Name), cfg.Name); err != nil {
+		return nil, &grpcError{codeInternal, err.Error()}
+	}
+	return &grpcGenerateProjectResponse{Name: cfg.Name, Archive: archive.Bytes()}, nil
+}
+
+func (s *Server) grpcListTemplates() (*grpcListTemplatesResponse, error) {
+	resp := &grpcListTemplatesResponse{}
+	for _, t := range config.ProjectTypes {
+		resp.Templates = append(resp.Templates, grpcTemplate{Name: string(t), Description: t.Description()})
+	}
+	return resp, nil
+}
+
+func (s *Server) grpcValidateConfig(data []byte) (*grpcValidateConfigResponse, error) {
+	var req grpcValidateConfigRequest
+	if err := unmarshalProto(data, &req); err != nil {
+		return nil, &grpcError{codeInvalidArgument, err.Error()}
+	}
+
+	cfg, err := grpcToConfig(req.Config, s.opts.Policy.DefaultRules())
+	if err != nil {
+		return &grpcValidateConfigResponse{Violations: []grpcViolation{{Field: "config", Message: err.Error()}}}, nil
+	}
+
+	err = s.enforcePolicy(cfg)
+	var verr *policy.ValidationError
+	switch {
+	case errors.As(err, &verr):
+		resp := &grpcValidateConfigResponse{}
+		for _, v := range verr.Violations {
+			resp.Violations = append(resp.Violations, grpcViolation{Field: v.Field, Message: v.Message})
+		}
+		return resp, nil
+	case err != nil:
+		return nil, &grpcError{codeInternal, err.Error()}
+	}
+	return &grpcValidateConfigResponse{Valid: true}, nil
+}
+
+// grpcToConfig converts a ProjectConfig message using the same rules as the HTTP API
+func grpcToConfig(msg *grpcProjectConfig, rules []config.Rule) (*config.ProjectConfig, error) {
+	if msg == nil {
+		return nil, errors.New("config is required")
+	}
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeConfigWithRules(bytes.NewReader(data), rules)
+}
+
+// readGRPCMessage reads a single length-prefixed gRPC message
+func readGRPCMessage(r io.Reader) ([]byte, error) {
+	var header [5]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, fmt.Errorf("failed to read message header: %v", err)
+	}
+	if header[0] != 0 {
+		return nil, errors.New("compressed messages are not supported")
+	}
+
+	data := make([]byte, binary.BigEndian.Uint32(header[1:]))
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, fmt.Errorf("failed to read message: %v", err)
+	}
+	return data, nil
+}
+
+// writeGRPCError writes a trailers-only response carrying the error status
+func writeGRPCError(w http.ResponseWriter, err error) {
+	var gerr *grpcError
+	if !errors.As(err, &gerr) {
+		gerr = &grpcError{codeInternal, err.Error()}
+	}
+
+	w.Header().Set("Content-Type", "application/grpc+proto")
+	w.Header().Set("Grpc-Status", strconv.Itoa(gerr.code))
+	w.Header().Set("Grpc-Message", encodeGRPCMessage(gerr.message))
+	w.WriteHeader(http.StatusOK)
+}
+
+// encodeGRPCMessage percent-encodes a status message as required by the gRPC protocol
+func encodeGRPCMessage(msg string) string {
+	var b strings.Builder
+	for i := 0; i < len(msg); i++ {
+		c := msg[i]
+		if c < 0x20 || c > 0x7e || c == '%' {
+			fmt.Fprintf(&b, "%%%02X", c)
+			continue
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
@@ -0,0 +1,142 @@
+package server
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oculus-core/gogo/internal/policy"
+)
+
+// grpcCall makes a unary gRPC call over h2c and returns the response message
+// and the grpc-status
+func grpcCall(t *testing.T, srv *Server, method string, req, resp interface{}) string {
+	t.Helper()
+
+	ts := httptest.NewUnstartedServer(srv)
+	ts.Config.Protocols = new(http.Protocols)
+	ts.Config.Protocols.SetHTTP1(true)
+	ts.Config.Protocols.SetUnencryptedHTTP2(true)
+	ts.Start()
+	defer ts.Close()
+
+	data, err := marshalProto(req)
+	require.NoError(t, err)
+	frame := make([]byte, 5)
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(data)))
+
+	httpReq, err := http.NewRequest(http.MethodPost, ts.URL+"/"+grpcService+"/"+method,
+		bytes.NewReader(append(frame, data...)))
+	require.NoError(t, err)
+	httpReq.Header.Set("Content-Type", "application/grpc")
+	httpReq.Header.Set("TE", "trailers")
+
+	protocols := new(http.Protocols)
+	protocols.SetUnencryptedHTTP2(true)
+	client := &http.Client{Transport: &http.Transport{Protocols: protocols}}
+
+	httpResp, err := client.Do(httpReq)
+	require.NoError(t, err)
+	defer httpResp.Body.Close()
+	require.Equal(t, 2, httpResp.ProtoMajor)
+
+	body, err := io.ReadAll(httpResp.Body)
+	require.NoError(t, err)
+
+	// Errors are sent trailers-only, in the headers
+	if status := httpResp.Header.Get("Grpc-Status"); status != "" {
+		return status
+	}
+
+	msg, err := readGRPCMessage(bytes.NewReader(body))
+	require.NoError(t, err)
+	require.NoError(t, unmarshalProto(msg, resp))
+	return httpResp.Trailer.Get("Grpc-Status")
+}
+
+func TestGRPCListTemplates(t *testing.T) {
+	var resp grpcListTemplatesResponse
+	status := grpcCall(t, New(Options{EnableGRPC: true}), "ListTemplates", &struct{}{}, &resp)
+	require.Equal(t, "0", status)
+
+	names := map[string]string{}
+	for _, tmpl := range resp.Templates {
+		names[tmpl.Name] = tmpl.Description
+	}
+	assert.Contains(t, names, "cli")
+	assert.Contains(t, names, "api")
+	assert.NotEmpty(t, names["library"])
+}
+
+func TestGRPCGenerateProject(t *testing.T) {
+	req := &grpcGenerateProjectRequest{Config: &grpcProjectConfig{
+		Name:   "svc",
+		Module: "github.com/acme/svc",
+		Type:   "api",
+	}}
+
+	var resp grpcGenerateProjectResponse
+	status := grpcCall(t, New(Options{EnableGRPC: true}), "GenerateProject", req, &resp)
+	require.Equal(t, "0", status)
+	assert.Equal(t, "svc", resp.Name)
+
+	files := archiveFiles(t, bytes.NewReader(resp.Archive))
+	assert.Contains(t, files["svc/go.mod"], "module github.com/acme/svc")
+}
+
+func TestGRPCGenerateProjectOutputDir(t *testing.T) {
+	outputDir := t.TempDir()
+	srv := New(Options{EnableGRPC: true, OutputDir: outputDir})
+	req := &grpcGenerateProjectRequest{Config: &grpcProjectConfig{Name: "tool", Type: "cli"}}
+
+	var resp grpcGenerateProjectResponse
+	require.Equal(t, "0", grpcCall(t, srv, "GenerateProject", req, &resp))
+	assert.Equal(t, filepath.Join(outputDir, "tool"), resp.Path)
+	assert.Empty(t, resp.Archive)
+
+	_, err := os.Stat(filepath.Join(outputDir, "tool", "go.mod"))
+	assert.NoError(t, err)
+
+	assert.Equal(t, "6", grpcCall(t, srv, "GenerateProject", req, &resp))
+}
+
+func TestGRPCValidateConfig(t *testing.T) {
+	srv := New(Options{EnableGRPC: true, Policy: &policy.Policy{
+		Name:         "acme",
+		ModulePrefix: "github.com/acme/",
+	}})
+
+	var resp grpcValidateConfigResponse
+	req := &grpcValidateConfigRequest{Config: &grpcProjectConfig{Name: "svc", Module: "github.com/other/svc"}}
+	require.Equal(t, "0", grpcCall(t, srv, "ValidateConfig", req, &resp))
+	assert.False(t, resp.Valid)
+	require.Len(t, resp.Violations, 1)
+	assert.Equal(t, "module", resp.Violations[0].Field)
+
+	resp = grpcValidateConfigResponse{}
+	req.Config.Module = "github.com/acme/svc"
+	require.Equal(t, "0", grpcCall(t, srv, "ValidateConfig", req, &resp))
+	assert.True(t, resp.Valid)
+}
+
+func TestGRPCErrors(t *testing.T) {
+	srv := New(Options{EnableGRPC: true})
+
+	var resp grpcGenerateProjectResponse
+	assert.Equal(t, "3", grpcCall(t, srv, "GenerateProject", &grpcGenerateProjectRequest{}, &resp))
+	assert.Equal(t, "12", grpcCall(t, srv, "DeleteProject", &struct{}{}, &resp))
+
+	// The gRPC routes are only registered when enabled
+	req := httptest.NewRequest(http.MethodPost, "/"+grpcService+"/ListTemplates", nil)
+	rec := httptest.NewRecorder()
+	New(Options{}).ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
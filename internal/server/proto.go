@@ -0,0 +1,251 @@
+package server
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// Protobuf wire types
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+// marshalProto encodes a struct using the protobuf wire format. Fields are
+// mapped with `protobuf:"<number>"` tags and may be string, []byte, bool,
+// *bool (proto3 optional), int32, int64, nested structs (pointer or value),
+// or slices of strings and structs.
+func marshalProto(v interface{}) ([]byte, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot marshal %s", rv.Type())
+	}
+	return appendMessage(nil, rv)
+}
+
+func appendMessage(b []byte, rv reflect.Value) ([]byte, error) {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		num, ok := fieldNumber(rt.Field(i))
+		if !ok {
+			continue
+		}
+
+		var err error
+		b, err = appendField(b, num, rv.Field(i))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", rt.Field(i).Name, err)
+		}
+	}
+	return b, nil
+}
+
+func appendField(b []byte, num int, fv reflect.Value) ([]byte, error) {
+	switch fv.Kind() {
+	case reflect.String:
+		if fv.Len() > 0 {
+			b = appendBytes(b, num, []byte(fv.String()))
+		}
+	case reflect.Bool:
+		if fv.Bool() {
+			b = appendVarint(appendTag(b, num, wireVarint), 1)
+		}
+	case reflect.Int32, reflect.Int64:
+		if fv.Int() != 0 {
+			b = appendVarint(appendTag(b, num, wireVarint), uint64(fv.Int()))
+		}
+	case reflect.Ptr:
+		if fv.IsNil() {
+			return b, nil
+		}
+		elem := fv.Elem()
+		if elem.Kind() == reflect.Bool {
+			// Explicit presence: encode false as well
+			v := uint64(0)
+			if elem.Bool() {
+				v = 1
+			}
+			return appendVarint(appendTag(b, num, wireVarint), v), nil
+		}
+		return appendField(b, num, elem)
+	case reflect.Struct:
+		msg, err := appendMessage(nil, fv)
+		if err != nil {
+			return nil, err
+		}
+		b = appendBytes(b, num, msg)
+	case reflect.Slice:
+		if fv.Type().Elem().Kind() == reflect.Uint8 {
+			if fv.Len() > 0 {
+				b = appendBytes(b, num, fv.Bytes())
+			}
+			return b, nil
+		}
+		for i := 0; i < fv.Len(); i++ {
+			item := fv.Index(i)
+			switch item.Kind() {
+			case reflect.String:
+				b = appendBytes(b, num, []byte(item.String()))
+			case reflect.Struct:
+				msg, err := appendMessage(nil, item)
+				if err != nil {
+					return nil, err
+				}
+				b = appendBytes(b, num, msg)
+			default:
+				return nil, fmt.Errorf("unsupported repeated type %s", item.Type())
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported type %s", fv.Type())
+	}
+	return b, nil
+}
+
+// unmarshalProto decodes protobuf wire data into a struct tagged as for marshalProto.
+// Unknown fields are skipped.
+func unmarshalProto(data []byte, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("unmarshal target must be a pointer to a struct")
+	}
+	return decodeMessage(data, rv.Elem())
+}
+
+func decodeMessage(data []byte, rv reflect.Value) error {
+	fields := map[int]int{}
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		if num, ok := fieldNumber(rt.Field(i)); ok {
+			fields[num] = i
+		}
+	}
+
+	for len(data) > 0 {
+		key, n := binary.Uvarint(data)
+		if n <= 0 {
+			return errors.New("invalid field key")
+		}
+		data = data[n:]
+		num, wire := int(key>>3), int(key&7)
+
+		var varint uint64
+		var payload []byte
+		switch wire {
+		case wireVarint:
+			varint, n = binary.Uvarint(data)
+			if n <= 0 {
+				return errors.New("invalid varint")
+			}
+			data = data[n:]
+		case wireBytes:
+			length, n := binary.Uvarint(data)
+			if n <= 0 || uint64(len(data)-n) < length {
+				return errors.New("invalid length-delimited field")
+			}
+			payload = data[n : n+int(length)]
+			data = data[n+int(length):]
+		case wireFixed64:
+			if len(data) < 8 {
+				return errors.New("truncated fixed64")
+			}
+			data = data[8:]
+		case wireFixed32:
+			if len(data) < 4 {
+				return errors.New("truncated fixed32")
+			}
+			data = data[4:]
+		default:
+			return fmt.Errorf("unsupported wire type %d", wire)
+		}
+
+		idx, ok := fields[num]
+		if !ok {
+			continue
+		}
+		if err := setField(rv.Field(idx), wire, varint, payload); err != nil {
+			return fmt.Errorf("%s: %w", rt.Field(idx).Name, err)
+		}
+	}
+	return nil
+}
+
+func setField(fv reflect.Value, wire int, varint uint64, payload []byte) error {
+	switch fv.Kind() {
+	case reflect.String:
+		if wire != wireBytes {
+			return errors.New("expected length-delimited string")
+		}
+		fv.SetString(string(payload))
+	case reflect.Bool:
+		if wire != wireVarint {
+			return errors.New("expected varint bool")
+		}
+		fv.SetBool(varint != 0)
+	case reflect.Int32, reflect.Int64:
+		if wire != wireVarint {
+			return errors.New("expected varint integer")
+		}
+		fv.SetInt(int64(varint))
+	case reflect.Ptr:
+		if fv.IsNil() {
+			fv.Set(reflect.New(fv.Type().Elem()))
+		}
+		return setField(fv.Elem(), wire, varint, payload)
+	case reflect.Struct:
+		if wire != wireBytes {
+			return errors.New("expected embedded message")
+		}
+		return decodeMessage(payload, fv)
+	case reflect.Slice:
+		if wire != wireBytes {
+			return errors.New("expected length-delimited field")
+		}
+		if fv.Type().Elem().Kind() == reflect.Uint8 {
+			fv.SetBytes(append([]byte(nil), payload...))
+			return nil
+		}
+		item := reflect.New(fv.Type().Elem()).Elem()
+		if err := setField(item, wire, varint, payload); err != nil {
+			return err
+		}
+		fv.Set(reflect.Append(fv, item))
+	default:
+		return fmt.Errorf("unsupported type %s", fv.Type())
+	}
+	return nil
+}
+
+func fieldNumber(f reflect.StructField) (int, bool) {
+	tag := f.Tag.Get("protobuf")
+	if tag == "" {
+		return 0, false
+	}
+	num, err := strconv.Atoi(tag)
+	if err != nil || num <= 0 {
+		return 0, false
+	}
+	return num, true
+}
+
+func appendTag(b []byte, num, wire int) []byte {
+	return appendVarint(b, uint64(num)<<3|uint64(wire))
+}
+
+func appendVarint(b []byte, v uint64) []byte {
+	return binary.AppendUvarint(b, v)
+}
+
+func appendBytes(b []byte, num int, data []byte) []byte {
+	b = appendTag(b, num, wireBytes)
+	b = appendVarint(b, uint64(len(data)))
+	return append(b, data...)
+}
@@ -0,0 +1,57 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProtoRoundTrip(t *testing.T) {
+	no := false
+	in := grpcGenerateProjectRequest{Config: &grpcProjectConfig{
+		Name:     "svc",
+		Module:   "github.com/acme/svc",
+		Type:     "api",
+		UseGin:   &no,
+		UseCobra: nil,
+	}}
+
+	data, err := marshalProto(&in)
+	require.NoError(t, err)
+
+	var out grpcGenerateProjectRequest
+	require.NoError(t, unmarshalProto(data, &out))
+	require.NotNil(t, out.Config)
+	assert.Equal(t, "svc", out.Config.Name)
+	assert.Equal(t, "github.com/acme/svc", out.Config.Module)
+
+	// Optional fields keep their presence
+	require.NotNil(t, out.Config.UseGin)
+	assert.False(t, *out.Config.UseGin)
+	assert.Nil(t, out.Config.UseCobra)
+}
+
+func TestProtoRepeated(t *testing.T) {
+	in := grpcValidateConfigResponse{Violations: []grpcViolation{
+		{Field: "module", Message: "must start with github.com/acme/"},
+		{Field: "license", Message: "must be Apache-2.0"},
+	}}
+
+	data, err := marshalProto(&in)
+	require.NoError(t, err)
+
+	var out grpcValidateConfigResponse
+	require.NoError(t, unmarshalProto(data, &out))
+	assert.Equal(t, in, out)
+}
+
+func TestProtoSkipsUnknownFields(t *testing.T) {
+	// Field 99 is unknown to grpcTemplate
+	data := appendBytes(nil, 99, []byte("ignored"))
+	data = appendBytes(data, 1, []byte("cli"))
+
+	var out grpcTemplate
+	require.NoError(t, unmarshalProto(data, &out))
+	assert.Equal(t, "cli", out.Name)
+}
@@ -0,0 +1,330 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/oculus-core/gogo/internal/policy"
+	"github.com/oculus-core/gogo/internal/templates"
+	"github.com/oculus-core/gogo/internal/wizard"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// maxRequestBytes limits the size of a project configuration request body
+const maxRequestBytes = 1 << 20
+
+// errProjectExists is returned when the output directory already contains the project
+var errProjectExists = errors.New("project already exists")
+
+// Options configures the generation API
+type Options struct {
+	// OutputDir, when set, makes the server write projects to this directory
+	// instead of returning them as tar.gz archives
+	OutputDir string
+
+	// Policy is enforced on every request; may be nil
+	Policy *policy.Policy
+
+	// OnGenerated is called after a project has been generated. projectDir is
+	// empty when the project was only returned as an archive.
+	OnGenerated func(cfg *config.ProjectConfig, projectDir string)
+
+	// Logger receives request errors; defaults to the standard logger
+	Logger *log.Logger
+
+	// EnableGRPC also serves the gogo.v1.GogoService gRPC service. The HTTP
+	// server must accept HTTP/2 (h2c or TLS) for gRPC clients to connect.
+	EnableGRPC bool
+}
+
+// Server exposes project generation over HTTP
+type Server struct {
+	opts Options
+	mux  *http.ServeMux
+}
+
+// errorResponse is the JSON body returned for failed requests
+type errorResponse struct {
+	Error      string             `json:"error"`
+	Violations []policy.Violation `json:"violations,omitempty"`
+}
+
+// createdResponse is returned when a project is written to the output directory
+type createdResponse struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+// New creates a generation API server
+func New(opts Options) *Server {
+	if opts.Logger == nil {
+		opts.Logger = log.Default()
+	}
+
+	s := &Server{opts: opts, mux: http.NewServeMux()}
+	s.mux.HandleFunc("GET /healthz", s.handleHealth)
+	s.mux.HandleFunc("POST /v1/projects", s.handleCreateProject)
+	if opts.EnableGRPC {
+		s.mux.HandleFunc("POST /"+grpcService+"/{method}", s.handleGRPC)
+	}
+	return s
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// handleCreateProject generates a project from a JSON ProjectConfig
+func (s *Server) handleCreateProject(w http.ResponseWriter, r *http.Request) {
+	cfg, err := DecodeConfigWithRules(http.MaxBytesReader(w, r.Body, maxRequestBytes), s.opts.Policy.DefaultRules())
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+		return
+	}
+
+	if err := s.enforcePolicy(cfg); err != nil {
+		resp := errorResponse{Error: err.Error()}
+		var verr *policy.ValidationError
+		if errors.As(err, &verr) {
+			resp.Violations = verr.Violations
+		}
+		writeJSON(w, http.StatusUnprocessableEntity, resp)
+		return
+	}
+
+	if s.opts.OutputDir != "" {
+		projectDir, err := s.writeProject(cfg)
+		switch {
+		case errors.Is(err, errProjectExists):
+			writeJSON(w, http.StatusConflict, errorResponse{Error: err.Error()})
+		case errors.Is(err, templates.ErrOutputLimit):
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+		case err != nil:
+			writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "failed to generate project"})
+		default:
+			writeJSON(w, http.StatusCreated, createdResponse{Name: cfg.Name, Path: projectDir})
+		}
+		return
+	}
+
+	tmpDir, err := s.buildProject(cfg)
+	if errors.Is(err, templates.ErrOutputLimit) {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+		return
+	}
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "failed to generate project"})
+		return
+	}
+	defer os.RemoveAll(tmpDir)
+
+	w.Header().Set("Content-Type", "application/gzip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", cfg.Name+".tar.gz"))
+	w.WriteHeader(http.StatusOK)
+
+	if err := WriteTarGz(w, filepath.Join(tmpDir, cfg.Name), cfg.Name); err != nil {
+		// Headers are already sent; the client sees a truncated archive
+		s.opts.Logger.Printf("failed to write archive for %s: %v", cfg.Name, err)
+	}
+}
+
+// enforcePolicy applies the locked values of the policy and validates the result
+func (s *Server) enforcePolicy(cfg *config.ProjectConfig) error {
+	if _, err := s.opts.Policy.Apply(cfg); err != nil {
+		return err
+	}
+	return s.opts.Policy.Validate(cfg)
+}
+
+// writeProject generates the project below the configured output directory
+func (s *Server) writeProject(cfg *config.ProjectConfig) (string, error) {
+	projectDir := filepath.Join(s.opts.OutputDir, cfg.Name)
+	if _, err := os.Stat(projectDir); err == nil {
+		return "", fmt.Errorf("%w: %s", errProjectExists, cfg.Name)
+	}
+
+	if err := wizard.GenerateProject(cfg, s.opts.OutputDir); err != nil {
+		s.opts.Logger.Printf("failed to generate project %s: %v", cfg.Name, err)
+		return "", err
+	}
+
+	if s.opts.OnGenerated != nil {
+		s.opts.OnGenerated(cfg, projectDir)
+	}
+	return projectDir, nil
+}
+
+// buildProject generates the project in a new temporary directory, which the
+// caller must remove
+func (s *Server) buildProject(cfg *config.ProjectConfig) (string, error) {
+	tmpDir, err := os.MkdirTemp("", "gogo-serve-*")
+	if err != nil {
+		s.opts.Logger.Printf("failed to create temporary directory: %v", err)
+		return "", err
+	}
+
+	if err := wizard.GenerateProject(cfg, tmpDir); err != nil {
+		os.RemoveAll(tmpDir)
+		s.opts.Logger.Printf("failed to generate project %s: %v", cfg.Name, err)
+		return "", err
+	}
+
+	if s.opts.OnGenerated != nil {
+		s.opts.OnGenerated(cfg, "")
+	}
+	return tmpDir, nil
+}
+
+// DecodeConfig decodes a JSON ProjectConfig on top of the defaults for its project type.
+// The name is required and the module defaults to the name.
+func DecodeConfig(body io.Reader) (*config.ProjectConfig, error) {
+	return DecodeConfigWithRules(body, nil)
+}
+
+// DecodeConfigWithRules is like DecodeConfig but also applies the rules, such
+// as those of a policy, to the fields the request leaves out
+func DecodeConfigWithRules(body io.Reader, rules []config.Rule) (*config.ProjectConfig, error) {
+	var raw json.RawMessage
+	if err := json.NewDecoder(body).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("invalid JSON body: %v", err)
+	}
+
+	var typed struct {
+		Type config.ProjectType `json:"type"`
+	}
+	if err := json.Unmarshal(raw, &typed); err != nil {
+		return nil, fmt.Errorf("invalid project type: %v", err)
+	}
+	if typed.Type != "" && !config.IsValidProjectType(typed.Type) {
+		return nil, fmt.Errorf("unknown project type %q", typed.Type)
+	}
+
+	cfg := config.GetProjectConfigForType(typed.Type)
+	cfg.Name, cfg.Module = "", ""
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, fmt.Errorf("invalid project config: %v", err)
+	}
+	if err := json.Unmarshal(raw, cfg); err != nil {
+		return nil, fmt.Errorf("invalid project config: %v", err)
+	}
+	if _, ok := fields["openapi_spec"]; ok {
+		return nil, fmt.Errorf("openapi_spec is a local path and cannot be set in requests")
+	}
+	keep := make(map[string]bool, len(fields))
+	for field := range fields {
+		keep[field] = true
+	}
+	if _, err := config.ApplyRules(cfg, rules, keep); err != nil {
+		return nil, err
+	}
+
+	if err := validateName(cfg.Name); err != nil {
+		return nil, err
+	}
+	if err := cfg.NormalizeNames(); err != nil {
+		return nil, err
+	}
+	if !config.IsValidLicense(cfg.License) {
+		return nil, fmt.Errorf("unknown license %q", cfg.License)
+	}
+	if !config.IsValidVisibility(cfg.Visibility) {
+		return nil, fmt.Errorf("unknown visibility %q", cfg.Visibility)
+	}
+	if !config.IsValidFormatting(cfg.Formatting) {
+		return nil, fmt.Errorf("unknown formatting %q", cfg.Formatting)
+	}
+	if !config.IsValidLintPreset(cfg.LintPreset) {
+		return nil, fmt.Errorf("unknown lint preset %q", cfg.LintPreset)
+	}
+	if !config.IsValidCommitConvention(cfg.CommitConvention) {
+		return nil, fmt.Errorf("unknown commit convention %q", cfg.CommitConvention)
+	}
+	if !config.IsValidToolVersionManager(cfg.ToolVersionManager) {
+		return nil, fmt.Errorf("unknown tool version manager %q", cfg.ToolVersionManager)
+	}
+	if !config.IsValidDockerBase(cfg.DockerBase) {
+		return nil, fmt.Errorf("unknown docker base %q", cfg.DockerBase)
+	}
+	if !config.IsValidContainerRegistry(cfg.ContainerRegistry) {
+		return nil, fmt.Errorf("unknown container registry %q", cfg.ContainerRegistry)
+	}
+	if !config.IsValidRequestValidation(cfg.RequestValidation) {
+		return nil, fmt.Errorf("unknown request validation %q", cfg.RequestValidation)
+	}
+	if !config.IsValidPagination(cfg.Pagination) {
+		return nil, fmt.Errorf("unknown pagination %q", cfg.Pagination)
+	}
+	if !config.IsValidTaskQueue(cfg.TaskQueue) {
+		return nil, fmt.Errorf("unknown task queue %q", cfg.TaskQueue)
+	}
+	if !config.IsValidDocsSite(cfg.DocsSite) {
+		return nil, fmt.Errorf("unknown docs site %q", cfg.DocsSite)
+	}
+	if !config.IsValidDirPlaceholder(cfg.DirPlaceholder) {
+		return nil, fmt.Errorf("unknown directory placeholder %q", cfg.DirPlaceholder)
+	}
+	if !config.IsValidSecretsManager(cfg.SecretsManager) {
+		return nil, fmt.Errorf("unknown secrets manager %q", cfg.SecretsManager)
+	}
+	if !config.IsValidFeatureFlags(cfg.FeatureFlags) {
+		return nil, fmt.Errorf("unknown feature flag backend %q", cfg.FeatureFlags)
+	}
+	if !config.IsValidPromptLibrary(cfg.PromptLibrary) {
+		return nil, fmt.Errorf("unknown prompt library %q", cfg.PromptLibrary)
+	}
+	if !config.IsValidActionRuntime(cfg.ActionRuntime) {
+		return nil, fmt.Errorf("unknown action runtime %q", cfg.ActionRuntime)
+	}
+	if err := config.ValidateOperatorAPI(cfg.OperatorGroup, cfg.OperatorKind); err != nil {
+		return nil, err
+	}
+	if !config.IsValidEventBroker(cfg.EventBroker) {
+		return nil, fmt.Errorf("unknown event broker %q", cfg.EventBroker)
+	}
+	if err := config.ValidatePackages(cfg.Packages); err != nil {
+		return nil, err
+	}
+	if err := config.ValidateLayout(cfg.Layout); err != nil {
+		return nil, err
+	}
+	if err := config.ValidateTopics(cfg.Topics); err != nil {
+		return nil, err
+	}
+	if err := config.ValidateBranch(cfg.DefaultBranch); err != nil {
+		return nil, err
+	}
+	if cfg.Module == "" {
+		cfg.Module = cfg.Name
+	}
+	return cfg, nil
+}
+
+// validateName ensures the project name is safe to use as a directory name
+func validateName(name string) error {
+	if name == "" {
+		return errors.New("name is required")
+	}
+	if name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("invalid project name %q", name)
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
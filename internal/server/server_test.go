@@ -0,0 +1,188 @@
+package server
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oculus-core/gogo/internal/policy"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+func post(t *testing.T, handler http.Handler, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/v1/projects", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+// archiveFiles returns the names of the regular files in a tar.gz archive
+func archiveFiles(t *testing.T, r io.Reader) map[string]string {
+	t.Helper()
+	gz, err := gzip.NewReader(r)
+	require.NoError(t, err)
+
+	files := map[string]string{}
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		if header.Typeflag == tar.TypeReg {
+			content, err := io.ReadAll(tr)
+			require.NoError(t, err)
+			files[header.Name] = string(content)
+		}
+	}
+	return files
+}
+
+func TestCreateProjectArchive(t *testing.T) {
+	var generated *config.ProjectConfig
+	srv := New(Options{OnGenerated: func(cfg *config.ProjectConfig, projectDir string) {
+		generated = cfg
+		assert.Empty(t, projectDir)
+	}})
+
+	rec := post(t, srv, `{"name": "svc", "module": "github.com/acme/svc", "type": "api"}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, "application/gzip", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Header().Get("Content-Disposition"), "svc.tar.gz")
+
+	files := archiveFiles(t, rec.Body)
+	assert.Contains(t, files, "svc/go.mod")
+	assert.Contains(t, files, "svc/internal/api/server.go")
+	assert.Contains(t, files["svc/go.mod"], "module github.com/acme/svc")
+
+	// Type defaults are applied beneath the request
+	require.NotNil(t, generated)
+	assert.True(t, generated.UseGin)
+}
+
+func TestCreateProjectUnicodeName(t *testing.T) {
+	srv := New(Options{})
+
+	rec := post(t, srv, `{"name": "Café", "module": "bücher.example/café", "type": "library"}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Contains(t, rec.Header().Get("Content-Disposition"), "Cafe.tar.gz")
+
+	files := archiveFiles(t, rec.Body)
+	assert.Contains(t, files["Cafe/go.mod"], "module xn--bcher-kva.example/cafe\n")
+	assert.True(t, strings.HasPrefix(files["Cafe/README.md"], "# Café\n"))
+}
+
+func TestCreateProjectOutputDir(t *testing.T) {
+	outputDir := t.TempDir()
+	srv := New(Options{OutputDir: outputDir})
+
+	rec := post(t, srv, `{"name": "tool", "type": "cli"}`)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+
+	var resp createdResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, filepath.Join(outputDir, "tool"), resp.Path)
+
+	_, err := os.Stat(filepath.Join(outputDir, "tool", "cmd", "tool", "main.go"))
+	assert.NoError(t, err)
+
+	// Generating the same project again conflicts
+	rec = post(t, srv, `{"name": "tool", "type": "cli"}`)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
+
+func TestCreateProjectInvalid(t *testing.T) {
+	srv := New(Options{})
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"malformed JSON", `{"name": `},
+		{"missing name", `{"type": "cli"}`},
+		{"path in name", `{"name": "../escape"}`},
+		{"unknown type", `{"name": "x", "type": "spaceship"}`},
+		{"unknown tool version manager", `{"name": "x", "tool_version_manager": "nix"}`},
+		{"unknown docs site", `{"name": "x", "docs_site": "sphinx"}`},
+		{"name without ASCII letters", `{"name": "日本語"}`},
+		{"package outside the module", `{"name": "x", "type": "library", "packages": ["../escape"]}`},
+		{"local OpenAPI spec", `{"name": "x", "type": "sdk", "openapi_spec": "/etc/passwd"}`},
+		{"huge metadata", `{"name": "x", "metadata_files": [{"path": "m.yaml", "fields": {"a": "{{ repeat 1000000000 \"x\" }}"}}]}`},
+		{"metadata over the limit", `{"name": "x", "metadata_files": [{"path": "m.yaml", "fields": {"a": "{{ $x := repeat 100000 \"x\" }}{{ range $i := repeat 20 \"x\" | split \"\" }}{{ $x }}{{ end }}"}}]}`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := post(t, srv, tc.body)
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			assert.Contains(t, rec.Body.String(), `"error"`)
+		})
+	}
+
+	// Only POST is accepted
+	req := httptest.NewRequest(http.MethodGet, "/v1/projects", nil)
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestCreateProjectPolicyRules(t *testing.T) {
+	var generated *config.ProjectConfig
+	srv := New(Options{
+		Policy: &policy.Policy{Name: "acme", Rules: []config.Rule{
+			{When: map[string]interface{}{"type": "api"}, Set: map[string]interface{}{"use_pprof": true, "use_live_reload": true}},
+		}},
+		OnGenerated: func(cfg *config.ProjectConfig, _ string) { generated = cfg },
+	})
+
+	// Rules set the fields the request leaves out
+	rec := post(t, srv, `{"name": "svc", "type": "api", "use_live_reload": false}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	require.NotNil(t, generated)
+	assert.True(t, generated.UsePprof)
+	assert.False(t, generated.UseLiveReload)
+
+	rec = post(t, srv, `{"name": "tool", "type": "cli"}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.False(t, generated.UsePprof)
+}
+
+func TestCreateProjectPolicy(t *testing.T) {
+	srv := New(Options{Policy: &policy.Policy{
+		Name:         "acme",
+		Locked:       map[string]interface{}{"license": "Apache-2.0"},
+		ModulePrefix: "github.com/acme/",
+	}})
+
+	rec := post(t, srv, `{"name": "svc", "module": "github.com/other/svc"}`)
+	require.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+
+	var resp errorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Len(t, resp.Violations, 1)
+	assert.Equal(t, "module", resp.Violations[0].Field)
+
+	rec = post(t, srv, `{"name": "svc", "module": "github.com/acme/svc", "license": "MIT"}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, archiveFiles(t, rec.Body)["svc/gogo.yaml"], `license: "Apache-2.0"`)
+}
+
+func TestHealth(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+	rec := httptest.NewRecorder()
+	New(Options{}).ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"status":"ok"}`, rec.Body.String())
+}
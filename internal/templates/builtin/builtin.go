@@ -0,0 +1,210 @@
+// Package builtin embeds the templates gogo generates projects and components
+// from, so the gogo binary is self-contained and reads no template files at
+// run time. Templates exported with Export can be edited and loaded back with
+// Override to replace the built-in ones.
+package builtin
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FS holds the built-in templates below one directory per package that
+// renders them, such as wizard/crawler/main.tmpl
+//
+//go:embed wizard component
+var FS embed.FS
+
+// SchemaVersion is the version of the data the built-in templates are
+// rendered with. It changes when fields are renamed or removed, so templates
+// exported by an earlier gogo are not rendered with data they do not expect.
+const SchemaVersion = 1
+
+// NoteFile is the file Export writes next to the templates to record where
+// they come from
+const NoteFile = "builtin.yaml"
+
+// Note records the gogo version and template schema version of exported
+// templates
+type Note struct {
+	SchemaVersion int    `yaml:"schema_version"`
+	GogoVersion   string `yaml:"gogo_version"`
+	// Type is the project type the templates were exported for, empty for
+	// all of them
+	Type string `yaml:"type,omitempty"`
+}
+
+// names maps the text of each built-in template to its name, so Resolve can
+// find the override of a template from its text
+var names = map[string]string{}
+
+// overrides holds the templates loaded by Override by name
+var overrides map[string]string
+
+// Template returns the text of a built-in template, or an error when no
+// built-in template has the name
+func Template(name string) (string, error) {
+	data, err := fs.ReadFile(FS, name)
+	if err != nil {
+		return "", fmt.Errorf("no built-in template %s", name)
+	}
+	names[string(data)] = name
+	return string(data), nil
+}
+
+// MustTemplate is like Template but panics when the template does not exist.
+// It is meant for the names written in gogo's own code, which are looked up
+// when their package is initialized, so every test of the package catches a
+// missing one.
+func MustTemplate(name string) string {
+	text, err := Template(name)
+	if err != nil {
+		panic(err)
+	}
+	return text
+}
+
+// Names returns the names of the built-in templates in lexical order. With
+// dirs, only the templates below those directories are returned.
+func Names(dirs ...string) ([]string, error) {
+	var result []string
+	err := fs.WalkDir(FS, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		if len(dirs) == 0 || below(name, dirs) {
+			result = append(result, name)
+		}
+		return nil
+	})
+	return result, err
+}
+
+// below reports whether name is in one of dirs or a directory below it
+func below(name string, dirs []string) bool {
+	for _, dir := range dirs {
+		if strings.HasPrefix(name, strings.TrimSuffix(dir, "/")+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// Export writes the named built-in templates below dir, keeping their names,
+// and the note to NoteFile, and returns the paths it wrote. Existing files are
+// not overwritten unless force is set; in that case Export writes nothing.
+func Export(dir string, templates []string, note Note, force bool) ([]string, error) {
+	paths := append([]string{NoteFile}, templates...)
+	if !force {
+		for _, name := range paths {
+			path := filepath.Join(dir, filepath.FromSlash(name))
+			if _, err := os.Stat(path); err == nil {
+				return nil, fmt.Errorf("%s already exists: use --force to overwrite it", path)
+			}
+		}
+	}
+
+	written := make([]string, 0, len(paths))
+	for _, name := range paths {
+		var data []byte
+		var err error
+		if name == NoteFile {
+			data, err = noteContent(note)
+		} else {
+			data, err = fs.ReadFile(FS, name)
+		}
+		if err != nil {
+			return written, err
+		}
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return written, err
+		}
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			return written, err
+		}
+		written = append(written, path)
+	}
+	return written, nil
+}
+
+// noteContent explains the exported templates above the note
+func noteContent(note Note) ([]byte, error) {
+	data, err := yaml.Marshal(note)
+	if err != nil {
+		return nil, err
+	}
+	header := "# Built-in templates exported by gogo. Edit them, delete the ones you do not\n" +
+		"# change, and pass the parent directory to gogo new or gogo add with --template.\n" +
+		"# Templates are only loaded by a gogo that renders the same schema version.\n"
+	return append([]byte(header), data...), nil
+}
+
+// Override loads the templates in dirs, exported by Export, in place of the
+// built-in templates with the same names. Templates in later directories
+// replace those in earlier ones. Each note must have the current schema
+// version.
+func Override(dirs ...string) error {
+	loaded := map[string]string{}
+	for _, dir := range dirs {
+		if err := loadOverrides(dir, loaded); err != nil {
+			return err
+		}
+	}
+	overrides = loaded
+	return nil
+}
+
+// loadOverrides adds the templates exported to dir to loaded by name
+func loadOverrides(dir string, loaded map[string]string) error {
+	data, err := os.ReadFile(filepath.Join(dir, NoteFile))
+	if err != nil {
+		return fmt.Errorf("%s is not a directory of exported templates: %w", dir, err)
+	}
+	var note Note
+	if err := yaml.Unmarshal(data, &note); err != nil {
+		return fmt.Errorf("invalid %s: %w", filepath.Join(dir, NoteFile), err)
+	}
+	if note.SchemaVersion != SchemaVersion {
+		return fmt.Errorf("templates in %s have schema version %d, but gogo renders version %d: "+
+			"export the built-in templates again and reapply your changes", dir, note.SchemaVersion, SchemaVersion)
+	}
+
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(path, ".tmpl") {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(rel)
+		if _, err := fs.Stat(FS, name); err != nil {
+			return fmt.Errorf("%s does not replace a built-in template", path)
+		}
+		text, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		loaded[name] = string(text)
+		return nil
+	})
+}
+
+// Resolve returns the template loaded by Override in place of the built-in
+// template with the given text, or the text itself
+func Resolve(text string) string {
+	if len(overrides) == 0 {
+		return text
+	}
+	if override, ok := overrides[names[text]]; ok {
+		return override
+	}
+	return text
+}
@@ -0,0 +1,268 @@
+package builtin_test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/oculus-core/gogo/internal/templates"
+	"github.com/oculus-core/gogo/internal/templates/builtin"
+)
+
+func TestTemplatesParse(t *testing.T) {
+	names, err := builtin.Names()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) == 0 {
+		t.Fatal("no built-in templates embedded")
+	}
+	for _, name := range names {
+		if !strings.HasSuffix(name, ".tmpl") {
+			t.Errorf("%s: built-in templates end in .tmpl", name)
+		}
+		if _, err := templates.New(name).Parse(builtin.MustTemplate(name)); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+}
+
+func TestTemplateMissing(t *testing.T) {
+	if _, err := builtin.Template("wizard/crawler/missing.tmpl"); err == nil {
+		t.Error("Template returned no error for a missing template")
+	}
+}
+
+func TestTemplatesUnique(t *testing.T) {
+	names, _ := builtin.Names()
+	seen := map[string]string{}
+	for _, name := range names {
+		text := builtin.MustTemplate(name)
+		if other, ok := seen[text]; ok {
+			t.Errorf("%s has the same text as %s, so they cannot be overridden separately", name, other)
+		}
+		seen[text] = name
+	}
+}
+
+func TestExport(t *testing.T) {
+	dir := t.TempDir()
+	names, err := builtin.Names("wizard/crawler")
+	if err != nil {
+		t.Fatal(err)
+	}
+	note := builtin.Note{SchemaVersion: builtin.SchemaVersion, GogoVersion: "v1.2.3", Type: "crawler"}
+	written, err := builtin.Export(dir, names, note, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(written) != len(names)+1 {
+		t.Fatalf("Export wrote %d files, want %d templates and the note", len(written), len(names))
+	}
+	if _, err := os.Stat(filepath.Join(dir, "wizard", "grpc")); err == nil {
+		t.Error("Export wrote templates of other project types")
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, builtin.NoteFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"schema_version: 1", "gogo_version: v1.2.3", "type: crawler", "--template"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("%s does not contain %q:\n%s", builtin.NoteFile, want, data)
+		}
+	}
+
+	path := filepath.Join(dir, "wizard", "crawler", "main.tmpl")
+	data, err = os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != builtin.MustTemplate("wizard/crawler/main.tmpl") {
+		t.Error("exported template differs from the built-in one")
+	}
+
+	if err := os.WriteFile(path, []byte("custom"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := builtin.Export(dir, names, note, false); err == nil {
+		t.Error("Export overwrote existing files without force")
+	}
+	if data, _ := os.ReadFile(path); string(data) != "custom" {
+		t.Error("failed Export modified existing files")
+	}
+	if _, err := builtin.Export(dir, names, note, true); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(path); string(data) == "custom" {
+		t.Error("Export with force did not overwrite existing files")
+	}
+}
+
+func TestOverride(t *testing.T) {
+	dir := t.TempDir()
+	note := builtin.Note{SchemaVersion: builtin.SchemaVersion}
+	if _, err := builtin.Export(dir, []string{"wizard/crawler/main.tmpl"}, note, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "wizard", "crawler", "main.tmpl"), []byte("package main // {{ .Name }}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := builtin.Override(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		// Load an empty directory to restore the built-in templates
+		empty := t.TempDir()
+		if _, err := builtin.Export(empty, nil, note, false); err == nil {
+			_ = builtin.Override(empty)
+		}
+	})
+
+	out, err := templates.Render("main.go", builtin.MustTemplate("wizard/crawler/main.tmpl"), map[string]string{"Name": "crawly"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != "package main // crawly\n" {
+		t.Errorf("Render = %q, want the overriding template", out)
+	}
+	if text := builtin.MustTemplate("wizard/crawler/fetch.tmpl"); builtin.Resolve(text) != text {
+		t.Error("Resolve replaced a template that is not overridden")
+	}
+}
+
+func TestOverrideLayers(t *testing.T) {
+	note := builtin.Note{SchemaVersion: builtin.SchemaVersion}
+	names := []string{"wizard/crawler/main.tmpl", "wizard/crawler/fetch.tmpl"}
+	team, user := t.TempDir(), t.TempDir()
+	for dir, texts := range map[string][]string{team: {"team main\n", "team fetch\n"}, user: {"user main\n"}} {
+		if _, err := builtin.Export(dir, names[:len(texts)], note, false); err != nil {
+			t.Fatal(err)
+		}
+		for i, text := range texts {
+			if err := os.WriteFile(filepath.Join(dir, filepath.FromSlash(names[i])), []byte(text), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := builtin.Override(team, user); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = builtin.Override() })
+
+	for name, want := range map[string]string{names[0]: "user main\n", names[1]: "team fetch\n"} {
+		if got := builtin.Resolve(builtin.MustTemplate(name)); got != want {
+			t.Errorf("Resolve(%s) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestOverrideErrors(t *testing.T) {
+	note := func(version int) string { return "schema_version: " + strconv.Itoa(version) + "\n" }
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  string
+	}{
+		{"no note", map[string]string{"wizard/crawler/main.tmpl": "x"}, "not a directory of exported templates"},
+		{"old schema", map[string]string{builtin.NoteFile: note(builtin.SchemaVersion - 1)}, "export the built-in templates again"},
+		{"unknown template", map[string]string{builtin.NoteFile: note(builtin.SchemaVersion), "wizard/crawler/extra.tmpl": "x"}, "does not replace a built-in template"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				path := filepath.Join(dir, filepath.FromSlash(name))
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			err := builtin.Override(dir)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Override() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+// runtimeLookups are the functions that find files next to the binary or the
+// source, or by pattern, which a self-contained binary must not depend on
+var runtimeLookups = map[string]map[string]bool{
+	"os":            {"DirFS": true, "Executable": true},
+	"runtime":       {"Caller": true, "Callers": true},
+	"path/filepath": {"Glob": true},
+	"text/template": {"ParseFiles": true, "ParseGlob": true},
+	"html/template": {"ParseFiles": true, "ParseGlob": true},
+}
+
+// userDirFuncs are the functions that open a directory the user names, such
+// as the templates given to gogo new with --template, by file and name
+var userDirFuncs = map[string]string{
+	"internal/templates/set.go": "OpenSet",
+}
+
+// TestNoRuntimeFileLookups keeps gogo a single self-contained binary: built-in
+// templates and catalogs are embedded, and the only files gogo reads on its
+// own are its config in the home directory and its caches in the XDG cache
+// directory. Directories the user names are opened by userDirFuncs only.
+func TestNoRuntimeFileLookups(t *testing.T) {
+	root := filepath.Join("..", "..", "..")
+	fset := token.NewFileSet()
+	var files []string
+	for _, dir := range []string{"cmd", "internal", "pkg"} {
+		err := filepath.WalkDir(filepath.Join(root, dir), func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			if strings.HasSuffix(path, ".go") && !strings.HasSuffix(path, "_test.go") {
+				files = append(files, path)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	files = append(files, filepath.Join(root, "main.go"))
+
+	for _, path := range files {
+		f, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			t.Fatal(err)
+		}
+		imports := map[string]string{}
+		for _, spec := range f.Imports {
+			importPath, _ := strconv.Unquote(spec.Path.Value)
+			name := importPath[strings.LastIndex(importPath, "/")+1:]
+			if spec.Name != nil {
+				name = spec.Name.Name
+			}
+			imports[name] = importPath
+		}
+		rel, _ := filepath.Rel(root, path)
+		ast.Inspect(f, func(n ast.Node) bool {
+			if fn, ok := n.(*ast.FuncDecl); ok && fn.Recv == nil && userDirFuncs[filepath.ToSlash(rel)] == fn.Name.Name {
+				return false
+			}
+			sel, ok := n.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			pkg, ok := sel.X.(*ast.Ident)
+			if ok && runtimeLookups[imports[pkg.Name]][sel.Sel.Name] {
+				t.Errorf("%s: %s.%s looks up files at run time; embed them in internal/templates/builtin instead",
+					fset.Position(sel.Pos()), pkg.Name, sel.Sel.Name)
+			}
+			return true
+		})
+	}
+}
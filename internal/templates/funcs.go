@@ -0,0 +1,214 @@
+package templates
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Func documents a function available to templates
+type Func struct {
+	Name        string
+	Category    string
+	Signature   string
+	Description string
+	Fn          interface{}
+}
+
+// funcs is the complete set of functions templates may call. Templates run
+// with this set only: there are deliberately no functions that execute
+// commands, read files or environment variables, or access the network.
+var funcs = []Func{
+	{"lower", "Strings", "lower STRING", "Converts to lower case", strings.ToLower},
+	{"upper", "Strings", "upper STRING", "Converts to upper case", strings.ToUpper},
+	{"title", "Strings", "title STRING", "Capitalizes the first letter of each word", title},
+	{"trim", "Strings", "trim STRING", "Removes leading and trailing white space", strings.TrimSpace},
+	{"trimPrefix", "Strings", "trimPrefix PREFIX STRING", "Removes a leading prefix", trimPrefix},
+	{"trimSuffix", "Strings", "trimSuffix SUFFIX STRING", "Removes a trailing suffix", trimSuffix},
+	{"replace", "Strings", "replace OLD NEW STRING", "Replaces every occurrence of OLD with NEW", replace},
+	{"contains", "Strings", "contains SUBSTR STRING", "Reports whether STRING contains SUBSTR", contains},
+	{"hasPrefix", "Strings", "hasPrefix PREFIX STRING", "Reports whether STRING starts with PREFIX", hasPrefix},
+	{"hasSuffix", "Strings", "hasSuffix SUFFIX STRING", "Reports whether STRING ends with SUFFIX", hasSuffix},
+	{"split", "Strings", "split SEP STRING", "Splits STRING into a list around SEP", split},
+	{"join", "Strings", "join SEP LIST", "Joins a list of strings with SEP", join},
+	{"repeat", "Strings", "repeat COUNT STRING", "Repeats STRING COUNT times", repeat},
+	{"indent", "Strings", "indent SPACES STRING", "Indents every line by SPACES spaces", indent},
+	{"nindent", "Strings", "nindent SPACES STRING", "Like indent, preceded by a newline", nindent},
+	{"quote", "Strings", "quote VALUE", "Wraps the value in double quotes, escaping as needed", quote},
+	{"squote", "Strings", "squote VALUE", "Wraps the value in single quotes", squote},
+	{"camelCase", "Casing", "camelCase STRING", "Converts to camelCase: user_id -> userId", camelCase},
+	{"pascalCase", "Casing", "pascalCase STRING", "Converts to PascalCase: user_id -> UserId", pascalCase},
+	{"snakeCase", "Casing", "snakeCase STRING", "Converts to snake_case: UserID -> user_id", snakeCase},
+	{"kebabCase", "Casing", "kebabCase STRING", "Converts to kebab-case: UserID -> user-id", kebabCase},
+	{"pluralize", "Casing", "pluralize STRING", "Returns the English plural: category -> categories", pluralize},
+	{"default", "Logic", "default DEFAULT VALUE", "Returns VALUE, or DEFAULT when VALUE is empty", defaultValue},
+	{"empty", "Logic", "empty VALUE", "Reports whether VALUE is the zero value", empty},
+	{"ternary", "Logic", "ternary TRUE FALSE COND", "Returns TRUE when COND is true, FALSE otherwise", ternary},
+	{"list", "Data", "list VALUES...", "Builds a list from its arguments", list},
+	{"dict", "Data", "dict KEY VALUE ...", "Builds a map from key/value pairs", dict},
+	{"toJson", "Data", "toJson VALUE", "Encodes the value as JSON", toJSON},
+	{"toYaml", "Data", "toYaml VALUE", "Encodes the value as YAML", toYAML},
+	{"moduleBase", "Modules", "moduleBase MODULE", "Last element of a module path: github.com/acme/svc -> svc", moduleBase},
+	{"moduleHost", "Modules", "moduleHost MODULE", "Host of a module path: github.com/acme/svc -> github.com", moduleHost},
+	{"moduleOwner", "Modules", "moduleOwner MODULE", "Owner of a module path: github.com/acme/svc -> acme", moduleOwner},
+	{"semverMajor", "Versions", "semverMajor VERSION", "Major number of a semantic version", semverMajor},
+	{"semverMinor", "Versions", "semverMinor VERSION", "Minor number of a semantic version", semverMinor},
+	{"semverPatch", "Versions", "semverPatch VERSION", "Patch number of a semantic version", semverPatch},
+	{"semverBump", "Versions", "semverBump PART VERSION", "Increments the major, minor, or patch part: v1.2.3 -> v1.3.0", semverBump},
+	{"semverCompare", "Versions", "semverCompare A B", "Returns -1, 0, or 1 as version A is lower, equal, or higher than B", semverCompare},
+	{"year", "Dates", "year", "Current year, e.g. for copyright notices", year},
+}
+
+// Categories lists the function categories in documentation order
+var Categories = []string{"Strings", "Casing", "Logic", "Data", "Modules", "Versions", "Dates"}
+
+// Funcs returns the functions available to templates, sorted by category and name
+func Funcs() []Func {
+	rank := make(map[string]int, len(Categories))
+	for i, c := range Categories {
+		rank[c] = i
+	}
+
+	out := make([]Func, len(funcs))
+	copy(out, funcs)
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Category != out[j].Category {
+			return rank[out[i].Category] < rank[out[j].Category]
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
+
+// FuncMap returns the template.FuncMap used for rendering
+func FuncMap() template.FuncMap {
+	m := make(template.FuncMap, len(funcs))
+	for _, f := range funcs {
+		m[f.Name] = f.Fn
+	}
+	return m
+}
+
+func title(s string) string {
+	var b strings.Builder
+	start := true
+	for _, r := range s {
+		if start {
+			r = unicode.ToUpper(r)
+		}
+		start = unicode.IsSpace(r)
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// String functions take the subject last so they work in pipelines
+func trimPrefix(prefix, s string) string { return strings.TrimPrefix(s, prefix) }
+func trimSuffix(suffix, s string) string { return strings.TrimSuffix(s, suffix) }
+func replace(old, repl, s string) string { return strings.ReplaceAll(s, old, repl) }
+func contains(substr, s string) bool     { return strings.Contains(s, substr) }
+func hasPrefix(prefix, s string) bool    { return strings.HasPrefix(s, prefix) }
+func hasSuffix(suffix, s string) bool    { return strings.HasSuffix(s, suffix) }
+func split(sep, s string) []string       { return strings.Split(s, sep) }
+func join(sep string, l []string) string { return strings.Join(l, sep) }
+
+// maxFuncBytes limits the strings that repeat and indent build, so a template
+// cannot exhaust memory with a single call
+const maxFuncBytes = 1 << 20
+
+func repeat(count int, s string) (string, error) {
+	if count < 0 {
+		count = 0
+	}
+	if len(s) > 0 && count > maxFuncBytes/len(s) {
+		return "", fmt.Errorf("repeat %d times: %w", count, ErrOutputLimit)
+	}
+	return strings.Repeat(s, count), nil
+}
+
+func indent(spaces int, s string) (string, error) {
+	if spaces < 0 {
+		spaces = 0
+	}
+	lines := strings.Count(s, "\n") + 1
+	if spaces > (maxFuncBytes-len(s))/lines {
+		return "", fmt.Errorf("indent %d lines by %d spaces: %w", lines, spaces, ErrOutputLimit)
+	}
+	pad := strings.Repeat(" ", spaces)
+	return pad + strings.ReplaceAll(s, "\n", "\n"+pad), nil
+}
+
+func nindent(spaces int, s string) (string, error) {
+	out, err := indent(spaces, s)
+	return "\n" + out, err
+}
+
+func quote(v interface{}) string {
+	return fmt.Sprintf("%q", fmt.Sprint(v))
+}
+
+func squote(v interface{}) string {
+	return "'" + fmt.Sprint(v) + "'"
+}
+
+func defaultValue(def, v interface{}) interface{} {
+	if empty(v) {
+		return def
+	}
+	return v
+}
+
+func empty(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return rv.Len() == 0
+	default:
+		return rv.IsZero()
+	}
+}
+
+func ternary(t, f interface{}, cond bool) interface{} {
+	if cond {
+		return t
+	}
+	return f
+}
+
+func list(values ...interface{}) []interface{} {
+	return values
+}
+
+func dict(pairs ...interface{}) (map[string]interface{}, error) {
+	if len(pairs)%2 != 0 {
+		return nil, fmt.Errorf("dict requires key/value pairs")
+	}
+	m := make(map[string]interface{}, len(pairs)/2)
+	for i := 0; i < len(pairs); i += 2 {
+		key, ok := pairs[i].(string)
+		if !ok {
+			return nil, fmt.Errorf("dict key %v is not a string", pairs[i])
+		}
+		m[key] = pairs[i+1]
+	}
+	return m, nil
+}
+
+func toJSON(v interface{}) (string, error) {
+	data, err := json.Marshal(v)
+	return string(data), err
+}
+
+func toYAML(v interface{}) (string, error) {
+	data, err := yaml.Marshal(v)
+	return strings.TrimSuffix(string(data), "\n"), err
+}
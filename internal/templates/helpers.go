@@ -0,0 +1,229 @@
+package templates
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// words splits an identifier into lower-case words at separators and case
+// changes, keeping acronyms together: "HTTPServer_v2" -> [http server v2]
+func words(s string) []string {
+	var out []string
+	var cur []rune
+	flush := func() {
+		if len(cur) > 0 {
+			out = append(out, strings.ToLower(string(cur)))
+			cur = cur[:0]
+		}
+	}
+
+	runes := []rune(s)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush()
+			continue
+		}
+		if unicode.IsUpper(r) && len(cur) > 0 {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				flush()
+			}
+		}
+		cur = append(cur, r)
+	}
+	flush()
+	return out
+}
+
+func capitalize(w string) string {
+	r := []rune(w)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
+
+func camelCase(s string) string {
+	ws := words(s)
+	for i := 1; i < len(ws); i++ {
+		ws[i] = capitalize(ws[i])
+	}
+	return strings.Join(ws, "")
+}
+
+func pascalCase(s string) string {
+	ws := words(s)
+	for i := range ws {
+		ws[i] = capitalize(ws[i])
+	}
+	return strings.Join(ws, "")
+}
+
+func snakeCase(s string) string {
+	return strings.Join(words(s), "_")
+}
+
+func kebabCase(s string) string {
+	return strings.Join(words(s), "-")
+}
+
+// irregularPlurals covers common nouns used as resource names
+var irregularPlurals = map[string]string{
+	"child":  "children",
+	"person": "people",
+	"man":    "men",
+	"woman":  "women",
+	"mouse":  "mice",
+	"goose":  "geese",
+	"foot":   "feet",
+	"tooth":  "teeth",
+	"datum":  "data",
+	"index":  "indices",
+	"status": "statuses",
+}
+
+// pluralize returns the English plural of a singular noun, keeping the
+// capitalization of its first letter
+func pluralize(s string) string {
+	if s == "" {
+		return s
+	}
+	lower := strings.ToLower(s)
+	if p, ok := irregularPlurals[lower]; ok {
+		if unicode.IsUpper([]rune(s)[0]) {
+			return capitalize(p)
+		}
+		return p
+	}
+
+	switch {
+	case strings.HasSuffix(lower, "s"), strings.HasSuffix(lower, "x"), strings.HasSuffix(lower, "z"),
+		strings.HasSuffix(lower, "ch"), strings.HasSuffix(lower, "sh"):
+		return s + "es"
+	case strings.HasSuffix(lower, "y") && len(lower) > 1 && !strings.ContainsRune("aeiou", rune(lower[len(lower)-2])):
+		return s[:len(s)-1] + "ies"
+	default:
+		return s + "s"
+	}
+}
+
+// splitModule splits a module path like github.com/acme/svc into its host,
+// owner, and base; paths without a host return only the base
+func splitModule(module string) (host, owner, base string) {
+	parts := strings.Split(module, "/")
+	base = parts[len(parts)-1]
+	if len(parts) >= 3 && strings.Contains(parts[0], ".") {
+		host, owner = parts[0], parts[1]
+	}
+	return host, owner, base
+}
+
+func moduleBase(module string) string {
+	_, _, base := splitModule(module)
+	return base
+}
+
+func moduleHost(module string) string {
+	host, _, _ := splitModule(module)
+	return host
+}
+
+func moduleOwner(module string) string {
+	_, owner, _ := splitModule(module)
+	return owner
+}
+
+func year() int {
+	return time.Now().Year()
+}
+
+// semver is a parsed MAJOR.MINOR.PATCH version; pre-release and build
+// suffixes are ignored
+type semver struct {
+	major, minor, patch int
+	prefix              string
+}
+
+func parseSemver(v string) (semver, error) {
+	var s semver
+	if strings.HasPrefix(v, "v") {
+		s.prefix, v = "v", v[1:]
+	}
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+
+	parts := strings.Split(v, ".")
+	if len(parts) == 0 || len(parts) > 3 {
+		return s, fmt.Errorf("invalid semantic version %q", v)
+	}
+	nums := make([]int, 3)
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return s, fmt.Errorf("invalid semantic version %q", v)
+		}
+		nums[i] = n
+	}
+	s.major, s.minor, s.patch = nums[0], nums[1], nums[2]
+	return s, nil
+}
+
+func (s semver) String() string {
+	return fmt.Sprintf("%s%d.%d.%d", s.prefix, s.major, s.minor, s.patch)
+}
+
+func semverMajor(v string) (int, error) {
+	s, err := parseSemver(v)
+	return s.major, err
+}
+
+func semverMinor(v string) (int, error) {
+	s, err := parseSemver(v)
+	return s.minor, err
+}
+
+func semverPatch(v string) (int, error) {
+	s, err := parseSemver(v)
+	return s.patch, err
+}
+
+func semverBump(part, v string) (string, error) {
+	s, err := parseSemver(v)
+	if err != nil {
+		return "", err
+	}
+	switch part {
+	case "major":
+		s.major, s.minor, s.patch = s.major+1, 0, 0
+	case "minor":
+		s.minor, s.patch = s.minor+1, 0
+	case "patch":
+		s.patch++
+	default:
+		return "", fmt.Errorf("unknown version part %q, expected major, minor, or patch", part)
+	}
+	return s.String(), nil
+}
+
+func semverCompare(a, b string) (int, error) {
+	sa, err := parseSemver(a)
+	if err != nil {
+		return 0, err
+	}
+	sb, err := parseSemver(b)
+	if err != nil {
+		return 0, err
+	}
+	for _, d := range []int{sa.major - sb.major, sa.minor - sb.minor, sa.patch - sb.patch} {
+		switch {
+		case d < 0:
+			return -1, nil
+		case d > 0:
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
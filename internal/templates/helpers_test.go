@@ -0,0 +1,93 @@
+package templates
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCasing(t *testing.T) {
+	tests := []struct {
+		in, camel, pascal, snake, kebab string
+	}{
+		{"user_id", "userId", "UserId", "user_id", "user-id"},
+		{"UserID", "userId", "UserId", "user_id", "user-id"},
+		{"HTTPServer", "httpServer", "HttpServer", "http_server", "http-server"},
+		{"my-cool app", "myCoolApp", "MyCoolApp", "my_cool_app", "my-cool-app"},
+		{"orderItems2", "orderItems2", "OrderItems2", "order_items2", "order-items2"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.in, func(t *testing.T) {
+			assert.Equal(t, tc.camel, camelCase(tc.in))
+			assert.Equal(t, tc.pascal, pascalCase(tc.in))
+			assert.Equal(t, tc.snake, snakeCase(tc.in))
+			assert.Equal(t, tc.kebab, kebabCase(tc.in))
+		})
+	}
+}
+
+func TestPluralize(t *testing.T) {
+	tests := map[string]string{
+		"user":     "users",
+		"category": "categories",
+		"key":      "keys",
+		"box":      "boxes",
+		"address":  "addresses",
+		"batch":    "batches",
+		"person":   "people",
+		"Child":    "Children",
+		"":         "",
+	}
+	for in, want := range tests {
+		assert.Equal(t, want, pluralize(in), in)
+	}
+}
+
+func TestModuleHelpers(t *testing.T) {
+	assert.Equal(t, "svc", moduleBase("github.com/acme/svc"))
+	assert.Equal(t, "github.com", moduleHost("github.com/acme/svc"))
+	assert.Equal(t, "acme", moduleOwner("github.com/acme/svc"))
+
+	assert.Equal(t, "svc", moduleBase("svc"))
+	assert.Empty(t, moduleHost("svc"))
+	assert.Empty(t, moduleOwner("svc"))
+}
+
+func TestSemver(t *testing.T) {
+	major, err := semverMajor("v1.2.3")
+	require.NoError(t, err)
+	assert.Equal(t, 1, major)
+
+	minor, err := semverMinor("1.2.3-rc.1")
+	require.NoError(t, err)
+	assert.Equal(t, 2, minor)
+
+	bumped, err := semverBump("minor", "v1.2.3")
+	require.NoError(t, err)
+	assert.Equal(t, "v1.3.0", bumped)
+
+	bumped, err = semverBump("major", "0.9")
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", bumped)
+
+	cmp, err := semverCompare("v1.10.0", "v1.9.9")
+	require.NoError(t, err)
+	assert.Equal(t, 1, cmp)
+
+	_, err = semverMajor("latest")
+	assert.Error(t, err)
+	_, err = semverBump("build", "v1.0.0")
+	assert.Error(t, err)
+}
+
+func TestRenderHelpers(t *testing.T) {
+	cfg := struct{ Name, Module string }{"order-service", "github.com/acme/order-service"}
+
+	got, err := Render("test", `{{ pascalCase .Name }} {{ moduleOwner .Module }} {{ "item" | pluralize }} {{ year }}`, cfg)
+	require.NoError(t, err)
+	assert.Equal(t, "OrderService acme items "+strconv.Itoa(time.Now().Year()), got)
+}
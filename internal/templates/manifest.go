@@ -0,0 +1,175 @@
+package templates
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ManifestFile is the name of the manifest at the root of a template set
+const ManifestFile = "template.yaml"
+
+// Manifest describes a template set
+type Manifest struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+
+	// Files holds conditions for files and directories of the set
+	Files []FileRule `yaml:"files"`
+
+	// Hooks run after the template set has been rendered. They are the only
+	// way a template set can run commands.
+	Hooks []Hook `yaml:"hooks"`
+}
+
+// FileRule applies to a file or directory of a template set
+type FileRule struct {
+	// Path is relative to the root of the set, as named in the set. A
+	// directory applies to everything below it; glob patterns are allowed.
+	Path string `yaml:"path"`
+
+	// OnlyIf includes the path only when the condition holds
+	OnlyIf string `yaml:"only_if"`
+
+	// Unless excludes the path when the condition holds
+	Unless string `yaml:"unless"`
+
+	// Merge is how the file is written over an existing file; defaults to overwrite
+	Merge MergeStrategy `yaml:"merge"`
+}
+
+// matches reports whether the rule applies to a template path
+func (r FileRule) matches(p string) bool {
+	dir := strings.TrimSuffix(r.Path, "/")
+	if p == dir || strings.HasPrefix(p, dir+"/") {
+		return true
+	}
+	ok, _ := path.Match(dir, p)
+	return ok
+}
+
+// applies evaluates the conditions of the rule
+func (r FileRule) applies(values map[string]interface{}) (bool, error) {
+	if r.OnlyIf != "" {
+		ok, err := evalCondition(r.OnlyIf, values)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	if r.Unless != "" {
+		ok, err := evalCondition(r.Unless, values)
+		if err != nil || ok {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// evalCondition evaluates a condition on configuration fields, using their
+// YAML names: "use_docker", "!use_docker", "type == api", or "license != MIT"
+func evalCondition(cond string, values map[string]interface{}) (bool, error) {
+	cond = strings.TrimSpace(cond)
+	for _, op := range []string{"==", "!="} {
+		field, want, ok := strings.Cut(cond, op)
+		if !ok {
+			continue
+		}
+		field, want = strings.TrimSpace(field), strings.Trim(strings.TrimSpace(want), `"'`)
+		v, known := values[field]
+		if !known {
+			return false, fmt.Errorf("condition %q: unknown configuration field %q", cond, field)
+		}
+		equal := fmt.Sprint(v) == want
+		return equal == (op == "=="), nil
+	}
+
+	if field, ok := strings.CutPrefix(cond, "!"); ok {
+		set, err := truthy(values, strings.TrimSpace(field))
+		return !set, err
+	}
+	return truthy(values, cond)
+}
+
+// Hook is a command declared by a template set
+type Hook struct {
+	Name string `yaml:"name"`
+
+	// Command is executed directly, without a shell, in the project directory
+	Command []string `yaml:"command"`
+}
+
+func (h Hook) String() string {
+	return fmt.Sprintf("%s (%s)", h.Name, strings.Join(h.Command, " "))
+}
+
+// LoadManifest reads the manifest of a template set. A set without a
+// manifest has an empty one.
+func LoadManifest(fsys fs.FS) (*Manifest, error) {
+	data, err := fs.ReadFile(fsys, ManifestFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &Manifest{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", ManifestFile, err)
+	}
+
+	var m Manifest
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", ManifestFile, err)
+	}
+	for i, r := range m.Files {
+		if r.Path == "" {
+			return nil, fmt.Errorf("%s: file rule %d needs a path", ManifestFile, i+1)
+		}
+		if _, err := path.Match(r.Path, ""); err != nil {
+			return nil, fmt.Errorf("%s: invalid path %q: %w", ManifestFile, r.Path, err)
+		}
+		if r.Merge != "" && !validMergeStrategy(r.Merge) {
+			return nil, fmt.Errorf("%s: unknown merge strategy %q for %s", ManifestFile, r.Merge, r.Path)
+		}
+	}
+	for i, h := range m.Hooks {
+		if h.Name == "" || len(h.Command) == 0 {
+			return nil, fmt.Errorf("%s: hook %d needs a name and a command", ManifestFile, i+1)
+		}
+	}
+	return &m, nil
+}
+
+// ConfirmFunc asks the user whether a hook may run
+type ConfirmFunc func(h Hook) (bool, error)
+
+// RunHooks runs each hook the user confirms in dir. Hooks are never run
+// without confirmation; a nil confirm function skips them all.
+func RunHooks(hooks []Hook, dir string, confirm ConfirmFunc, out io.Writer) error {
+	for _, h := range hooks {
+		ok := false
+		if confirm != nil {
+			var err error
+			if ok, err = confirm(h); err != nil {
+				return err
+			}
+		}
+		if !ok {
+			fmt.Fprintf(out, "Skipped hook %s\n", h)
+			continue
+		}
+
+		cmd := exec.Command(h.Command[0], h.Command[1:]...)
+		cmd.Dir = dir
+		cmd.Stdout = out
+		cmd.Stderr = out
+		cmd.Stdin = os.Stdin
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("hook %s failed: %w", h.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,268 @@
+package templates
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// MergeStrategy decides how a template file is written over an existing file
+type MergeStrategy string
+
+// Merge strategies
+const (
+	// MergeOverwrite replaces the existing file
+	MergeOverwrite MergeStrategy = "overwrite"
+	// MergeSkipIfExists keeps an existing file untouched
+	MergeSkipIfExists MergeStrategy = "skip-if-exists"
+	// MergeAppendSection appends the rendered content, such as a Makefile
+	// target, unless the file already contains it
+	MergeAppendSection MergeStrategy = "append-section"
+	// MergeThreeWay merges the changes between the originally generated file
+	// and the new template into the existing file. Without a recorded
+	// original, the existing file is kept as with MergeSkipIfExists.
+	MergeThreeWay MergeStrategy = "three-way"
+)
+
+// MergeStrategies lists the valid merge strategies
+var MergeStrategies = []MergeStrategy{MergeOverwrite, MergeSkipIfExists, MergeAppendSection, MergeThreeWay}
+
+func validMergeStrategy(m MergeStrategy) bool {
+	for _, s := range MergeStrategies {
+		if s == m {
+			return true
+		}
+	}
+	return false
+}
+
+// Action is what applying a file did
+type Action string
+
+// Actions reported by Apply
+const (
+	ActionCreated   Action = "created"
+	ActionUpdated   Action = "updated"
+	ActionUnchanged Action = "unchanged"
+	ActionSkipped   Action = "skipped"
+	ActionAppended  Action = "appended"
+	ActionMerged    Action = "merged"
+	// ActionConflict means the file was written with conflict markers
+	ActionConflict Action = "conflict"
+)
+
+// Result reports how a file was applied
+type Result struct {
+	Path   string
+	Action Action
+}
+
+// ApplyOptions configures Apply
+type ApplyOptions struct {
+	// Base returns the content of a file as it was originally generated, for
+	// three-way merges. Files without a base are skipped.
+	Base func(path string) ([]byte, bool)
+}
+
+// Apply writes the files of the set into an existing project directory,
+// honoring the merge strategy of each file
+func (s *Set) Apply(dir string, cfg *config.ProjectConfig, opts ApplyOptions) ([]Result, error) {
+	files, err := s.Files(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]Result, 0, len(files))
+	for _, f := range files {
+		var base []byte
+		hasBase := false
+		if opts.Base != nil {
+			base, hasBase = opts.Base(f.Path)
+		}
+
+		action, err := ApplyFile(dir, f, base, hasBase)
+		if err != nil {
+			return results, err
+		}
+		results = append(results, Result{Path: f.Path, Action: action})
+	}
+	return results, nil
+}
+
+// ApplyFile writes a single file below dir using its merge strategy
+func ApplyFile(dir string, f File, base []byte, hasBase bool) (Action, error) {
+	target := filepath.Join(dir, filepath.FromSlash(f.Path))
+	current, err := os.ReadFile(target)
+	if errors.Is(err, fs.ErrNotExist) {
+		return ActionCreated, writeFile(target, f.Content, f.Mode)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", f.Path, err)
+	}
+	if bytes.Equal(current, f.Content) {
+		return ActionUnchanged, nil
+	}
+
+	switch f.Merge {
+	case MergeSkipIfExists:
+		return ActionSkipped, nil
+	case MergeAppendSection:
+		section := bytes.TrimSpace(f.Content)
+		if bytes.Contains(current, section) {
+			return ActionUnchanged, nil
+		}
+		merged := append(bytes.TrimRight(current, "\n"), '\n', '\n')
+		merged = append(merged, section...)
+		merged = append(merged, '\n')
+		return ActionAppended, writeFile(target, merged, f.Mode)
+	case MergeThreeWay:
+		// Every line would conflict without the original, so keep the file
+		if !hasBase {
+			return ActionSkipped, nil
+		}
+		merged, conflict := merge3(string(base), string(current), string(f.Content))
+		action := ActionMerged
+		if conflict {
+			action = ActionConflict
+		}
+		return action, writeFile(target, []byte(merged), f.Mode)
+	default:
+		return ActionUpdated, writeFile(target, f.Content, f.Mode)
+	}
+}
+
+func writeFile(path string, content []byte, mode fs.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", path, err)
+	}
+	if err := os.WriteFile(path, content, mode); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// Conflict markers written by three-way merges
+const (
+	conflictStart = "<<<<<<< current"
+	conflictSep   = "======="
+	conflictEnd   = ">>>>>>> template"
+)
+
+// merge3 merges the changes from base to theirs into ours, line by line.
+// Overlapping changes are written with conflict markers.
+func merge3(base, ours, theirs string) (string, bool) {
+	b, o, t := splitLines(base), splitLines(ours), splitLines(theirs)
+	mo, mt := matchLines(b, o), matchLines(b, t)
+
+	var out []string
+	conflict := false
+	i, jo, jt := 0, 0, 0
+	for {
+		// Find the next base line kept by both sides
+		next := i
+		for next < len(b) && (mo[next] < jo || mt[next] < jt) {
+			next++
+		}
+
+		eo, et := len(o), len(t)
+		if next < len(b) {
+			eo, et = mo[next], mt[next]
+		}
+		chunkB, chunkO, chunkT := b[i:next], o[jo:eo], t[jt:et]
+
+		switch {
+		case equalLines(chunkO, chunkB):
+			out = append(out, chunkT...)
+		case equalLines(chunkT, chunkB), equalLines(chunkO, chunkT):
+			out = append(out, chunkO...)
+		default:
+			conflict = true
+			out = append(out, conflictStart+"\n")
+			out = append(out, ensureNewline(chunkO)...)
+			out = append(out, conflictSep+"\n")
+			out = append(out, ensureNewline(chunkT)...)
+			out = append(out, conflictEnd+"\n")
+		}
+
+		if next >= len(b) {
+			break
+		}
+		out = append(out, b[next])
+		i, jo, jt = next+1, eo+1, et+1
+	}
+	return strings.Join(out, ""), conflict
+}
+
+// splitLines splits text into lines that keep their newline
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+func ensureNewline(lines []string) []string {
+	if n := len(lines); n > 0 && !strings.HasSuffix(lines[n-1], "\n") {
+		lines = append(lines[:n-1:n-1], lines[n-1]+"\n")
+	}
+	return lines
+}
+
+func equalLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// matchLines returns, for each line of a, the index of the matching line of
+// b in a longest common subsequence, or -1
+func matchLines(a, b []string) []int {
+	// lcs[i][j] is the LCS length of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	match := make([]int, len(a))
+	for i := range match {
+		match[i] = -1
+	}
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] == b[j]:
+			match[i] = j
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return match
+}
@@ -0,0 +1,163 @@
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+func TestMerge3(t *testing.T) {
+	tests := []struct {
+		name               string
+		base, ours, theirs string
+		want               string
+		conflict           bool
+	}{
+		{
+			name:   "template change only",
+			base:   "a\nb\nc\n",
+			ours:   "a\nb\nc\n",
+			theirs: "a\nB\nc\n",
+			want:   "a\nB\nc\n",
+		},
+		{
+			name:   "user change kept",
+			base:   "a\nb\nc\n",
+			ours:   "a\nb\nc\nuser\n",
+			theirs: "a\nb\nc\n",
+			want:   "a\nb\nc\nuser\n",
+		},
+		{
+			name:   "independent changes",
+			base:   "a\nb\nc\nd\n",
+			ours:   "user\na\nb\nc\nd\n",
+			theirs: "a\nb\nc\nD\n",
+			want:   "user\na\nb\nc\nD\n",
+		},
+		{
+			name:   "same change",
+			base:   "a\nb\n",
+			ours:   "a\nx\n",
+			theirs: "a\nx\n",
+			want:   "a\nx\n",
+		},
+		{
+			name:     "overlapping changes",
+			base:     "a\nb\nc\n",
+			ours:     "a\nmine\nc\n",
+			theirs:   "a\ntheirs\nc\n",
+			want:     "a\n<<<<<<< current\nmine\n=======\ntheirs\n>>>>>>> template\nc\n",
+			conflict: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, conflict := merge3(tc.base, tc.ours, tc.theirs)
+			assert.Equal(t, tc.want, got)
+			assert.Equal(t, tc.conflict, conflict)
+		})
+	}
+}
+
+func TestApplyStrategies(t *testing.T) {
+	set := testSet(t, fstest.MapFS{
+		"template.yaml": {Data: []byte(`
+files:
+  - path: config.yaml
+    merge: skip-if-exists
+  - path: Makefile
+    merge: append-section
+  - path: main.go.tmpl
+    merge: three-way
+`)},
+		"README.md":    {Data: []byte("new readme\n")},
+		"config.yaml":  {Data: []byte("port: 8080\n")},
+		"Makefile":     {Data: []byte("docker:\n\tdocker build .\n")},
+		"main.go.tmpl": {Data: []byte("package main\n\n// {{ .Name }} v2\nfunc main() {}\n")},
+		"new.txt":      {Data: []byte("new\n")},
+	})
+
+	dir := t.TempDir()
+	write := func(name, content string) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0600))
+	}
+	write("README.md", "old readme\n")
+	write("config.yaml", "port: 9090\n")
+	write("Makefile", "build:\n\tgo build ./...\n")
+	write("main.go", "package main\n\n// app v1\nfunc main() {}\n\nfunc helper() {}\n")
+
+	cfg := config.NewDefaultProjectConfig()
+	cfg.Name = "app"
+	base := map[string]string{"main.go": "package main\n\n// app v1\nfunc main() {}\n"}
+
+	results, err := set.Apply(dir, cfg, ApplyOptions{Base: func(p string) ([]byte, bool) {
+		content, ok := base[p]
+		return []byte(content), ok
+	}})
+	require.NoError(t, err)
+
+	actions := map[string]Action{}
+	for _, r := range results {
+		actions[r.Path] = r.Action
+	}
+	assert.Equal(t, map[string]Action{
+		"README.md":   ActionUpdated,
+		"config.yaml": ActionSkipped,
+		"Makefile":    ActionAppended,
+		"main.go":     ActionMerged,
+		"new.txt":     ActionCreated,
+	}, actions)
+
+	read := func(name string) string {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		require.NoError(t, err)
+		return string(data)
+	}
+	assert.Equal(t, "port: 9090\n", read("config.yaml"))
+	assert.Equal(t, "build:\n\tgo build ./...\n\ndocker:\n\tdocker build .\n", read("Makefile"))
+	assert.Equal(t, "package main\n\n// app v2\nfunc main() {}\n\nfunc helper() {}\n", read("main.go"))
+
+	// Applying again changes nothing, and three-way merges without a base
+	// keep the file instead of marking every line as a conflict
+	results, err = set.Apply(dir, cfg, ApplyOptions{})
+	require.NoError(t, err)
+	for _, r := range results {
+		switch r.Path {
+		case "main.go", "config.yaml":
+			assert.Equal(t, ActionSkipped, r.Action, r.Path)
+		default:
+			assert.Equal(t, ActionUnchanged, r.Action, r.Path)
+		}
+	}
+	assert.Equal(t, "package main\n\n// app v2\nfunc main() {}\n\nfunc helper() {}\n", read("main.go"))
+}
+
+func TestApplyThreeWayWithoutBase(t *testing.T) {
+	set := testSet(t, fstest.MapFS{
+		"template.yaml": {Data: []byte("files:\n  - path: README.md\n    merge: three-way\n")},
+		"README.md":     {Data: []byte("# Team readme\n")},
+	})
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("# app\n\nGenerated.\n"), 0600))
+
+	results, err := set.Apply(dir, config.NewDefaultProjectConfig(), ApplyOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []Result{{Path: "README.md", Action: ActionSkipped}}, results)
+
+	data, err := os.ReadFile(filepath.Join(dir, "README.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "# app\n\nGenerated.\n", string(data))
+}
+
+func TestLoadManifestInvalidMerge(t *testing.T) {
+	_, err := LoadManifest(fstest.MapFS{ManifestFile: {Data: []byte("files:\n  - path: x\n    merge: rebase\n")}})
+	assert.ErrorContains(t, err, "rebase")
+}
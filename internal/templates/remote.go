@@ -0,0 +1,208 @@
+package templates
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/oculus-core/gogo/internal/gitsource"
+)
+
+// ErrNotCached is returned by FetchCached when a git-hosted template set has
+// never been fetched
+var ErrNotCached = errors.New("template set is not cached")
+
+// sumSuffix names the file next to a cached template set that records the
+// commit and checksum it was fetched at
+const sumSuffix = ".sum"
+
+// cacheEntry is the content of the sum file of a cached template set
+type cacheEntry struct {
+	Source string `yaml:"source"`
+	Commit string `yaml:"commit"`
+	Sum    string `yaml:"sum"`
+}
+
+// Fetch returns the directory of the template set at source: a local
+// directory, or a git repository such as github.com/acme/gogo-templates,
+// pinned to a tag, branch, or commit with an @ref suffix. Repositories are
+// fetched into the user cache directory; when they cannot be reached, the
+// cached copy is used.
+func Fetch(source string) (string, error) {
+	return fetchSet(source, false)
+}
+
+// FetchCached is like Fetch but never accesses the network. Git sources are
+// read from the cache and fail with ErrNotCached when they were never fetched.
+func FetchCached(source string) (string, error) {
+	return fetchSet(source, true)
+}
+
+func fetchSet(source string, offline bool) (string, error) {
+	if isLocalSource(source) {
+		return source, nil
+	}
+
+	dir, err := CacheDir(source)
+	if err != nil {
+		return "", err
+	}
+	entry, cacheErr := verifyCache(source, dir)
+	if offline {
+		return dir, cacheErr
+	}
+
+	// Tags and commits are expected not to move, so a verified copy is used as is
+	_, ref := gitsource.URL(source)
+	if cacheErr == nil && isPinned(ref) {
+		return dir, nil
+	}
+
+	fetched, fetchErr := clone(source, dir, entry, isPinned(ref))
+	if fetchErr == nil {
+		return dir, nil
+	}
+	if fetched || cacheErr != nil {
+		return "", fetchErr
+	}
+	fmt.Fprintf(os.Stderr, "Warning: could not update template set %s, using cached copy\n", source)
+	return dir, nil
+}
+
+// isLocalSource reports whether source names a directory rather than a git
+// repository: an existing path, or one that starts like a path
+func isLocalSource(source string) bool {
+	if _, err := os.Stat(source); err == nil {
+		return true
+	}
+	return filepath.IsAbs(source) || strings.HasPrefix(source, ".") || strings.HasPrefix(source, "~")
+}
+
+// isPinned reports whether ref is a full commit hash or a semantic version tag
+func isPinned(ref string) bool {
+	if len(ref) == 40 && strings.Trim(ref, "0123456789abcdef") == "" {
+		return true
+	}
+	_, err := parseSemver(ref)
+	return err == nil && strings.HasPrefix(ref, "v")
+}
+
+// verifyCache checks the cached copy of source in dir against its recorded
+// checksum and returns its entry
+func verifyCache(source, dir string) (*cacheEntry, error) {
+	data, err := os.ReadFile(dir + sumSuffix)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrNotCached, source)
+	}
+	var entry cacheEntry
+	if err := yaml.Unmarshal(data, &entry); err != nil {
+		return nil, fmt.Errorf("invalid checksum file of cached template set %s: %w", source, err)
+	}
+	sum, err := Checksum(dir)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrNotCached, source)
+	}
+	if sum != entry.Sum {
+		return &entry, fmt.Errorf("cached template set %s has checksum %s, want %s; remove %s to fetch it again",
+			source, sum, entry.Sum, dir)
+	}
+	return &entry, nil
+}
+
+// clone fetches source into dir and records its commit and checksum. A pinned
+// ref whose content differs from the previous entry is rejected, as the tag
+// was moved or the repository was tampered with. fetched reports whether the
+// repository could be fetched, so that such errors don't fall back to the cache.
+func clone(source, dir string, previous *cacheEntry, pinned bool) (fetched bool, err error) {
+	url, ref := gitsource.URL(source)
+	if ref == "" {
+		ref = "HEAD"
+	}
+
+	tmp := dir + ".tmp"
+	_ = os.RemoveAll(tmp)
+	defer os.RemoveAll(tmp)
+
+	steps := [][]string{
+		{"init", "--quiet", tmp},
+		{"-C", tmp, "fetch", "--quiet", "--depth", "1", url, ref},
+		{"-C", tmp, "checkout", "--quiet", "FETCH_HEAD"},
+	}
+	for _, args := range steps {
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			return false, fmt.Errorf("failed to fetch template set %s: %v: %s", source, err, strings.TrimSpace(string(out)))
+		}
+	}
+	out, err := exec.Command("git", "-C", tmp, "rev-parse", "HEAD").Output()
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch template set %s: %v", source, err)
+	}
+	if err := os.RemoveAll(filepath.Join(tmp, ".git")); err != nil {
+		return true, fmt.Errorf("failed to cache template set %s: %w", source, err)
+	}
+
+	entry := cacheEntry{Source: source, Commit: strings.TrimSpace(string(out))}
+	if entry.Sum, err = Checksum(tmp); err != nil {
+		return true, fmt.Errorf("failed to cache template set %s: %w", source, err)
+	}
+	if pinned && previous != nil && previous.Sum != entry.Sum {
+		return true, fmt.Errorf("template set %s has checksum %s, but it was %s when first fetched; the ref was moved",
+			source, entry.Sum, previous.Sum)
+	}
+
+	data, err := yaml.Marshal(entry)
+	if err != nil {
+		return true, err
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return true, fmt.Errorf("failed to replace cached template set: %w", err)
+	}
+	if err := os.Rename(tmp, dir); err != nil {
+		return true, fmt.Errorf("failed to cache template set: %w", err)
+	}
+	if err := os.WriteFile(dir+sumSuffix, data, 0644); err != nil {
+		return true, fmt.Errorf("failed to cache template set: %w", err)
+	}
+	return true, nil
+}
+
+// Checksum returns the hash of the files below dir, like the h1: hashes of
+// go.sum: the SHA-256 of the sorted list of the SHA-256 and path of each file
+func Checksum(dir string) (string, error) {
+	var lines []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		lines = append(lines, fmt.Sprintf("%x  %s\n", sha256.Sum256(data), filepath.ToSlash(rel)))
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	sort.Strings(lines)
+	sum := sha256.Sum256([]byte(strings.Join(lines, "")))
+	return "h1:" + base64.StdEncoding.EncodeToString(sum[:]), nil
+}
+
+// CacheDir returns the directory where a git-hosted template set is cached
+func CacheDir(source string) (string, error) {
+	return gitsource.CacheDir("templates", source)
+}
@@ -0,0 +1,97 @@
+package templates
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gitRepo creates a repository with a README.md.tmpl and returns its file URL
+func gitRepo(t *testing.T) (dir, url string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir = t.TempDir()
+	git(t, dir, "init", "--quiet")
+	commit(t, dir, "# {{ .Name }}\n", "v1.0.0")
+	return dir, "file://" + filepath.ToSlash(dir)
+}
+
+// commit replaces README.md.tmpl in dir and tags the commit
+func commit(t *testing.T, dir, readme, tag string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md.tmpl"), []byte(readme), 0644))
+	git(t, dir, "add", "README.md.tmpl")
+	git(t, dir, "-c", "user.name=gogo", "-c", "user.email=gogo@example.com", "commit", "--quiet", "-m", "Update README")
+	git(t, dir, "tag", "--force", tag)
+}
+
+func git(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput()
+	require.NoError(t, err, string(out))
+}
+
+func TestIsPinned(t *testing.T) {
+	assert.True(t, isPinned("v1.2.0"))
+	assert.True(t, isPinned("0123456789abcdef0123456789abcdef01234567"))
+	assert.False(t, isPinned("main"))
+	assert.False(t, isPinned("1.2.0"))
+	assert.False(t, isPinned(""))
+}
+
+func TestFetch(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	repo, url := gitRepo(t)
+	source := url + "@v1.0.0"
+
+	_, err := FetchCached(source)
+	assert.ErrorIs(t, err, ErrNotCached)
+
+	dir, err := Fetch(source)
+	require.NoError(t, err)
+	readme, err := os.ReadFile(filepath.Join(dir, "README.md.tmpl"))
+	require.NoError(t, err)
+	assert.Equal(t, "# {{ .Name }}\n", string(readme))
+	assert.NoDirExists(t, filepath.Join(dir, ".git"))
+
+	cached, err := FetchCached(source)
+	require.NoError(t, err)
+	assert.Equal(t, dir, cached)
+
+	// A pinned tag that was moved no longer matches the recorded checksum
+	commit(t, repo, "# moved\n", "v1.0.0")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "extra"), nil, 0644))
+	_, err = FetchCached(source)
+	assert.ErrorContains(t, err, "checksum")
+	_, err = Fetch(source)
+	assert.ErrorContains(t, err, "the ref was moved")
+
+	// Branches are fetched again and fall back to the cache when unreachable
+	branch := url + "@HEAD"
+	dir, err = Fetch(branch)
+	require.NoError(t, err)
+	readme, err = os.ReadFile(filepath.Join(dir, "README.md.tmpl"))
+	require.NoError(t, err)
+	assert.Equal(t, "# moved\n", string(readme))
+	require.NoError(t, os.RemoveAll(repo))
+	cached, err = Fetch(branch)
+	require.NoError(t, err)
+	assert.Equal(t, dir, cached)
+}
+
+func TestFetchLocal(t *testing.T) {
+	dir := t.TempDir()
+	got, err := Fetch(dir)
+	require.NoError(t, err)
+	assert.Equal(t, dir, got)
+
+	got, err = FetchCached("./missing")
+	require.NoError(t, err)
+	assert.Equal(t, "./missing", got)
+}
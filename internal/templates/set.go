@@ -0,0 +1,325 @@
+package templates
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// TemplateExt marks files whose content is rendered; it is removed from the output name
+const TemplateExt = ".tmpl"
+
+// Path element suffixes that make a file or directory conditional, e.g.
+// Dockerfile.when-use_docker.tmpl or docs.unless-use_mkdocs/
+const (
+	whenMarker   = ".when-"
+	unlessMarker = ".unless-"
+)
+
+// OverridesDir is the directory of a template set that holds built-in
+// templates exported with gogo template export. They replace the built-in
+// templates instead of being rendered into the project.
+const OverridesDir = "builtin"
+
+// Set is a tree of template files rendered into a project directory.
+// File and directory names may contain template actions, such as
+// cmd/{{ .Name }}/main.go.tmpl.
+type Set struct {
+	FS       fs.FS
+	Manifest *Manifest
+}
+
+// LoadSet opens a template set rooted at fsys
+func LoadSet(fsys fs.FS) (*Set, error) {
+	m, err := LoadManifest(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return &Set{FS: fsys, Manifest: m}, nil
+}
+
+// OpenSet opens the template set in dir, a directory the user named
+func OpenSet(dir string) (*Set, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", dir)
+	}
+	return LoadSet(os.DirFS(dir))
+}
+
+// File is a rendered file of a template set
+type File struct {
+	// Path is relative to the project directory, using forward slashes
+	Path    string
+	Content []byte
+	Mode    fs.FileMode
+	Merge   MergeStrategy
+}
+
+// entry is a file of the set that applies to a configuration, before its
+// content is read or rendered
+type entry struct {
+	// Template is the path of the file in the set
+	Template string
+	// Path is the output path relative to the project directory
+	Path string
+	Mode fs.FileMode
+}
+
+// entries returns the files of the set that apply to the configuration,
+// without reading them
+func (s *Set) entries(cfg *config.ProjectConfig) ([]entry, error) {
+	values, err := configValues(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []entry
+	err = fs.WalkDir(s.FS, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if p == "." {
+			return nil
+		}
+		if p == ManifestFile {
+			return nil
+		}
+		if p == OverridesDir && d.IsDir() {
+			return fs.SkipDir
+		}
+
+		ok, err := s.included(p, values)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		out, err := outputPath(p, cfg)
+		if err != nil {
+			return err
+		}
+
+		mode := fs.FileMode(0644)
+		if info, err := d.Info(); err == nil && info.Mode()&0111 != 0 {
+			mode = 0755
+		}
+		entries = append(entries, entry{Template: p, Path: out, Mode: mode})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// write renders a file of the set to w. Template files are executed into w
+// and other files copied, so large files are never held in memory.
+func (s *Set) write(w io.Writer, e entry, cfg *config.ProjectConfig) error {
+	if strings.HasSuffix(e.Template, TemplateExt) {
+		text, err := fs.ReadFile(s.FS, e.Template)
+		if err != nil {
+			return fmt.Errorf("failed to read template %s: %w", e.Template, err)
+		}
+		return Execute(w, e.Template, string(text), cfg)
+	}
+
+	f, err := s.FS.Open(e.Template)
+	if err != nil {
+		return fmt.Errorf("failed to read template %s: %w", e.Template, err)
+	}
+	defer f.Close()
+	if _, err := io.Copy(w, f); err != nil {
+		return fmt.Errorf("failed to read template %s: %w", e.Template, err)
+	}
+	return nil
+}
+
+// Files renders the files of the set that apply to the configuration
+func (s *Set) Files(cfg *config.ProjectConfig) ([]File, error) {
+	entries, err := s.entries(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]File, 0, len(entries))
+	for _, e := range entries {
+		var buf bytes.Buffer
+		if err := s.write(&buf, e, cfg); err != nil {
+			return nil, err
+		}
+		files = append(files, File{Path: e.Path, Content: buf.Bytes(), Mode: e.Mode, Merge: s.mergeStrategy(e.Template)})
+	}
+	return files, nil
+}
+
+// Render writes the files of the set that apply to the configuration below dir
+// and returns their paths relative to dir. Each file is streamed to disk as it
+// renders, so the memory Render needs does not grow with the size of the set.
+func (s *Set) Render(dir string, cfg *config.ProjectConfig) ([]string, error) {
+	entries, err := s.entries(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if err := s.renderFile(dir, e, cfg); err != nil {
+			return nil, err
+		}
+		paths = append(paths, e.Path)
+	}
+	return paths, nil
+}
+
+// renderFile streams a file of the set to its path below dir through a
+// buffered writer
+func (s *Set) renderFile(dir string, e entry, cfg *config.ProjectConfig) (err error) {
+	target := filepath.Join(dir, filepath.FromSlash(e.Path))
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", e.Path, err)
+	}
+	f, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, e.Mode)
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", e.Path, err)
+	}
+	defer func() {
+		if cerr := f.Close(); cerr != nil && err == nil {
+			err = fmt.Errorf("failed to write %s: %w", e.Path, cerr)
+		}
+	}()
+
+	w := bufio.NewWriter(f)
+	if err := s.write(w, e, cfg); err != nil {
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", e.Path, err)
+	}
+	return nil
+}
+
+// included reports whether a template path applies: every element's
+// .when-/.unless- suffix and every manifest rule matching the path must hold
+func (s *Set) included(p string, values map[string]interface{}) (bool, error) {
+	for _, elem := range strings.Split(p, "/") {
+		name := strings.TrimSuffix(elem, TemplateExt)
+		if i := strings.LastIndex(name, whenMarker); i >= 0 {
+			ok, err := truthy(values, name[i+len(whenMarker):])
+			if err != nil || !ok {
+				return false, err
+			}
+		}
+		if i := strings.LastIndex(name, unlessMarker); i >= 0 {
+			ok, err := truthy(values, name[i+len(unlessMarker):])
+			if err != nil || ok {
+				return false, err
+			}
+		}
+	}
+
+	for _, rule := range s.Manifest.Files {
+		if !rule.matches(p) {
+			continue
+		}
+		ok, err := rule.applies(values)
+		if err != nil {
+			return false, err
+		}
+		if !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// mergeStrategy returns the merge strategy of the last rule matching a template path
+func (s *Set) mergeStrategy(p string) MergeStrategy {
+	strategy := MergeOverwrite
+	for _, rule := range s.Manifest.Files {
+		if rule.Merge != "" && rule.matches(p) {
+			strategy = rule.Merge
+		}
+	}
+	return strategy
+}
+
+// outputPath strips the template and condition suffixes from a template path
+// and renders template actions in its elements
+func outputPath(p string, cfg *config.ProjectConfig) (string, error) {
+	elems := strings.Split(p, "/")
+	for i, elem := range elems {
+		elem = strings.TrimSuffix(elem, TemplateExt)
+		if j := strings.LastIndex(elem, whenMarker); j >= 0 {
+			elem = elem[:j]
+		}
+		if j := strings.LastIndex(elem, unlessMarker); j >= 0 {
+			elem = elem[:j]
+		}
+		if strings.Contains(elem, "{{") {
+			rendered, err := Render(p, elem, cfg)
+			if err != nil {
+				return "", err
+			}
+			elem = rendered
+		}
+		if elem == "" || elem == "." || elem == ".." || strings.ContainsAny(elem, `/\`) {
+			return "", fmt.Errorf("template %s renders to an invalid path element %q", p, elem)
+		}
+		elems[i] = elem
+	}
+	return path.Join(elems...), nil
+}
+
+// configValues maps the YAML field names of the configuration to their values
+func configValues(cfg *config.ProjectConfig) (map[string]interface{}, error) {
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read configuration: %w", err)
+	}
+	values := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("failed to read configuration: %w", err)
+	}
+	return values, nil
+}
+
+// truthy reports whether a configuration field is set: true, or a non-empty value
+func truthy(values map[string]interface{}, field string) (bool, error) {
+	v, ok := values[field]
+	if !ok {
+		if field == "metadata_files" {
+			return false, nil
+		}
+		return false, fmt.Errorf("unknown configuration field %q", field)
+	}
+	switch v := v.(type) {
+	case bool:
+		return v, nil
+	case string:
+		return v != "", nil
+	default:
+		return !empty(v), nil
+	}
+}
@@ -0,0 +1,190 @@
+package templates
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+func testSet(t *testing.T, fsys fstest.MapFS) *Set {
+	t.Helper()
+	set, err := LoadSet(fsys)
+	require.NoError(t, err)
+	return set
+}
+
+func filePaths(t *testing.T, set *Set, cfg *config.ProjectConfig) map[string]string {
+	t.Helper()
+	files, err := set.Files(cfg)
+	require.NoError(t, err)
+
+	out := map[string]string{}
+	for _, f := range files {
+		out[f.Path] = string(f.Content)
+	}
+	return out
+}
+
+func TestSetFiles(t *testing.T) {
+	set := testSet(t, fstest.MapFS{
+		"template.yaml":                            {Data: []byte("name: test\n")},
+		"README.md.tmpl":                           {Data: []byte("# {{ .Name }}\n")},
+		"cmd/{{ .Name }}/main.go.tmpl":             {Data: []byte("package main // {{ .Module }}\n")},
+		"static/logo.txt":                          {Data: []byte("{{ not rendered }}")},
+		"docs.when-use_docs/index.md":              {Data: []byte("docs")},
+		"Makefile.unless-create_makefile":          {Data: []byte("fallback")},
+		"internal/api/server.go.when-use_gin.tmpl": {Data: []byte("package api")},
+	})
+
+	cfg := config.NewDefaultProjectConfig()
+	cfg.Name, cfg.Module = "svc", "github.com/acme/svc"
+	cfg.UseDocs, cfg.CreateMakefile, cfg.UseGin = true, true, false
+
+	files := filePaths(t, set, cfg)
+	assert.Equal(t, map[string]string{
+		"README.md":       "# svc\n",
+		"cmd/svc/main.go": "package main // github.com/acme/svc\n",
+		"static/logo.txt": "{{ not rendered }}",
+		"docs/index.md":   "docs",
+	}, files)
+
+	cfg.UseDocs, cfg.CreateMakefile, cfg.UseGin = false, false, true
+	files = filePaths(t, set, cfg)
+	assert.NotContains(t, files, "docs/index.md")
+	assert.Contains(t, files, "Makefile")
+	assert.Contains(t, files, "internal/api/server.go")
+}
+
+func TestSetFilesLayout(t *testing.T) {
+	set := testSet(t, fstest.MapFS{
+		"template.yaml": {Data: []byte("name: test\n")},
+		`{{ .Dir "internal" }}/store/store.go.tmpl`: {Data: []byte(`// {{ .Module }}/{{ .LayoutPath "internal/store" }}`)},
+	})
+
+	cfg := config.NewDefaultProjectConfig()
+	cfg.Module = "github.com/acme/svc"
+	cfg.Layout = map[string]string{"internal": "app"}
+	assert.Equal(t, map[string]string{
+		"app/store/store.go": "// github.com/acme/svc/app/store",
+	}, filePaths(t, set, cfg))
+}
+
+func TestSetManifestRules(t *testing.T) {
+	set := testSet(t, fstest.MapFS{
+		"template.yaml": {Data: []byte(`
+files:
+  - path: api/
+    only_if: type == api
+  - path: "*.md"
+    unless: "!create_readme"
+  - path: LICENSE
+    only_if: license != None
+`)},
+		"api/routes.go": {Data: []byte("routes")},
+		"README.md":     {Data: []byte("readme")},
+		"LICENSE":       {Data: []byte("license")},
+	})
+
+	cfg := config.GetProjectConfigForType(config.TypeAPI)
+	cfg.CreateReadme = true
+	assert.Len(t, filePaths(t, set, cfg), 3)
+
+	cfg = config.GetProjectConfigForType(config.TypeCLI)
+	cfg.CreateReadme = false
+	cfg.License = "None"
+	assert.Empty(t, filePaths(t, set, cfg))
+}
+
+func TestSetConditionErrors(t *testing.T) {
+	cfg := config.NewDefaultProjectConfig()
+
+	set := testSet(t, fstest.MapFS{"Dockerfile.when-use_dockr": {Data: []byte("")}})
+	_, err := set.Files(cfg)
+	assert.ErrorContains(t, err, "use_dockr")
+
+	set = testSet(t, fstest.MapFS{
+		"template.yaml": {Data: []byte("files:\n  - path: x\n    only_if: colour == red\n")},
+		"x":             {Data: []byte("")},
+	})
+	_, err = set.Files(cfg)
+	assert.ErrorContains(t, err, "colour")
+
+	_, err = LoadSet(fstest.MapFS{"template.yaml": {Data: []byte("files:\n  - only_if: use_docs\n")}})
+	assert.Error(t, err)
+
+	// Rendered names cannot escape the project directory
+	set = testSet(t, fstest.MapFS{"{{ .Module }}.go": {Data: []byte("")}})
+	cfg.Module = "../../etc"
+	_, err = set.Files(cfg)
+	assert.Error(t, err)
+}
+
+func TestSetRender(t *testing.T) {
+	set := testSet(t, fstest.MapFS{
+		"cmd/{{ .Name }}/main.go.tmpl": {Data: []byte("package main\n")},
+		"scripts/run.sh":               {Data: []byte("#!/bin/sh\n"), Mode: 0755},
+	})
+
+	dir := t.TempDir()
+	cfg := config.NewDefaultProjectConfig()
+	cfg.Name = "tool"
+
+	paths, err := set.Render(dir, cfg)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"cmd/tool/main.go", "scripts/run.sh"}, paths)
+
+	info, err := os.Stat(filepath.Join(dir, "scripts", "run.sh"))
+	require.NoError(t, err)
+	assert.NotZero(t, info.Mode()&0100)
+}
+
+// largeSet returns a set with a rendered and a static file of about 4 MB each,
+// like the generated tables and assets of big blueprints
+func largeSet(tb testing.TB) *Set {
+	tb.Helper()
+	set, err := LoadSet(fstest.MapFS{
+		"gen/table.go.tmpl": {Data: []byte("package gen\n{{ range 100000 }}// {{ $.Module }} row {{ . }}\n{{ end }}")},
+		"assets/data.txt":   {Data: bytes.Repeat([]byte("0123456789abcdef\n"), 250000)},
+	})
+	require.NoError(tb, err)
+	return set
+}
+
+func TestSetRenderLarge(t *testing.T) {
+	set := largeSet(t)
+	cfg := config.NewDefaultProjectConfig()
+	cfg.Module = "github.com/acme/svc"
+
+	dir := t.TempDir()
+	_, err := set.Render(dir, cfg)
+	require.NoError(t, err)
+
+	// Streaming writes the same content as rendering in memory
+	for path, want := range filePaths(t, set, cfg) {
+		got, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(path)))
+		require.NoError(t, err)
+		assert.Equal(t, len(want), len(got), path)
+		assert.True(t, string(got) == want, path)
+	}
+}
+
+func BenchmarkSetRender(b *testing.B) {
+	set := largeSet(b)
+	cfg := config.NewDefaultProjectConfig()
+	cfg.Module = "github.com/acme/svc"
+	dir := b.TempDir()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := set.Render(dir, cfg); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+// Package templates renders project templates in a sandbox. Templates can
+// only call the functions listed by Funcs, and commands run only through
+// hooks that are declared in a template manifest and confirmed by the user.
+package templates
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"text/template"
+
+	"github.com/oculus-core/gogo/internal/templates/builtin"
+)
+
+// New returns an empty template using the sandboxed function set.
+// Missing map keys are errors rather than "<no value>".
+func New(name string) *template.Template {
+	return template.New(name).Funcs(FuncMap()).Option("missingkey=error")
+}
+
+// Render parses and executes a template with the given data
+func Render(name, text string, data interface{}) (string, error) {
+	var buf bytes.Buffer
+	if err := Execute(&buf, name, text, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// ErrOutputLimit is returned when a template renders more output than
+// RenderLimit allows, or a function such as repeat would build a string
+// larger than a template needs
+var ErrOutputLimit = errors.New("template output exceeds the size limit")
+
+// RenderLimit is like Render, but fails with ErrOutputLimit once the output
+// exceeds limit bytes. It renders templates that come from requests, such as
+// the metadata files of gogo serve.
+func RenderLimit(name, text string, data interface{}, limit int) (string, error) {
+	buf := &limitedBuffer{limit: limit}
+	if err := Execute(buf, name, text, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// limitedBuffer is a bytes.Buffer that refuses writes beyond limit bytes
+type limitedBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.Len()+len(p) > b.limit {
+		return 0, ErrOutputLimit
+	}
+	return b.Buffer.Write(p)
+}
+
+// Execute parses a template and writes its output for the given data to w, so
+// large output can be streamed rather than built in memory. Built-in templates
+// are replaced by the templates loaded with builtin.Override.
+func Execute(w io.Writer, name, text string, data interface{}) error {
+	tmpl, err := New(name).Parse(builtin.Resolve(text))
+	if err != nil {
+		return fmt.Errorf("failed to parse template %s: %w", name, err)
+	}
+	if err := tmpl.Execute(w, data); err != nil {
+		return fmt.Errorf("failed to render template %s: %w", name, err)
+	}
+	return nil
+}
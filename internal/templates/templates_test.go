@@ -0,0 +1,132 @@
+package templates
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender(t *testing.T) {
+	data := map[string]interface{}{"Name": "my service", "Tags": []string{"a", "b"}, "Empty": ""}
+
+	tests := []struct {
+		text string
+		want string
+	}{
+		{`{{ .Name | upper }}`, "MY SERVICE"},
+		{`{{ .Name | title }}`, "My Service"},
+		{`{{ .Name | replace " " "-" }}`, "my-service"},
+		{`{{ .Tags | join "," }}`, "a,b"},
+		{`{{ .Empty | default "none" }}`, "none"},
+		{`{{ .Name | quote }}`, `"my service"`},
+		{`{{ ternary "yes" "no" (hasPrefix "my" .Name) }}`, "yes"},
+		{`{{ dict "name" .Name | toJson }}`, `{"name":"my service"}`},
+		{`x:{{ "a: 1" | nindent 2 }}`, "x:\n  a: 1"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.text, func(t *testing.T) {
+			got, err := Render("test", tc.text, data)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestRenderSandbox(t *testing.T) {
+	// Functions outside the allowed set do not exist
+	for _, text := range []string{`{{ exec "id" }}`, `{{ env "HOME" }}`, `{{ readFile "/etc/passwd" }}`} {
+		_, err := Render("test", text, nil)
+		assert.Error(t, err, text)
+	}
+
+	// Missing keys are errors
+	_, err := Render("test", `{{ .Missing }}`, map[string]string{})
+	assert.Error(t, err)
+}
+
+func TestRenderLimit(t *testing.T) {
+	// Functions refuse to build huge strings
+	for _, text := range []string{
+		`{{ repeat 1000000000 "x" }}`,
+		`{{ repeat 1000 (repeat 1000 (repeat 1000 "x")) }}`,
+		`{{ indent 1000000000 "x" }}`,
+		`{{ repeat 100000 "\n" | nindent 100 }}`,
+	} {
+		_, err := Render("test", text, nil)
+		assert.ErrorIs(t, err, ErrOutputLimit, text)
+	}
+
+	// RenderLimit caps the output, however it is produced
+	text := `{{ $x := repeat 1000 "x" }}{{ $x }}{{ $x }}`
+	_, err := RenderLimit("test", text, nil, 1500)
+	assert.ErrorIs(t, err, ErrOutputLimit)
+	got, err := RenderLimit("test", text, nil, 2000)
+	require.NoError(t, err)
+	assert.Len(t, got, 2000)
+}
+
+func TestFuncsDocumented(t *testing.T) {
+	for _, f := range Funcs() {
+		assert.NotEmpty(t, f.Signature, f.Name)
+		assert.NotEmpty(t, f.Description, f.Name)
+	}
+	assert.Len(t, FuncMap(), len(Funcs()))
+}
+
+func TestLoadManifest(t *testing.T) {
+	m, err := LoadManifest(fstest.MapFS{})
+	require.NoError(t, err)
+	assert.Empty(t, m.Hooks)
+
+	m, err = LoadManifest(fstest.MapFS{ManifestFile: {Data: []byte(`
+name: service
+hooks:
+  - name: tidy
+    command: [go, mod, tidy]
+`)}})
+	require.NoError(t, err)
+	require.Len(t, m.Hooks, 1)
+	assert.Equal(t, []string{"go", "mod", "tidy"}, m.Hooks[0].Command)
+
+	_, err = LoadManifest(fstest.MapFS{ManifestFile: {Data: []byte("hooks:\n  - name: empty\n")}})
+	assert.Error(t, err)
+}
+
+func TestRunHooks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses touch")
+	}
+	dir := t.TempDir()
+	hooks := []Hook{
+		{Name: "first", Command: []string{"touch", "first"}},
+		{Name: "second", Command: []string{"touch", "second"}},
+	}
+
+	// Without confirmation nothing runs
+	var out bytes.Buffer
+	require.NoError(t, RunHooks(hooks, dir, nil, &out))
+	assert.Contains(t, out.String(), "Skipped hook first")
+	assert.NoFileExists(t, filepath.Join(dir, "first"))
+
+	// Only confirmed hooks run
+	err := RunHooks(hooks, dir, func(h Hook) (bool, error) { return h.Name == "second", nil }, &out)
+	require.NoError(t, err)
+	assert.NoFileExists(t, filepath.Join(dir, "first"))
+	assert.FileExists(t, filepath.Join(dir, "second"))
+
+	// Confirmation errors abort
+	abort := errors.New("interrupted")
+	err = RunHooks(hooks, dir, func(Hook) (bool, error) { return false, abort }, &out)
+	assert.ErrorIs(t, err, abort)
+
+	_, err = os.Stat(filepath.Join(dir, "second"))
+	assert.NoError(t, err)
+}
@@ -0,0 +1,54 @@
+package wizard
+
+import (
+	"github.com/oculus-core/gogo/internal/templates/builtin"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// adrScriptPath is the script behind make adr, relative to the project
+const adrScriptPath = "scripts/new-adr.sh"
+
+// Pages of the decision records. The template is in the format described by
+// Michael Nygard; new-adr.sh fills in the number, title, and date, and uses
+// the number as the Hugo weight.
+var (
+	adrIndexPage       = docsPage{Path: "adr/index", Title: "Architecture decisions", Weight: 30, Body: adrIndexTemplate}
+	adrTemplatePage    = docsPage{Path: "adr/template", Title: "NNNN. Title of the decision", Weight: 1000, Body: adrPageTemplate}
+	adrFirstRecordPage = docsPage{
+		Path:   "adr/0001-record-architecture-decisions",
+		Title:  "0001. Record architecture decisions",
+		Weight: 1,
+		Body:   adrFirstRecordTemplate,
+	}
+)
+
+// adrFiles maps the path of the script that numbers, names, and dates a new
+// record to its template
+var adrFiles = []struct{ path, text string }{
+	{adrScriptPath, adrScriptTemplate},
+}
+
+// generateADR creates the decision record directory with its index, template,
+// and first record, and the script behind make adr
+func generateADR(cfg *config.ProjectConfig, projectDir string) error {
+	data := newDocsData(cfg)
+	files, err := renderFiles(data, adrFiles)
+	if err != nil {
+		return err
+	}
+	if err := renderDocsPages(cfg, files, data, adrIndexPage, adrTemplatePage, adrFirstRecordPage); err != nil {
+		return err
+	}
+	return writeFiles(projectDir, files)
+}
+
+var (
+	adrIndexTemplate       = builtin.MustTemplate("wizard/adr/index.tmpl")
+	adrPageTemplate        = builtin.MustTemplate("wizard/adr/template.tmpl")
+	adrFirstRecordTemplate = builtin.MustTemplate("wizard/adr/first-record.tmpl")
+	adrScriptTemplate      = builtin.MustTemplate("wizard/adr/new-adr.tmpl")
+	// adrMakeTargetsTemplate creates the next decision record from the
+	// template
+	adrMakeTargetsTemplate = builtin.MustTemplate("wizard/adr/make-targets.tmpl")
+	adrMakeHelpTemplate    = builtin.MustTemplate("wizard/adr/make-help.tmpl")
+)
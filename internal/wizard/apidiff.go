@@ -0,0 +1,32 @@
+package wizard
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/oculus-core/gogo/internal/templates"
+	"github.com/oculus-core/gogo/internal/templates/builtin"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// generateAPIDiffWorkflow creates a workflow that runs gorelease against the
+// latest tag on pull requests, so breaking API changes show up before release.
+// It installs the current Go release, which gorelease requires, instead of the
+// version the project builds with.
+func generateAPIDiffWorkflow(cfg *config.ProjectConfig, projectDir string) error {
+	content, err := templates.Render("apidiff.yml", apidiffWorkflowTemplate, cfg)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(projectDir, ".github", "workflows", "apidiff.yml"), []byte(content), 0600)
+}
+
+var (
+	apidiffWorkflowTemplate = builtin.MustTemplate("wizard/project/apidiff-workflow.tmpl")
+	// apidiffMakeTargetsTemplate compares the exported API with the latest
+	// release tag. gorelease fails when the changes need a new major
+	// version, and passes until the first tag exists.
+	apidiffMakeTargetsTemplate = builtin.MustTemplate("wizard/project/apidiff-make-targets.tmpl")
+	apidiffMakeHelpTemplate    = builtin.MustTemplate("wizard/project/apidiff-make-help.tmpl")
+)
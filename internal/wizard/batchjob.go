@@ -0,0 +1,89 @@
+package wizard
+
+import (
+	"github.com/oculus-core/gogo/internal/templates"
+	"github.com/oculus-core/gogo/internal/templates/builtin"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// batchDependencies lists the direct dependencies of batch jobs: the AWS SDK
+// for the S3 source and sink, and pgx for the Postgres ones
+var batchDependencies = []Dependency{
+	{"github.com/aws/aws-sdk-go-v2", "v1.32.2"},
+	{"github.com/aws/aws-sdk-go-v2/config", "v1.28.0"},
+	{"github.com/aws/aws-sdk-go-v2/service/s3", "v1.66.0"},
+	{"github.com/jackc/pgx/v5", pgxVersion},
+}
+
+// batchData is the data the batch job templates are rendered with
+type batchData struct {
+	Name   string
+	Module string
+	// CrashImport and CrashDefer report panics of main with use_crash_handler
+	CrashImport string
+	CrashDefer  string
+}
+
+// batchMakePhony lists the batch targets that are not files
+const batchMakePhony = " run dry-run"
+
+// batchFiles maps the paths of the batch job files to their templates
+var batchFiles = []struct{ path, text string }{
+	{"cmd/{{ .Name }}/main.go", batchMainTemplate},
+	{"internal/batch/batch.go", batchTemplate},
+	{"internal/batch/file.go", batchFileTemplate},
+	{"internal/batch/file_test.go", batchFileTestTemplate},
+	{"internal/batch/s3.go", batchS3Template},
+	{"internal/batch/postgres.go", batchPostgresTemplate},
+	{"internal/batch/checkpoint.go", batchCheckpointTemplate},
+	{"internal/batch/runner.go", batchRunnerTemplate},
+	{"internal/batch/runner_test.go", batchRunnerTestTemplate},
+	{"internal/job/job.go", batchJobTemplate},
+	{"internal/job/job_test.go", batchJobTestTemplate},
+	{"testdata/input.jsonl", batchInputTemplate},
+}
+
+// generateBatchCode generates a job that reads records from a file, S3, or
+// Postgres in chunks, transforms them on a pool of workers, and writes them in
+// order, saving a checkpoint after each chunk
+func generateBatchCode(cfg *config.ProjectConfig, projectDir string) error {
+	data := batchData{
+		Name:        cfg.Name,
+		Module:      cfg.Module,
+		CrashImport: crashImport(cfg),
+		CrashDefer:  crashDefer(cfg),
+	}
+	files := make(map[string]string, len(batchFiles))
+	for _, f := range batchFiles {
+		path, err := templates.Render("path", f.path, data)
+		if err != nil {
+			return err
+		}
+		content, err := templates.Render(path, f.text, data)
+		if err != nil {
+			return err
+		}
+		files[path] = content
+	}
+	return writeFiles(projectDir, files)
+}
+
+var (
+	batchMainTemplate       = builtin.MustTemplate("wizard/batchjob/main.tmpl")
+	batchTemplate           = builtin.MustTemplate("wizard/batchjob/batch.tmpl")
+	batchFileTemplate       = builtin.MustTemplate("wizard/batchjob/file.tmpl")
+	batchFileTestTemplate   = builtin.MustTemplate("wizard/batchjob/file-test.tmpl")
+	batchS3Template         = builtin.MustTemplate("wizard/batchjob/s3.tmpl")
+	batchPostgresTemplate   = builtin.MustTemplate("wizard/batchjob/postgres.tmpl")
+	batchCheckpointTemplate = builtin.MustTemplate("wizard/batchjob/checkpoint.tmpl")
+	batchRunnerTemplate     = builtin.MustTemplate("wizard/batchjob/runner.tmpl")
+	batchRunnerTestTemplate = builtin.MustTemplate("wizard/batchjob/runner-test.tmpl")
+	batchJobTemplate        = builtin.MustTemplate("wizard/batchjob/job.tmpl")
+	batchJobTestTemplate    = builtin.MustTemplate("wizard/batchjob/job-test.tmpl")
+	batchInputTemplate      = builtin.MustTemplate("wizard/batchjob/input.tmpl")
+	// batchMakeTargetsTemplate has the targets that run the job on INPUT, and
+	// batchReadmeTemplate explains its inputs and outputs and how it resumes
+	batchMakeTargetsTemplate = builtin.MustTemplate("wizard/batchjob/make-targets.tmpl")
+	batchMakeHelpTemplate    = builtin.MustTemplate("wizard/batchjob/make-help.tmpl")
+	batchReadmeTemplate      = builtin.MustTemplate("wizard/batchjob/readme-section.tmpl")
+)
@@ -0,0 +1,44 @@
+package wizard
+
+import (
+	"github.com/oculus-core/gogo/internal/templates/builtin"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// libraryBenchmark benchmarks the generated library function, so the
+// benchmark workflow has something to compare from the start
+const libraryBenchmark = `
+func BenchmarkHello(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		Hello("Gopher")
+	}
+}
+`
+
+// generateBenchmarkWorkflow creates a workflow that runs the benchmarks of a
+// pull request and of its base branch and compares them with benchstat
+func generateBenchmarkWorkflow(cfg *config.ProjectConfig, projectDir string) error {
+	data := newProjectData(cfg)
+	setupGo, err := setupGoStep(cfg)
+	if err != nil {
+		return err
+	}
+	data.SetupGo = setupGo
+	files, err := renderFiles(data, benchWorkflowFiles)
+	if err != nil {
+		return err
+	}
+	return writeFiles(projectDir, files)
+}
+
+// benchWorkflowFiles are the files of the benchmark workflow
+var benchWorkflowFiles = []struct{ path, text string }{
+	{".github/workflows/bench.yml", benchWorkflowTemplate},
+}
+
+var (
+	benchWorkflowTemplate = builtin.MustTemplate("wizard/benchmarks/bench-workflow.tmpl")
+	// benchMakeTargetsTemplate runs the benchmarks of every package
+	benchMakeTargetsTemplate = builtin.MustTemplate("wizard/benchmarks/make-targets.tmpl")
+	benchMakeHelpTemplate    = builtin.MustTemplate("wizard/benchmarks/make-help.tmpl")
+)
@@ -0,0 +1,237 @@
+package wizard
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/oculus-core/gogo/internal/templates"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// catalogEntity is a Backstage catalog entity descriptor
+type catalogEntity struct {
+	APIVersion string          `yaml:"apiVersion"`
+	Kind       string          `yaml:"kind"`
+	Metadata   catalogMetadata `yaml:"metadata"`
+	Spec       catalogSpec     `yaml:"spec"`
+}
+
+type catalogMetadata struct {
+	Name        string            `yaml:"name"`
+	Description string            `yaml:"description,omitempty"`
+	Annotations map[string]string `yaml:"annotations,omitempty"`
+	Tags        []string          `yaml:"tags,omitempty"`
+	Links       []catalogLink     `yaml:"links,omitempty"`
+}
+
+type catalogLink struct {
+	URL   string `yaml:"url"`
+	Title string `yaml:"title"`
+	Icon  string `yaml:"icon,omitempty"`
+}
+
+type catalogSpec struct {
+	Type      string `yaml:"type"`
+	Lifecycle string `yaml:"lifecycle"`
+	Owner     string `yaml:"owner"`
+}
+
+// generateCatalogInfo creates a Backstage catalog-info.yaml describing the project
+func generateCatalogInfo(cfg *config.ProjectConfig, projectDir string) error {
+	entity := catalogEntity{
+		APIVersion: "backstage.io/v1alpha1",
+		Kind:       "Component",
+		Metadata: catalogMetadata{
+			Name:        catalogName(cfg.Name),
+			Description: cfg.Description,
+			Annotations: catalogAnnotations(cfg),
+			Tags:        catalogTags(cfg),
+			Links:       catalogLinks(cfg),
+		},
+		Spec: catalogSpec{
+			Type:      catalogComponentType(cfg.Type),
+			Lifecycle: cfg.Lifecycle,
+			Owner:     catalogOwner(cfg),
+		},
+	}
+	if entity.Spec.Lifecycle == "" {
+		entity.Spec.Lifecycle = "experimental"
+	}
+
+	data, err := yaml.Marshal(&entity)
+	if err != nil {
+		return fmt.Errorf("failed to marshal catalog-info.yaml: %v", err)
+	}
+
+	catalogPath := filepath.Join(projectDir, "catalog-info.yaml")
+	if err := os.WriteFile(catalogPath, data, 0600); err != nil {
+		return fmt.Errorf("failed to create catalog-info.yaml: %v", err)
+	}
+
+	return nil
+}
+
+// catalogComponentType maps a project type to a Backstage component type
+func catalogComponentType(t config.ProjectType) string {
+	switch t {
+	case config.TypeLibrary:
+		return "library"
+	case config.TypeCLI, config.TypeGitHubAction:
+		return "tool"
+	default:
+		return "service"
+	}
+}
+
+// catalogOwner returns the configured owner, falling back to the team and then
+// to the owner in the module path
+func catalogOwner(cfg *config.ProjectConfig) string {
+	if cfg.Owner != "" {
+		return cfg.Owner
+	}
+	if cfg.Team != "" {
+		return cfg.Team
+	}
+	if host, owner, _ := splitModule(cfg.Module); host != "" && owner != "" {
+		return owner
+	}
+	return "unknown"
+}
+
+// catalogAnnotations links the component to its repository and CI
+func catalogAnnotations(cfg *config.ProjectConfig) map[string]string {
+	host, owner, repo := splitModule(cfg.Module)
+	if host == "" || owner == "" || repo == "" {
+		return nil
+	}
+
+	annotations := map[string]string{
+		"backstage.io/source-location": fmt.Sprintf("url:https://%s/%s/%s", host, owner, repo),
+	}
+	if host == "github.com" {
+		// Used by the Backstage GitHub and GitHub Actions plugins
+		annotations["github.com/project-slug"] = owner + "/" + repo
+	}
+	return annotations
+}
+
+// catalogName converts a project name into a valid Backstage entity name
+func catalogName(name string) string {
+	name = strings.ToLower(name)
+	var b strings.Builder
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			b.WriteRune(r)
+		default:
+			b.WriteRune('-')
+		}
+	}
+	return strings.Trim(b.String(), "-_.")
+}
+
+// splitModule splits a module path like github.com/owner/repo into its components
+func splitModule(module string) (host, owner, repo string) {
+	parts := strings.Split(module, "/")
+	if len(parts) < 3 || !strings.Contains(parts[0], ".") {
+		return "", "", ""
+	}
+	return parts[0], parts[1], parts[2]
+}
+
+// maxMetadataBytes limits the rendered values of all metadata files of a
+// project. The templates of the values come from the configuration, which
+// gogo serve takes from requests.
+const maxMetadataBytes = 1 << 20
+
+// generateMetadataFiles renders the configured metadata files, such as a
+// service.yaml or app.json for an internal developer platform
+func generateMetadataFiles(cfg *config.ProjectConfig, projectDir string) error {
+	budget := maxMetadataBytes
+	for _, m := range cfg.MetadataFiles {
+		if err := generateMetadataFile(cfg, projectDir, m, &budget); err != nil {
+			return fmt.Errorf("failed to create metadata file %s: %w", m.Path, err)
+		}
+	}
+	return nil
+}
+
+// generateMetadataFile renders a metadata file, whose values may take up to
+// budget bytes, and subtracts their size from budget
+func generateMetadataFile(cfg *config.ProjectConfig, projectDir string, m config.MetadataFile, budget *int) error {
+	rel := filepath.Clean(m.Path)
+	if m.Path == "" || filepath.IsAbs(rel) || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("path must be relative to the project directory")
+	}
+
+	values, err := renderMetadataValue(cfg, m.Fields, budget)
+	if err != nil {
+		return err
+	}
+
+	format := m.Format
+	if format == "" {
+		format = strings.TrimPrefix(filepath.Ext(rel), ".")
+	}
+
+	var data []byte
+	switch format {
+	case "json":
+		data, err = json.MarshalIndent(values, "", "  ")
+		data = append(data, '\n')
+	case "yaml", "yml":
+		data, err = yaml.Marshal(values)
+	default:
+		return fmt.Errorf("unsupported format %q (use yaml or json)", format)
+	}
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(projectDir, rel)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// renderMetadataValue renders every string in a (possibly nested) value as a
+// template, within budget bytes
+func renderMetadataValue(cfg *config.ProjectConfig, value interface{}, budget *int) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		rendered, err := templates.RenderLimit("metadata", v, cfg, *budget)
+		if err != nil {
+			return nil, err
+		}
+		*budget -= len(rendered)
+		return rendered, nil
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			rendered, err := renderMetadataValue(cfg, item, budget)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", key, err)
+			}
+			out[key] = rendered
+		}
+		return out, nil
+	case []interface{}:
+		out := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			rendered, err := renderMetadataValue(cfg, item, budget)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, rendered)
+		}
+		return out, nil
+	default:
+		return v, nil
+	}
+}
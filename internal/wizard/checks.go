@@ -0,0 +1,48 @@
+package wizard
+
+import (
+	"github.com/oculus-core/gogo/internal/templates/builtin"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// Versions of staticcheck and govulncheck, which run with go run so they
+// need not be installed. The older ones still build with the Go 1.19 that CI
+// installs for most project types.
+const (
+	staticcheckVersion      = "2024.1.1"
+	govulncheckVersion      = "v1.1.3"
+	staticcheckGo119Version = "2023.1.7"
+	govulncheckGo119Version = "v1.0.4"
+)
+
+// checkToolVersions returns the versions of staticcheck and govulncheck that
+// build with the Go version of the project
+func checkToolVersions(cfg *config.ProjectConfig) (staticcheck, govulncheck string) {
+	if needsNewerGo(cfg) {
+		return staticcheckVersion, govulncheckVersion
+	}
+	return staticcheckGo119Version, govulncheckGo119Version
+}
+
+// checksData is the data the check targets and CI step are rendered with
+type checksData struct {
+	Staticcheck string
+	Govulncheck string
+}
+
+func newChecksData(cfg *config.ProjectConfig) checksData {
+	staticcheck, govulncheck := checkToolVersions(cfg)
+	return checksData{Staticcheck: staticcheck, Govulncheck: govulncheck}
+}
+
+// checksMakePhony lists the check targets that are not files
+const checksMakePhony = " vet staticcheck govulncheck checks"
+
+var (
+	// checksMakeTargetsTemplate has the vet, staticcheck, and govulncheck
+	// targets, and checks, which runs all three, and checksCIStepTemplate runs
+	// the same checks in the CI workflow
+	checksMakeTargetsTemplate = builtin.MustTemplate("wizard/project/checks-make-targets.tmpl")
+	checksMakeHelpTemplate    = builtin.MustTemplate("wizard/project/checks-make-help.tmpl")
+	checksCIStepTemplate      = builtin.MustTemplate("wizard/project/checks-ci-step.tmpl")
+)
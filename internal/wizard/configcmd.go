@@ -0,0 +1,68 @@
+package wizard
+
+import (
+	"fmt"
+
+	"github.com/oculus-core/gogo/internal/templates/builtin"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// hasConfigCommand reports whether the CLI gets config get/set/list commands
+// and keeps its config file in the XDG config directory, which needs Cobra
+// and Viper
+func hasConfigCommand(cfg *config.ProjectConfig) bool {
+	return cfg.UseConfigCommand && cfg.UseCobra && cfg.UseViper && cfg.Type == config.TypeCLI
+}
+
+// configFileDefault describes the default config file in the help of the
+// --config flag
+func configFileDefault(cfg *config.ProjectConfig) string {
+	if hasConfigCommand(cfg) {
+		return "$XDG_CONFIG_HOME/" + cfg.Name + "/config.yaml"
+	}
+	return "$HOME/." + cfg.Name + ".yaml"
+}
+
+// configSearch returns the code of initConfig that finds the config file
+// when --config is not given
+func configSearch(cfg *config.ProjectConfig) string {
+	if hasConfigCommand(cfg) {
+		return fmt.Sprintf(`		// Search config in the XDG config directory, such as ~/.config/%s/config.yaml
+		dir, err := configDir()
+		cobra.CheckErr(err)
+
+		viper.AddConfigPath(dir)
+		viper.SetConfigType("yaml")
+		viper.SetConfigName("config")
+`, cfg.Name)
+	}
+	return fmt.Sprintf(`		// Find home directory.
+		home, err := os.UserHomeDir()
+		cobra.CheckErr(err)
+
+		// Search config in home directory with name ".%[1]s" (without extension).
+		viper.AddConfigPath(home)
+		viper.SetConfigType("yaml")
+		viper.SetConfigName(".%[1]s")
+`, cfg.Name)
+}
+
+// generateConfigCommand creates the config command of a CLI and its tests
+func generateConfigCommand(cfg *config.ProjectConfig, projectDir string) error {
+	files, err := renderFiles(newProjectData(cfg), configCommandFiles)
+	if err != nil {
+		return err
+	}
+	return writeFiles(projectDir, files)
+}
+
+// configCommandFiles are the config command of a CLI and its tests
+var configCommandFiles = []struct{ path, text string }{
+	{"cmd/{{ .Name }}/cmd/config.go", configCommandTemplate},
+	{"cmd/{{ .Name }}/cmd/config_test.go", configCommandTestTemplate},
+}
+
+var (
+	configCommandTemplate     = builtin.MustTemplate("wizard/configcmd/command.tmpl")
+	configCommandTestTemplate = builtin.MustTemplate("wizard/configcmd/command-test.tmpl")
+)
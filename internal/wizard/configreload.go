@@ -0,0 +1,42 @@
+package wizard
+
+import (
+	"github.com/oculus-core/gogo/internal/templates/builtin"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// configReloadPath is the config file that the service watches, relative to
+// the project
+const configReloadPath = "config.yaml"
+
+// hasConfigReload reports whether the service reloads its config file when it
+// changes, which API projects with Viper and use_config_reload do
+func hasConfigReload(cfg *config.ProjectConfig) bool {
+	return cfg.UseConfigReload && cfg.UseViper && cfg.Type == config.TypeAPI
+}
+
+// configReloadFiles maps the paths of the config watcher files to their
+// templates
+var configReloadFiles = []struct{ path, text string }{
+	{"internal/config/watch.go", configReloadWatcherTemplate},
+	{"internal/config/watch_test.go", configReloadTestTemplate},
+	{configReloadPath, configReloadFileTemplate},
+}
+
+// generateConfigReload creates the config watcher, its test, and the config file
+func generateConfigReload(cfg *config.ProjectConfig, projectDir string) error {
+	files, err := renderFiles(newProjectData(cfg), configReloadFiles)
+	if err != nil {
+		return err
+	}
+	return writeFiles(projectDir, files)
+}
+
+var (
+	configReloadWatcherTemplate = builtin.MustTemplate("wizard/configreload/watch.tmpl")
+	configReloadTestTemplate    = builtin.MustTemplate("wizard/configreload/watch-test.tmpl")
+	configReloadFileTemplate    = builtin.MustTemplate("wizard/configreload/config.tmpl")
+	// configReloadSetupTemplate is the code of main that watches the config
+	// file and logs the changes
+	configReloadSetupTemplate = builtin.MustTemplate("wizard/configreload/main-setup.tmpl")
+)
@@ -0,0 +1,55 @@
+package wizard
+
+import (
+	"fmt"
+
+	"github.com/oculus-core/gogo/internal/templates/builtin"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// hasCrashHandler reports whether main recovers and reports panics, which
+// projects that build a binary do with use_crash_handler
+func hasCrashHandler(cfg *config.ProjectConfig) bool {
+	return cfg.UseCrashHandler && cfg.Type != config.TypeLibrary
+}
+
+// crashImport returns the import of the crash package for main, or an empty
+// string without the crash handler
+func crashImport(cfg *config.ProjectConfig) string {
+	if !hasCrashHandler(cfg) {
+		return ""
+	}
+	return fmt.Sprintf("\t\"%s/internal/crash\"\n", cfg.Module)
+}
+
+// crashDefer returns the first statement of main, which reports a panic of
+// the main goroutine, or an empty string without the crash handler
+func crashDefer(cfg *config.ProjectConfig) string {
+	if !hasCrashHandler(cfg) {
+		return ""
+	}
+	return "\tdefer crash.Handle()\n\n"
+}
+
+// generateCrashHandler creates the crash package and its tests
+func generateCrashHandler(cfg *config.ProjectConfig, projectDir string) error {
+	files, err := renderFiles(newProjectData(cfg), crashFiles)
+	if err != nil {
+		return err
+	}
+	return writeFiles(projectDir, files)
+}
+
+// crashFiles are the crash package, its webhook and Sentry reporters, which
+// only need the standard library, and its tests
+var crashFiles = []struct{ path, text string }{
+	{"internal/crash/crash.go", crashTemplate},
+	{"internal/crash/reporters.go", crashReportersTemplate},
+	{"internal/crash/crash_test.go", crashTestTemplate},
+}
+
+var (
+	crashTemplate          = builtin.MustTemplate("wizard/crash/crash.tmpl")
+	crashReportersTemplate = builtin.MustTemplate("wizard/crash/reporters.tmpl")
+	crashTestTemplate      = builtin.MustTemplate("wizard/crash/crash-test.tmpl")
+)
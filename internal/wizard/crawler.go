@@ -0,0 +1,83 @@
+package wizard
+
+import (
+	"github.com/oculus-core/gogo/internal/templates"
+	"github.com/oculus-core/gogo/internal/templates/builtin"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// crawlerDependencies lists the direct dependencies of crawlers: the HTML
+// parser and the rate limiter
+var crawlerDependencies = []Dependency{
+	{"golang.org/x/net", "v0.30.0"},
+	{"golang.org/x/time", "v0.7.0"},
+}
+
+// crawlerData is the data the crawler templates are rendered with
+type crawlerData struct {
+	Name   string
+	Module string
+	// CrashImport and CrashDefer report panics of main with use_crash_handler
+	CrashImport string
+	CrashDefer  string
+}
+
+// crawlerMakePhony lists the crawler targets that are not files
+const crawlerMakePhony = " crawl"
+
+// crawlerFiles maps the paths of the crawler files to their templates
+var crawlerFiles = []struct{ path, text string }{
+	{"cmd/{{ .Name }}/main.go", crawlerMainTemplate},
+	{"internal/robots/robots.go", robotsTemplate},
+	{"internal/robots/robots_test.go", robotsTestTemplate},
+	{"internal/fetch/fetch.go", fetchTemplate},
+	{"internal/fetch/fetch_test.go", fetchTestTemplate},
+	{"internal/parse/parse.go", parseTemplate},
+	{"internal/parse/parse_test.go", parseTestTemplate},
+	{"internal/storage/storage.go", storageTemplate},
+	{"internal/crawler/crawler.go", crawlerTemplate},
+	{"internal/crawler/crawler_test.go", crawlerTestTemplate},
+}
+
+// generateCrawlerCode generates a crawler that fetches pages politely, runs
+// pluggable parsers on them, and stores the items they find
+func generateCrawlerCode(cfg *config.ProjectConfig, projectDir string) error {
+	data := crawlerData{
+		Name:        cfg.Name,
+		Module:      cfg.Module,
+		CrashImport: crashImport(cfg),
+		CrashDefer:  crashDefer(cfg),
+	}
+	files := make(map[string]string, len(crawlerFiles))
+	for _, f := range crawlerFiles {
+		path, err := templates.Render("path", f.path, data)
+		if err != nil {
+			return err
+		}
+		content, err := templates.Render(path, f.text, data)
+		if err != nil {
+			return err
+		}
+		files[path] = content
+	}
+	return writeFiles(projectDir, files)
+}
+
+var (
+	crawlerMainTemplate = builtin.MustTemplate("wizard/crawler/main.tmpl")
+	robotsTemplate      = builtin.MustTemplate("wizard/crawler/robots.tmpl")
+	robotsTestTemplate  = builtin.MustTemplate("wizard/crawler/robots-test.tmpl")
+	fetchTemplate       = builtin.MustTemplate("wizard/crawler/fetch.tmpl")
+	fetchTestTemplate   = builtin.MustTemplate("wizard/crawler/fetch-test.tmpl")
+	parseTemplate       = builtin.MustTemplate("wizard/crawler/parse.tmpl")
+	parseTestTemplate   = builtin.MustTemplate("wizard/crawler/parse-test.tmpl")
+	storageTemplate     = builtin.MustTemplate("wizard/crawler/storage.tmpl")
+	crawlerTemplate     = builtin.MustTemplate("wizard/crawler/crawler.tmpl")
+	crawlerTestTemplate = builtin.MustTemplate("wizard/crawler/test.tmpl")
+	// crawlerMakeTargetsTemplate has the target that crawls from SEED, and
+	// crawlerReadmeTemplate explains how the crawler behaves and where to
+	// extend it
+	crawlerMakeTargetsTemplate = builtin.MustTemplate("wizard/crawler/make-targets.tmpl")
+	crawlerMakeHelpTemplate    = builtin.MustTemplate("wizard/crawler/make-help.tmpl")
+	crawlerReadmeTemplate      = builtin.MustTemplate("wizard/crawler/readme-section.tmpl")
+)
@@ -0,0 +1,166 @@
+package wizard
+
+import (
+	"sort"
+
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// Dependency is a module that the generated code imports, required in go.mod
+type Dependency struct {
+	Path    string
+	Version string
+}
+
+// featureDependency maps a feature to the modules its code imports
+type featureDependency struct {
+	// Feature names the feature in tests
+	Feature string
+
+	// Enabled reports whether the project gets the code of the feature
+	Enabled func(cfg *config.ProjectConfig) bool
+
+	// Requires returns the modules the code of the feature imports
+	Requires func(cfg *config.ProjectConfig) []Dependency
+}
+
+// featureDependencies lists the features whose code imports other modules.
+// generateGoMod requires the modules of every enabled feature, so a feature
+// that imports a module must be listed here with the same condition that
+// generates its code.
+var featureDependencies = []featureDependency{
+	{
+		Feature: "cobra",
+		Enabled: func(cfg *config.ProjectConfig) bool {
+			return cfg.Type == config.TypeCLI && cfg.UseCobra
+		},
+		Requires: requires(Dependency{"github.com/spf13/cobra", "v1.9.1"}),
+	},
+	{
+		Feature: "viper",
+		Enabled: func(cfg *config.ProjectConfig) bool {
+			return (cfg.Type == config.TypeCLI && cfg.UseViper) || hasConfigReload(cfg)
+		},
+		Requires: requires(Dependency{"github.com/spf13/viper", "v1.19.0"}),
+	},
+	{
+		Feature: "gin",
+		Enabled: func(cfg *config.ProjectConfig) bool {
+			return cfg.Type == config.TypeAPI && cfg.UseGin
+		},
+		Requires: requires(Dependency{"github.com/gin-gonic/gin", "v1.9.1"}),
+	},
+	{
+		// The config watcher imports fsnotify for the events of Viper
+		Feature:  "config-reload",
+		Enabled:  hasConfigReload,
+		Requires: requires(Dependency{"github.com/fsnotify/fsnotify", "v1.7.0"}),
+	},
+	{
+		// The output package encodes YAML with yaml.v3
+		Feature:  "output",
+		Enabled:  hasOutput,
+		Requires: requires(Dependency{"gopkg.in/yaml.v3", "v3.0.1"}),
+	},
+	{
+		Feature: "prompt",
+		Enabled: hasPrompt,
+		Requires: func(cfg *config.ProjectConfig) []Dependency {
+			return []Dependency{promptDependency(cfg)}
+		},
+	},
+	{
+		Feature:  "feature-flags",
+		Enabled:  hasFeatureFlags,
+		Requires: featureFlagsDependencies,
+	},
+	{
+		Feature:  "i18n",
+		Enabled:  hasI18n,
+		Requires: requires(Dependency{"golang.org/x/text", "v0.14.0"}),
+	},
+	{
+		Feature:  "validation",
+		Enabled:  hasValidation,
+		Requires: requires(validatorDependency),
+	},
+	{
+		Feature: "task-queue",
+		Enabled: func(cfg *config.ProjectConfig) bool {
+			return hasTaskQueue(cfg) && cfg.TaskQueue == config.TaskQueueAsynq
+		},
+		Requires: requires(asynqDependency),
+	},
+	{
+		Feature:  "plugins",
+		Enabled:  hasPlugins,
+		Requires: requires(pluginsDependencies...),
+	},
+	{
+		Feature:  "operator",
+		Enabled:  isType(config.TypeOperator),
+		Requires: requires(operatorDependencies...),
+	},
+	{
+		Feature:  "grpc",
+		Enabled:  isType(config.TypeGRPC),
+		Requires: requires(grpcDependencies...),
+	},
+	{
+		Feature:  "event-driven",
+		Enabled:  isType(config.TypeEventDriven),
+		Requires: eventDependencies,
+	},
+	{
+		Feature:  "batch",
+		Enabled:  isType(config.TypeBatch),
+		Requires: requires(batchDependencies...),
+	},
+	{
+		Feature:  "crawler",
+		Enabled:  isType(config.TypeCrawler),
+		Requires: requires(crawlerDependencies...),
+	},
+	{
+		Feature:  "tui",
+		Enabled:  isType(config.TypeTUI),
+		Requires: requires(tuiDependencies...),
+	},
+}
+
+// requires returns a Requires function for modules that do not depend on the
+// configuration
+func requires(deps ...Dependency) func(*config.ProjectConfig) []Dependency {
+	return func(*config.ProjectConfig) []Dependency {
+		return deps
+	}
+}
+
+// isType returns an Enabled function for the features of a project type
+func isType(t config.ProjectType) func(*config.ProjectConfig) bool {
+	return func(cfg *config.ProjectConfig) bool {
+		return cfg.Type == t
+	}
+}
+
+// Dependencies returns the modules the code generated for cfg imports, sorted
+// by path as go mod tidy does
+func Dependencies(cfg *config.ProjectConfig) []Dependency {
+	seen := map[string]bool{}
+	var deps []Dependency
+	for _, f := range featureDependencies {
+		if !f.Enabled(cfg) {
+			continue
+		}
+		for _, dep := range f.Requires(cfg) {
+			if !seen[dep.Path] {
+				seen[dep.Path] = true
+				deps = append(deps, dep)
+			}
+		}
+	}
+	sort.Slice(deps, func(i, j int) bool {
+		return deps[i].Path < deps[j].Path
+	})
+	return deps
+}
@@ -0,0 +1,97 @@
+package wizard
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// standardDir is a standard directory of the project layout
+type standardDir struct {
+	Name string
+
+	// Enabled reports whether the project has the directory
+	Enabled func(cfg *config.ProjectConfig) bool
+
+	// Purpose completes "Package <name>" or "The <name> directory" in the
+	// placeholder that describes the directory
+	Purpose string
+}
+
+// standardDirs lists the directories of the standard project layout
+var standardDirs = []standardDir{
+	{
+		Name:    "cmd",
+		Enabled: func(cfg *config.ProjectConfig) bool { return cfg.UseCmd },
+		Purpose: "holds the entrypoints of %s: each subdirectory is a main package that builds a binary",
+	},
+	{
+		Name:    "internal",
+		Enabled: func(cfg *config.ProjectConfig) bool { return cfg.UseInternal },
+		Purpose: "holds the private packages of %s, which the go command keeps other modules from importing",
+	},
+	{
+		Name:    "pkg",
+		Enabled: func(cfg *config.ProjectConfig) bool { return cfg.UsePkg },
+		Purpose: "holds the public packages of %s, which other modules may import",
+	},
+	{
+		Name:    "test",
+		Enabled: func(cfg *config.ProjectConfig) bool { return cfg.UseTest },
+		Purpose: "holds the integration tests and test utilities of %s",
+	},
+}
+
+// generateDirectories creates the standard directories of the project. Those
+// that no other step filled get the placeholder chosen by cfg.DirPlaceholder,
+// so that Git tracks them while they are empty.
+func generateDirectories(cfg *config.ProjectConfig, projectDir string) error {
+	if !config.IsValidDirPlaceholder(cfg.DirPlaceholder) {
+		return fmt.Errorf("unknown directory placeholder %q: use %s",
+			cfg.DirPlaceholder, strings.Join(config.DirPlaceholders, ", "))
+	}
+
+	for _, dir := range standardDirs {
+		if !dir.Enabled(cfg) {
+			continue
+		}
+		dirPath := filepath.Join(projectDir, dir.Name)
+		if err := os.MkdirAll(dirPath, 0755); err != nil {
+			return fmt.Errorf("failed to create directory %s: %v", dir.Name, err)
+		}
+
+		entries, err := os.ReadDir(dirPath)
+		if err != nil {
+			return fmt.Errorf("failed to read directory %s: %v", dir.Name, err)
+		}
+		if len(entries) > 0 {
+			continue
+		}
+		file, content := dirPlaceholder(cfg, dir)
+		if file == "" {
+			continue
+		}
+		if err := os.WriteFile(filepath.Join(dirPath, file), []byte(content), 0600); err != nil {
+			return fmt.Errorf("failed to create %s in %s: %v", file, dir.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// dirPlaceholder returns the name and content of the placeholder of an empty
+// directory, or an empty name for none
+func dirPlaceholder(cfg *config.ProjectConfig, dir standardDir) (string, string) {
+	purpose := fmt.Sprintf(dir.Purpose, displayName(cfg))
+	switch cfg.DirPlaceholder {
+	case config.DirPlaceholderNone:
+		return "", ""
+	case config.DirPlaceholderDoc:
+		return "doc.go", fmt.Sprintf("// Package %s %s.\npackage %s\n", dir.Name, purpose, dir.Name)
+	default:
+		return ".gitkeep", ""
+	}
+}
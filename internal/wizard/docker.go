@@ -0,0 +1,139 @@
+package wizard
+
+import (
+	"strings"
+
+	"github.com/oculus-core/gogo/internal/templates/builtin"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// hasDocker reports whether the project gets the Dockerfile of use_docker.
+// GitHub Actions and operators always get a Dockerfile of their own.
+func hasDocker(cfg *config.ProjectConfig) bool {
+	switch cfg.Type {
+	case config.TypeGitHubAction, config.TypeOperator:
+		return false
+	}
+	return cfg.UseDocker && mainPackage(cfg) != ""
+}
+
+// dockerBase returns the runtime base image of the Dockerfile
+func dockerBase(cfg *config.ProjectConfig) string {
+	if cfg.DockerBase == "" {
+		return config.DockerBaseDistroless
+	}
+	return cfg.DockerBase
+}
+
+// dockerData is the data the Docker templates are rendered with
+type dockerData struct {
+	*config.ProjectConfig
+	// GoVersion is the Go image that builds MainPackage, with the version in
+	// VersionPackage
+	GoVersion      string
+	MainPackage    string
+	VersionPackage string
+	// Description labels the image, and Registry is the registry the image
+	// workflow pushes to
+	Description string
+	Registry    string
+}
+
+// containerRegistry returns the registry the image is pushed to, none when
+// the project pushes to none
+func containerRegistry(cfg *config.ProjectConfig) string {
+	if cfg.ContainerRegistry == "" {
+		return config.ContainerRegistryNone
+	}
+	return cfg.ContainerRegistry
+}
+
+// hasImageWorkflow reports whether the project gets the workflow that
+// publishes its image, which projects with a Dockerfile, GitHub Actions, and a
+// container registry do
+func hasImageWorkflow(cfg *config.ProjectConfig) bool {
+	return hasDocker(cfg) && cfg.UseGitHubActions && containerRegistry(cfg) != config.ContainerRegistryNone
+}
+
+// makefileRegistry returns the REGISTRY the Makefile tags the image for,
+// from the environment of the registry, or nothing without a registry
+func makefileRegistry(cfg *config.ProjectConfig) string {
+	switch containerRegistry(cfg) {
+	case config.ContainerRegistryGHCR:
+		owner := "OWNER"
+		if host, o, _ := splitModule(cfg.Module); host == "github.com" {
+			owner = strings.ToLower(o)
+		}
+		return "ghcr.io/" + owner
+	case config.ContainerRegistryECR:
+		return "$(AWS_ACCOUNT_ID).dkr.ecr.$(AWS_REGION).amazonaws.com"
+	case config.ContainerRegistryGAR:
+		return "$(GAR_LOCATION)-docker.pkg.dev/$(GCP_PROJECT)/$(GAR_REPOSITORY)"
+	}
+	return ""
+}
+
+// dockerMakeData is the data the docker targets of the Makefile are rendered
+// with: the REGISTRY the image is tagged for, if any, and the version it is
+// built with
+type dockerMakeData struct {
+	Name     string
+	Registry string
+	Version  string
+}
+
+func newDockerMakeData(cfg *config.ProjectConfig) dockerMakeData {
+	return dockerMakeData{Name: cfg.Name, Registry: makefileRegistry(cfg), Version: makefileVersion(cfg)}
+}
+
+// imageWorkflowPath is the workflow that publishes the image
+const imageWorkflowPath = ".github/workflows/image.yml"
+
+// dockerFiles maps the paths of the Docker files to their templates. The
+// Dockerfile builds the binary on the platform of the builder for the target
+// platform of docker buildx, and downloads the modules before copying the
+// source, so their layer is only rebuilt when go.mod or go.sum change.
+var dockerFiles = []struct{ path, text string }{
+	{"Dockerfile", dockerfileTemplate},
+	{".dockerignore", dockerignoreTemplate},
+}
+
+// imageWorkflowFiles maps the path of the workflow that builds the image for
+// linux/amd64 and linux/arm64 with buildx and pushes it to the registry of the
+// project, with a provenance attestation, to its template
+var imageWorkflowFiles = []struct{ path, text string }{
+	{imageWorkflowPath, imageWorkflowTemplate},
+}
+
+// generateDocker creates the Dockerfile and .dockerignore of use_docker, and
+// the workflow that publishes the image
+func generateDocker(cfg *config.ProjectConfig, projectDir string) error {
+	data := dockerData{
+		ProjectConfig:  cfg,
+		GoVersion:      goToolchainVersion(cfg),
+		MainPackage:    mainPackage(cfg),
+		VersionPackage: versionPackage(cfg),
+		Description:    description(cfg),
+		Registry:       containerRegistry(cfg),
+	}
+	lists := [][]struct{ path, text string }{dockerFiles}
+	if hasImageWorkflow(cfg) {
+		lists = append(lists, imageWorkflowFiles)
+	}
+	files, err := renderFiles(data, lists...)
+	if err != nil {
+		return err
+	}
+	return writeFiles(projectDir, files)
+}
+
+var (
+	dockerfileTemplate    = builtin.MustTemplate("wizard/docker/dockerfile.tmpl")
+	dockerignoreTemplate  = builtin.MustTemplate("wizard/docker/dockerignore.tmpl")
+	imageWorkflowTemplate = builtin.MustTemplate("wizard/docker/image-workflow.tmpl")
+	// dockerMakeTargetsTemplate has the targets that build the image for the
+	// local platform and push it for PLATFORMS, with the version and commit of
+	// the Makefile as build args
+	dockerMakeTargetsTemplate = builtin.MustTemplate("wizard/docker/make-targets.tmpl")
+	dockerMakeHelpTemplate    = builtin.MustTemplate("wizard/docker/make-help.tmpl")
+)
@@ -0,0 +1,282 @@
+package wizard
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/oculus-core/gogo/internal/templates"
+	"github.com/oculus-core/gogo/internal/templates/builtin"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// hasDocsSite reports whether the project gets a documentation site
+func hasDocsSite(cfg *config.ProjectConfig) bool {
+	return cfg.DocsSite != "" && cfg.DocsSite != config.DocsSiteNone
+}
+
+// docsContentDir returns the directory that holds the Markdown pages of the
+// documentation site, relative to the project
+func docsContentDir(cfg *config.ProjectConfig) string {
+	if cfg.DocsSite == config.DocsSiteHugo {
+		return "docs/content"
+	}
+	return "docs"
+}
+
+// docsPage is a page of the generated documentation site. Its title and body
+// are templates, rendered by renderDocsPages.
+type docsPage struct {
+	// Path is the page below the content directory, without extension.
+	// Section indexes end in "index".
+	Path   string
+	Title  string
+	Weight int
+	Body   string
+}
+
+// docsData is the data the documentation pages and the site are rendered with
+type docsData struct {
+	*config.ProjectConfig
+	// Title is the display name of the project, and PagesURL, Owner, and Repo
+	// locate its site on GitHub Pages
+	Title    string
+	PagesURL string
+	Owner    string
+	Repo     string
+	// ContentDir holds the pages, and Date dates the first decision record
+	ContentDir string
+	Date       string
+	// Binary, MainPackage, Action, Broker, and SpecFile describe the code of
+	// the project
+	Binary      string
+	MainPackage string
+	Action      string
+	Broker      string
+	SpecFile    string
+	// Tools are the pinned tools by their display name, ToolNames lists
+	// them, and ToolVersionFile is the file that pins them
+	Tools           []toolVersion
+	ToolNames       string
+	ToolVersionFile string
+	// The features below add their pages or notes to the documentation
+	StarterDocs bool
+	TestHelpers bool
+	LiveReload  bool
+	Pprof       bool
+	Telemetry   bool
+	Alerting    bool
+}
+
+// newDocsData returns the data of the documentation of cfg
+func newDocsData(cfg *config.ProjectConfig) docsData {
+	_, owner, repo := splitModule(cfg.Module)
+	data := docsData{
+		ProjectConfig:   cfg,
+		Title:           displayName(cfg),
+		PagesURL:        pagesURL(cfg),
+		Owner:           owner,
+		Repo:            repo,
+		ContentDir:      docsContentDir(cfg),
+		Date:            time.Now().Format("2006-01-02"),
+		Binary:          strings.ToLower(cfg.Name),
+		MainPackage:     mainPackage(cfg),
+		Action:          actionRepository(cfg),
+		Broker:          eventBrokerSetups[eventBroker(cfg)].Title,
+		SpecFile:        sdkSpecFile(cfg),
+		ToolVersionFile: toolVersionFile(cfg),
+		StarterDocs:     hasStarterDocs(cfg),
+		TestHelpers:     hasTestHelpers(cfg),
+		LiveReload:      hasLiveReload(cfg),
+		Pprof:           hasPprof(cfg),
+		Telemetry:       hasTelemetry(cfg),
+		Alerting:        hasAlerting(cfg),
+	}
+	var names []string
+	for _, t := range pinnedTools(cfg) {
+		if t.Name == "golang" {
+			t.Name = "Go"
+		}
+		data.Tools = append(data.Tools, t)
+		names = append(names, t.Name)
+	}
+	data.ToolNames = strings.Join(names, ", ")
+	return data
+}
+
+// Link links to another page with docsLink
+func (d docsData) Link(text, page string) string {
+	return docsLink(d.ProjectConfig, text, page)
+}
+
+// Pages of the documentation site. The architecture page comes with the
+// starter docs, and the decision records with use_adr.
+var (
+	docsIndexPage      = docsPage{Path: "index", Title: "{{ .Name }}", Body: docsIndexTemplate}
+	gettingStartedPage = docsPage{Path: "getting-started", Title: "Getting started", Weight: 10, Body: gettingStartedTemplate}
+)
+
+// docsLink links to another page. MkDocs resolves links to Markdown files;
+// Hugo lists the pages in its navigation, so there the text stays plain.
+func docsLink(cfg *config.ProjectConfig, text, page string) string {
+	if cfg.DocsSite == config.DocsSiteHugo {
+		return text
+	}
+	return "[" + text + "](" + page + ".md)"
+}
+
+// renderDocsPage returns the path of a page relative to the project and its
+// Markdown source for the documentation site generator
+func renderDocsPage(cfg *config.ProjectConfig, page docsPage) (string, string) {
+	path := page.Path
+	if cfg.DocsSite == config.DocsSiteHugo {
+		// Hugo takes titles from front matter and names section indexes _index.md
+		if strings.HasSuffix(path, "index") {
+			path = strings.TrimSuffix(path, "index") + "_index"
+		}
+		return docsContentDir(cfg) + "/" + path + ".md",
+			fmt.Sprintf("---\ntitle: %q\nweight: %d\n---\n\n", page.Title, page.Weight) + page.Body
+	}
+	return docsContentDir(cfg) + "/" + path + ".md", "# " + page.Title + "\n\n" + page.Body
+}
+
+// renderDocsPages renders the titles and bodies of pages with data, and adds
+// the pages to files by their path relative to the project
+func renderDocsPages(cfg *config.ProjectConfig, files map[string]string, data interface{}, pages ...docsPage) error {
+	for _, page := range pages {
+		title, err := templates.Render(page.Path, page.Title, data)
+		if err != nil {
+			return err
+		}
+		body, err := templates.Render(page.Path, page.Body, data)
+		if err != nil {
+			return err
+		}
+		path, content := renderDocsPage(cfg, docsPage{Path: page.Path, Title: title, Weight: page.Weight, Body: body})
+		files[path] = content
+	}
+	return nil
+}
+
+// writeFiles writes files given by their path relative to the project
+func writeFiles(projectDir string, files map[string]string) error {
+	for rel, content := range files {
+		path := filepath.Join(projectDir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %v", rel, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			return fmt.Errorf("failed to create %s: %v", rel, err)
+		}
+	}
+	return nil
+}
+
+// renderFiles renders the paths and the templates of the lists of files,
+// given by their path relative to the project, with data
+func renderFiles(data interface{}, lists ...[]struct{ path, text string }) (map[string]string, error) {
+	rendered := map[string]string{}
+	for _, files := range lists {
+		for _, f := range files {
+			path, err := templates.Render("path", f.path, data)
+			if err != nil {
+				return nil, err
+			}
+			content, err := templates.Render(path, f.text, data)
+			if err != nil {
+				return nil, err
+			}
+			rendered[path] = content
+		}
+	}
+	return rendered, nil
+}
+
+// generateDocsSite creates the documentation site skeleton: an MkDocs site
+// with the Material theme, or a Hugo site with minimal layouts in docs/
+func generateDocsSite(cfg *config.ProjectConfig, projectDir string) error {
+	if !config.IsValidDocsSite(cfg.DocsSite) {
+		return fmt.Errorf("unknown docs site %q: use %s", cfg.DocsSite, strings.Join(config.DocsSites, ", "))
+	}
+
+	var siteFiles []struct{ path, text string }
+	switch cfg.DocsSite {
+	case config.DocsSiteMkDocs:
+		siteFiles = mkdocsFiles
+	case config.DocsSiteHugo:
+		siteFiles = hugoFiles
+	default:
+		return nil
+	}
+
+	data := newDocsData(cfg)
+	files, err := renderFiles(data, siteFiles)
+	if err != nil {
+		return err
+	}
+	err = renderDocsPages(cfg, files, data, docsIndexPage, gettingStartedPage)
+	if err != nil {
+		return err
+	}
+	return writeFiles(projectDir, files)
+}
+
+// mkdocsFiles and hugoFiles map the paths of the configuration and layouts of
+// each site generator to their templates. The minimal Hugo layouts let the
+// site build without a theme.
+var (
+	mkdocsFiles = []struct{ path, text string }{
+		{"mkdocs.yml", mkdocsTemplate},
+	}
+	hugoFiles = []struct{ path, text string }{
+		{"docs/hugo.toml", hugoTemplate},
+		{"docs/layouts/_default/baseof.html", hugoBaseLayoutTemplate},
+		{"docs/layouts/_default/single.html", hugoSingleLayoutTemplate},
+		{"docs/layouts/_default/list.html", hugoListLayoutTemplate},
+	}
+)
+
+// pagesURL returns the GitHub Pages URL of the project, or an empty string
+// when it is not hosted on GitHub
+func pagesURL(cfg *config.ProjectConfig) string {
+	host, owner, repo := splitModule(cfg.Module)
+	if host != "github.com" {
+		return ""
+	}
+	return fmt.Sprintf("https://%s.github.io/%s/", owner, repo)
+}
+
+// docsWorkflowFiles maps the path of the workflow that builds the
+// documentation site and deploys it to GitHub Pages to its template
+var docsWorkflowFiles = []struct{ path, text string }{
+	{".github/workflows/docs.yml", docsWorkflowTemplate},
+}
+
+// generateDocsWorkflow creates a workflow that builds the documentation site
+// and deploys it to GitHub Pages on pushes to main
+func generateDocsWorkflow(cfg *config.ProjectConfig, projectDir string) error {
+	files, err := renderFiles(newDocsData(cfg), docsWorkflowFiles)
+	if err != nil {
+		return err
+	}
+	return writeFiles(projectDir, files)
+}
+
+var (
+	docsIndexTemplate        = builtin.MustTemplate("wizard/docssite/index.tmpl")
+	gettingStartedTemplate   = builtin.MustTemplate("wizard/docssite/getting-started.tmpl")
+	mkdocsTemplate           = builtin.MustTemplate("wizard/docssite/mkdocs.tmpl")
+	hugoTemplate             = builtin.MustTemplate("wizard/docssite/hugo.tmpl")
+	hugoBaseLayoutTemplate   = builtin.MustTemplate("wizard/docssite/hugo-baseof.tmpl")
+	hugoSingleLayoutTemplate = builtin.MustTemplate("wizard/docssite/hugo-single.tmpl")
+	hugoListLayoutTemplate   = builtin.MustTemplate("wizard/docssite/hugo-list.tmpl")
+	docsWorkflowTemplate     = builtin.MustTemplate("wizard/docssite/docs-workflow.tmpl")
+	// docsMakeTargetsTemplate has the targets that serve and build the
+	// documentation site, and docsIgnoreTemplate the .gitignore entries for
+	// the built site
+	docsMakeTargetsTemplate = builtin.MustTemplate("wizard/docssite/make-targets.tmpl")
+	docsMakeHelpTemplate    = builtin.MustTemplate("wizard/docssite/make-help.tmpl")
+	docsIgnoreTemplate      = builtin.MustTemplate("wizard/docssite/gitignore.tmpl")
+)
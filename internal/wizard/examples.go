@@ -0,0 +1,60 @@
+package wizard
+
+import (
+	"github.com/oculus-core/gogo/internal/templates/builtin"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// hasExamples reports whether the project gets example programs, which
+// libraries, APIs, and SDKs do
+func hasExamples(cfg *config.ProjectConfig) bool {
+	return cfg.UseExamples && (cfg.Type == config.TypeLibrary || cfg.Type == config.TypeAPI || cfg.Type == config.TypeSDK)
+}
+
+// exampleData is the data the example templates are rendered with
+type exampleData struct {
+	*config.ProjectConfig
+	// Package is the library package the example program uses
+	Package libraryPackage
+}
+
+// generateExamples creates an example program in examples/<name>/main.go.
+// Release builds only include the main package of the project, so the examples
+// are built by CI and make examples instead.
+func generateExamples(cfg *config.ProjectConfig, projectDir string) error {
+	// The example of an SDK calls an operation of its spec, so generateSDKCode
+	// writes it
+	if cfg.Type == config.TypeSDK {
+		return nil
+	}
+	example := libraryExampleFiles
+	if cfg.Type == config.TypeAPI {
+		example = clientExampleFiles
+	}
+	files, err := renderFiles(exampleData{ProjectConfig: cfg, Package: primaryPackage(cfg)}, example)
+	if err != nil {
+		return err
+	}
+	return writeFiles(projectDir, files)
+}
+
+var (
+	// libraryExampleFiles are the example program of a library
+	libraryExampleFiles = []struct{ path, text string }{
+		{"examples/hello/main.go", libraryExampleTemplate},
+	}
+	// clientExampleFiles are the example program of an API, which calls the
+	// endpoints of a running server
+	clientExampleFiles = []struct{ path, text string }{
+		{"examples/client/main.go", clientExampleTemplate},
+	}
+)
+
+var (
+	libraryExampleTemplate = builtin.MustTemplate("wizard/examples/hello.tmpl")
+	clientExampleTemplate  = builtin.MustTemplate("wizard/examples/client.tmpl")
+	// examplesMakeTargetsTemplate builds the example programs without
+	// keeping the binaries
+	examplesMakeTargetsTemplate = builtin.MustTemplate("wizard/examples/make-targets.tmpl")
+	examplesMakeHelpTemplate    = builtin.MustTemplate("wizard/examples/make-help.tmpl")
+)
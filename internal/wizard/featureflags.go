@@ -0,0 +1,74 @@
+package wizard
+
+import (
+	"github.com/oculus-core/gogo/internal/templates/builtin"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// flagdDefinitionsPath is the flag definition file that make flagd serves
+const flagdDefinitionsPath = "flags.flagd.json"
+
+// hasFeatureFlags reports whether the project gets OpenFeature flags, which
+// API projects with Gin and a flag backend do
+func hasFeatureFlags(cfg *config.ProjectConfig) bool {
+	return cfg.Type == config.TypeAPI && cfg.UseGin &&
+		(cfg.FeatureFlags == config.FeatureFlagsMemory || cfg.FeatureFlags == config.FeatureFlagsFlagd)
+}
+
+// featureFlagsDependencies returns the OpenFeature SDK and, with flagd, its
+// provider. These versions still build with the Go version of API projects.
+func featureFlagsDependencies(cfg *config.ProjectConfig) []Dependency {
+	deps := []Dependency{{"github.com/open-feature/go-sdk", "v1.10.0"}}
+	if cfg.FeatureFlags == config.FeatureFlagsFlagd {
+		deps = append(deps, Dependency{"github.com/open-feature/go-sdk-contrib/providers/flagd", "v0.1.22"})
+	}
+	return deps
+}
+
+// generateFeatureFlags creates the flags package, the routes gated by flags,
+// and for flagd the flag definitions
+func generateFeatureFlags(cfg *config.ProjectConfig, projectDir string) error {
+	providerFiles := memoryFlagsFiles
+	if cfg.FeatureFlags == config.FeatureFlagsFlagd {
+		providerFiles = flagdFlagsFiles
+	}
+	files, err := renderFiles(newProjectData(cfg), flagsFiles, providerFiles)
+	if err != nil {
+		return err
+	}
+	return writeFiles(projectDir, files)
+}
+
+var (
+	// flagsFiles are the typed flags package, shared by every backend, its
+	// tests against an in-memory provider, and the API routes gated by flags
+	flagsFiles = []struct{ path, text string }{
+		{"internal/flags/flags.go", flagsTemplate},
+		{"internal/flags/flags_test.go", flagsTestTemplate},
+		{"internal/api/flags.go", flagsRoutesTemplate},
+	}
+	// memoryFlagsFiles set the in-memory OpenFeature provider
+	memoryFlagsFiles = []struct{ path, text string }{
+		{"internal/flags/provider.go", memoryProviderTemplate},
+	}
+	// flagdFlagsFiles set the flagd provider and hold the flagd flag
+	// definitions, with the same flags and defaults as the in-memory provider
+	flagdFlagsFiles = []struct{ path, text string }{
+		{"internal/flags/provider.go", flagdProviderTemplate},
+		{flagdDefinitionsPath, flagdDefinitionsTemplate},
+	}
+)
+
+var (
+	flagsTemplate            = builtin.MustTemplate("wizard/featureflags/flags.tmpl")
+	flagsTestTemplate        = builtin.MustTemplate("wizard/featureflags/flags-test.tmpl")
+	flagsRoutesTemplate      = builtin.MustTemplate("wizard/featureflags/routes.tmpl")
+	memoryProviderTemplate   = builtin.MustTemplate("wizard/featureflags/memory-provider.tmpl")
+	flagdProviderTemplate    = builtin.MustTemplate("wizard/featureflags/flagd-provider.tmpl")
+	flagdDefinitionsTemplate = builtin.MustTemplate("wizard/featureflags/flagd.tmpl")
+	// flagdMakeTargetsTemplate runs flagd locally with the flag definitions of
+	// the project, and flagsSetupTemplate sets the provider in main
+	flagdMakeTargetsTemplate = builtin.MustTemplate("wizard/featureflags/make-targets.tmpl")
+	flagdMakeHelpTemplate    = builtin.MustTemplate("wizard/featureflags/make-help.tmpl")
+	flagsSetupTemplate       = builtin.MustTemplate("wizard/featureflags/main-setup.tmpl")
+)
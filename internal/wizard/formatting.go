@@ -0,0 +1,51 @@
+package wizard
+
+import (
+	"github.com/oculus-core/gogo/internal/templates/builtin"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// Versions of the formatters that make fmt runs with go run, so they need
+// not be installed
+const (
+	goimportsVersion = "v0.26.0"
+	gofumptVersion   = "v0.7.0"
+	gciVersion       = "v0.13.5"
+)
+
+// strictLint reports whether .golangci.yml enables the strict linters
+func strictLint(cfg *config.ProjectConfig) bool {
+	return cfg.UseLinters && cfg.LintPreset == config.LintPresetStrict
+}
+
+// formatter returns the formatting of the project: gofumpt with the strict
+// linters, which check it, and the formatting option otherwise
+func formatter(cfg *config.ProjectConfig) string {
+	if strictLint(cfg) {
+		return config.FormattingGofumpt
+	}
+	return cfg.Formatting
+}
+
+// hasFormatting reports whether the Makefile has a fmt target
+func hasFormatting(cfg *config.ProjectConfig) bool {
+	f := formatter(cfg)
+	return f == config.FormattingGoimports || f == config.FormattingGofumpt
+}
+
+// newFormatData returns the project data with the formatting of the project
+// and the versions of its formatters
+func newFormatData(cfg *config.ProjectConfig) projectData {
+	data := newProjectData(cfg)
+	data.Formatter = formatter(cfg)
+	data.GofumptVersion, data.GciVersion, data.GoimportsVersion = gofumptVersion, gciVersion, goimportsVersion
+	return data
+}
+
+var (
+	// formatMakeTargetsTemplate has the fmt target, which formats the code and
+	// puts the imports of the module in their own group, the way
+	// .golangci.yml checks
+	formatMakeTargetsTemplate = builtin.MustTemplate("wizard/project/format-make-targets.tmpl")
+	formatMakeHelpTemplate    = builtin.MustTemplate("wizard/project/format-make-help.tmpl")
+)
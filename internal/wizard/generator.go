@@ -2,104 +2,298 @@ package wizard
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
 
+	"github.com/oculus-core/gogo/internal/manifest"
+	"github.com/oculus-core/gogo/internal/templates"
+	"github.com/oculus-core/gogo/internal/templates/builtin"
 	"github.com/oculus-core/gogo/pkg/config"
 )
 
 // GenerateProject creates a new Go project based on the provided configuration
+// in the directory named after the project in outputDir
 func GenerateProject(cfg *config.ProjectConfig, outputDir string) error {
-	// Create project directory if it doesn't exist
-	projectDir := filepath.Join(outputDir, cfg.Name)
-	if err := os.MkdirAll(projectDir, 0755); err != nil {
-		return fmt.Errorf("failed to create project directory: %v", err)
-	}
-
-	// Generate root files
-	if err := generateRootFiles(cfg, projectDir); err != nil {
-		return err
-	}
+	return GenerateProjectIn(cfg, filepath.Join(outputDir, cfg.Name))
+}
 
-	// Create standard directory structure
-	dirs := []string{}
+// GenerateProjectIn creates a new Go project based on the provided
+// configuration in projectDir itself, such as a freshly cloned repository
+func GenerateProjectIn(cfg *config.ProjectConfig, projectDir string) error {
+	return GenerateProjectWithOptions(cfg, projectDir, Options{})
+}
 
-	if cfg.UseCmd {
-		dirs = append(dirs, "cmd")
-	}
+// Options tune a generation beyond the project configuration
+type Options struct {
+	// RunTools runs the installed tools that complete the project: buf for
+	// gRPC services and kubebuilder for operators with use_kubebuilder. They
+	// may need the network, so only gogo new sets it, and not when offline.
+	RunTools bool
+}
 
-	if cfg.UseInternal {
-		dirs = append(dirs, "internal")
+// GenerateProjectWithOptions creates a new Go project in projectDir like
+// GenerateProjectIn, with the given options
+func GenerateProjectWithOptions(cfg *config.ProjectConfig, projectDir string, opts Options) error {
+	if err := config.ValidateLayout(cfg.Layout); err != nil {
+		return err
 	}
-
-	if cfg.UsePkg {
-		dirs = append(dirs, "pkg")
+	if err := config.ValidateTopics(cfg.Topics); err != nil {
+		return err
 	}
-
-	if cfg.UseDocs {
-		dirs = append(dirs, "docs")
+	if err := config.ValidateBranch(cfg.DefaultBranch); err != nil {
+		return err
 	}
 
-	if cfg.UseTest {
-		dirs = append(dirs, "test")
+	// Create project directory if it doesn't exist
+	if err := os.MkdirAll(projectDir, 0755); err != nil {
+		return fmt.Errorf("failed to create project directory: %v", err)
 	}
 
-	for _, dir := range dirs {
-		dirPath := filepath.Join(projectDir, dir)
-		if err := os.MkdirAll(dirPath, 0755); err != nil {
-			return fmt.Errorf("failed to create directory %s: %v", dir, err)
+	for _, step := range generationSteps {
+		if step.Enabled != nil && !step.Enabled(cfg) {
+			continue
 		}
-
-		// Create a .gitkeep file to ensure the directory is tracked by Git
-		gitkeepPath := filepath.Join(dirPath, ".gitkeep")
-		if err := os.WriteFile(gitkeepPath, []byte(""), 0600); err != nil {
-			return fmt.Errorf("failed to create .gitkeep in %s: %v", dir, err)
+		if err := step.run(cfg, projectDir, opts); err != nil {
+			return err
 		}
 	}
 
-	// Generate initial code based on application type
-	if err := generateInitialCodeByType(cfg, projectDir); err != nil {
+	// Move the conventional directories to those of the layout
+	if err := relayout(cfg, projectDir); err != nil {
 		return err
 	}
 
-	// Generate config file
-	if err := generateConfigFile(cfg, projectDir); err != nil {
-		return err
+	// Layer the user templates over the generated files
+	if cfg.Template != "" {
+		if err := applyUserTemplates(cfg, projectDir); err != nil {
+			return err
+		}
 	}
 
-	// Generate go.mod file
-	if err := generateGoMod(cfg, projectDir); err != nil {
+	// Record the generated files so modifications can be detected later
+	m, err := manifest.Build(projectDir)
+	if err != nil {
 		return err
 	}
-
-	// Generate GitHub Actions workflows if enabled
-	if cfg.UseGitHubActions {
-		if err := generateGitHubWorkflows(cfg, projectDir); err != nil {
-			return err
-		}
+	if err := m.Write(projectDir); err != nil {
+		return fmt.Errorf("failed to write manifest: %v", err)
 	}
 
-	// Generate linter configuration if enabled
-	if cfg.UseLinters {
-		if err := generateLinterConfig(cfg, projectDir); err != nil {
+	// Keep the generated files of projects with user templates, whose
+	// three-way merges start from them
+	if cfg.Template != "" {
+		if err := m.SaveOriginals(projectDir); err != nil {
 			return err
 		}
 	}
 
-	// Generate pre-commit hooks configuration if enabled
-	if cfg.UsePreCommitHooks {
-		if err := generatePreCommitConfig(cfg, projectDir); err != nil {
-			return err
-		}
+	return nil
+}
+
+// generationStep generates a part of the project
+type generationStep struct {
+	// Name names the step in tests
+	Name string
+
+	// Enabled reports whether the project gets the files of the step; steps
+	// without it always run
+	Enabled func(cfg *config.ProjectConfig) bool
+
+	// Generate writes the files of the step into projectDir
+	Generate func(cfg *config.ProjectConfig, projectDir string) error
+
+	// GenerateWith replaces Generate for the steps that depend on the options
+	GenerateWith func(cfg *config.ProjectConfig, projectDir string, opts Options) error
+}
+
+// run runs the generator of the step
+func (s generationStep) run(cfg *config.ProjectConfig, projectDir string, opts Options) error {
+	if s.GenerateWith != nil {
+		return s.GenerateWith(cfg, projectDir, opts)
 	}
+	return s.Generate(cfg, projectDir)
+}
 
-	return nil
+// generationSteps is the plan GenerateProjectIn runs in order. Each file of
+// the project is written by exactly one step, so a step must not write the
+// files of another, such as gogo.yaml or go.mod.
+var generationSteps = []generationStep{
+	{Name: "root-files", Generate: generateRootFiles},
+	{Name: "code", GenerateWith: generateInitialCodeByType},
+	{Name: "docs-site", Generate: generateDocsSite},
+	{Name: "starter-docs", Enabled: hasStarterDocs, Generate: generateStarterDocs},
+	{
+		Name:     "adr",
+		Enabled:  func(cfg *config.ProjectConfig) bool { return cfg.UseADR },
+		Generate: generateADR,
+	},
+	{Name: "secrets", Generate: generateSecrets},
+	{Name: "feature-flags", Enabled: hasFeatureFlags, Generate: generateFeatureFlags},
+	{Name: "i18n", Enabled: hasI18n, Generate: generateI18n},
+	{Name: "multi-tenancy", Enabled: hasMultiTenancy, Generate: generateMultiTenancy},
+	{Name: "plugins", Enabled: hasPlugins, Generate: generatePlugins},
+	{Name: "validation", Enabled: hasValidation, Generate: generateValidation},
+	{Name: "pagination", Enabled: hasPagination, Generate: generatePagination},
+	{Name: "task-queue", Enabled: hasTaskQueue, Generate: generateTaskQueue},
+	{Name: "http-cache", Enabled: hasHTTPCache, Generate: generateHTTPCache},
+	{Name: "crash-handler", Enabled: hasCrashHandler, Generate: generateCrashHandler},
+	{Name: "config-reload", Enabled: hasConfigReload, Generate: generateConfigReload},
+	{Name: "notify", Enabled: hasNotify, Generate: generateNotify},
+	{Name: "pprof", Enabled: hasPprof, Generate: generatePprof},
+	{Name: "live-reload", Enabled: hasLiveReload, Generate: generateLiveReload},
+	{Name: "docker", Enabled: hasDocker, Generate: generateDocker},
+	{Name: "examples", Enabled: hasExamples, Generate: generateExamples},
+	{Name: "config-file", Generate: generateConfigFile},
+	{Name: "go-mod", Generate: generateGoMod},
+	{
+		Name:     "github-actions",
+		Enabled:  func(cfg *config.ProjectConfig) bool { return cfg.UseGitHubActions },
+		Generate: generateGitHubWorkflows,
+	},
+	{
+		Name:     "version-files",
+		Enabled:  func(cfg *config.ProjectConfig) bool { return cfg.CreateVersionFile },
+		Generate: generateVersionFiles,
+	},
+	{Name: "tool-versions", Generate: generateToolVersions},
+	{
+		Name:     "linters",
+		Enabled:  func(cfg *config.ProjectConfig) bool { return cfg.UseLinters },
+		Generate: generateLinterConfig,
+	},
+	{
+		Name:     "pre-commit",
+		Enabled:  func(cfg *config.ProjectConfig) bool { return cfg.UsePreCommitHooks },
+		Generate: generatePreCommitConfig,
+	},
+	{
+		Name:     "catalog-info",
+		Enabled:  func(cfg *config.ProjectConfig) bool { return cfg.CreateCatalogInfo },
+		Generate: generateCatalogInfo,
+	},
+	{
+		Name:     "codeowners",
+		Enabled:  hasCodeowners,
+		Generate: generateCodeowners,
+	},
+	{Name: "alerting", Enabled: hasAlerting, Generate: generateAlerting},
+	{Name: "metadata-files", Generate: generateMetadataFiles},
+	{Name: "test-helpers", Enabled: hasTestHelpers, Generate: generateTestHelpers},
+	// The directories come last, so only those no step filled get a placeholder
+	{Name: "directories", Generate: generateDirectories},
+}
+
+// projectData is the data the project templates are rendered with: the
+// configuration, and the parts of the files that the enabled features add
+type projectData struct {
+	*config.ProjectConfig
+	// Title is the display name, Binary the name of the built binary
+	Title  string
+	Binary string
+	// MainDoc is the package doc comment of main, and CrashImport and
+	// CrashDefer report its panics with use_crash_handler
+	MainDoc     string
+	CrashImport string
+	CrashDefer  string
+
+	// RootExecute, ConfigFileDefault, and ConfigSearch complete the root
+	// command of CLI projects, and the Version fields its version command
+	RootExecute        string
+	ConfigFileDefault  string
+	ConfigSearch       string
+	VersionImports     string
+	VersionTypes       string
+	VersionRun         string
+	CommandDescription string
+
+	// Imports, Setup, and Serve complete main of API projects, and
+	// MultiTenancy adds the tenant settings to their config
+	Imports      string
+	Setup        string
+	Serve        string
+	MultiTenancy bool
+	// ServerFields, Middleware, Banner, and ExtraRoutes complete the Gin
+	// server, and PostHelper adds the post helper of the server without Gin
+	ServerFields string
+	Middleware   string
+	Banner       string
+	ExtraRoutes  string
+	PostHelper   bool
+
+	// Package is the library package being generated, and Benchmark the
+	// benchmark of its tests
+	Package   libraryPackage
+	Benchmark string
+
+	// Badges, TopicBadges, Sections, and the fields below complete the README
+	Badges         string
+	TopicBadges    string
+	Sections       string
+	HasBinary      bool
+	LiveReload     bool
+	LibraryInstall string
+	Ownership      string
+	Maintainers    string
+	// Year is the year of the license copyright
+	Year int
+	// GitignoreExtras lists the files the enabled features ignore
+	GitignoreExtras string
+	// Phony, VersionExpr, VersionPackage, ExtraTargets, and ExtraHelp
+	// complete the Makefile
+	Phony          string
+	VersionExpr    string
+	VersionPackage string
+	ExtraTargets   string
+	ExtraHelp      string
+	// AuthorList, GoVersion, and Requires complete go.mod
+	AuthorList string
+	GoVersion  string
+	Requires   []Dependency
+
+	// GoPrivate, Services, SetupGo, ProtoCheck, Examples, and ExtraSteps
+	// complete the CI workflow, and the tool versions the lint workflow and
+	// the pre-commit hooks
+	GoPrivate               string
+	Services                string
+	SetupGo                 string
+	ProtoCheck              string
+	Examples                bool
+	ExtraSteps              string
+	GolangciLintVersion     string
+	GolangciLintToolVersion string
+	// Formatter is the formatting of .golangci.yml and the pre-commit hooks,
+	// and StrictLint enables the strict linters
+	Formatter  string
+	StrictLint bool
+	// GofumptVersion, GciVersion, and GoimportsVersion pin the formatters of
+	// the pre-commit hooks
+	GofumptVersion   string
+	GciVersion       string
+	GoimportsVersion string
+	// Dockerfile, Workflows, and Markdown add the hooks that lint those files
+	Dockerfile bool
+	Workflows  bool
+	Markdown   bool
+}
+
+// newProjectData returns the data of the project templates shared by every
+// file of cfg
+func newProjectData(cfg *config.ProjectConfig) projectData {
+	return projectData{
+		ProjectConfig: cfg,
+		Title:         displayName(cfg),
+		Binary:        strings.ToLower(cfg.Name),
+		MainDoc:       mainDoc(cfg),
+		CrashImport:   crashImport(cfg),
+		CrashDefer:    crashDefer(cfg),
+	}
 }
 
 // generateInitialCodeByType generates initial code based on the application type
-func generateInitialCodeByType(cfg *config.ProjectConfig, projectDir string) error {
+func generateInitialCodeByType(cfg *config.ProjectConfig, projectDir string, opts Options) error {
 	switch cfg.Type {
 	case config.TypeCLI:
 		return generateCLICode(cfg, projectDir)
@@ -107,6 +301,26 @@ func generateInitialCodeByType(cfg *config.ProjectConfig, projectDir string) err
 		return generateAPICode(cfg, projectDir)
 	case config.TypeLibrary:
 		return generateLibraryCode(cfg, projectDir)
+	case config.TypeGitHubAction:
+		return generateGitHubActionCode(cfg, projectDir)
+	case config.TypeOperator:
+		return generateOperatorCode(cfg, projectDir, opts)
+	case config.TypeGRPC:
+		return generateGRPCCode(cfg, projectDir, opts)
+	case config.TypeEventDriven:
+		return generateEventDrivenCode(cfg, projectDir)
+	case config.TypeBatch:
+		return generateBatchCode(cfg, projectDir)
+	case config.TypeCrawler:
+		return generateCrawlerCode(cfg, projectDir)
+	case config.TypeWorker:
+		return generateWorkerCode(cfg, projectDir)
+	case config.TypeTUI:
+		return generateTUICode(cfg, projectDir)
+	case config.TypeSDK:
+		return generateSDKCode(cfg, projectDir)
+	case config.TypeScript:
+		return generateScriptCode(cfg, projectDir)
 	default:
 		return generateDefaultCode(cfg, projectDir)
 	}
@@ -122,22 +336,10 @@ func generateCLICode(cfg *config.ProjectConfig, projectDir string) error {
 
 	// Generate main.go
 	mainPath := filepath.Join(cmdDir, "main.go")
-	mainContent := fmt.Sprintf(`package main
-
-import (
-	"fmt"
-	"os"
-
-	"%s/cmd/%s/cmd"
-)
-
-func main() {
-	if err := cmd.Execute(); err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+	mainContent, err := templates.Render("main.go", cliMainTemplate, newProjectData(cfg))
+	if err != nil {
+		return err
 	}
-}
-`, cfg.Module, cfg.Name)
 
 	if err := os.WriteFile(mainPath, []byte(mainContent), 0600); err != nil {
 		return fmt.Errorf("failed to create main.go: %v", err)
@@ -149,118 +351,123 @@ func main() {
 		return fmt.Errorf("failed to create cmd package directory: %v", err)
 	}
 
-	// Generate root.go with Cobra
+	// Generate root.go with Cobra, or with the flag package without it
 	rootPath := filepath.Join(cmdPkgDir, "root.go")
-	rootContent := fmt.Sprintf(`package cmd
-
-import (
-	"fmt"
-	"os"
-
-	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
-)
-
-var cfgFile string
-
-// rootCmd represents the base command when called without any subcommands
-var rootCmd = &cobra.Command{
-	Use:   "%s",
-	Short: "A brief description of your application",
-	Long: `+"`"+`A longer description that spans multiple lines and likely contains
-examples and usage of using your application.`+"`"+`,
-	// Uncomment the following line if your bare application
-	// has an action associated with it:
-	// Run: func(cmd *cobra.Command, args []string) { },
-}
-
-// Execute adds all child commands to the root command and sets flags appropriately.
-// This is called by main.main(). It only needs to happen once to the rootCmd.
-func Execute() error {
-	return rootCmd.Execute()
-}
-
-func init() {
-	cobra.OnInitialize(initConfig)
-
-	// Here you will define your flags and configuration settings.
-	// Cobra supports persistent flags, which, if defined here,
-	// will be global for your application.
-
-	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.%s.yaml)")
+	render := stdlibRoot
+	if cfg.UseCobra {
+		render = cobraRoot
+	}
+	rootContent, err := render(cfg)
+	if err != nil {
+		return err
+	}
 
-	// Cobra also supports local flags, which will only run
-	// when this action is called directly.
-	rootCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
-}
+	if err := os.WriteFile(rootPath, []byte(rootContent), 0600); err != nil {
+		return fmt.Errorf("failed to create root.go: %v", err)
+	}
 
-// initConfig reads in config file and ENV variables if set.
-func initConfig() {
-	if cfgFile != "" {
-		// Use config file from the flag.
-		viper.SetConfigFile(cfgFile)
-	} else {
-		// Find home directory.
-		home, err := os.UserHomeDir()
-		cobra.CheckErr(err)
+	// Generate the config command if enabled
+	if hasConfigCommand(cfg) {
+		if err := generateConfigCommand(cfg, projectDir); err != nil {
+			return err
+		}
+	}
 
-		// Search config in home directory with name ".%s" (without extension).
-		viper.AddConfigPath(home)
-		viper.SetConfigType("yaml")
-		viper.SetConfigName(".%s")
+	// Generate the self-update command and update notification if enabled
+	if hasSelfUpdate(cfg) {
+		if err := generateSelfUpdate(cfg, projectDir); err != nil {
+			return err
+		}
 	}
 
-	viper.AutomaticEnv() // read in environment variables that match
+	// Generate the output package and the --output flag if enabled
+	if hasOutput(cfg) {
+		if err := generateOutput(cfg, projectDir); err != nil {
+			return err
+		}
+	}
 
-	// If a config file is found, read it in.
-	if err := viper.ReadInConfig(); err == nil {
-		fmt.Fprintln(os.Stderr, "Using config file:", viper.ConfigFileUsed())
+	// Generate the telemetry package and command if enabled
+	if hasTelemetry(cfg) {
+		if err := generateTelemetry(cfg, projectDir); err != nil {
+			return err
+		}
 	}
-}
-`, cfg.Name, cfg.Name, cfg.Name, cfg.Name)
 
-	if err := os.WriteFile(rootPath, []byte(rootContent), 0600); err != nil {
-		return fmt.Errorf("failed to create root.go: %v", err)
+	// Generate the prompt package and the setup command if enabled
+	if hasPrompt(cfg) {
+		if err := generatePrompt(cfg, projectDir); err != nil {
+			return err
+		}
 	}
 
 	// Generate version.go
 	versionPath := filepath.Join(cmdPkgDir, "version.go")
-	versionContent := fmt.Sprintf(`package cmd
+	render = stdlibVersion
+	if cfg.UseCobra {
+		render = cobraVersion
+	}
+	versionContent, err := render(cfg)
+	if err != nil {
+		return err
+	}
 
-import (
-	"fmt"
+	if err := os.WriteFile(versionPath, []byte(versionContent), 0600); err != nil {
+		return fmt.Errorf("failed to create version.go: %v", err)
+	}
 
-	"github.com/spf13/cobra"
-)
+	return nil
+}
 
-// Version information
-var (
-	Version   = "dev"
-	Commit    = "none"
-	BuildDate = "unknown"
-)
+// cobraRoot returns the root.go of CLI projects with Cobra. With Viper, a
+// persistent --config flag selects the config file that initConfig reads.
+func cobraRoot(cfg *config.ProjectConfig) (string, error) {
+	data := newProjectData(cfg)
+	data.RootExecute = rootExecute(cfg)
+	if cfg.UseViper {
+		data.ConfigFileDefault, data.ConfigSearch = configFileDefault(cfg), configSearch(cfg)
+	}
+	return templates.Render("root.go", cobraRootTemplate, data)
+}
 
-// versionCmd represents the version command
-var versionCmd = &cobra.Command{
-	Use:   "version",
-	Short: "Print the version number",
-	Long:  `+"`"+`Print the version, commit, and build date information for your application.`+"`"+`,
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Printf("%s version %%s (%%s) built on %%s\n", Version, Commit, BuildDate)
-	},
+// cobraVersion returns the version command of CLI projects with Cobra
+func cobraVersion(cfg *config.ProjectConfig) (string, error) {
+	data := newProjectData(cfg)
+	data.VersionImports, data.VersionTypes, data.VersionRun = versionOutput(cfg)
+	data.CommandDescription = commandDescription(cfg)
+	return templates.Render("version.go", cobraVersionTemplate, data)
 }
 
-func init() {
-	rootCmd.AddCommand(versionCmd)
+// apiMainPackages are the packages of the features that main of API projects
+// sets up
+var apiMainPackages = []struct {
+	Enabled func(cfg *config.ProjectConfig) bool
+	Name    string
+}{
+	{hasPprof, "debug"},
+	{hasFeatureFlags, "flags"},
+	{hasPlugins, "plugins"},
+	{hasTaskQueue, "tasks"},
 }
-`, cfg.Name)
 
-	if err := os.WriteFile(versionPath, []byte(versionContent), 0600); err != nil {
-		return fmt.Errorf("failed to create version.go: %v", err)
+// apiMainSetup is the code of main of API projects that sets up the features
+// before serving. With config reload, it watches the config file, with pprof
+// it starts the debug server, with feature flags it sets the OpenFeature
+// provider, and with plugins it stops the plugin processes on exit and on
+// signals. With a task queue, it starts the workers, which drain on signals,
+// and apiMainServe gives the server the queue.
+var (
+	apiMainSetup = []snippet{
+		{Enabled: hasConfigReload, Text: configReloadSetupTemplate},
+		{Enabled: hasPprof, Text: pprofMainSetupTemplate},
+		{Enabled: hasFeatureFlags, Text: flagsSetupTemplate},
+		{Enabled: hasPlugins, Text: pluginsSetupTemplate},
+		{Enabled: hasTaskQueue, Text: taskQueueSetupTemplate},
 	}
-
-	return nil
-}
+	apiMainServe = []snippet{
+		{Enabled: hasTaskQueue, Text: taskQueueServeTemplate},
+	}
+)
 
 // generateAPICode generates code for an API application
 func generateAPICode(cfg *config.ProjectConfig, projectDir string) error {
@@ -270,29 +477,31 @@ func generateAPICode(cfg *config.ProjectConfig, projectDir string) error {
 		return fmt.Errorf("failed to create cmd directory: %v", err)
 	}
 
-	// Generate main.go
-	mainPath := filepath.Join(cmdDir, "main.go")
-	mainContent := fmt.Sprintf(`package main
-
-import (
-	"log"
-
-	"%s/internal/api"
-	"%s/internal/config"
-)
-
-func main() {
-	cfg, err := config.Load()
+	// With the crash handler, main reports panics first, and the enabled
+	// features import their packages and set them up before serving
+	imports := crashImport(cfg)
+	for _, p := range apiMainPackages {
+		if p.Enabled(cfg) {
+			imports += fmt.Sprintf("\t\"%s/internal/%s\"\n", cfg.Module, p.Name)
+		}
+	}
+	setup, err := renderSnippets(cfg, "main.go", apiMainSetup)
 	if err != nil {
-		log.Fatalf("Failed to load configuration: %%v", err)
+		return err
+	}
+	serve, err := renderSnippets(cfg, "main.go", apiMainServe)
+	if err != nil {
+		return err
 	}
 
-	server := api.NewServer(cfg)
-	if err := server.Run(); err != nil {
-		log.Fatalf("Failed to start server: %%v", err)
+	// Generate main.go
+	data := newProjectData(cfg)
+	data.Imports, data.Setup, data.Serve = imports, setup, serve
+	mainPath := filepath.Join(cmdDir, "main.go")
+	mainContent, err := templates.Render("main.go", apiMainTemplate, data)
+	if err != nil {
+		return err
 	}
-}
-`, cfg.Module, cfg.Module)
 
 	if err := os.WriteFile(mainPath, []byte(mainContent), 0600); err != nil {
 		return fmt.Errorf("failed to create main.go: %v", err)
@@ -304,52 +513,15 @@ func main() {
 		return fmt.Errorf("failed to create internal/config directory: %v", err)
 	}
 
-	// Generate config.go
+	// Generate config.go. With multi-tenancy, the config also holds the
+	// tenant settings of internal/config/tenant.go.
+	data.MultiTenancy = hasMultiTenancy(cfg)
 	configPath := filepath.Join(configDir, "config.go")
-	configContent := `package config
-
-import (
-	"fmt"
-	"os"
-	"strconv"
-)
-
-// Config holds the application configuration
-type Config struct {
-	Server ServerConfig
-}
-
-// ServerConfig holds the server configuration
-type ServerConfig struct {
-	Port int
-	Host string
-}
-
-// Load loads the configuration from environment variables
-func Load() (*Config, error) {
-	port := 8080
-	if portStr := os.Getenv("PORT"); portStr != "" {
-		var err error
-		port, err = strconv.Atoi(portStr)
-		if err != nil {
-			return nil, fmt.Errorf("invalid PORT: %v", err)
-		}
-	}
-
-	host := "localhost"
-	if hostEnv := os.Getenv("HOST"); hostEnv != "" {
-		host = hostEnv
+	configContent, err := templates.Render("config.go", apiConfigTemplate, data)
+	if err != nil {
+		return err
 	}
 
-	return &Config{
-		Server: ServerConfig{
-			Port: port,
-			Host: host,
-		},
-	}, nil
-}
-`
-
 	if err := os.WriteFile(configPath, []byte(configContent), 0600); err != nil {
 		return fmt.Errorf("failed to create config.go: %v", err)
 	}
@@ -360,69 +532,16 @@ func Load() (*Config, error) {
 		return fmt.Errorf("failed to create internal/api directory: %v", err)
 	}
 
-	// Generate server.go
+	// Generate server.go with Gin, or with http.ServeMux without it
 	serverPath := filepath.Join(apiDir, "server.go")
-	serverContent := fmt.Sprintf(`package api
-
-import (
-	"fmt"
-	"net/http"
-
-	"github.com/gin-gonic/gin"
-
-	"%s/internal/config"
-)
-
-// Server represents the API server
-type Server struct {
-	router *gin.Engine
-	cfg    *config.Config
-}
-
-// NewServer creates a new API server
-func NewServer(cfg *config.Config) *Server {
-	router := gin.Default()
-
-	server := &Server{
-		router: router,
-		cfg:    cfg,
+	render := stdlibServer
+	if cfg.UseGin {
+		render = ginServer
 	}
-
-	server.registerRoutes()
-
-	return server
-}
-
-// Run starts the server
-func (s *Server) Run() error {
-	addr := fmt.Sprintf("%%s:%%d", s.cfg.Server.Host, s.cfg.Server.Port)
-	return s.router.Run(addr)
-}
-
-// registerRoutes sets up the API routes
-func (s *Server) registerRoutes() {
-	s.router.GET("/health", s.healthCheck)
-
-	v1 := s.router.Group("/api/v1")
-	{
-		v1.GET("/hello", s.helloWorld)
+	serverContent, err := render(cfg)
+	if err != nil {
+		return err
 	}
-}
-
-// healthCheck handles the health check endpoint
-func (s *Server) healthCheck(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{
-		"status": "ok",
-	})
-}
-
-// helloWorld handles the hello world endpoint
-func (s *Server) helloWorld(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{
-		"message": "Hello, World!",
-	})
-}
-`, cfg.Module)
 
 	if err := os.WriteFile(serverPath, []byte(serverContent), 0600); err != nil {
 		return fmt.Errorf("failed to create server.go: %v", err)
@@ -431,72 +550,103 @@ func (s *Server) helloWorld(c *gin.Context) {
 	return nil
 }
 
+// ginServer returns the server.go of API projects with Gin
+func ginServer(cfg *config.ProjectConfig) (string, error) {
+	// Routes gated by feature flags are registered from internal/api/flags.go,
+	// localized routes from internal/api/i18n.go behind the localize middleware,
+	// routes scoped by tenant from internal/api/tenant.go, plugin routes from
+	// internal/api/plugins.go, cached routes from internal/api/cache.go, and
+	// the example routes from internal/api/greeting.go, books.go, and tasks.go
+	data := newProjectData(cfg)
+	if hasFeatureFlags(cfg) {
+		data.ExtraRoutes += "\n\t\ts.registerFlaggedRoutes(v1)"
+	}
+	if hasI18n(cfg) {
+		data.Middleware = "\n\trouter.Use(localize())"
+		data.ExtraRoutes += "\n\t\ts.registerLocalizedRoutes(v1)"
+	}
+	if hasMultiTenancy(cfg) {
+		data.ExtraRoutes += "\n\t\ts.registerTenantRoutes(v1)"
+	}
+	if hasPlugins(cfg) {
+		data.ExtraRoutes += "\n\t\ts.registerPluginRoutes(v1)"
+	}
+	if hasHTTPCache(cfg) {
+		data.ExtraRoutes += "\n\t\ts.registerCachedRoutes(v1)"
+	}
+	if hasValidation(cfg) {
+		data.ExtraRoutes += "\n\t\tv1.POST(\"/greetings\", s.createGreeting)"
+	}
+	if hasPagination(cfg) {
+		data.ExtraRoutes += "\n\t\tv1.GET(\"/books\", s.listBooks)"
+	}
+	if hasTaskQueue(cfg) {
+		data.ExtraRoutes += "\n\t\tv1.POST(\"/signups\", s.createSignup)"
+		data.ServerFields = "\tqueue  TaskQueue\n"
+	}
+	data.Banner = serverBanner(cfg)
+	return templates.Render("server.go", ginServerTemplate, data)
+}
+
 // generateLibraryCode generates code for a library
 func generateLibraryCode(cfg *config.ProjectConfig, projectDir string) error {
-	// Create pkg directory structure
-	pkgDir := filepath.Join(projectDir, "pkg", cfg.Name)
-	if err := os.MkdirAll(pkgDir, 0755); err != nil {
-		return fmt.Errorf("failed to create pkg directory: %v", err)
+	if err := config.ValidatePackages(cfg.Packages); err != nil {
+		return err
 	}
+	for _, pkg := range libraryPackages(cfg) {
+		if err := generateLibraryPackage(cfg, projectDir, pkg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-	// Generate library.go
-	libPath := filepath.Join(pkgDir, fmt.Sprintf("%s.go", cfg.Name))
-	libContent := fmt.Sprintf(`package %s
-
-// Version is the current version of the library
-const Version = "0.1.1"
+// generateLibraryPackage generates a package of a library with its tests, and
+// doc.go when the library has several packages or package docs are enabled
+func generateLibraryPackage(cfg *config.ProjectConfig, projectDir string, pkg libraryPackage) error {
+	// Create the package directory
+	pkgDir := filepath.Join(projectDir, filepath.FromSlash(pkg.Dir))
+	if err := os.MkdirAll(pkgDir, 0755); err != nil {
+		return fmt.Errorf("failed to create %s directory: %v", pkg.Dir, err)
+	}
 
-// Hello returns a greeting message
-func Hello(name string) string {
-	if name == "" {
-		name = "World"
+	// Generate the package source
+	data := newProjectData(cfg)
+	data.Package = pkg
+	libPath := filepath.Join(pkgDir, fmt.Sprintf("%s.go", pkg.Name))
+	libContent, err := templates.Render(libPath, libraryTemplate, data)
+	if err != nil {
+		return err
 	}
-	return "Hello, " + name + "!"
-}
-`, cfg.Name)
 
 	if err := os.WriteFile(libPath, []byte(libContent), 0600); err != nil {
 		return fmt.Errorf("failed to create library file: %v", err)
 	}
 
 	// Generate test file
-	testPath := filepath.Join(pkgDir, fmt.Sprintf("%s_test.go", cfg.Name))
-	testContent := fmt.Sprintf(`package %s
-
-import "testing"
-
-func TestHello(t *testing.T) {
-	tests := []struct {
-		name     string
-		input    string
-		expected string
-	}{
-		{
-			name:     "empty name",
-			input:    "",
-			expected: "Hello, World!",
-		},
-		{
-			name:     "with name",
-			input:    "Gopher",
-			expected: "Hello, Gopher!",
-		},
+	if cfg.UseBenchmarks {
+		data.Benchmark = libraryBenchmark
 	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			if got := Hello(tt.input); got != tt.expected {
-				t.Errorf("Hello() = %%q, want %%q", got, tt.expected)
-			}
-		})
+	testPath := filepath.Join(pkgDir, fmt.Sprintf("%s_test.go", pkg.Name))
+	testContent, err := templates.Render(testPath, libraryTestTemplate, data)
+	if err != nil {
+		return err
 	}
-}
-`, cfg.Name)
 
 	if err := os.WriteFile(testPath, []byte(testContent), 0600); err != nil {
 		return fmt.Errorf("failed to create test file: %v", err)
 	}
 
+	if len(cfg.Packages) > 0 || hasPackageDocs(cfg) {
+		if err := generateDocFile(cfg, pkgDir, pkg); err != nil {
+			return err
+		}
+	}
+	if hasPackageDocs(cfg) {
+		if err := generateExamplesTest(pkgDir, pkg); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -504,14 +654,10 @@ func TestHello(t *testing.T) {
 func generateDefaultCode(cfg *config.ProjectConfig, projectDir string) error {
 	// Create a simple main.go in the project root
 	mainPath := filepath.Join(projectDir, "main.go")
-	mainContent := fmt.Sprintf(`package main
-
-import "fmt"
-
-func main() {
-	fmt.Println("Hello from %s!")
-}
-`, cfg.Name)
+	mainContent, err := templates.Render("main.go", defaultMainTemplate, newProjectData(cfg))
+	if err != nil {
+		return err
+	}
 
 	if err := os.WriteFile(mainPath, []byte(mainContent), 0600); err != nil {
 		return fmt.Errorf("failed to create main.go: %v", err)
@@ -522,136 +668,252 @@ func main() {
 
 // generateConfigFile creates the gogo.yaml configuration file
 func generateConfigFile(cfg *config.ProjectConfig, projectDir string) error {
-	configPath := filepath.Join(projectDir, "gogo.yaml")
-
-	configContent := fmt.Sprintf(`# Gogo Project Configuration
-# Generated on: %s
-
-# Project Information
-project:
-  name: %q
-  module: %q
-  description: %q
-  license: %q
-  author: %q
-
-# Project Structure
-structure:
-  use_cmd: %t
-  use_internal: %t
-  use_pkg: %t
-  use_test: %t
-  use_docs: %t
-
-# Generated Files
-files:
-  create_readme: %t
-  create_license: %t
-  create_makefile: %t
-
-# Code Quality
-quality:
-  use_linters: %t
-  use_pre_commit_hooks: %t
-  use_git_hooks: %t
-
-# Dependencies
-dependencies:
-  use_cobra: %t
-  use_viper: %t
-
-# CI/CD
-cicd:
-  use_github_actions: %t
-`,
-		time.Now().Format(time.RFC3339),
-		cfg.Name,
-		cfg.Module,
-		cfg.Description,
-		cfg.License,
-		cfg.Author,
-		cfg.UseCmd,
-		cfg.UseInternal,
-		cfg.UsePkg,
-		cfg.UseTest,
-		cfg.UseDocs,
-		cfg.CreateReadme,
-		cfg.CreateLicense,
-		cfg.CreateMakefile,
-		cfg.UseLinters,
-		cfg.UsePreCommitHooks,
-		cfg.UseGitHooks,
-		cfg.UseCobra,
-		cfg.UseViper,
-		cfg.UseGitHubActions,
-	)
-
-	return os.WriteFile(configPath, []byte(configContent), 0600)
+	return config.SaveProjectFile(cfg, filepath.Join(projectDir, config.ProjectFileName))
+}
+
+// makefileVersion returns the version the Makefile builds with: the version
+// in VERSION with a VERSION file, and the latest git tag otherwise
+func makefileVersion(cfg *config.ProjectConfig) string {
+	if cfg.CreateVersionFile {
+		return "$(VERSION)"
+	}
+	return "$(GIT_TAG)"
 }
 
+// Data of the snippets that are not rendered with the project data
+var (
+	formatSnippetData    = func(cfg *config.ProjectConfig) interface{} { return newFormatData(cfg) }
+	checksSnippetData    = func(cfg *config.ProjectConfig) interface{} { return newChecksData(cfg) }
+	secretsSnippetData   = func(cfg *config.ProjectConfig) interface{} { return newSecretsData(cfg) }
+	pprofSnippetData     = func(cfg *config.ProjectConfig) interface{} { return newPprofData(cfg) }
+	dockerSnippetData    = func(cfg *config.ProjectConfig) interface{} { return newDockerMakeData(cfg) }
+	operatorSnippetData  = func(cfg *config.ProjectConfig) interface{} { return newOperatorData(cfg) }
+	grpcSnippetData      = func(cfg *config.ProjectConfig) interface{} { return newGRPCData(cfg) }
+	eventSnippetData     = func(cfg *config.ProjectConfig) interface{} { return newEventData(cfg) }
+	goPrivateSnippetData = func(cfg *config.ProjectConfig) interface{} { return newGoPrivateData(cfg) }
+)
+
+// makeSnippets are the Makefile targets of the features, in the order the
+// Makefile lists them
+var makeSnippets = []makeSnippet{
+	// With a VERSION file, the targets bump and tag it
+	{
+		Enabled: func(cfg *config.ProjectConfig) bool { return cfg.CreateVersionFile },
+		Targets: versionMakeTargetsTemplate,
+		Help:    versionMakeHelpTemplate,
+	},
+	{Enabled: hasFormatting, Targets: formatMakeTargetsTemplate, Help: formatMakeHelpTemplate, Data: formatSnippetData},
+	{
+		Enabled: func(cfg *config.ProjectConfig) bool { return cfg.UseBenchmarks },
+		Targets: benchMakeTargetsTemplate,
+		Help:    benchMakeHelpTemplate,
+	},
+	{
+		Enabled: func(cfg *config.ProjectConfig) bool { return cfg.UseAPIDiff },
+		Targets: apidiffMakeTargetsTemplate,
+		Help:    apidiffMakeHelpTemplate,
+	},
+	{
+		Enabled: func(cfg *config.ProjectConfig) bool { return cfg.UseChecks },
+		Phony:   checksMakePhony,
+		Targets: checksMakeTargetsTemplate,
+		Help:    checksMakeHelpTemplate,
+		Data:    checksSnippetData,
+	},
+	{Enabled: hasTestHelpers, Phony: " test-unit", Targets: testMakeTargetsTemplate, Help: testMakeHelpTemplate},
+	// Event-driven projects have a test-integration target of their own
+	{
+		Enabled: func(cfg *config.ProjectConfig) bool {
+			return hasTestHelpers(cfg) && cfg.Type != config.TypeEventDriven
+		},
+		Phony: " test-integration",
+	},
+	{Enabled: hasExamples, Phony: " examples", Targets: examplesMakeTargetsTemplate, Help: examplesMakeHelpTemplate},
+	{
+		Enabled: func(cfg *config.ProjectConfig) bool { return cfg.UseADR },
+		Targets: adrMakeTargetsTemplate,
+		Help:    adrMakeHelpTemplate,
+	},
+	{Enabled: hasDocsSite, Targets: docsMakeTargetsTemplate, Help: docsMakeHelpTemplate},
+	{Enabled: hasSecrets, Targets: secretsMakeTargetsTemplate, Help: secretsMakeHelpTemplate, Data: secretsSnippetData},
+	{
+		Enabled: func(cfg *config.ProjectConfig) bool {
+			return hasFeatureFlags(cfg) && cfg.FeatureFlags == config.FeatureFlagsFlagd
+		},
+		Targets: flagdMakeTargetsTemplate,
+		Help:    flagdMakeHelpTemplate,
+	},
+	{
+		Enabled: hasLiveReload,
+		Phony:   " dev",
+		Targets: liveReloadMakeTargetsTemplate,
+		Help:    liveReloadMakeHelpTemplate,
+		Data:    func(cfg *config.ProjectConfig) interface{} { return liveReloadData{AirVersion: airVersion} },
+	},
+	{Enabled: hasPprof, Targets: pprofMakeTargetsTemplate, Help: pprofMakeHelpTemplate, Data: pprofSnippetData},
+	{
+		Enabled: func(cfg *config.ProjectConfig) bool {
+			return hasTaskQueue(cfg) && cfg.TaskQueue == config.TaskQueueAsynq
+		},
+		Targets: redisMakeTargetsTemplate,
+		Help:    redisMakeHelpTemplate,
+	},
+	{
+		Enabled: hasDocker,
+		Phony:   " docker-build docker-push",
+		Targets: dockerMakeTargetsTemplate,
+		Help:    dockerMakeHelpTemplate,
+		Data:    dockerSnippetData,
+	},
+	{Enabled: hasPlugins, Phony: " plugins", Targets: pluginsMakeTargetsTemplate, Help: pluginsMakeHelpTemplate},
+	{
+		Enabled: ofType(config.TypeOperator),
+		Phony:   operatorMakePhony,
+		Targets: operatorMakeTargetsTemplate,
+		Help:    operatorMakeHelpTemplate,
+		Data:    operatorSnippetData,
+	},
+	{Enabled: ofType(config.TypeGRPC), Phony: grpcMakePhony, Targets: grpcMakeTargetsTemplate, Help: grpcMakeHelpTemplate},
+	{
+		Enabled: ofType(config.TypeEventDriven),
+		Phony:   eventMakePhony,
+		Targets: eventMakeTargetsTemplate,
+		Help:    eventMakeHelpTemplate,
+		Data:    eventSnippetData,
+	},
+	{Enabled: ofType(config.TypeBatch), Phony: batchMakePhony, Targets: batchMakeTargetsTemplate, Help: batchMakeHelpTemplate},
+	{
+		Enabled: ofType(config.TypeCrawler),
+		Phony:   crawlerMakePhony,
+		Targets: crawlerMakeTargetsTemplate,
+		Help:    crawlerMakeHelpTemplate,
+	},
+	{Enabled: ofType(config.TypeWorker), Phony: workerMakePhony, Targets: workerMakeTargetsTemplate, Help: workerMakeHelpTemplate},
+	{Enabled: ofType(config.TypeTUI), Phony: tuiMakePhony, Targets: tuiMakeTargetsTemplate, Help: tuiMakeHelpTemplate},
+	{Enabled: ofType(config.TypeSDK), Phony: sdkMakePhony, Targets: sdkMakeTargetsTemplate, Help: sdkMakeHelpTemplate},
+}
+
+// readmeSections are the README sections of the features and project types,
+// in order
+var readmeSections = []snippet{
+	{Enabled: (*config.ProjectConfig).IsInternal, Text: readmeInternalTemplate, Data: goPrivateSnippetData},
+	{
+		Enabled: hasPackageDocs,
+		Text:    readmeDocumentationTemplate,
+		Data:    func(cfg *config.ProjectConfig) interface{} { return newPackageDocsData(cfg) },
+	},
+	{
+		Enabled: ofType(config.TypeGitHubAction),
+		Text:    actionReadmeTemplate,
+		Data:    func(cfg *config.ProjectConfig) interface{} { return newActionData(cfg) },
+	},
+	{Enabled: ofType(config.TypeOperator), Text: operatorReadmeTemplate, Data: operatorSnippetData},
+	{Enabled: ofType(config.TypeGRPC), Text: grpcReadmeTemplate, Data: grpcSnippetData},
+	{Enabled: ofType(config.TypeEventDriven), Text: eventReadmeTemplate, Data: eventSnippetData},
+	{Enabled: ofType(config.TypeBatch), Text: batchReadmeTemplate},
+	{Enabled: ofType(config.TypeCrawler), Text: crawlerReadmeTemplate},
+	{Enabled: ofType(config.TypeWorker), Text: workerReadmeTemplate},
+	{Enabled: ofType(config.TypeTUI), Text: tuiReadmeTemplate},
+	{Enabled: ofType(config.TypeSDK), Text: sdkReadmeTemplate, Data: sdkReadmeData},
+	{Enabled: ofType(config.TypeScript), Text: scriptReadmeTemplate},
+}
+
+// The other parts of the README. Projects without a binary, such as
+// libraries, are installed with go get instead of built.
+var (
+	readmeLibraryInstall = []snippet{{
+		Enabled: func(cfg *config.ProjectConfig) bool { return mainPackage(cfg) == "" },
+		Text:    readmeLibraryInstallTemplate,
+	}}
+	readmeOwnership   = []snippet{{Enabled: hasOwnership, Text: readmeOwnershipTemplate}}
+	readmeMaintainers = []snippet{{
+		Enabled: func(cfg *config.ProjectConfig) bool { return len(cfg.Authors) > 0 },
+		Text:    readmeMaintainersTemplate,
+	}}
+)
+
+// gitignoreSnippets list the files the features generate or fetch that git
+// ignores: the built documentation site, decrypted or rendered secrets, the
+// builds of air, and captured profiles
+var gitignoreSnippets = []snippet{
+	{Enabled: hasDocsSite, Text: docsIgnoreTemplate},
+	{Enabled: hasSecrets, Text: secretsIgnoreTemplate},
+	{Enabled: hasLiveReload, Text: liveReloadIgnoreTemplate},
+	{Enabled: hasPprof, Text: pprofIgnoreTemplate},
+}
+
+// The parts of the CI workflow that the features add: the GOPRIVATE of
+// internal projects, the Postgres service of event-driven projects, the check
+// of the code generated from the protos of gRPC services, and the steps after
+// the tests
+var (
+	ciGoPrivate  = []snippet{{Enabled: hasGoPrivate, Text: ciGoPrivateTemplate, Data: goPrivateSnippetData}}
+	ciServices   = []snippet{{Enabled: ofType(config.TypeEventDriven), Text: eventCIServicesTemplate, Data: eventSnippetData}}
+	ciProtoCheck = []snippet{{Enabled: ofType(config.TypeGRPC), Text: grpcCIStepTemplate}}
+	ciSteps      = []snippet{
+		{Enabled: func(cfg *config.ProjectConfig) bool { return cfg.UseChecks }, Text: checksCIStepTemplate, Data: checksSnippetData},
+		{Enabled: hasTestHelpers, Text: testCIStepTemplate},
+		{
+			Enabled: func(cfg *config.ProjectConfig) bool { return cfg.SecretsManager == config.SecretsManagerSops },
+			Text:    sopsCIStepTemplate,
+		},
+	}
+)
+
 // generateRootFiles creates the basic files at the project root
 func generateRootFiles(cfg *config.ProjectConfig, projectDir string) error {
-	// Generate config file
-	if err := generateConfigFile(cfg, projectDir); err != nil {
-		return fmt.Errorf("failed to generate config file: %w", err)
-	}
+	data := newProjectData(cfg)
 
 	// Generate README.md
 	if cfg.CreateReadme {
 		readmePath := filepath.Join(projectDir, "README.md")
 
-		// Fix: Split the string format to avoid backtick issues
-		readmeContent := fmt.Sprintf("# %s\n\n%s\n\n## Overview\n\nTODO: Add project overview\n\n## Installation\n\n### Prerequisites\n\n- Go 1.16 or later\n\n### Building from Source\n\n", cfg.Name, cfg.Description)
-
-		// Add code block separately to avoid backtick issues
-		readmeContent += "```bash\n"
-		readmeContent += fmt.Sprintf("# Clone the repository\ngit clone %s.git\ncd %s\n\n# Build the binary\ngo build -o bin/%s\n\n# Run tests\ngo test ./...\n", cfg.Module, cfg.Name, strings.ToLower(cfg.Name))
-		readmeContent += "```\n\n"
-
-		if cfg.CreateMakefile {
-			readmeContent += "## Using Make\n\nThe project includes a Makefile to simplify common tasks:\n\n```bash\n"
-			readmeContent += "# Build the binary\nmake build\n\n# Run tests\nmake test\n\n# Clean build artifacts\nmake clean\n"
-			readmeContent += "```\n\nFor more details, run `make help` to see all available commands.\n"
+		// The badges are on consecutive lines, so they render as one row
+		data.Badges = readmeCIBadge(cfg)
+		if hasPublicBadges(cfg) {
+			badges, err := templates.Render("README.md", readmeBadgesTemplate, data)
+			if err != nil {
+				return err
+			}
+			data.Badges += badges
+		} else if data.Badges != "" {
+			data.Badges += "\n"
+		}
+		if len(cfg.Topics) > 0 {
+			data.TopicBadges = readmeTopics(cfg)
+		}
+		// Projects without a binary, such as libraries, are installed with
+		// go get instead of built
+		data.HasBinary = mainPackage(cfg) != ""
+		data.LiveReload = hasLiveReload(cfg)
+		err := renderSnippetFields(cfg, "README.md",
+			snippetField{&data.Sections, readmeSections},
+			snippetField{&data.LibraryInstall, readmeLibraryInstall},
+			snippetField{&data.Ownership, readmeOwnership},
+			snippetField{&data.Maintainers, readmeMaintainers})
+		if err != nil {
+			return err
 		}
 
+		readmeContent, err := templates.Render("README.md", readmeTemplate, data)
+		if err != nil {
+			return err
+		}
 		if err := os.WriteFile(readmePath, []byte(readmeContent), 0600); err != nil {
 			return err
 		}
 	}
 
-	// Generate LICENSE
-	if cfg.CreateLicense && cfg.License != "None" {
+	// Generate LICENSE. The MIT license is written in full, other licenses
+	// are referenced.
+	if hasLicense(cfg) {
 		licensePath := filepath.Join(projectDir, "LICENSE")
-		year := time.Now().Year()
-
-		var licenseContent string
-		switch cfg.License {
-		case "MIT":
-			licenseContent = fmt.Sprintf("MIT License\n\nCopyright (c) %d %s\n\n"+
-				"Permission is hereby granted, free of charge, to any person obtaining a copy\n"+
-				"of this software and associated documentation files (the \"Software\"), to deal\n"+
-				"in the Software without restriction, including without limitation the rights\n"+
-				"to use, copy, modify, merge, publish, distribute, sublicense, and/or sell\n"+
-				"copies of the Software, and to permit persons to whom the Software is\n"+
-				"furnished to do so, subject to the following conditions:\n\n"+
-				"The above copyright notice and this permission notice shall be included in all\n"+
-				"copies or substantial portions of the Software.\n\n"+
-				"THE SOFTWARE IS PROVIDED \"AS IS\", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR\n"+
-				"IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,\n"+
-				"FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE\n"+
-				"AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER\n"+
-				"LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,\n"+
-				"OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE\n"+
-				"SOFTWARE.\n", year, cfg.Author)
-
-		// Add more license templates as needed for other license types
-		default:
-			licenseContent = fmt.Sprintf("Copyright (c) %d %s\n\n"+
-				"This project is licensed under the %s License.\n"+
-				"Please see https://choosealicense.com/licenses/ for more information.\n",
-				year, cfg.Author, cfg.License)
-		}
+		data.Year = time.Now().Year()
 
+		licenseContent, err := templates.Render("LICENSE", licenseTemplate, data)
+		if err != nil {
+			return err
+		}
 		if err := os.WriteFile(licensePath, []byte(licenseContent), 0600); err != nil {
 			return err
 		}
@@ -659,35 +921,15 @@ func generateRootFiles(cfg *config.ProjectConfig, projectDir string) error {
 
 	// Generate .gitignore
 	gitignorePath := filepath.Join(projectDir, ".gitignore")
-	gitignoreContent := "# Binaries for programs and plugins\n" +
-		"*.exe\n" +
-		"*.exe~\n" +
-		"*.dll\n" +
-		"*.so\n" +
-		"*.dylib\n" +
-		"bin/\n\n" +
-		"# Test binary, built with 'go test -c'\n" +
-		"*.test\n\n" +
-		"# Output of the go coverage tool\n" +
-		"*.out\n" +
-		"coverage.html\n\n" +
-		"# Dependency directories (remove the comment below to include it)\n" +
-		"# vendor/\n\n" +
-		"# Go workspace file\n" +
-		"go.work\n\n" +
-		"# IDE specific files\n" +
-		".idea/\n" +
-		".vscode/\n" +
-		"*.swp\n" +
-		"*.swo\n\n" +
-		"# OS specific files\n" +
-		".DS_Store\n" +
-		".DS_Store?\n" +
-		"._*\n" +
-		".Spotlight-V100\n" +
-		".Trashes\n" +
-		"ehthumbs.db\n" +
-		"Thumbs.db\n"
+	gitignoreExtras, err := renderSnippets(cfg, ".gitignore", gitignoreSnippets)
+	if err != nil {
+		return err
+	}
+	data.GitignoreExtras = gitignoreExtras
+	gitignoreContent, err := templates.Render(".gitignore", gitignoreTemplate, data)
+	if err != nil {
+		return err
+	}
 
 	if err := os.WriteFile(gitignorePath, []byte(gitignoreContent), 0600); err != nil {
 		return err
@@ -695,79 +937,35 @@ func generateRootFiles(cfg *config.ProjectConfig, projectDir string) error {
 
 	// Generate Makefile
 	if cfg.CreateMakefile {
+		// Targets named like a directory must be phony to run
+		phony, extraTargets, extraHelp, err := renderMakeSnippets(cfg, "all build clean test", makeSnippets)
+		if err != nil {
+			return err
+		}
+
 		makefilePath := filepath.Join(projectDir, "Makefile")
-		makefileContent := fmt.Sprintf(".PHONY: all build clean test\n\n"+
-			"# Binary name\n"+
-			"BINARY_NAME=%s\n"+
-			"# Binary directory\n"+
-			"BIN_DIR=./bin\n\n"+
-			"# Go commands\n"+
-			"GO ?= go\n"+
-			"GOBUILD = $(GO) build\n"+
-			"GOCLEAN = $(GO) clean\n"+
-			"GOTEST = $(GO) test\n"+
-			"GOGET = $(GO) get\n\n"+
-			"# Version info from git\n"+
-			"GIT_COMMIT=$(shell git rev-parse --short HEAD || echo \"unknown\")\n"+
-			"GIT_DIRTY=$(shell test -n \"`git status --porcelain`\" && echo \"+DIRTY\" || echo \"\")\n"+
-			"GIT_TAG=$(shell git describe --tags --abbrev=0 2>/dev/null || echo \"v0.0.0\")\n"+
-			"BUILD_DATE=$(shell date '+%%Y-%%m-%%d-%%H:%%M:%%S')\n\n"+
-			"# Get the module name from go.mod\n"+
-			"MODULE_NAME=$(shell grep \"^module\" go.mod | awk '{print $$2}')\n\n"+
-			"# Linker flags\n"+
-			"LDFLAGS=-ldflags \"-X $(MODULE_NAME)/cmd.Version=$(GIT_TAG) \\\n"+
-			"-X $(MODULE_NAME)/cmd.Commit=$(GIT_COMMIT)$(GIT_DIRTY) \\\n"+
-			"-X $(MODULE_NAME)/cmd.BuildDate=$(BUILD_DATE)\"\n\n"+
-			"# Default target (build binary)\n"+
-			"all: build\n\n"+
-			"# Build binary\n"+
-			"build:\n"+
-			"\t@echo \"Building $(BINARY_NAME)...\"\n"+
-			"\t@echo \"Git commit: $(GIT_COMMIT)$(GIT_DIRTY)\"\n"+
-			"\t@echo \"Git tag: $(GIT_TAG)\"\n"+
-			"\t@echo \"Build date: $(BUILD_DATE)\"\n"+
-			"\t@mkdir -p $(BIN_DIR)\n"+
-			"\t$(GOBUILD) $(LDFLAGS) -o $(BIN_DIR)/$(BINARY_NAME)\n"+
-			"\t@echo \"Build complete: $(BIN_DIR)/$(BINARY_NAME)\"\n\n"+
-			"# Clean build artifacts\n"+
-			"clean:\n"+
-			"\t@echo \"Cleaning...\"\n"+
-			"\t@$(GOCLEAN)\n"+
-			"\t@rm -rf $(BIN_DIR)\n"+
-			"\t@rm -f coverage.out coverage.html\n"+
-			"\t@echo \"Clean complete\"\n\n"+
-			"# Run tests\n"+
-			"test:\n"+
-			"\t@echo \"Running tests...\"\n"+
-			"\t$(GOTEST) -v ./...\n"+
-			"\t@echo \"Tests complete\"\n\n"+
-			"# Run tests with coverage\n"+
-			"test-coverage:\n"+
-			"\t@echo \"Running tests with coverage...\"\n"+
-			"\t$(GOTEST) -v ./... -coverprofile=coverage.out\n"+
-			"\t$(GO) tool cover -html=coverage.out -o coverage.html\n"+
-			"\t@echo \"Coverage report generated at coverage.html\"\n\n"+
-			"# Install dependencies\n"+
-			"deps:\n"+
-			"\t@echo \"Installing dependencies...\"\n"+
-			"\t$(GOGET) -v ./...\n"+
-			"\t@echo \"Dependencies installed\"\n\n"+
-			"# Lint the code\n"+
-			"lint:\n"+
-			"\t@echo \"Linting code...\"\n"+
-			"\tgolangci-lint run ./...\n"+
-			"\t@echo \"Lint complete\"\n\n"+
-			"# Help target\n"+
-			"help:\n"+
-			"\t@echo \"Available targets:\"\n"+
-			"\t@echo \"  all               - Default target, builds the binary\"\n"+
-			"\t@echo \"  build             - Build the binary to $(BIN_DIR)/$(BINARY_NAME)\"\n"+
-			"\t@echo \"  clean             - Clean build artifacts\"\n"+
-			"\t@echo \"  test              - Run tests\"\n"+
-			"\t@echo \"  test-coverage     - Run tests with coverage reporting\"\n"+
-			"\t@echo \"  deps              - Install dependencies\"\n"+
-			"\t@echo \"  lint              - Lint the code\"\n",
-			strings.ToLower(cfg.Name))
+		data.Phony, data.ExtraTargets, data.ExtraHelp = phony, extraTargets, extraHelp
+		// Projects without a binary, such as libraries and SDKs, compile, test,
+		// and lint their packages and serve their documentation with pkgsite
+		makefile := libraryMakefileTemplate
+		if mainPackage(cfg) != "" {
+			data.VersionExpr, data.VersionPackage = makefileVersion(cfg), versionPackage(cfg)
+			makefile = makefileTemplate
+		}
+		makefileContent, err := templates.Render("Makefile", makefile, data)
+		if err != nil {
+			return err
+		}
+
+		if hasGoPrivate(cfg) {
+			goPrivate, err := templates.Render("Makefile", makefileGoPrivateTemplate, newGoPrivateData(cfg))
+			if err != nil {
+				return err
+			}
+			// Set GOPRIVATE after the .PHONY line, before the targets
+			i := strings.Index(makefileContent, "\n\n") + 2
+			makefileContent = makefileContent[:i] + goPrivate + makefileContent[i:]
+		}
 
 		if err := os.WriteFile(makefilePath, []byte(makefileContent), 0600); err != nil {
 			return err
@@ -779,23 +977,44 @@ func generateRootFiles(cfg *config.ProjectConfig, projectDir string) error {
 
 // generateGoMod creates the go.mod file
 func generateGoMod(cfg *config.ProjectConfig, projectDir string) error {
-	goModPath := filepath.Join(projectDir, "go.mod")
-	goModContent := fmt.Sprintf("module %s\n\ngo 1.19\n", cfg.Module)
+	// kubebuilder already wrote the go.mod of the operator it scaffolded
+	if scaffoldedByKubebuilder(cfg, projectDir) {
+		return nil
+	}
 
-	if cfg.UseCobra || cfg.UseViper {
-		goModContent += "\nrequire (\n"
-		if cfg.UseCobra {
-			goModContent += "\tgithub.com/spf13/cobra v1.9.1\n"
-		}
-		if cfg.UseViper {
-			goModContent += "\tgithub.com/spf13/viper v1.19.0\n"
-		}
-		goModContent += ")\n"
+	// Require the modules of the enabled features, so go.mod matches the
+	// generated code before go mod tidy adds the indirect dependencies
+	data := newProjectData(cfg)
+	data.AuthorList = config.FormatAuthors(cfg.Authors)
+	data.GoVersion = goVersion(cfg)
+	data.Requires = Dependencies(cfg)
+
+	goModPath := filepath.Join(projectDir, "go.mod")
+	goModContent, err := templates.Render("go.mod", goModTemplate, data)
+	if err != nil {
+		return err
 	}
 
 	return os.WriteFile(goModPath, []byte(goModContent), 0600)
 }
 
+// branchesFilter returns the branches filter of a workflow trigger, which runs
+// it for the default branch only
+func branchesFilter(cfg *config.ProjectConfig) string {
+	return "    branches: [ " + cfg.Branch() + " ]\n"
+}
+
+// readmeCIBadge returns the status badge of the CI workflow on the default
+// branch of projects hosted on GitHub, on its own line, or nothing
+func readmeCIBadge(cfg *config.ProjectConfig) string {
+	elems := strings.Split(cfg.Module, "/")
+	if !cfg.UseGitHubActions || len(elems) < 3 || elems[0] != "github.com" {
+		return ""
+	}
+	workflow := fmt.Sprintf("https://github.com/%s/%s/actions/workflows/ci.yml", elems[1], elems[2])
+	return fmt.Sprintf("[![CI](%[1]s/badge.svg?branch=%[2]s)](%[1]s?query=branch%%3A%[2]s)\n", workflow, url.QueryEscape(cfg.Branch()))
+}
+
 // generateGitHubWorkflows creates GitHub Actions workflow files
 func generateGitHubWorkflows(cfg *config.ProjectConfig, projectDir string) error {
 	workflowDir := filepath.Join(projectDir, ".github", "workflows")
@@ -806,50 +1025,68 @@ func generateGitHubWorkflows(cfg *config.ProjectConfig, projectDir string) error
 	}
 
 	// CI workflow
+	data := newProjectData(cfg)
+	setupGo, err := setupGoStep(cfg)
+	if err != nil {
+		return err
+	}
+	data.SetupGo = setupGo
+	data.Examples = hasExamples(cfg)
+	err = renderSnippetFields(cfg, "ci.yml",
+		snippetField{&data.GoPrivate, ciGoPrivate},
+		snippetField{&data.Services, ciServices},
+		snippetField{&data.ProtoCheck, ciProtoCheck},
+		snippetField{&data.ExtraSteps, ciSteps})
+	if err != nil {
+		return err
+	}
+
 	ciWorkflowPath := filepath.Join(workflowDir, "ci.yml")
-	ciWorkflowContent := "name: CI\n\n" +
-		"on:\n" +
-		"  push:\n" +
-		"    branches: [ main ]\n" +
-		"  pull_request:\n" +
-		"    branches: [ main ]\n\n" +
-		"jobs:\n" +
-		"  build:\n" +
-		"    runs-on: ubuntu-latest\n" +
-		"    steps:\n" +
-		"    - uses: actions/checkout@v3\n\n" +
-		"    - name: Set up Go\n" +
-		"      uses: actions/setup-go@v4\n" +
-		"      with:\n" +
-		"        go-version: '1.19'\n\n" +
-		"    - name: Build\n" +
-		"      run: go build -v ./...\n\n" +
-		"    - name: Test\n" +
-		"      run: go test -v ./...\n"
+	ciWorkflowContent, err := templates.Render("ci.yml", ciWorkflowTemplate, data)
+	if err != nil {
+		return err
+	}
 
 	if err := os.WriteFile(ciWorkflowPath, []byte(ciWorkflowContent), 0600); err != nil {
 		return err
 	}
 
+	// Benchmark comparison workflow
+	if cfg.UseBenchmarks {
+		if err := generateBenchmarkWorkflow(cfg, projectDir); err != nil {
+			return err
+		}
+	}
+
+	// Documentation site deploy workflow
+	if hasDocsSite(cfg) {
+		if err := generateDocsWorkflow(cfg, projectDir); err != nil {
+			return err
+		}
+	}
+
+	// API compatibility workflow
+	if cfg.UseAPIDiff {
+		if err := generateAPIDiffWorkflow(cfg, projectDir); err != nil {
+			return err
+		}
+	}
+
+	// Release workflow of SDKs
+	if cfg.Type == config.TypeSDK {
+		if err := generateSDKReleaseWorkflow(cfg, projectDir); err != nil {
+			return err
+		}
+	}
+
 	// Lint workflow
 	if cfg.UseLinters {
+		data.GolangciLintVersion = golangciLintVersion(cfg)
 		lintWorkflowPath := filepath.Join(workflowDir, "lint.yml")
-		lintWorkflowContent := "name: Lint\n\n" +
-			"on:\n" +
-			"  push:\n" +
-			"    branches: [ main ]\n" +
-			"  pull_request:\n" +
-			"    branches: [ main ]\n\n" +
-			"jobs:\n" +
-			"  golangci:\n" +
-			"    name: lint\n" +
-			"    runs-on: ubuntu-latest\n" +
-			"    steps:\n" +
-			"      - uses: actions/checkout@v3\n" +
-			"      - name: golangci-lint\n" +
-			"        uses: golangci/golangci-lint-action@v3\n" +
-			"        with:\n" +
-			"          version: latest\n"
+		lintWorkflowContent, err := templates.Render("lint.yml", lintWorkflowTemplate, data)
+		if err != nil {
+			return err
+		}
 
 		if err := os.WriteFile(lintWorkflowPath, []byte(lintWorkflowContent), 0600); err != nil {
 			return err
@@ -862,110 +1099,44 @@ func generateGitHubWorkflows(cfg *config.ProjectConfig, projectDir string) error
 // generateLinterConfig creates the golangci-lint configuration
 func generateLinterConfig(cfg *config.ProjectConfig, projectDir string) error {
 	linterConfigPath := filepath.Join(projectDir, ".golangci.yml")
-	linterConfigContent := "run:\n" +
-		"  timeout: 5m\n" +
-		"linters:\n" +
-		"  disable-all: true\n" +
-		"  enable:\n" +
-		"    - errcheck\n" +
-		"    - gosimple\n" +
-		"    - govet\n" +
-		"    - ineffassign\n" +
-		"    - staticcheck\n" +
-		"    - unused\n" +
-		"    - gofmt\n" +
-		"    - goimports\n" +
-		"    - gosec\n" +
-		"    - misspell\n" +
-		"    - revive\n" +
-		"    - unused\n" +
-		"    - whitespace\n" +
-		"linters-settings:\n" +
-		"  goimports:\n" +
-		"    local-prefixes: " + cfg.Module + "\n" +
-		"issues:\n" +
-		"  exclude-rules:\n" +
-		"    - path: _test\\.go\n" +
-		"      linters:\n" +
-		"        - gosec\n"
+	data := newProjectData(cfg)
+	data.Formatter, data.StrictLint = formatter(cfg), strictLint(cfg)
+	linterConfigContent, err := templates.Render(".golangci.yml", golangciTemplate, data)
+	if err != nil {
+		return err
+	}
 
 	return os.WriteFile(linterConfigPath, []byte(linterConfigContent), 0600)
 }
 
-// generatePreCommitConfig creates the pre-commit hooks configuration
+// generatePreCommitConfig creates the pre-commit hooks configuration, with
+// hooks for the enabled features and one named after the project that builds
+// it
 func generatePreCommitConfig(cfg *config.ProjectConfig, projectDir string) error {
-	preCommitConfigPath := filepath.Join(projectDir, ".pre-commit-config.yaml")
-
-	// Create a custom hook name based on the project name
-	customHookName := strings.ToLower(cfg.Name) + "-build"
-
-	preCommitConfigContent := "repos:\n" +
-		"  - repo: https://github.com/pre-commit/pre-commit-hooks\n" +
-		"    rev: v4.5.0\n" +
-		"    hooks:\n" +
-		"      - id: trailing-whitespace\n" +
-		"      - id: end-of-file-fixer\n" +
-		"      - id: check-yaml\n" +
-		"      - id: check-added-large-files\n" +
-		"      - id: check-json\n" +
-		"      - id: check-merge-conflict\n" +
-		"  # Commit message validation for conventional commits\n" +
-		"  - repo: https://github.com/compilerla/conventional-pre-commit\n" +
-		"    rev: v2.1.1\n" +
-		"    hooks:\n" +
-		"      - id: conventional-pre-commit\n" +
-		"        stages: [commit-msg]\n" +
-		"        args: [] # Add custom args here if needed\n" +
-		"  # Primary Go linting and formatting\n" +
-		"  - repo: https://github.com/golangci/golangci-lint\n" +
-		"    rev: v1.64.5\n" +
-		"    hooks:\n" +
-		"      - id: golangci-lint\n" +
-		"        args: [--timeout=5m]\n" +
-		"  # Additional Go tools\n" +
-		"  - repo: https://github.com/dnephin/pre-commit-golang\n" +
-		"    rev: v0.5.1\n" +
-		"    hooks:\n" +
-		"      - id: go-fmt\n" +
-		"      - id: go-mod-tidy\n" +
-		"      - id: go-unit-tests\n" +
-		"  # Local hooks\n" +
-		"  - repo: local\n" +
-		"    hooks:\n" +
-		"      - id: " + customHookName + "\n" +
-		"        name: " + customHookName + "\n" +
-		"        entry: bash -c 'go build ./...'\n" +
-		"        language: system\n" +
-		"        pass_filenames: false\n" +
-		"        description: Run go build on all packages\n"
-
-	// Also create a .commitlintrc.yaml file for additional configuration
-	commitlintPath := filepath.Join(projectDir, ".commitlintrc.yaml")
-	commitlintContent := "# .commitlintrc.yaml\n" +
-		"extends:\n" +
-		"  - conventional\n" +
-		"rules:\n" +
-		"  header-max-length: [2, always, 100]\n" +
-		"  body-max-line-length: [2, always, 100]\n" +
-		"  type-enum:\n" +
-		"    - 2\n" +
-		"    - always\n" +
-		"    - - feat     # A new feature\n" +
-		"      - fix      # A bug fix\n" +
-		"      - docs     # Documentation only changes\n" +
-		"      - style    # Changes that do not affect the meaning of the code\n" +
-		"      - refactor # A code change that neither fixes a bug nor adds a feature\n" +
-		"      - perf     # A code change that improves performance\n" +
-		"      - test     # Adding missing tests or correcting existing tests\n" +
-		"      - build    # Changes that affect the build system or external dependencies\n" +
-		"      - ci       # Changes to CI configuration files and scripts\n" +
-		"      - chore    # Other changes that don't modify src or test files\n" +
-		"      - revert   # Reverts a previous commit\n"
+	data := newFormatData(cfg)
+	data.GolangciLintToolVersion = golangciLintToolVersion
+	data.Dockerfile = hasDocker(cfg)
+	data.Workflows = cfg.UseGitHubActions
+	data.Markdown = cfg.CreateReadme || cfg.UseDocs
 
+	preCommitConfigPath := filepath.Join(projectDir, ".pre-commit-config.yaml")
+	preCommitConfigContent, err := templates.Render(".pre-commit-config.yaml", preCommitConfigTemplate, data)
+	if err != nil {
+		return err
+	}
 	if err := os.WriteFile(preCommitConfigPath, []byte(preCommitConfigContent), 0600); err != nil {
 		return err
 	}
 
+	// The commitlint configuration goes with the commit-msg hook
+	if !cfg.ConventionalCommits() {
+		return nil
+	}
+	commitlintPath := filepath.Join(projectDir, ".commitlintrc.yaml")
+	commitlintContent, err := templates.Render(".commitlintrc.yaml", commitlintTemplate, data)
+	if err != nil {
+		return err
+	}
 	return os.WriteFile(commitlintPath, []byte(commitlintContent), 0600)
 }
 
@@ -982,21 +1153,10 @@ func generateTemplates(cfg *config.ProjectConfig, projectDir string) error {
 
 	// Create example template file
 	exampleTemplatePath := filepath.Join(templatesDir, "example.tmpl")
-	exampleTemplateContent := "# Example Template for " + cfg.Name + "\n\n" +
-		"This is an example template file that can be used with your application.\n\n" +
-		"## Usage\n\n" +
-		"```go\n" +
-		"// Example code to use this template\n" +
-		"tmpl, err := template.ParseFiles(\"templates/example.tmpl\")\n" +
-		"if err != nil {\n" +
-		"    log.Fatal(err)\n" +
-		"}\n" +
-		"data := map[string]interface{}{\n" +
-		"    \"Title\": \"My Title\",\n" +
-		"    \"Content\": \"My Content\",\n" +
-		"}\n" +
-		"err = tmpl.Execute(os.Stdout, data)\n" +
-		"```\n"
+	exampleTemplateContent, err := templates.Render("example.tmpl", exampleTemplate, cfg)
+	if err != nil {
+		return err
+	}
 
 	if err := os.WriteFile(exampleTemplatePath, []byte(exampleTemplateContent), 0600); err != nil {
 		return err
@@ -1004,3 +1164,33 @@ func generateTemplates(cfg *config.ProjectConfig, projectDir string) error {
 
 	return nil
 }
+
+var (
+	cliMainTemplate      = builtin.MustTemplate("wizard/project/cli-main.tmpl")
+	cobraRootTemplate    = builtin.MustTemplate("wizard/project/cobra-root.tmpl")
+	cobraVersionTemplate = builtin.MustTemplate("wizard/project/cobra-version.tmpl")
+	apiMainTemplate      = builtin.MustTemplate("wizard/project/api-main.tmpl")
+	apiConfigTemplate    = builtin.MustTemplate("wizard/project/api-config.tmpl")
+	ginServerTemplate    = builtin.MustTemplate("wizard/project/gin-server.tmpl")
+	libraryTemplate      = builtin.MustTemplate("wizard/project/library.tmpl")
+	libraryTestTemplate  = builtin.MustTemplate("wizard/project/library-test.tmpl")
+	defaultMainTemplate  = builtin.MustTemplate("wizard/project/default-main.tmpl")
+	readmeTemplate       = builtin.MustTemplate("wizard/project/readme.tmpl")
+	// readmeLibraryInstallTemplate is the installation and development of
+	// projects without a binary, in place of the build steps of the others.
+	// SDKs show go get in their usage section.
+	readmeLibraryInstallTemplate = builtin.MustTemplate("wizard/project/readme-library-install.tmpl")
+	// readmeMaintainersTemplate lists the authors, linking their emails
+	readmeMaintainersTemplate = builtin.MustTemplate("wizard/project/readme-maintainers.tmpl")
+	licenseTemplate           = builtin.MustTemplate("wizard/project/license.tmpl")
+	gitignoreTemplate         = builtin.MustTemplate("wizard/project/gitignore.tmpl")
+	makefileTemplate          = builtin.MustTemplate("wizard/project/makefile.tmpl")
+	libraryMakefileTemplate   = builtin.MustTemplate("wizard/project/library-makefile.tmpl")
+	goModTemplate             = builtin.MustTemplate("wizard/project/go-mod.tmpl")
+	ciWorkflowTemplate        = builtin.MustTemplate("wizard/project/ci-workflow.tmpl")
+	lintWorkflowTemplate      = builtin.MustTemplate("wizard/project/lint-workflow.tmpl")
+	golangciTemplate          = builtin.MustTemplate("wizard/project/golangci.tmpl")
+	preCommitConfigTemplate   = builtin.MustTemplate("wizard/project/pre-commit-config.tmpl")
+	commitlintTemplate        = builtin.MustTemplate("wizard/project/commitlint.tmpl")
+	exampleTemplate           = builtin.MustTemplate("wizard/project/example-template.tmpl")
+)
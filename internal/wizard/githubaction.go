@@ -0,0 +1,111 @@
+package wizard
+
+import (
+	"github.com/oculus-core/gogo/internal/templates/builtin"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// actionRuntime returns how a GitHub Action project runs, docker by default
+func actionRuntime(cfg *config.ProjectConfig) string {
+	if cfg.ActionRuntime == "" {
+		return config.ActionRuntimeDocker
+	}
+	return cfg.ActionRuntime
+}
+
+// actionRepository returns the GitHub repository of the action that users
+// reference in their workflows, such as acme/greet-action
+func actionRepository(cfg *config.ProjectConfig) string {
+	host, owner, repo := splitModule(cfg.Module)
+	if host != "github.com" || owner == "" || repo == "" {
+		return "OWNER/" + cfg.Name
+	}
+	return owner + "/" + repo
+}
+
+// actionData is the data the GitHub Action templates are rendered with
+type actionData struct {
+	projectData
+	// Description is the description of action.yml, and Repository the
+	// GitHub repository that users reference in their workflows
+	Description string
+	Repository  string
+	// Composite is set for composite actions, which run the binary of a
+	// release instead of building a Docker image
+	Composite bool
+}
+
+func newActionData(cfg *config.ProjectConfig) actionData {
+	data := actionData{
+		projectData: newProjectData(cfg),
+		Description: cfg.Description,
+		Repository:  actionRepository(cfg),
+		Composite:   actionRuntime(cfg) == config.ActionRuntimeComposite,
+	}
+	if data.Description == "" {
+		data.Description = "GitHub Action written in Go"
+	}
+	return data
+}
+
+// generateGitHubActionCode generates the action: its metadata, main package,
+// runtime files, and the release and integration test workflows
+func generateGitHubActionCode(cfg *config.ProjectConfig, projectDir string) error {
+	data := newActionData(cfg)
+	setupGo, err := setupGoStep(cfg)
+	if err != nil {
+		return err
+	}
+	data.GoVersion, data.SetupGo = goToolVersion, setupGo
+
+	runtimeFiles := dockerActionFiles
+	if data.Composite {
+		runtimeFiles = compositeActionFiles
+	}
+	files, err := renderFiles(data, actionFiles, runtimeFiles)
+	if err != nil {
+		return err
+	}
+	return writeFiles(projectDir, files)
+}
+
+var (
+	// actionFiles are the metadata of the action, its main package, which
+	// greets the name input and sets the greeting output, the package that
+	// reads inputs, sets outputs, and writes workflow commands, and the release
+	// and integration test workflows. Docker actions are tested on Linux only;
+	// composite actions run anywhere.
+	actionFiles = []struct{ path, text string }{
+		{"action.yml", actionMetadataTemplate},
+		{"main.go", actionMainTemplate},
+		{"main_test.go", actionMainTestTemplate},
+		{"internal/action/action.go", actionPackageTemplate},
+		{"internal/action/action_test.go", actionPackageTestTemplate},
+		{".github/workflows/release.yml", actionReleaseWorkflowTemplate},
+		{".github/workflows/test-action.yml", actionTestWorkflowTemplate},
+	}
+	// dockerActionFiles build the action into a small static image
+	dockerActionFiles = []struct{ path, text string }{
+		{"Dockerfile", actionDockerfileTemplate},
+		{".dockerignore", actionDockerignoreTemplate},
+	}
+	// compositeActionFiles start the binary of a composite action
+	compositeActionFiles = []struct{ path, text string }{
+		{"scripts/run-action.sh", actionRunScriptTemplate},
+	}
+)
+
+var (
+	actionMetadataTemplate        = builtin.MustTemplate("wizard/githubaction/metadata.tmpl")
+	actionMainTemplate            = builtin.MustTemplate("wizard/githubaction/main.tmpl")
+	actionMainTestTemplate        = builtin.MustTemplate("wizard/githubaction/main-test.tmpl")
+	actionPackageTemplate         = builtin.MustTemplate("wizard/githubaction/action.tmpl")
+	actionPackageTestTemplate     = builtin.MustTemplate("wizard/githubaction/action-test.tmpl")
+	actionReleaseWorkflowTemplate = builtin.MustTemplate("wizard/githubaction/release-workflow.tmpl")
+	actionTestWorkflowTemplate    = builtin.MustTemplate("wizard/githubaction/test-workflow.tmpl")
+	actionDockerfileTemplate      = builtin.MustTemplate("wizard/githubaction/dockerfile.tmpl")
+	actionDockerignoreTemplate    = builtin.MustTemplate("wizard/githubaction/dockerignore.tmpl")
+	actionRunScriptTemplate       = builtin.MustTemplate("wizard/githubaction/run-action.tmpl")
+	// actionReadmeTemplate shows how to use the action in a workflow
+	actionReadmeTemplate = builtin.MustTemplate("wizard/githubaction/readme-section.tmpl")
+)
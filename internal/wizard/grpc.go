@@ -0,0 +1,183 @@
+package wizard
+
+import (
+	"fmt"
+	"go/token"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"github.com/oculus-core/gogo/internal/templates"
+	"github.com/oculus-core/gogo/internal/templates/builtin"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// Versions of the gRPC, grpc-gateway, and OpenTelemetry dependencies of gRPC
+// services. The googleapis annotations come with grpc-gateway.
+const (
+	grpcVersion        = "v1.67.1"
+	protobufVersion    = "v1.35.1"
+	grpcGatewayVersion = "v2.22.0"
+	otelVersion        = "v1.31.0"
+	otelContribVersion = "v0.56.0"
+)
+
+// grpcNameSuffixes are left out of the proto package of a service, so
+// orders-service serves the orders.v1 package
+var grpcNameSuffixes = []string{"-service", "-svc", "-grpc", "-api"}
+
+// nonAlphanumeric matches what a proto package name cannot contain
+var nonAlphanumeric = regexp.MustCompile(`[^a-z0-9]`)
+
+// grpcData is the data the gRPC service templates are rendered with
+type grpcData struct {
+	Name   string
+	Module string
+	// Package is the proto package without its version, and the Go package
+	// of the service implementation, such as orders
+	Package string
+	// Service prefixes the name of the proto service, such as Orders for
+	// OrdersService
+	Service string
+	// CrashImport and CrashDefer report panics of main with use_crash_handler
+	CrashImport string
+	CrashDefer  string
+}
+
+func newGRPCData(cfg *config.ProjectConfig) grpcData {
+	name := strings.ToLower(cfg.Name)
+	for _, suffix := range grpcNameSuffixes {
+		name = strings.TrimSuffix(name, suffix)
+	}
+	pkg := nonAlphanumeric.ReplaceAllString(name, "")
+	service := templates.FuncMap()["pascalCase"].(func(string) string)(name)
+	if pkg == "" || pkg[0] < 'a' || token.IsKeyword(pkg) || service == "" {
+		pkg, service = "app", "App"
+	}
+	return grpcData{
+		Name:        cfg.Name,
+		Module:      cfg.Module,
+		Package:     pkg,
+		Service:     service,
+		CrashImport: crashImport(cfg),
+		CrashDefer:  crashDefer(cfg),
+	}
+}
+
+// grpcProtoFile returns the path of the proto file of the service
+func grpcProtoFile(cfg *config.ProjectConfig) string {
+	pkg := newGRPCData(cfg).Package
+	return protoRoot + "/" + pkg + "/v1/" + pkg + ".proto"
+}
+
+// protoRoot is the buf module that holds the .proto files, as in gogo add proto
+const protoRoot = "api/proto"
+
+// grpcDependencies lists the direct dependencies of gRPC services
+var grpcDependencies = []Dependency{
+	{"github.com/grpc-ecosystem/grpc-gateway/v2", grpcGatewayVersion},
+	{"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc", otelContribVersion},
+	{"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp", otelContribVersion},
+	{"go.opentelemetry.io/otel", otelVersion},
+	{"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc", otelVersion},
+	{"go.opentelemetry.io/otel/sdk", otelVersion},
+	{"go.opentelemetry.io/otel/trace", otelVersion},
+	{"google.golang.org/grpc", grpcVersion},
+	{"google.golang.org/protobuf", protobufVersion},
+}
+
+// grpcMakePhony lists the gRPC targets that are not files
+const grpcMakePhony = " proto proto-breaking run"
+
+// grpcFiles maps the paths of the gRPC service files to their templates
+var grpcFiles = []struct{ path, text string }{
+	{protoRoot + "/{{ .Package }}/v1/{{ .Package }}.proto", grpcProtoTemplate},
+	{"buf.yaml", grpcBufTemplate},
+	{"buf.gen.yaml", grpcBufGenTemplate},
+	{"api/openapi/openapi.go", grpcOpenAPITemplate},
+	{"cmd/{{ .Name }}/main.go", grpcMainTemplate},
+	{"internal/config/config.go", grpcConfigTemplate},
+	{"internal/rpc/{{ .Package }}/server.go", grpcServiceTemplate},
+	{"internal/rpc/{{ .Package }}/server_test.go", grpcServiceTestTemplate},
+	{"internal/gateway/gateway.go", grpcGatewayTemplate},
+	{"internal/middleware/middleware.go", grpcMiddlewareTemplate},
+	{"internal/middleware/middleware_test.go", grpcMiddlewareTestTemplate},
+	{"internal/telemetry/telemetry.go", grpcTelemetryTemplate},
+	{"internal/server/server.go", grpcServerTemplate},
+	{"internal/server/server_test.go", grpcServerTestTemplate},
+	{"pkg/client/client.go", grpcClientTemplate},
+	{"pkg/client/{{ .Package }}.go", grpcTypedClientTemplate},
+	{"pkg/client/client_test.go", grpcClientTestTemplate},
+}
+
+// generateGRPCCode generates a service that serves its protos over gRPC and,
+// through grpc-gateway, as a REST API with an OpenAPI description. When the
+// options run the tools and buf is installed, it generates the Go code from
+// the protos right away.
+func generateGRPCCode(cfg *config.ProjectConfig, projectDir string, opts Options) error {
+	data := newGRPCData(cfg)
+	files := make(map[string]string, len(grpcFiles))
+	for _, f := range grpcFiles {
+		path, err := templates.Render("path", f.path, data)
+		if err != nil {
+			return err
+		}
+		content, err := templates.Render(path, f.text, data)
+		if err != nil {
+			return err
+		}
+		files[path] = content
+	}
+	if err := writeFiles(projectDir, files); err != nil {
+		return err
+	}
+
+	if !opts.RunTools {
+		fmt.Fprintln(warnings, "Run make proto to generate the code from the protos")
+		return nil
+	}
+	buf, err := lookPath("buf")
+	if err != nil {
+		fmt.Fprintln(warnings, "Warning: buf not found in PATH, run make proto to generate the code from the protos")
+		return nil
+	}
+	// The remote plugins need the network, so a failure leaves the project
+	// to make proto instead of failing it
+	for _, args := range [][]string{{"dep", "update"}, {"generate"}} {
+		cmd := exec.Command(buf, args...)
+		cmd.Dir = projectDir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			fmt.Fprintf(warnings, "Warning: buf %s failed, run make proto to generate the code from the protos: %v\n%s", strings.Join(args, " "), err, out)
+			return nil
+		}
+	}
+	return nil
+}
+
+var (
+	grpcProtoTemplate          = builtin.MustTemplate("wizard/grpc/proto.tmpl")
+	grpcBufTemplate            = builtin.MustTemplate("wizard/grpc/buf.tmpl")
+	grpcBufGenTemplate         = builtin.MustTemplate("wizard/grpc/buf-gen.tmpl")
+	grpcOpenAPITemplate        = builtin.MustTemplate("wizard/grpc/open-api.tmpl")
+	grpcMainTemplate           = builtin.MustTemplate("wizard/grpc/main.tmpl")
+	grpcConfigTemplate         = builtin.MustTemplate("wizard/grpc/config.tmpl")
+	grpcServiceTemplate        = builtin.MustTemplate("wizard/grpc/service.tmpl")
+	grpcServiceTestTemplate    = builtin.MustTemplate("wizard/grpc/service-test.tmpl")
+	grpcGatewayTemplate        = builtin.MustTemplate("wizard/grpc/gateway.tmpl")
+	grpcMiddlewareTemplate     = builtin.MustTemplate("wizard/grpc/middleware.tmpl")
+	grpcMiddlewareTestTemplate = builtin.MustTemplate("wizard/grpc/middleware-test.tmpl")
+	grpcTelemetryTemplate      = builtin.MustTemplate("wizard/grpc/telemetry.tmpl")
+	grpcServerTemplate         = builtin.MustTemplate("wizard/grpc/server.tmpl")
+	grpcServerTestTemplate     = builtin.MustTemplate("wizard/grpc/server-test.tmpl")
+	grpcClientTemplate         = builtin.MustTemplate("wizard/grpc/client.tmpl")
+	grpcTypedClientTemplate    = builtin.MustTemplate("wizard/grpc/typed-client.tmpl")
+	grpcClientTestTemplate     = builtin.MustTemplate("wizard/grpc/client-test.tmpl")
+	// grpcMakeTargetsTemplate has the targets that generate the code from the
+	// protos and run the service, grpcCIStepTemplate the CI steps that check
+	// the generated code is up to date, and grpcReadmeTemplate explains how to
+	// generate the code and call the service
+	grpcMakeTargetsTemplate = builtin.MustTemplate("wizard/grpc/make-targets.tmpl")
+	grpcMakeHelpTemplate    = builtin.MustTemplate("wizard/grpc/make-help.tmpl")
+	grpcCIStepTemplate      = builtin.MustTemplate("wizard/grpc/ci-step.tmpl")
+	grpcReadmeTemplate      = builtin.MustTemplate("wizard/grpc/readme-section.tmpl")
+)
@@ -0,0 +1,180 @@
+package wizard
+
+import (
+	"strings"
+)
+
+// fieldHelp explains the wizard question for each config field. Survey shows
+// it when the user types ? at the prompt; multi-select prompts list the help
+// of each of their options.
+var fieldHelp = map[string]string{
+	"name": "The name of the binary, of cmd/<name>, and of the project directory. Use letters,\n" +
+		"digits, hyphens, and underscores.",
+	"module": "The Go module path in go.mod, usually the repository URL without the scheme, such\n" +
+		"as github.com/acme/todo. Imports of the generated packages start with it.",
+	"description": "One line describing the project, used in the README, the package docs, the help\n" +
+		"of the command, the log of the API server, and the catalog entry.",
+	"topics": "Keywords that classify the project, one per line, like the topics of a GitHub\n" +
+		"repository. They are shown as badges in the README and tag the catalog entry.",
+	"authors": "The copyright holders in the LICENSE file and the maintainers in the README,\n" +
+		"as Name or Name <email> separated by commas. Their emails are owners in CODEOWNERS.\n" +
+		"Defaults to the user.name and user.email of git.",
+	"visibility": "public for open source projects. internal projects are only used inside the\n" +
+		"organization: they get no LICENSE or pkg.go.dev badges, and GOPRIVATE is set for\n" +
+		"their module in the Makefile and CI.",
+	"default_branch": "The branch the workflows run on, the README badge shows, and git init creates,\n" +
+		"such as main or trunk.",
+	"license": "The LICENSE file to generate. None generates no LICENSE file.",
+	"type": "What the project builds. The type sets the defaults of the next questions and\n" +
+		"the code generated in cmd/ and internal/.",
+
+	"operator_group": "The API group of the custom resource, such as apps.example.com. Resources are\n" +
+		"addressed as <kind>.<group> by kubectl.",
+	"operator_kind": "The kind of the custom resource, in PascalCase, such as Backup. The controller\n" +
+		"reconciles resources of this kind.",
+	"use_kubebuilder": "Run kubebuilder init and create api when kubebuilder is installed, instead of\n" +
+		"the built-in templates, which need no tools installed.",
+	"action_runtime": "How GitHub runs the action. Docker actions run a container built from the\n" +
+		"Dockerfile on Linux runners; composite actions download a release binary and run on\n" +
+		"any runner.",
+	"event_broker": "The broker the outbox relay publishes events to and the consumer reads them\n" +
+		"from. compose.yaml runs it next to Postgres for development.",
+	"openapi_spec": "The path of an OpenAPI 3 document, in YAML or JSON. A method is generated for\n" +
+		"each of its operations.",
+	"packages": "The packages of the library, as directories separated by commas. . is the root\n" +
+		"package; directories below internal/ cannot be imported by users.",
+
+	"use_cmd": "cmd/<name> holds the main package of the binary, keeping the root of the\n" +
+		"repository free for the packages.",
+	"use_internal": "Packages in internal/ can only be imported by this module; the Go toolchain\n" +
+		"enforces it. Put code here unless other modules need to import it.",
+	"use_pkg": "Packages in pkg/ can be imported by other modules, so their exported API is a\n" +
+		"promise to keep.",
+	"use_test": "test/ holds a testutil package with helpers for temporary directories and the\n" +
+		"environment, and integration tests behind the integration build tag.",
+	"use_docs": "docs/ starts with architecture, development, and deployment pages written for the\n" +
+		"project type.",
+	"use_examples": "examples/ holds runnable programs that use the project, built by CI so they\n" +
+		"keep compiling.",
+	"docs_site": "A documentation site built from docs/ with make docs-serve and make docs-build,\n" +
+		"and a workflow that deploys it to GitHub Pages.",
+	"dir_placeholder": "Git does not track empty directories. A placeholder file keeps the directories\n" +
+		"that get no generated code in the repository.",
+
+	"create_readme":   "A README with the build, test, and usage instructions of the project type.",
+	"create_license":  "A LICENSE file with the license chosen above.",
+	"create_makefile": "A Makefile with build, test, lint, and run targets; make help lists them.",
+	"create_catalog_info": "A catalog-info.yaml that registers the project in a Backstage software\n" +
+		"catalog, with its owner and lifecycle.",
+	"create_version_file": "A VERSION file with make bump-patch, bump-minor, bump-major, and tag targets,\n" +
+		"and a GoReleaser configuration that builds the release archives.",
+	"use_adr": "docs/adr holds architecture decision records, starting with the decision to\n" +
+		"record decisions, and a template for new ones.",
+
+	"team": "The team that owns the project. It owns all files in CODEOWNERS and the entry in\n" +
+		"the catalog.",
+	"slack_channel": "The channel where the owners can be reached, listed in the README and the\n" +
+		"catalog.",
+	"on_call":   "The on-call rotation or schedule URL, listed in the README and the catalog.",
+	"owner":     "The Backstage user or group that owns the catalog entry.",
+	"lifecycle": "The Backstage lifecycle of the catalog entry.",
+	"create_package_docs": "A doc.go with the package documentation, a runnable example that pkg.go.dev\n" +
+		"shows, and badges in the README.",
+
+	"use_linters": "A .golangci.yml and make lint. Requires golangci-lint to be installed.",
+	"formatting": "A make fmt target that formats the code and puts the imports of the module in a\n" +
+		"group after the standard library and third-party imports, and the same checks in\n" +
+		".golangci.yml. goimports keeps gofmt's style; gofumpt applies stricter rules and orders\n" +
+		"the import groups with gci.",
+	"lint_preset": "The linters of .golangci.yml. strict adds errorlint, exhaustive, gocritic, and other\n" +
+		"linters that catch more bugs but need more care, and formats the code with gofumpt\n" +
+		"and gci in make fmt and pre-commit.",
+	"use_checks": "make vet, make staticcheck, make govulncheck, and make checks to run all three,\n" +
+		"plus a CI step with the same checks. The tools run with go run at pinned versions, so\n" +
+		"they need not be installed; a lighter alternative to golangci-lint.",
+	"use_pre_commit_hooks": "A .pre-commit-config.yaml with hooks for the selected features: golangci-lint\n" +
+		"with the linters, unit tests with test/, hadolint with Docker, yamllint with GitHub\n" +
+		"Actions, and markdownlint with a README or docs/. Requires pre-commit (pip install\n" +
+		"pre-commit); run pre-commit install once in the clone.",
+	"commit_convention": "What the commit-msg hook checks commit messages against. conventional requires\n" +
+		"Conventional Commits, such as feat: or fix:, and adds .commitlintrc.yaml; install the\n" +
+		"hook with pre-commit install --hook-type pre-commit --hook-type commit-msg. none\n" +
+		"leaves commit messages unchecked.",
+	"use_git_hooks": "Records in gogo.yaml that the project uses Git hooks. The hooks themselves come\n" +
+		"from the pre-commit option.",
+	"tool_version_manager": "A file that pins the versions of Go and the tools the project uses, so asdf or\n" +
+		"mise installs the same versions on every machine.",
+	"secrets_manager": "How the service gets its secrets in development: files encrypted with sops and\n" +
+		"age in the repository, or templates rendered by Vault Agent.",
+	"use_live_reload": "A .air.toml and make dev, which rebuilds and restarts the server when a Go file\n" +
+		"changes. Requires air, which make dev installs.",
+	"use_pprof": "A second server on localhost that serves net/http/pprof, so CPU and memory\n" +
+		"profiles can be captured without exposing them.",
+
+	"use_cobra": "Cobra provides subcommands, flags, and help. Without it, the CLI uses the flag\n" +
+		"package and the features that need Cobra are not offered.",
+	"use_viper": "Viper reads the configuration from a file, the environment, and flags.",
+	"use_gin": "Gin provides routing and middleware. Without it, the API uses net/http and the\n" +
+		"features that need Gin are not offered.",
+	"feature_flags": "OpenFeature flags evaluated by the handlers, defined in code or served by flagd.",
+	"request_validation": "Validate request bodies against the rules in the tags of their structs and answer\n" +
+		"invalid ones with 400 and the fields at fault, with go-playground/validator or the\n" +
+		"binding of Gin. An example POST /api/v1/greetings route shows how.",
+	"pagination": "A pkg/pagination package that reads the limit, cursor or offset, sort, and filter\n" +
+		"query parameters of list endpoints, and an example GET /api/v1/books route that uses\n" +
+		"it. Cursors stay consistent while items are added; offsets allow jumping to a page.",
+	"task_queue": "Run background work outside the requests, with an example POST /api/v1/signups route\n" +
+		"that enqueues a welcome email. memory runs the tasks with a pool of workers in the\n" +
+		"process and loses the queued ones on exit; asynq keeps them in Redis and retries them.\n" +
+		"Either way, the workers drain on SIGTERM.",
+	"use_http_cache": "An internal/httpcache package that caches GET responses in memory, sets their ETag\n" +
+		"and Cache-Control headers, and answers If-None-Match requests with 304 Not Modified.\n" +
+		"An example GET /api/v1/catalog route uses it.",
+	"use_i18n": "Messages translated with golang.org/x/text and chosen by the Accept-Language\n" +
+		"header of the request.",
+	"use_multi_tenancy": "Middleware that reads the tenant ID header and a store that scopes every query\n" +
+		"by tenant.",
+	"use_plugins": "Plugins that run as separate processes and talk to the host over RPC with\n" +
+		"hashicorp/go-plugin, and a sample plugin.",
+	"use_notify":        "An internal/notify package that sends email over SMTP and Slack webhook messages.",
+	"use_config_reload": "Watch config.yaml and apply its changes without restarting the service.",
+	"use_config_command": "config get, set, and list commands that edit the config file in\n" +
+		"$XDG_CONFIG_HOME/<name>.",
+	"use_self_update": "A self-update command that replaces the binary with the latest GitHub release\n" +
+		"after checking its checksum, and a notice when a new release is out.",
+	"use_output": "A persistent --output flag and a printer for text, JSON, and YAML output.",
+	"prompt_library": "A prompt package and a setup command that asks for the settings not given as\n" +
+		"flags, and only in a terminal.",
+	"use_telemetry": "Anonymous usage events, off until the user opts in, and never sent when\n" +
+		"DO_NOT_TRACK is set.",
+	"use_crash_handler": "Recover panics in main, print a short message, and report the stack trace to\n" +
+		"Sentry or a webhook.",
+
+	"use_github_actions": "A CI workflow in .github/workflows/ci.yml that builds and tests the project on\n" +
+		"every push and pull request to main, and runs the checks of the other features,\n" +
+		"such as the integration tests and the examples.",
+	"use_benchmarks": "make bench, and a workflow that compares the benchmarks of pull requests with\n" +
+		"the base branch using benchstat.",
+	"use_apidiff": "make apidiff, and a workflow that reports breaking changes of the exported API\n" +
+		"in pull requests using gorelease.",
+	"use_docker": "A Dockerfile that caches the module downloads, builds for the platforms given\n" +
+		"to docker buildx, and labels the image with its source, version, and revision, and\n" +
+		"make docker-build and docker-push.",
+	"docker_base": "The image the binary runs in. distroless has CA certificates, time zones, and a\n" +
+		"nonroot user; scratch is empty apart from the CA certificates.",
+	"container_registry": "The registry a workflow pushes the image to for linux/amd64 and linux/arm64 on\n" +
+		"each push to the default branch and release tag, with a provenance attestation.\n" +
+		"ghcr uses the token of the workflow; ecr and gar log in through OIDC with the\n" +
+		"role or service account in the repository variables.",
+}
+
+// optionsHelp lists the help of each option of a multi-select prompt
+func optionsHelp(options []string, fields map[string]string) string {
+	var lines []string
+	for _, opt := range options {
+		if help := fieldHelp[fields[opt]]; help != "" {
+			lines = append(lines, opt+": "+strings.ReplaceAll(help, "\n", " "))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
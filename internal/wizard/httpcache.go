@@ -0,0 +1,44 @@
+package wizard
+
+import (
+	"github.com/oculus-core/gogo/internal/templates"
+	"github.com/oculus-core/gogo/internal/templates/builtin"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// hasHTTPCache reports whether the API gets internal/httpcache and the
+// example cached route
+func hasHTTPCache(cfg *config.ProjectConfig) bool {
+	return cfg.Type == config.TypeAPI && cfg.UseHTTPCache
+}
+
+// httpCacheFiles maps the paths of the HTTP cache files to their templates
+var httpCacheFiles = []struct{ path, text string }{
+	{"internal/httpcache/httpcache.go", httpCacheTemplate},
+	{"internal/httpcache/httpcache_test.go", httpCacheTestTemplate},
+	{"internal/api/cache.go", httpCacheRoutesTemplate},
+	{"internal/api/cache_test.go", httpCacheRoutesTestTemplate},
+}
+
+// generateHTTPCache creates internal/httpcache, which caches responses and
+// answers conditional requests, and the example cached route, with their
+// tests
+func generateHTTPCache(cfg *config.ProjectConfig, projectDir string) error {
+	data := newProjectData(cfg)
+	files := make(map[string]string, len(httpCacheFiles))
+	for _, f := range httpCacheFiles {
+		content, err := templates.Render(f.path, f.text, data)
+		if err != nil {
+			return err
+		}
+		files[f.path] = content
+	}
+	return writeFiles(projectDir, files)
+}
+
+var (
+	httpCacheTemplate           = builtin.MustTemplate("wizard/httpcache/httpcache.tmpl")
+	httpCacheTestTemplate       = builtin.MustTemplate("wizard/httpcache/httpcache-test.tmpl")
+	httpCacheRoutesTemplate     = builtin.MustTemplate("wizard/httpcache/routes.tmpl")
+	httpCacheRoutesTestTemplate = builtin.MustTemplate("wizard/httpcache/routes-test.tmpl")
+)
@@ -0,0 +1,39 @@
+package wizard
+
+import (
+	"github.com/oculus-core/gogo/internal/templates/builtin"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// hasI18n reports whether the project gets a message catalog, which API
+// projects with Gin and use_i18n do
+func hasI18n(cfg *config.ProjectConfig) bool {
+	return cfg.UseI18n && cfg.UseGin && cfg.Type == config.TypeAPI
+}
+
+// generateI18n creates the message catalog, its test, and the localized routes
+func generateI18n(cfg *config.ProjectConfig, projectDir string) error {
+	files, err := renderFiles(newProjectData(cfg), i18nFiles)
+	if err != nil {
+		return err
+	}
+	return writeFiles(projectDir, files)
+}
+
+// i18nFiles are the package that negotiates the language of a request and
+// formats messages from the catalog, the translations of its message keys,
+// its tests, and the middleware that localizes responses with an example
+// localized route
+var i18nFiles = []struct{ path, text string }{
+	{"internal/i18n/i18n.go", i18nTemplate},
+	{"internal/i18n/messages.go", i18nMessagesTemplate},
+	{"internal/i18n/i18n_test.go", i18nTestTemplate},
+	{"internal/api/i18n.go", i18nRoutesTemplate},
+}
+
+var (
+	i18nTemplate         = builtin.MustTemplate("wizard/i18n/i18n.tmpl")
+	i18nMessagesTemplate = builtin.MustTemplate("wizard/i18n/messages.tmpl")
+	i18nTestTemplate     = builtin.MustTemplate("wizard/i18n/i18n-test.tmpl")
+	i18nRoutesTemplate   = builtin.MustTemplate("wizard/i18n/routes.tmpl")
+)
@@ -0,0 +1,71 @@
+package wizard
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// relayout moves the conventional directories of a generated project to those
+// of cfg.Layout and rewrites the import paths and relative paths of the
+// generated files that refer to them
+func relayout(cfg *config.ProjectConfig, projectDir string) error {
+	if len(cfg.Layout) == 0 {
+		return nil
+	}
+
+	for _, dir := range config.LayoutDirs {
+		target := cfg.Dir(dir)
+		if target == dir {
+			continue
+		}
+		src := filepath.Join(projectDir, dir)
+		if _, err := os.Stat(src); errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		dst := filepath.Join(projectDir, filepath.FromSlash(target))
+		if _, err := os.Stat(dst); err == nil {
+			return fmt.Errorf("cannot move %s to %s: %s already exists", dir, target, target)
+		}
+		if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %v", target, err)
+		}
+		if err := os.Rename(src, dst); err != nil {
+			return fmt.Errorf("failed to move %s to %s: %v", dir, target, err)
+		}
+	}
+
+	return filepath.WalkDir(projectDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		// Leave binary files alone
+		if bytes.IndexByte(data, 0) >= 0 {
+			return nil
+		}
+		rewritten := cfg.RelayoutText(string(data))
+		if rewritten == string(data) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(path, []byte(rewritten), info.Mode().Perm())
+	})
+}
@@ -0,0 +1,62 @@
+package wizard
+
+import (
+	"path"
+	"strings"
+
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// libraryPackage is a package generated for a library project
+type libraryPackage struct {
+	// Dir is the directory relative to the project, with forward slashes
+	Dir string
+	// Name is the package name
+	Name string
+	// ImportPath is the module path followed by Dir
+	ImportPath string
+	// Primary marks the package that holds the library version and that the
+	// README documents: the first one listed
+	Primary bool
+}
+
+// libraryPackages returns the packages of a library project: the ones listed
+// in the config, or pkg/<name> when none are
+func libraryPackages(cfg *config.ProjectConfig) []libraryPackage {
+	if len(cfg.Packages) == 0 {
+		return []libraryPackage{{
+			Dir:        "pkg/" + cfg.Name,
+			Name:       cfg.Name,
+			ImportPath: cfg.Module + "/pkg/" + cfg.Name,
+			Primary:    true,
+		}}
+	}
+
+	pkgs := make([]libraryPackage, 0, len(cfg.Packages))
+	for i, dir := range cfg.Packages {
+		pkg := libraryPackage{Dir: dir, ImportPath: cfg.Module, Primary: i == 0}
+		if dir == "." {
+			pkg.Name = goPackageName(cfg.Name)
+		} else {
+			pkg.Name = path.Base(dir)
+			pkg.ImportPath += "/" + dir
+		}
+		pkgs = append(pkgs, pkg)
+	}
+	return pkgs
+}
+
+// goPackageName turns a project name such as "go-utils" into a package name
+// such as "goutils"
+func goPackageName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if r >= 'a' && r <= 'z' || r == '_' || r >= '0' && r <= '9' && b.Len() > 0 {
+			b.WriteRune(r)
+		}
+	}
+	if b.Len() == 0 {
+		return "lib"
+	}
+	return b.String()
+}
@@ -0,0 +1,47 @@
+package wizard
+
+import (
+	"github.com/oculus-core/gogo/internal/templates/builtin"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// airVersion is the version of air that make dev runs, so it needs no install
+const airVersion = "v1.61.7"
+
+// airConfigPath is the air configuration that make dev reads
+const airConfigPath = ".air.toml"
+
+// hasLiveReload reports whether the project gets make dev with live reload,
+// which API projects with use_live_reload do
+func hasLiveReload(cfg *config.ProjectConfig) bool {
+	return cfg.UseLiveReload && cfg.Type == config.TypeAPI
+}
+
+// liveReloadData is the data the make dev target is rendered with
+type liveReloadData struct {
+	AirVersion string
+}
+
+// generateLiveReload creates the air configuration of make dev
+func generateLiveReload(cfg *config.ProjectConfig, projectDir string) error {
+	files, err := renderFiles(newProjectData(cfg), liveReloadFiles)
+	if err != nil {
+		return err
+	}
+	return writeFiles(projectDir, files)
+}
+
+// liveReloadFiles are the air configuration, which rebuilds and restarts the
+// server when a Go file changes
+var liveReloadFiles = []struct{ path, text string }{
+	{airConfigPath, airConfigTemplate},
+}
+
+var (
+	airConfigTemplate = builtin.MustTemplate("wizard/livereload/air.tmpl")
+	// liveReloadMakeTargetsTemplate runs the server with air, and
+	// liveReloadIgnoreTemplate ignores the builds of air
+	liveReloadMakeTargetsTemplate = builtin.MustTemplate("wizard/livereload/make-targets.tmpl")
+	liveReloadMakeHelpTemplate    = builtin.MustTemplate("wizard/livereload/make-help.tmpl")
+	liveReloadIgnoreTemplate      = builtin.MustTemplate("wizard/livereload/gitignore.tmpl")
+)
@@ -0,0 +1,44 @@
+package wizard
+
+import (
+	"github.com/oculus-core/gogo/internal/templates/builtin"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// hasMultiTenancy reports whether the project scopes its requests and store
+// by tenant, which API projects with Gin and use_multi_tenancy do
+func hasMultiTenancy(cfg *config.ProjectConfig) bool {
+	return cfg.UseMultiTenancy && cfg.UseGin && cfg.Type == config.TypeAPI
+}
+
+// generateMultiTenancy creates the tenant package, the tenant configuration,
+// the tenant-scoped store, and the tenant routes, with their tests
+func generateMultiTenancy(cfg *config.ProjectConfig, projectDir string) error {
+	files, err := renderFiles(newProjectData(cfg), tenantFiles)
+	if err != nil {
+		return err
+	}
+	return writeFiles(projectDir, files)
+}
+
+// tenantFiles are the package that carries the tenant of a request through its
+// context, the tenant configuration, the store, which reads the tenant of
+// every call from its context, and the middleware that reads the tenant of
+// each request with example routes backed by the store, with their tests
+var tenantFiles = []struct{ path, text string }{
+	{"internal/tenant/tenant.go", tenantTemplate},
+	{"internal/config/tenant.go", tenantConfigTemplate},
+	{"internal/store/store.go", tenantStoreTemplate},
+	{"internal/store/store_test.go", tenantStoreTestTemplate},
+	{"internal/api/tenant.go", tenantRoutesTemplate},
+	{"internal/api/tenant_test.go", tenantRoutesTestTemplate},
+}
+
+var (
+	tenantTemplate           = builtin.MustTemplate("wizard/multitenancy/tenant.tmpl")
+	tenantConfigTemplate     = builtin.MustTemplate("wizard/multitenancy/config.tmpl")
+	tenantStoreTemplate      = builtin.MustTemplate("wizard/multitenancy/store.tmpl")
+	tenantStoreTestTemplate  = builtin.MustTemplate("wizard/multitenancy/store-test.tmpl")
+	tenantRoutesTemplate     = builtin.MustTemplate("wizard/multitenancy/routes.tmpl")
+	tenantRoutesTestTemplate = builtin.MustTemplate("wizard/multitenancy/routes-test.tmpl")
+)
@@ -0,0 +1,132 @@
+package wizard
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+
+	"github.com/oculus-core/gogo/internal/policy"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// Ways to resolve a project name that differs from the last element of its
+// module path, set with --sync-name
+const (
+	// SyncNameAsk asks which one to change in the wizard, and warns without it
+	SyncNameAsk = "ask"
+	// SyncNameToModule renames the project after its module
+	SyncNameToModule = "name"
+	// SyncModuleToName changes the module path to end with the project name
+	SyncModuleToName = "module"
+	// SyncNameKeep keeps both and warns
+	SyncNameKeep = "keep"
+)
+
+// SyncNameModes lists the ways to resolve a name that differs from the module
+var SyncNameModes = []string{SyncNameAsk, SyncNameToModule, SyncModuleToName, SyncNameKeep}
+
+// majorVersionPattern matches the major version suffix of a module path
+var majorVersionPattern = regexp.MustCompile(`^v[2-9][0-9]*$|^v1[0-9]+$`)
+
+// moduleName returns the last element of a module path, before its major
+// version suffix: billing for github.com/acme/billing/v2
+func moduleName(module string) string {
+	base := path.Base(module)
+	if majorVersionPattern.MatchString(base) && strings.Contains(module, "/") {
+		return path.Base(path.Dir(module))
+	}
+	return base
+}
+
+// renameModule returns the module path with its last element, before its
+// major version suffix, replaced by name
+func renameModule(module, name string) string {
+	base := path.Base(module)
+	if majorVersionPattern.MatchString(base) && strings.Contains(module, "/") {
+		return path.Join(path.Dir(path.Dir(module)), name, base)
+	}
+	if !strings.Contains(module, "/") {
+		return name
+	}
+	return path.Join(path.Dir(module), name)
+}
+
+// ResolveNameMismatch reconciles the project name with the last element of
+// its module path when they differ, since the name ends up in the binary,
+// cmd/ directory, and Makefile while the module ends up in import paths.
+// mode is one of SyncNameModes; SyncNameAsk prompts only when interactive.
+// Fields locked by the policy are left alone, and the placeholder module of a
+// configuration nobody set a module in is not checked.
+func ResolveNameMismatch(cfg *config.ProjectConfig, pol *policy.Policy, mode string, interactive bool) error {
+	if mode == "" {
+		mode = SyncNameAsk
+	}
+	if !contains(SyncNameModes, mode) {
+		return fmt.Errorf("invalid --sync-name %q: use one of %s", mode, strings.Join(SyncNameModes, ", "))
+	}
+	fromModule := moduleName(cfg.Module)
+	if cfg.Module == "" || cfg.Module == config.DefaultModule || cfg.Name == "" || fromModule == cfg.Name {
+		return nil
+	}
+	toName := renameModule(cfg.Module, cfg.Name)
+
+	if mode == SyncNameAsk && interactive {
+		renameOption := fmt.Sprintf("Rename the project to %s", fromModule)
+		moduleOption := fmt.Sprintf("Change the module to %s", toName)
+		keepOption := "Keep both"
+		var options []string
+		if !pol.IsLocked("name") {
+			options = append(options, renameOption)
+		}
+		if !pol.IsLocked("module") && moduleValidator(pol)(toName) == nil {
+			options = append(options, moduleOption)
+		}
+		options = append(options, keepOption)
+
+		choice := keepOption
+		prompt := &survey.Select{
+			Message: fmt.Sprintf("Project name %s differs from module %s:", cfg.Name, cfg.Module),
+			Help: "The binary and cmd/<name> use the project name, while imports use the module. Rename\n" +
+				"the project after the last element of the module, change the module to end in the\n" +
+				"name, or keep both as they are.",
+			Options: options,
+			Default: options[0],
+		}
+		if err := survey.AskOne(prompt, &choice); err != nil {
+			return err
+		}
+		switch choice {
+		case renameOption:
+			mode = SyncNameToModule
+		case moduleOption:
+			mode = SyncModuleToName
+		default:
+			mode = SyncNameKeep
+		}
+	}
+
+	switch {
+	case mode == SyncNameToModule && !pol.IsLocked("name"):
+		fmt.Printf("Renaming project %s to %s to match module %s\n", cfg.Name, fromModule, cfg.Module)
+		cfg.Name = fromModule
+	case mode == SyncModuleToName && !pol.IsLocked("module"):
+		fmt.Printf("Changing module %s to %s to match project %s\n", cfg.Module, toName, cfg.Name)
+		cfg.Module = toName
+	default:
+		fmt.Printf("Warning: project name %s differs from module %s; the binary and cmd/%s use the name, import paths the module (set --sync-name to sync them)\n",
+			cfg.Name, cfg.Module, cfg.Name)
+	}
+	return nil
+}
+
+// displayName returns the name of the project shown in the README and
+// documentation, which keeps the letters that the project name lost
+func displayName(cfg *config.ProjectConfig) string {
+	if cfg.DisplayName != "" {
+		return cfg.DisplayName
+	}
+	return cfg.Name
+}
@@ -0,0 +1,42 @@
+package wizard
+
+import (
+	"github.com/oculus-core/gogo/internal/templates/builtin"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// hasNotify reports whether the project gets a notification package, which
+// applications with use_notify do
+func hasNotify(cfg *config.ProjectConfig) bool {
+	return cfg.UseNotify && cfg.Type != config.TypeLibrary
+}
+
+// generateNotify creates the notify package, its tests, and the recorder for
+// the tests of its callers
+func generateNotify(cfg *config.ProjectConfig, projectDir string) error {
+	files, err := renderFiles(newProjectData(cfg), notifyFiles)
+	if err != nil {
+		return err
+	}
+	return writeFiles(projectDir, files)
+}
+
+// notifyFiles are the Notifier interface and its configuration from
+// environment variables, its email and Slack webhook implementations, its
+// tests, and a Notifier that records messages for the tests of the packages
+// that send notifications
+var notifyFiles = []struct{ path, text string }{
+	{"internal/notify/notify.go", notifyTemplate},
+	{"internal/notify/smtp.go", notifySMTPTemplate},
+	{"internal/notify/webhook.go", notifyWebhookTemplate},
+	{"internal/notify/notify_test.go", notifyTestTemplate},
+	{"internal/notify/notifytest/notifytest.go", notifyRecorderTemplate},
+}
+
+var (
+	notifyTemplate         = builtin.MustTemplate("wizard/notifier/notify.tmpl")
+	notifySMTPTemplate     = builtin.MustTemplate("wizard/notifier/smtp.tmpl")
+	notifyWebhookTemplate  = builtin.MustTemplate("wizard/notifier/webhook.tmpl")
+	notifyTestTemplate     = builtin.MustTemplate("wizard/notifier/notify-test.tmpl")
+	notifyRecorderTemplate = builtin.MustTemplate("wizard/notifier/notifytest.tmpl")
+)
@@ -0,0 +1,256 @@
+package wizard
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/oculus-core/gogo/internal/templates"
+	"github.com/oculus-core/gogo/internal/templates/builtin"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// Versions of the operator dependencies and of controller-gen, which
+// generates the CRD, RBAC, and DeepCopy code from the markers
+const (
+	controllerRuntimeVersion = "v0.19.4"
+	kubernetesVersion        = "v0.31.3"
+	controllerGenVersion     = "v0.16.5"
+)
+
+// operatorAPIVersion is the version of the API that operators start with
+const operatorAPIVersion = "v1alpha1"
+
+// lookPath finds kubebuilder and buf; tests replace it
+var lookPath = exec.LookPath
+
+// warnings receives the notes of the generators, such as a missing tool. It
+// is stderr rather than stdout, which gogo mcp uses for its messages; tests
+// replace it.
+var warnings io.Writer = os.Stderr
+
+// operatorKind returns the kind of the custom resource: the configured one,
+// or the name of the project without an -operator suffix, such as Redis for
+// redis-operator
+func operatorKind(cfg *config.ProjectConfig) string {
+	if cfg.OperatorKind != "" {
+		return cfg.OperatorKind
+	}
+	name := strings.TrimSuffix(strings.TrimSuffix(cfg.Name, "-operator"), "-controller")
+	kind := templates.FuncMap()["pascalCase"].(func(string) string)(name)
+	if kind == "" || config.ValidateOperatorAPI("", kind) != nil {
+		return "App"
+	}
+	return kind
+}
+
+// operatorGroup returns the API group of the custom resource, by default the
+// kind in the example.com domain
+func operatorGroup(cfg *config.ProjectConfig) string {
+	if cfg.OperatorGroup != "" {
+		return cfg.OperatorGroup
+	}
+	return strings.ToLower(operatorKind(cfg)) + ".example.com"
+}
+
+// operatorData is the data the operator templates are rendered with
+type operatorData struct {
+	Name    string
+	Module  string
+	Group   string
+	Version string
+	Kind    string
+	// Resource is the lowercase kind, used in file and object names
+	Resource string
+	// Plural is the resource name of the kind in the API, such as memcacheds
+	Plural string
+	// CrashImport and CrashDefer report panics of the manager with use_crash_handler
+	CrashImport string
+	CrashDefer  string
+	// ControllerGenVersion is the controller-gen the Makefile runs
+	ControllerGenVersion string
+}
+
+func newOperatorData(cfg *config.ProjectConfig) operatorData {
+	kind := operatorKind(cfg)
+	return operatorData{
+		Name:        cfg.Name,
+		Module:      cfg.Module,
+		Group:       operatorGroup(cfg),
+		Version:     operatorAPIVersion,
+		Kind:        kind,
+		Resource:    strings.ToLower(kind),
+		Plural:      strings.ToLower(templates.FuncMap()["pluralize"].(func(string) string)(kind)),
+		CrashImport: crashImport(cfg),
+		CrashDefer:  crashDefer(cfg),
+
+		ControllerGenVersion: controllerGenVersion,
+	}
+}
+
+// operatorDependencies lists the direct dependencies of operators
+var operatorDependencies = []Dependency{
+	{"k8s.io/api", kubernetesVersion},
+	{"k8s.io/apimachinery", kubernetesVersion},
+	{"k8s.io/client-go", kubernetesVersion},
+	{"sigs.k8s.io/controller-runtime", controllerRuntimeVersion},
+}
+
+// operatorMakePhony lists the operator targets that are not files
+const operatorMakePhony = " manifests generate install uninstall run docker-build deploy undeploy"
+
+// operatorFiles maps the paths of the operator files to their templates
+var operatorFiles = []struct{ path, text string }{
+	{"cmd/{{ .Name }}/main.go", operatorMainTemplate},
+	{"api/{{ .Version }}/groupversion_info.go", groupVersionTemplate},
+	{"api/{{ .Version }}/{{ .Resource }}_types.go", operatorTypesTemplate},
+	{"api/{{ .Version }}/zz_generated.deepcopy.go", deepCopyTemplate},
+	{"internal/controller/{{ .Resource }}_controller.go", controllerTemplate},
+	{"internal/controller/{{ .Resource }}_controller_test.go", controllerTestTemplate},
+	{"config/crd/bases/{{ .Group }}_{{ .Plural }}.yaml", crdTemplate},
+	{"config/crd/kustomization.yaml", crdKustomizationTemplate},
+	{"config/rbac/role.yaml", roleTemplate},
+	{"config/rbac/role_binding.yaml", roleBindingTemplate},
+	{"config/rbac/leader_election_role.yaml", leaderElectionRoleTemplate},
+	{"config/rbac/leader_election_role_binding.yaml", leaderElectionRoleBindingTemplate},
+	{"config/rbac/service_account.yaml", serviceAccountTemplate},
+	{"config/rbac/kustomization.yaml", rbacKustomizationTemplate},
+	{"config/manager/manager.yaml", managerTemplate},
+	{"config/manager/kustomization.yaml", managerKustomizationTemplate},
+	{"config/default/kustomization.yaml", defaultKustomizationTemplate},
+	{"config/samples/{{ .Version }}_{{ .Resource }}.yaml", sampleTemplate},
+	{"Dockerfile", operatorDockerfileTemplate},
+}
+
+// generateOperatorCode generates the operator: its API types, a sample
+// reconciler, the CRD and deployment manifests, and a Dockerfile. With
+// use_kubebuilder, options that run the tools, and kubebuilder installed,
+// kubebuilder scaffolds it instead.
+func generateOperatorCode(cfg *config.ProjectConfig, projectDir string, opts Options) error {
+	if cfg.UseKubebuilder && !opts.RunTools {
+		fmt.Fprintln(warnings, "Warning: skipping kubebuilder, using the built-in operator templates")
+	} else if cfg.UseKubebuilder {
+		if kubebuilder, err := lookPath("kubebuilder"); err == nil {
+			return runKubebuilder(cfg, projectDir, kubebuilder)
+		}
+		fmt.Fprintln(warnings, "Warning: kubebuilder not found in PATH, using the built-in operator templates")
+	}
+
+	data := newOperatorData(cfg)
+	files := make(map[string]string, len(operatorFiles))
+	for _, f := range operatorFiles {
+		path, err := templates.Render("path", f.path, data)
+		if err != nil {
+			return err
+		}
+		content, err := templates.Render(path, f.text, data)
+		if err != nil {
+			return err
+		}
+		files[path] = content
+	}
+	return writeFiles(projectDir, files)
+}
+
+// runKubebuilder scaffolds the operator with kubebuilder in a temporary
+// directory, then copies the files into the project. The files gogo already
+// generated are kept, except the Makefile, whose kubebuilder targets drive
+// the operator.
+func runKubebuilder(cfg *config.ProjectConfig, projectDir, kubebuilder string) error {
+	tmpDir, err := os.MkdirTemp("", "gogo-kubebuilder-")
+	if err != nil {
+		return fmt.Errorf("failed to create a directory for kubebuilder: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	group := operatorGroup(cfg)
+	prefix, domain, _ := strings.Cut(group, ".")
+	commands := [][]string{
+		{"init", "--domain", domain, "--repo", cfg.Module, "--project-name", cfg.Name},
+		{"create", "api", "--group", prefix, "--version", operatorAPIVersion, "--kind", operatorKind(cfg), "--resource", "--controller"},
+	}
+	for _, args := range commands {
+		cmd := exec.Command(kubebuilder, args...)
+		cmd.Dir = tmpDir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("kubebuilder %s failed: %v\n%s", strings.Join(args, " "), err, out)
+		}
+	}
+
+	return filepath.WalkDir(tmpDir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(tmpDir, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(projectDir, rel)
+		if _, err := os.Stat(target); err == nil && rel != "Makefile" {
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %v", rel, err)
+		}
+		return os.WriteFile(target, content, info.Mode().Perm())
+	})
+}
+
+// scaffoldedByKubebuilder reports whether kubebuilder scaffolded the project,
+// which leaves a PROJECT file and its own go.mod
+func scaffoldedByKubebuilder(cfg *config.ProjectConfig, projectDir string) bool {
+	if cfg.Type != config.TypeOperator {
+		return false
+	}
+	_, err := os.Stat(filepath.Join(projectDir, "PROJECT"))
+	return err == nil
+}
+
+var (
+	operatorMainTemplate  = builtin.MustTemplate("wizard/operator/main.tmpl")
+	groupVersionTemplate  = builtin.MustTemplate("wizard/operator/group-version.tmpl")
+	operatorTypesTemplate = builtin.MustTemplate("wizard/operator/types.tmpl")
+	// deepCopyTemplate is what controller-gen generates for the types, so the
+	// project builds before make generate runs
+	deepCopyTemplate       = builtin.MustTemplate("wizard/operator/deep-copy.tmpl")
+	controllerTemplate     = builtin.MustTemplate("wizard/operator/controller.tmpl")
+	controllerTestTemplate = builtin.MustTemplate("wizard/operator/controller-test.tmpl")
+	// crdTemplate is what controller-gen generates for the types; make manifests
+	// updates it
+	crdTemplate              = builtin.MustTemplate("wizard/operator/crd.tmpl")
+	crdKustomizationTemplate = builtin.MustTemplate("wizard/operator/crd-kustomization.tmpl")
+	// roleTemplate is what controller-gen generates from the RBAC markers; make
+	// manifests updates it
+	roleTemplate        = builtin.MustTemplate("wizard/operator/role.tmpl")
+	roleBindingTemplate = builtin.MustTemplate("wizard/operator/role-binding.tmpl")
+	// leaderElectionRoleTemplate lets the replicas of the manager elect a leader
+	// with a Lease in their namespace
+	leaderElectionRoleTemplate        = builtin.MustTemplate("wizard/operator/leader-election-role.tmpl")
+	leaderElectionRoleBindingTemplate = builtin.MustTemplate("wizard/operator/leader-election-role-binding.tmpl")
+	serviceAccountTemplate            = builtin.MustTemplate("wizard/operator/service-account.tmpl")
+	rbacKustomizationTemplate         = builtin.MustTemplate("wizard/operator/rbac-kustomization.tmpl")
+	managerTemplate                   = builtin.MustTemplate("wizard/operator/manager.tmpl")
+	managerKustomizationTemplate      = builtin.MustTemplate("wizard/operator/manager-kustomization.tmpl")
+	// defaultKustomizationTemplate deploys the CRDs, the RBAC rules, and the
+	// manager into the <name>-system namespace
+	defaultKustomizationTemplate = builtin.MustTemplate("wizard/operator/default-kustomization.tmpl")
+	sampleTemplate               = builtin.MustTemplate("wizard/operator/sample.tmpl")
+	operatorDockerfileTemplate   = builtin.MustTemplate("wizard/operator/dockerfile.tmpl")
+	// operatorMakeTargetsTemplate has the targets that generate the manifests
+	// and install, run, and deploy the operator, and operatorReadmeTemplate
+	// explains how to run it
+	operatorMakeTargetsTemplate = builtin.MustTemplate("wizard/operator/make-targets.tmpl")
+	operatorMakeHelpTemplate    = builtin.MustTemplate("wizard/operator/make-help.tmpl")
+	operatorReadmeTemplate      = builtin.MustTemplate("wizard/operator/readme-section.tmpl")
+)
@@ -0,0 +1,68 @@
+package wizard
+
+import (
+	"fmt"
+
+	"github.com/oculus-core/gogo/internal/templates/builtin"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// hasOutput reports whether the CLI gets the output package and a persistent
+// --output flag, which needs Cobra
+func hasOutput(cfg *config.ProjectConfig) bool {
+	return cfg.UseOutput && cfg.UseCobra && cfg.Type == config.TypeCLI
+}
+
+// versionOutput returns the imports, types, and command body of the version
+// command, which prints through the output package when it is generated
+func versionOutput(cfg *config.ProjectConfig) (imports, types, run string) {
+	if !hasOutput(cfg) {
+		return "", "", fmt.Sprintf(`	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Printf("%s version %%s (%%s) built on %%s\n", Version, Commit, BuildDate)
+	},
+`, cfg.Name)
+	}
+	types = fmt.Sprintf(`
+// versionInfo is the output of the version command
+type versionInfo struct {
+	Version   string `+"`json:\"version\" yaml:\"version\"`"+`
+	Commit    string `+"`json:\"commit\" yaml:\"commit\"`"+`
+	BuildDate string `+"`json:\"build_date\" yaml:\"build_date\"`"+`
+}
+
+// Text prints the version on one line
+func (v versionInfo) Text(w io.Writer) error {
+	_, err := fmt.Fprintf(w, "%s version %%s (%%s) built on %%s\n", v.Version, v.Commit, v.BuildDate)
+	return err
+}
+`, cfg.Name)
+	run = `	RunE: func(cmd *cobra.Command, args []string) error {
+		return printOutput(cmd, versionInfo{Version: Version, Commit: Commit, BuildDate: BuildDate})
+	},
+`
+	return "\t\"io\"\n", types, run
+}
+
+// generateOutput creates the output package, its tests, and the --output flag
+func generateOutput(cfg *config.ProjectConfig, projectDir string) error {
+	files, err := renderFiles(newProjectData(cfg), outputFiles)
+	if err != nil {
+		return err
+	}
+	return writeFiles(projectDir, files)
+}
+
+// outputFiles are the output package, which prints values as text for people
+// or as JSON or YAML for scripts, its tests, and the persistent --output flag
+// of the root command with the helper that commands print their results with
+var outputFiles = []struct{ path, text string }{
+	{"pkg/output/output.go", outputTemplate},
+	{"pkg/output/output_test.go", outputTestTemplate},
+	{"cmd/{{ .Name }}/cmd/output.go", outputFlagTemplate},
+}
+
+var (
+	outputTemplate     = builtin.MustTemplate("wizard/output/output.tmpl")
+	outputTestTemplate = builtin.MustTemplate("wizard/output/output-test.tmpl")
+	outputFlagTemplate = builtin.MustTemplate("wizard/output/flag.tmpl")
+)
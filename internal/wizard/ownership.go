@@ -0,0 +1,184 @@
+package wizard
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/oculus-core/gogo/internal/templates/builtin"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// hasOwnership reports whether the team, Slack channel, or on-call rotation
+// of the project is known
+func hasOwnership(cfg *config.ProjectConfig) bool {
+	return cfg.Team != "" || cfg.SlackChannel != "" || cfg.OnCall != ""
+}
+
+// hasAlerting reports whether the project gets alerting config templates,
+// which services with an owner do
+func hasAlerting(cfg *config.ProjectConfig) bool {
+	return cfg.Type == config.TypeAPI && hasOwnership(cfg)
+}
+
+// slackChannelName returns the Slack channel without its leading #
+func slackChannelName(cfg *config.ProjectConfig) string {
+	return strings.TrimPrefix(cfg.SlackChannel, "#")
+}
+
+// isURL reports whether s is an http or https URL, as on-call schedules often are
+func isURL(s string) bool {
+	return strings.HasPrefix(s, "https://") || strings.HasPrefix(s, "http://")
+}
+
+// catalogLinks links the catalog entity to the Slack channel and on-call
+// schedule of its team
+func catalogLinks(cfg *config.ProjectConfig) []catalogLink {
+	var links []catalogLink
+	if cfg.SlackChannel != "" {
+		links = append(links, catalogLink{
+			URL:   "https://slack.com/app_redirect?channel=" + slackChannelName(cfg),
+			Title: "#" + slackChannelName(cfg),
+			Icon:  "chat",
+		})
+	}
+	if isURL(cfg.OnCall) {
+		links = append(links, catalogLink{URL: cfg.OnCall, Title: "On-call", Icon: "alarm"})
+	}
+	return links
+}
+
+// codeownersPath returns where the hosting service of the module looks for
+// CODEOWNERS
+func codeownersPath(cfg *config.ProjectConfig) string {
+	if host, _, _ := splitModule(cfg.Module); host == "github.com" {
+		return ".github/CODEOWNERS"
+	}
+	return "CODEOWNERS"
+}
+
+// codeownersTeam returns the team as a CODEOWNERS handle. GitHub teams are
+// named after their organization, so "platform" in a module owned by acme
+// becomes @acme/platform.
+func codeownersTeam(cfg *config.ProjectConfig) string {
+	if strings.HasPrefix(cfg.Team, "@") {
+		return cfg.Team
+	}
+	if host, owner, _ := splitModule(cfg.Module); host == "github.com" && owner != "" && !strings.Contains(cfg.Team, "/") {
+		return "@" + owner + "/" + cfg.Team
+	}
+	return "@" + cfg.Team
+}
+
+// codeowners returns the owners of every file: the team and the authors with
+// an email address
+func codeowners(cfg *config.ProjectConfig) []string {
+	var owners []string
+	if cfg.Team != "" {
+		owners = append(owners, codeownersTeam(cfg))
+	}
+	for _, a := range cfg.Authors {
+		if a.Email != "" {
+			owners = append(owners, a.Email)
+		}
+	}
+	return owners
+}
+
+// hasCodeowners reports whether the project has a team or authors to own its
+// files
+func hasCodeowners(cfg *config.ProjectConfig) bool {
+	return len(codeowners(cfg)) > 0
+}
+
+// codeownersData is the data the CODEOWNERS template is rendered with
+type codeownersData struct {
+	*config.ProjectConfig
+	// Owners own every file
+	Owners []string
+}
+
+// generateCodeowners makes the team and the authors the owners of every file,
+// so their review is requested on each pull request
+func generateCodeowners(cfg *config.ProjectConfig, projectDir string) error {
+	data := codeownersData{ProjectConfig: cfg, Owners: codeowners(cfg)}
+	files, err := renderFiles(data, []struct{ path, text string }{{codeownersPath(cfg), codeownersTemplate}})
+	if err != nil {
+		return err
+	}
+	return writeFiles(projectDir, files)
+}
+
+// alertingData is the data the alerting templates are rendered with
+type alertingData struct {
+	*config.ProjectConfig
+	// AlertName prefixes the alerts, and AlertTeam labels them so
+	// Alertmanager can route them
+	AlertName string
+	AlertTeam string
+	// Channel is the Slack channel that warnings go to, and Pager the on-call
+	// rotation that critical alerts page
+	Channel string
+	Pager   string
+}
+
+// alertName turns a project name such as "user-api" into the CamelCase
+// prefix of its alerts, such as "UserApi"
+func alertName(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9':
+			if upper {
+				r = unicode.ToUpper(r)
+			}
+			b.WriteRune(r)
+			upper = false
+		default:
+			upper = true
+		}
+	}
+	return b.String()
+}
+
+// generateAlerting creates the Prometheus rules and Alertmanager route of a
+// service in alerting/
+func generateAlerting(cfg *config.ProjectConfig, projectDir string) error {
+	data := alertingData{
+		ProjectConfig: cfg,
+		AlertName:     alertName(cfg.Name),
+		AlertTeam:     cfg.Team,
+		Channel:       slackChannelName(cfg),
+		Pager:         cfg.OnCall,
+	}
+	if data.AlertTeam == "" {
+		data.AlertTeam = catalogOwner(cfg)
+	}
+	if data.Channel == "" {
+		data.Channel = "alerts"
+	}
+	if data.Pager == "" {
+		data.Pager = "the team's on-call rotation"
+	}
+	files, err := renderFiles(data, alertingFiles)
+	if err != nil {
+		return err
+	}
+	return writeFiles(projectDir, files)
+}
+
+// alertingFiles are the Prometheus alerting rules of the service, labelled
+// with its team, and an Alertmanager route and receivers for the team, meant
+// to be merged into the shared Alertmanager config
+var alertingFiles = []struct{ path, text string }{
+	{"alerting/rules.yml", alertingRulesTemplate},
+	{"alerting/alertmanager.yml", alertingRouteTemplate},
+}
+
+var (
+	codeownersTemplate    = builtin.MustTemplate("wizard/ownership/codeowners.tmpl")
+	alertingRulesTemplate = builtin.MustTemplate("wizard/ownership/rules.tmpl")
+	alertingRouteTemplate = builtin.MustTemplate("wizard/ownership/alertmanager.tmpl")
+	// readmeOwnershipTemplate lists who owns the project and how to reach them
+	readmeOwnershipTemplate = builtin.MustTemplate("wizard/ownership/readme-section.tmpl")
+)
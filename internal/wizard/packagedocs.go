@@ -0,0 +1,134 @@
+package wizard
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/oculus-core/gogo/internal/templates"
+	"github.com/oculus-core/gogo/internal/templates/builtin"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// hasPackageDocs reports whether the project gets package documentation,
+// which only libraries do
+func hasPackageDocs(cfg *config.ProjectConfig) bool {
+	return cfg.Type == config.TypeLibrary && cfg.CreatePackageDocs
+}
+
+// primaryPackage returns the library package the README documents
+func primaryPackage(cfg *config.ProjectConfig) libraryPackage {
+	return libraryPackages(cfg)[0]
+}
+
+// newPackageDocsData returns the project data with the package the README
+// documents
+func newPackageDocsData(cfg *config.ProjectConfig) projectData {
+	data := newProjectData(cfg)
+	data.Package = primaryPackage(cfg)
+	return data
+}
+
+// packageDocData is the data the package documentation templates are
+// rendered with
+type packageDocData struct {
+	Package libraryPackage
+	// Summary completes the sentence "Package <name> ..."
+	Summary string
+}
+
+// generateDocFile creates doc.go with the package documentation
+func generateDocFile(cfg *config.ProjectConfig, pkgDir string, pkg libraryPackage) error {
+	docPath := filepath.Join(pkgDir, "doc.go")
+	docContent, err := templates.Render(docPath, docFileTemplate, packageDocData{Package: pkg, Summary: packageSummary(cfg, pkg)})
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(docPath, []byte(docContent), 0600); err != nil {
+		return fmt.Errorf("failed to create %s/doc.go: %v", pkg.Dir, err)
+	}
+	return nil
+}
+
+// generateExamplesTest creates a runnable example that pkg.go.dev shows next
+// to Hello
+func generateExamplesTest(pkgDir string, pkg libraryPackage) error {
+	examplePath := filepath.Join(pkgDir, "examples_test.go")
+	exampleContent, err := templates.Render(examplePath, examplesTestTemplate, packageDocData{Package: pkg})
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(examplePath, []byte(exampleContent), 0600); err != nil {
+		return fmt.Errorf("failed to create %s/examples_test.go: %v", pkg.Dir, err)
+	}
+	return nil
+}
+
+// packageSummary returns the rest of the sentence "Package <name> ...": the
+// project description for the primary package
+func packageSummary(cfg *config.ProjectConfig, pkg libraryPackage) string {
+	if !pkg.Primary {
+		return "is part of " + cfg.Module
+	}
+	summary := description(cfg)
+	if summary == "" {
+		return "provides " + cfg.Name
+	}
+	return "is " + lowerFirst(summary)
+}
+
+// description returns the project description without surrounding spaces and
+// trailing periods, to use it in a sentence or as a one-line summary
+func description(cfg *config.ProjectConfig) string {
+	return strings.TrimRight(strings.TrimSpace(cfg.Description), ".")
+}
+
+// commandDescription returns the description of the binary of a CLI project,
+// which its root command shows
+func commandDescription(cfg *config.ProjectConfig) string {
+	if d := description(cfg); d != "" {
+		return d
+	}
+	return "The " + cfg.Name + " command line tool"
+}
+
+// mainDoc returns the doc comment of the main package of the project, which
+// go doc shows for the command, or nothing without a description
+func mainDoc(cfg *config.ProjectConfig) string {
+	d := description(cfg)
+	if d == "" {
+		return ""
+	}
+	return fmt.Sprintf("// Command %s: %s.\n", cfg.Name, d)
+}
+
+// serverBanner returns the statement that logs the name and description of an
+// API project and the address it listens on
+func serverBanner(cfg *config.ProjectConfig) string {
+	if d := description(cfg); d != "" {
+		return fmt.Sprintf("\tlog.Printf(\"%%s - %%s - listening on %%s\", %q, %q, addr)\n", cfg.Name, d)
+	}
+	return fmt.Sprintf("\tlog.Printf(\"%%s listening on %%s\", %q, addr)\n", cfg.Name)
+}
+
+// lowerFirst lowers the first letter of s, unless s starts with an acronym
+func lowerFirst(s string) string {
+	if len(s) > 1 && s[0] >= 'A' && s[0] <= 'Z' && !(s[1] >= 'A' && s[1] <= 'Z') {
+		return string(s[0]+'a'-'A') + s[1:]
+	}
+	return s
+}
+
+var (
+	docFileTemplate      = builtin.MustTemplate("wizard/packagedocs/doc.tmpl")
+	examplesTestTemplate = builtin.MustTemplate("wizard/packagedocs/examples-test.tmpl")
+	// readmeBadgesTemplate has the pkg.go.dev and Go Report Card badges of the
+	// module, and readmeDocumentationTemplate the README section that links
+	// to the package documentation. The public services cannot read the
+	// module of an internal project, whose documentation is only read locally.
+	readmeBadgesTemplate        = builtin.MustTemplate("wizard/packagedocs/readme-badges.tmpl")
+	readmeDocumentationTemplate = builtin.MustTemplate("wizard/packagedocs/readme-section.tmpl")
+)
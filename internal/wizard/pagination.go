@@ -0,0 +1,40 @@
+package wizard
+
+import (
+	"github.com/oculus-core/gogo/internal/templates/builtin"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// hasPagination reports whether the API gets pkg/pagination and the example
+// list endpoint
+func hasPagination(cfg *config.ProjectConfig) bool {
+	return cfg.Type == config.TypeAPI && (cfg.Pagination == config.PaginationCursor || cfg.Pagination == config.PaginationOffset)
+}
+
+// generatePagination creates pkg/pagination and the example list endpoint,
+// with their tests
+func generatePagination(cfg *config.ProjectConfig, projectDir string) error {
+	files, err := renderFiles(newProjectData(cfg), paginationFiles)
+	if err != nil {
+		return err
+	}
+	return writeFiles(projectDir, files)
+}
+
+// paginationFiles are pkg/pagination, which parses the list parameters of a
+// request and builds the pages of the pagination style, and
+// internal/api/books.go, an example list endpoint that filters, sorts, and
+// pages a list of books, with their tests
+var paginationFiles = []struct{ path, text string }{
+	{"pkg/pagination/pagination.go", paginationTemplate},
+	{"pkg/pagination/pagination_test.go", paginationTestTemplate},
+	{"internal/api/books.go", booksTemplate},
+	{"internal/api/books_test.go", booksTestTemplate},
+}
+
+var (
+	paginationTemplate     = builtin.MustTemplate("wizard/pagination/pagination.tmpl")
+	paginationTestTemplate = builtin.MustTemplate("wizard/pagination/pagination-test.tmpl")
+	booksTemplate          = builtin.MustTemplate("wizard/pagination/books.tmpl")
+	booksTestTemplate      = builtin.MustTemplate("wizard/pagination/books-test.tmpl")
+)
@@ -0,0 +1,97 @@
+package wizard
+
+import (
+	"github.com/oculus-core/gogo/internal/templates"
+	"github.com/oculus-core/gogo/internal/templates/builtin"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// hasPlugins reports whether the application hosts plugins, which CLI and
+// API projects do with use_plugins when they use Cobra or Gin
+func hasPlugins(cfg *config.ProjectConfig) bool {
+	return cfg.UsePlugins &&
+		((cfg.Type == config.TypeCLI && cfg.UseCobra) || (cfg.Type == config.TypeAPI && cfg.UseGin))
+}
+
+// pluginsDependencies lists the direct dependencies of the plugin host:
+// go-plugin v1.6 still builds with the Go version of CLI and API projects
+var pluginsDependencies = []Dependency{
+	{"github.com/hashicorp/go-hclog", "v1.6.3"},
+	{"github.com/hashicorp/go-plugin", "v1.6.2"},
+}
+
+// pluginData is the data the plugin templates are rendered with
+type pluginData struct {
+	Name      string
+	Module    string
+	EnvPrefix string
+	// RunHint shows how to run the sample plugin
+	RunHint string
+}
+
+// pluginFiles maps the paths of the plugin host files to their templates.
+// The interface lives in pkg/ so that plugins built in other modules can
+// import it.
+var pluginFiles = []struct{ path, text string }{
+	{"pkg/plugin/plugin.go", pluginInterfaceTemplate},
+	{"pkg/plugin/rpc.go", pluginRPCTemplate},
+	{"pkg/plugin/plugin_test.go", pluginInterfaceTestTemplate},
+	{"internal/plugins/plugins.go", pluginHostTemplate},
+	{"internal/plugins/plugins_test.go", pluginHostTestTemplate},
+	{"plugins/hello/main.go", pluginHelloTemplate},
+}
+
+// generatePlugins creates the versioned plugin interface, the host that
+// discovers and starts plugins, a sample plugin, and the command or routes
+// that run them
+func generatePlugins(cfg *config.ProjectConfig, projectDir string) error {
+	data := pluginData{
+		Name:      cfg.Name,
+		Module:    cfg.Module,
+		EnvPrefix: envPrefix(cfg.Name),
+		RunHint:   cfg.Name + " plugins run hello Gopher",
+	}
+	files := []struct{ path, text string }{}
+	files = append(files, pluginFiles...)
+	if cfg.Type == config.TypeAPI {
+		data.RunHint = "curl -X POST localhost:8080/api/v1/plugins/hello/run -d '{\"args\": [\"Gopher\"]}'"
+		files = append(files,
+			struct{ path, text string }{"internal/api/plugins.go", pluginRoutesTemplate},
+			struct{ path, text string }{"internal/api/plugins_test.go", pluginRoutesTestTemplate})
+	} else {
+		files = append(files, struct{ path, text string }{"cmd/{{ .Name }}/cmd/plugins.go", pluginCommandTemplate})
+	}
+
+	out := make(map[string]string, len(files))
+	for _, f := range files {
+		path, err := templates.Render("path", f.path, data)
+		if err != nil {
+			return err
+		}
+		content, err := templates.Render(path, f.text, data)
+		if err != nil {
+			return err
+		}
+		out[path] = content
+	}
+	return writeFiles(projectDir, out)
+}
+
+var (
+	pluginInterfaceTemplate     = builtin.MustTemplate("wizard/plugins/interface.tmpl")
+	pluginRPCTemplate           = builtin.MustTemplate("wizard/plugins/rpc.tmpl")
+	pluginInterfaceTestTemplate = builtin.MustTemplate("wizard/plugins/interface-test.tmpl")
+	pluginHostTemplate          = builtin.MustTemplate("wizard/plugins/host.tmpl")
+	pluginHostTestTemplate      = builtin.MustTemplate("wizard/plugins/host-test.tmpl")
+	pluginHelloTemplate         = builtin.MustTemplate("wizard/plugins/hello.tmpl")
+	pluginCommandTemplate       = builtin.MustTemplate("wizard/plugins/command.tmpl")
+	pluginRoutesTemplate        = builtin.MustTemplate("wizard/plugins/routes.tmpl")
+	pluginRoutesTestTemplate    = builtin.MustTemplate("wizard/plugins/routes-test.tmpl")
+	// pluginsMakeTargetsTemplate builds each plugin in plugins/ as
+	// <name>-plugin-<dir> in the plugins directory beside the binary, where
+	// the host discovers it, and pluginsSetupTemplate stops the plugin
+	// processes in main on exit and on signals
+	pluginsMakeTargetsTemplate = builtin.MustTemplate("wizard/plugins/make-targets.tmpl")
+	pluginsMakeHelpTemplate    = builtin.MustTemplate("wizard/plugins/make-help.tmpl")
+	pluginsSetupTemplate       = builtin.MustTemplate("wizard/plugins/main-setup.tmpl")
+)
@@ -0,0 +1,61 @@
+package wizard
+
+import (
+	"github.com/oculus-core/gogo/internal/templates/builtin"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// pprofDefaultAddr is the address of the debug server when PPROF_ADDR is unset
+const pprofDefaultAddr = "localhost:6060"
+
+// hasPprof reports whether the service serves pprof profiles, which API
+// projects with use_pprof do
+func hasPprof(cfg *config.ProjectConfig) bool {
+	return cfg.UsePprof && cfg.Type == config.TypeAPI
+}
+
+// pprofData is the data the pprof templates are rendered with
+type pprofData struct {
+	*config.ProjectConfig
+	// DefaultAddr is the address of the debug server when PPROF_ADDR is unset
+	DefaultAddr string
+}
+
+func newPprofData(cfg *config.ProjectConfig) pprofData {
+	return pprofData{ProjectConfig: cfg, DefaultAddr: pprofDefaultAddr}
+}
+
+// pprofFiles maps the paths of the debug package files to their templates
+var pprofFiles = []struct{ path, text string }{
+	{"internal/debug/pprof.go", pprofTemplate},
+	{"internal/debug/pprof_test.go", pprofTestTemplate},
+}
+
+// pprofDocsPage explains how to capture and read profiles
+var pprofDocsPage = docsPage{Path: "profiling", Title: "Profiling", Weight: 40, Body: pprofDocsTemplate}
+
+// generatePprof creates the debug package, its test, and the profiling page
+func generatePprof(cfg *config.ProjectConfig, projectDir string) error {
+	data := newPprofData(cfg)
+	files, err := renderFiles(data, pprofFiles)
+	if err != nil {
+		return err
+	}
+	if err := renderDocsPages(cfg, files, data, pprofDocsPage); err != nil {
+		return err
+	}
+	return writeFiles(projectDir, files)
+}
+
+var (
+	pprofTemplate     = builtin.MustTemplate("wizard/pprof/pprof.tmpl")
+	pprofTestTemplate = builtin.MustTemplate("wizard/pprof/pprof-test.tmpl")
+	pprofDocsTemplate = builtin.MustTemplate("wizard/pprof/docs.tmpl")
+	// pprofMakeTargetsTemplate captures profiles from the debug server of the
+	// running service into profiles/, which pprofIgnoreTemplate ignores, and
+	// pprofMainSetupTemplate starts the debug server in main
+	pprofMakeTargetsTemplate = builtin.MustTemplate("wizard/pprof/make-targets.tmpl")
+	pprofMakeHelpTemplate    = builtin.MustTemplate("wizard/pprof/make-help.tmpl")
+	pprofIgnoreTemplate      = builtin.MustTemplate("wizard/pprof/gitignore.tmpl")
+	pprofMainSetupTemplate   = builtin.MustTemplate("wizard/pprof/main-setup.tmpl")
+)
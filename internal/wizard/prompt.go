@@ -0,0 +1,73 @@
+package wizard
+
+import (
+	"github.com/oculus-core/gogo/internal/templates/builtin"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// Versions of the prompt libraries required by generated projects
+const (
+	surveyVersion    = "v2.3.7"
+	bubbleteaVersion = "v1.3.4"
+)
+
+// hasPrompt reports whether the CLI gets the prompt package and the
+// interactive setup command, which prompt_library survey or bubbletea adds
+// to CLI projects with Cobra
+func hasPrompt(cfg *config.ProjectConfig) bool {
+	return cfg.Type == config.TypeCLI && cfg.UseCobra &&
+		(cfg.PromptLibrary == config.PromptLibrarySurvey || cfg.PromptLibrary == config.PromptLibraryBubbletea)
+}
+
+// promptDependency returns the module of the prompt library
+func promptDependency(cfg *config.ProjectConfig) Dependency {
+	if cfg.PromptLibrary == config.PromptLibraryBubbletea {
+		return Dependency{"github.com/charmbracelet/bubbletea", bubbleteaVersion}
+	}
+	return Dependency{"github.com/AlecAivazis/survey/v2", surveyVersion}
+}
+
+// generatePrompt creates the prompt package for the chosen library and the
+// setup command that uses it
+func generatePrompt(cfg *config.ProjectConfig, projectDir string) error {
+	libraryFiles := surveyPromptFiles
+	if cfg.PromptLibrary == config.PromptLibraryBubbletea {
+		libraryFiles = bubbleteaPromptFiles
+	}
+	files, err := renderFiles(newProjectData(cfg), promptFiles, libraryFiles)
+	if err != nil {
+		return err
+	}
+	return writeFiles(projectDir, files)
+}
+
+var (
+	// promptFiles are the part of the prompt package that does not depend on
+	// the library, the terminal check and the cancellation error, and the
+	// setup command, which asks for the settings not given as flags, with its
+	// tests without a terminal
+	promptFiles = []struct{ path, text string }{
+		{"internal/prompt/interactive.go", promptInteractiveTemplate},
+		{"cmd/{{ .Name }}/cmd/setup.go", promptSetupTemplate},
+		{"cmd/{{ .Name }}/cmd/setup_test.go", promptSetupTestTemplate},
+	}
+	// surveyPromptFiles implement the prompts with survey
+	surveyPromptFiles = []struct{ path, text string }{
+		{"internal/prompt/prompt.go", promptSurveyTemplate},
+	}
+	// bubbleteaPromptFiles implement the prompts as small Bubble Tea models,
+	// tested by driving the models with key messages
+	bubbleteaPromptFiles = []struct{ path, text string }{
+		{"internal/prompt/prompt.go", promptBubbleteaTemplate},
+		{"internal/prompt/prompt_test.go", promptBubbleteaTestTemplate},
+	}
+)
+
+var (
+	promptInteractiveTemplate   = builtin.MustTemplate("wizard/prompt/interactive.tmpl")
+	promptSurveyTemplate        = builtin.MustTemplate("wizard/prompt/survey.tmpl")
+	promptBubbleteaTemplate     = builtin.MustTemplate("wizard/prompt/bubbletea.tmpl")
+	promptBubbleteaTestTemplate = builtin.MustTemplate("wizard/prompt/bubbletea-test.tmpl")
+	promptSetupTemplate         = builtin.MustTemplate("wizard/prompt/setup.tmpl")
+	promptSetupTestTemplate     = builtin.MustTemplate("wizard/prompt/setup-test.tmpl")
+)
@@ -0,0 +1,50 @@
+package wizard
+
+import (
+	"github.com/oculus-core/gogo/internal/templates"
+	"github.com/oculus-core/gogo/internal/templates/builtin"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// scriptData is the data the script templates are rendered with
+type scriptData struct {
+	Name        string
+	Description string
+	CrashImport string
+	CrashDefer  string
+}
+
+// scriptFiles maps the paths of the script files to their templates
+var scriptFiles = []struct{ path, text string }{
+	{"main.go", scriptMainTemplate},
+	{"main_test.go", scriptMainTestTemplate},
+}
+
+// generateScriptCode generates a single main package at the root of the
+// project, with the flag parsing, logging, and error handling of the other
+// project types but none of their layout
+func generateScriptCode(cfg *config.ProjectConfig, projectDir string) error {
+	data := scriptData{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		CrashImport: crashImport(cfg),
+		CrashDefer:  crashDefer(cfg),
+	}
+	files := make(map[string]string, len(scriptFiles))
+	for _, f := range scriptFiles {
+		content, err := templates.Render(f.path, f.text, data)
+		if err != nil {
+			return err
+		}
+		files[f.path] = content
+	}
+	return writeFiles(projectDir, files)
+}
+
+var (
+	scriptMainTemplate     = builtin.MustTemplate("wizard/script/main.tmpl")
+	scriptMainTestTemplate = builtin.MustTemplate("wizard/script/main-test.tmpl")
+	// scriptReadmeTemplate explains the conventions of the script and how to
+	// run it
+	scriptReadmeTemplate = builtin.MustTemplate("wizard/script/readme-section.tmpl")
+)
@@ -0,0 +1,285 @@
+package wizard
+
+import (
+	"fmt"
+	"go/format"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/oculus-core/gogo/internal/templates"
+	"github.com/oculus-core/gogo/internal/templates/builtin"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// sdkData is the data the SDK templates are rendered with
+type sdkData struct {
+	*sdkModel
+	Name    string
+	Module  string
+	Package string
+	// ImportName is the name the example imports the SDK as, when the
+	// module path does not end in the package name
+	ImportName string
+	Spec       string
+	// TokenEnv and BaseURLEnv are the environment variables of the example
+	TokenEnv   string
+	BaseURLEnv string
+	// PackageComment is the package doc comment, ExampleArgs the arguments
+	// of the example operation, and ExampleDir its directory below examples/
+	PackageComment string
+	ExampleArgs    string
+	ExampleDir     string
+}
+
+// sdkFileData is the data a file of operations is rendered with: the
+// operations of a service, or those of the Client when Service is nil
+type sdkFileData struct {
+	Package    string
+	Imports    []string
+	Service    *sdkService
+	Operations []*sdkOperation
+}
+
+// sdkMakePhony lists the SDK targets that are not files
+const sdkMakePhony = " publish"
+
+// sdkPackage returns the package name of an SDK: the project name without a
+// go- prefix or -go suffix, so that petstore-go is package petstore
+func sdkPackage(cfg *config.ProjectConfig) string {
+	name := strings.TrimSuffix(strings.TrimPrefix(cfg.Name, "go-"), "-go")
+	return goPackageName(name)
+}
+
+// sdkSpecFile returns the path the OpenAPI spec of an SDK is copied to
+func sdkSpecFile(cfg *config.ProjectConfig) string {
+	if strings.EqualFold(path.Ext(cfg.OpenAPISpec), ".json") {
+		return "api/openapi.json"
+	}
+	return "api/openapi.yaml"
+}
+
+// sdkReadmeData returns the data the README section of an SDK is rendered
+// with, which needs no operations
+func sdkReadmeData(cfg *config.ProjectConfig) interface{} {
+	pkg := sdkPackage(cfg)
+	return sdkData{
+		Name:     cfg.Name,
+		Module:   cfg.Module,
+		Package:  pkg,
+		Spec:     sdkSpecFile(cfg),
+		TokenEnv: strings.ToUpper(pkg) + "_TOKEN",
+	}
+}
+
+// generateSDKCode generates a client library from the OpenAPI spec of the
+// config: a typed client with a service per tag, retries, pagination, an
+// example, and a copy of the spec
+func generateSDKCode(cfg *config.ProjectConfig, projectDir string) error {
+	doc, err := loadSDKSpec(cfg.OpenAPISpec)
+	if err != nil {
+		return err
+	}
+	model, err := buildSDKModel(doc)
+	if err != nil {
+		return fmt.Errorf("failed to generate the SDK from %s: %w", cfg.OpenAPISpec, err)
+	}
+	describeSDKModel(model)
+	spec, err := os.ReadFile(cfg.OpenAPISpec)
+	if err != nil {
+		return fmt.Errorf("failed to read OpenAPI spec: %w", err)
+	}
+
+	pkg := sdkPackage(cfg)
+	data := sdkData{
+		sdkModel:   model,
+		Name:       cfg.Name,
+		Module:     cfg.Module,
+		Package:    pkg,
+		Spec:       sdkSpecFile(cfg),
+		TokenEnv:   strings.ToUpper(pkg) + "_TOKEN",
+		BaseURLEnv: strings.ToUpper(pkg) + "_BASE_URL",
+		ExampleDir: "client",
+	}
+	if path.Base(cfg.Module) != pkg {
+		data.ImportName = pkg
+	}
+	title := "the API"
+	if model.Title != "" {
+		title = model.Title
+	}
+	data.PackageComment = wrapComment("", "Package "+pkg+" is a client of "+title+", generated from "+data.Spec+".")
+	if model.Description != "" {
+		data.PackageComment += "//\n" + wrapComment("", model.Description)
+	}
+	if ex := model.Example; ex != nil {
+		data.ExampleDir = templates.FuncMap()["kebabCase"].(func(string) string)(ex.Name)
+		data.ExampleArgs = "ctx"
+		if ex.Query != nil {
+			data.ExampleArgs += ", nil"
+		}
+	}
+
+	files := map[string]string{data.Spec: string(spec)}
+	render := func(path, text string, data interface{}) error {
+		rendered, err := templates.Render(path, text, data)
+		if err != nil {
+			return err
+		}
+		src, err := format.Source([]byte(rendered))
+		if err != nil {
+			return fmt.Errorf("template %s produced invalid Go code: %w", path, err)
+		}
+		files[path] = string(src)
+		return nil
+	}
+	for _, f := range []struct{ path, text string }{
+		{"client.go", sdkClientTemplate},
+		{"retry.go", sdkRetryTemplate},
+		{"pagination.go", sdkPaginationTemplate},
+		{"types.go", sdkTypesTemplate},
+		{"client_test.go", sdkClientTestTemplate},
+	} {
+		if err := render(f.path, f.text, data); err != nil {
+			return err
+		}
+	}
+	if hasExamples(cfg) {
+		if err := render("examples/"+data.ExampleDir+"/main.go", sdkExampleTemplate, data); err != nil {
+			return err
+		}
+	}
+
+	if len(model.Operations) > 0 {
+		file := sdkFileData{Package: pkg, Imports: sdkImports(model.Operations), Operations: model.Operations}
+		if err := render("operations.go", sdkOperationsTemplate, file); err != nil {
+			return err
+		}
+	}
+	for _, svc := range model.Services {
+		file := sdkFileData{Package: pkg, Imports: sdkImports(svc.Operations), Service: svc, Operations: svc.Operations}
+		if err := render(svc.File, sdkOperationsTemplate, file); err != nil {
+			return err
+		}
+	}
+	return writeFiles(projectDir, files)
+}
+
+// sdkImports returns the packages the methods of ops use
+func sdkImports(ops []*sdkOperation) []string {
+	var usesFmt, usesIO, usesURL bool
+	for _, op := range ops {
+		usesFmt = usesFmt || strings.Contains(op.PathExpr, "fmt.Sprint")
+		usesIO = usesIO || op.Body == "io.Reader"
+		usesURL = usesURL || op.Query != nil || len(op.PathParams) > 0
+	}
+	imports := []string{"context"}
+	if usesFmt {
+		imports = append(imports, "fmt")
+	}
+	if usesIO {
+		imports = append(imports, "io")
+	}
+	imports = append(imports, "net/http")
+	if usesURL {
+		imports = append(imports, "net/url")
+	}
+	return imports
+}
+
+// describeSDKModel writes the doc comments of the generated declarations
+func describeSDKModel(m *sdkModel) {
+	for _, t := range m.Types {
+		t.Comment = wrapComment("", t.Name+" is "+t.Origin+".")
+		if t.Doc != "" {
+			t.Comment += "//\n" + wrapComment("", t.Doc)
+		}
+		for i := range t.Fields {
+			if t.Fields[i].Doc != "" {
+				t.Fields[i].Comment = wrapComment("\t", t.Fields[i].Doc)
+			}
+		}
+	}
+	for _, svc := range m.Services {
+		svc.Comment = wrapComment("", svc.Name+" calls the operations of the "+svc.Tag+" tag. Use it as Client."+svc.Field+".")
+		if svc.Doc != "" {
+			svc.Comment += "//\n" + wrapComment("", svc.Doc)
+		}
+	}
+	for _, op := range m.allOperations() {
+		op.Comment = wrapComment("", op.Name+" sends "+op.Method+" "+op.Path+".")
+		if op.Doc != "" {
+			op.Comment += "//\n" + wrapComment("", op.Doc)
+		}
+		if op.Deprecated {
+			op.Comment += "//\n// Deprecated: the API marks this operation as deprecated.\n"
+		}
+		if q := op.Query; q != nil {
+			q.Comment = wrapComment("", q.Type+" are the query parameters of "+op.Name+". Zero values are not sent.")
+			for i := range q.Fields {
+				if q.Fields[i].Doc != "" {
+					q.Fields[i].Comment = wrapComment("\t", q.Fields[i].Doc)
+				}
+			}
+		}
+		if p := op.Pager; p != nil {
+			p.Comment = wrapComment("", p.Name+" returns a Pager over the "+p.ItemsKey+" of "+op.Name+
+				", which fetches the next page when the items of the previous one are used up. It starts at params."+
+				p.CursorField+".")
+		}
+	}
+}
+
+// wrapComment formats text as a line comment indented by indent, wrapped
+// before 100 columns
+func wrapComment(indent, text string) string {
+	var b strings.Builder
+	line := indent + "//"
+	for _, word := range strings.Fields(text) {
+		if len(line)+1+len(word) > 100 && line != indent+"//" {
+			b.WriteString(line + "\n")
+			line = indent + "//"
+		}
+		line += " " + word
+	}
+	b.WriteString(line + "\n")
+	return b.String()
+}
+
+// generateSDKReleaseWorkflow creates a workflow that releases tagged versions
+// of an SDK: Go modules are published by their tags, so it only tests the
+// tag, creates a GitHub release, and warms the module proxy
+func generateSDKReleaseWorkflow(cfg *config.ProjectConfig, projectDir string) error {
+	data := newProjectData(cfg)
+	setupGo, err := setupGoStep(cfg)
+	if err != nil {
+		return err
+	}
+	data.SetupGo = setupGo
+	content, err := templates.Render("release.yml", sdkReleaseWorkflowTemplate, data)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(projectDir, ".github", "workflows", "release.yml"), []byte(content), 0600)
+}
+
+var (
+	sdkClientTemplate     = builtin.MustTemplate("wizard/sdk/client.tmpl")
+	sdkRetryTemplate      = builtin.MustTemplate("wizard/sdk/retry.tmpl")
+	sdkPaginationTemplate = builtin.MustTemplate("wizard/sdk/pagination.tmpl")
+	sdkTypesTemplate      = builtin.MustTemplate("wizard/sdk/types.tmpl")
+	sdkOperationsTemplate = builtin.MustTemplate("wizard/sdk/operations.tmpl")
+	sdkClientTestTemplate = builtin.MustTemplate("wizard/sdk/client-test.tmpl")
+	sdkExampleTemplate    = builtin.MustTemplate("wizard/sdk/example.tmpl")
+
+	sdkReleaseWorkflowTemplate = builtin.MustTemplate("wizard/sdk/release-workflow.tmpl")
+	// sdkMakeTargetsTemplate has the target that makes a released version
+	// known to the module proxy, so that go get finds it at once, and
+	// sdkReadmeTemplate explains how to use the client and how releases are
+	// published
+	sdkMakeTargetsTemplate = builtin.MustTemplate("wizard/sdk/make-targets.tmpl")
+	sdkMakeHelpTemplate    = builtin.MustTemplate("wizard/sdk/make-help.tmpl")
+	sdkReadmeTemplate      = builtin.MustTemplate("wizard/sdk/readme-section.tmpl")
+)
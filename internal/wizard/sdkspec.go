@@ -0,0 +1,1076 @@
+package wizard
+
+import (
+	"fmt"
+	"go/token"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/oculus-core/gogo/internal/templates"
+)
+
+// openAPIDocument is the part of an OpenAPI 3 document that SDKs are
+// generated from
+type openAPIDocument struct {
+	OpenAPI string `yaml:"openapi"`
+	Info    struct {
+		Title       string `yaml:"title"`
+		Description string `yaml:"description"`
+		Version     string `yaml:"version"`
+	} `yaml:"info"`
+	Servers []struct {
+		URL string `yaml:"url"`
+	} `yaml:"servers"`
+	Tags []struct {
+		Name        string `yaml:"name"`
+		Description string `yaml:"description"`
+	} `yaml:"tags"`
+	Paths      map[string]map[string]yaml.Node `yaml:"paths"`
+	Components struct {
+		Schemas       map[string]*openAPISchema      `yaml:"schemas"`
+		Parameters    map[string]*openAPIParameter   `yaml:"parameters"`
+		RequestBodies map[string]*openAPIRequestBody `yaml:"requestBodies"`
+		Responses     map[string]*openAPIResponse    `yaml:"responses"`
+	} `yaml:"components"`
+}
+
+// openAPIOperation is an operation of a path item
+type openAPIOperation struct {
+	OperationID string                      `yaml:"operationId"`
+	Summary     string                      `yaml:"summary"`
+	Description string                      `yaml:"description"`
+	Tags        []string                    `yaml:"tags"`
+	Deprecated  bool                        `yaml:"deprecated"`
+	Parameters  []*openAPIParameter         `yaml:"parameters"`
+	RequestBody *openAPIRequestBody         `yaml:"requestBody"`
+	Responses   map[string]*openAPIResponse `yaml:"responses"`
+}
+
+// openAPIParameter is a path, query, header, or cookie parameter
+type openAPIParameter struct {
+	Ref         string         `yaml:"$ref"`
+	Name        string         `yaml:"name"`
+	In          string         `yaml:"in"`
+	Description string         `yaml:"description"`
+	Required    bool           `yaml:"required"`
+	Schema      *openAPISchema `yaml:"schema"`
+}
+
+// openAPIRequestBody is the body of an operation by media type
+type openAPIRequestBody struct {
+	Ref     string                      `yaml:"$ref"`
+	Content map[string]openAPIMediaType `yaml:"content"`
+}
+
+// openAPIResponse is a response of an operation by media type
+type openAPIResponse struct {
+	Ref     string                      `yaml:"$ref"`
+	Content map[string]openAPIMediaType `yaml:"content"`
+}
+
+// openAPIMediaType is the schema of a body in a media type
+type openAPIMediaType struct {
+	Schema *openAPISchema `yaml:"schema"`
+}
+
+// openAPISchema is the part of a JSON schema that maps to Go types
+type openAPISchema struct {
+	Ref                  string           `yaml:"$ref"`
+	Type                 schemaType       `yaml:"type"`
+	Format               string           `yaml:"format"`
+	Description          string           `yaml:"description"`
+	Nullable             bool             `yaml:"nullable"`
+	Items                *openAPISchema   `yaml:"items"`
+	Properties           schemaProperties `yaml:"properties"`
+	Required             []string         `yaml:"required"`
+	Enum                 []interface{}    `yaml:"enum"`
+	AdditionalProperties yaml.Node        `yaml:"additionalProperties"`
+	AllOf                []*openAPISchema `yaml:"allOf"`
+	OneOf                []*openAPISchema `yaml:"oneOf"`
+	AnyOf                []*openAPISchema `yaml:"anyOf"`
+}
+
+// schemaType is the type of a schema: a name in OpenAPI 3.0, and a name or a
+// list such as [string, "null"] in 3.1
+type schemaType struct {
+	name     string
+	nullable bool
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler
+func (t *schemaType) UnmarshalYAML(node *yaml.Node) error {
+	var names []string
+	if node.Kind == yaml.SequenceNode {
+		if err := node.Decode(&names); err != nil {
+			return err
+		}
+	} else {
+		names = []string{node.Value}
+	}
+	for _, name := range names {
+		if name == "null" {
+			t.nullable = true
+		} else if t.name == "" {
+			t.name = name
+		}
+	}
+	return nil
+}
+
+// schemaProperties are the properties of an object schema, in the order of
+// the document so that the fields of structs follow it
+type schemaProperties struct {
+	names   []string
+	schemas map[string]*openAPISchema
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler
+func (p *schemaProperties) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: properties must be a map", node.Line)
+	}
+	p.schemas = make(map[string]*openAPISchema)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		var schema openAPISchema
+		if err := node.Content[i+1].Decode(&schema); err != nil {
+			return err
+		}
+		name := node.Content[i].Value
+		p.names = append(p.names, name)
+		p.schemas[name] = &schema
+	}
+	return nil
+}
+
+// loadSDKSpec reads an OpenAPI 3 document in YAML or JSON
+func loadSDKSpec(specPath string) (*openAPIDocument, error) {
+	if specPath == "" {
+		return nil, fmt.Errorf("sdk projects need an OpenAPI spec: pass --from-openapi or set openapi_spec")
+	}
+	raw, err := os.ReadFile(specPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OpenAPI spec: %w", err)
+	}
+	var doc openAPIDocument
+	if err := yaml.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI spec %s: %w", specPath, err)
+	}
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		return nil, fmt.Errorf("%s is not an OpenAPI 3 document: convert Swagger 2 specs to OpenAPI 3 first", specPath)
+	}
+	return &doc, nil
+}
+
+// sdkModel is the client an SDK is generated as
+type sdkModel struct {
+	Title       string
+	Description string
+	BaseURL     string
+	Types       []*sdkType
+	Services    []*sdkService
+	// Operations are the operations without a tag, which are methods of the
+	// Client
+	Operations []*sdkOperation
+	// Example is the operation the example program and its test call, a GET
+	// without path parameters or a body, or nil when there is none
+	Example *sdkOperation
+	// UsesJSON and UsesTime report whether types.go imports encoding/json
+	// and time
+	UsesJSON bool
+	UsesTime bool
+}
+
+// HasQuery reports whether an operation has query parameters
+func (m *sdkModel) HasQuery() bool {
+	for _, op := range m.allOperations() {
+		if op.Query != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// allOperations returns the operations of the Client and of the services
+func (m *sdkModel) allOperations() []*sdkOperation {
+	ops := append([]*sdkOperation(nil), m.Operations...)
+	for _, s := range m.Services {
+		ops = append(ops, s.Operations...)
+	}
+	return ops
+}
+
+// sdkService groups the operations of a tag
+type sdkService struct {
+	// Name is the Go type, such as PetsService, and Field the field of the
+	// Client, such as Pets
+	Name       string
+	Field      string
+	Tag        string
+	Doc        string
+	File       string
+	Operations []*sdkOperation
+	Comment    string
+}
+
+// sdkType is a Go type declared for a schema: a struct with Fields, or a
+// defined type of Underlying with the constants of Enum
+type sdkType struct {
+	Name       string
+	Origin     string
+	Doc        string
+	Fields     []sdkField
+	Underlying string
+	Enum       []sdkEnumValue
+	Comment    string
+	// Alias declares an alias of Underlying, which keeps the JSON methods
+	// of types such as json.RawMessage and time.Time
+	Alias bool
+}
+
+// sdkField is a field of a struct
+type sdkField struct {
+	Name    string
+	Type    string
+	Key     string
+	JSON    string
+	Doc     string
+	Comment string
+}
+
+// sdkEnumValue is a constant of an enum type
+type sdkEnumValue struct {
+	Name  string
+	Value string
+}
+
+// sdkOperation is a method generated for an operation
+type sdkOperation struct {
+	Name        string
+	Method      string
+	MethodConst string
+	Path        string
+	Doc         string
+	Deprecated  bool
+	// Receiver is the receiver of the method, ReceiverVar its name, and
+	// Client the expression of the Client in the method
+	Receiver    string
+	ReceiverVar string
+	Client      string
+	// Call is how the method is called on a Client, such as Pets.ListPets
+	Call       string
+	PathExpr   string
+	PathParams []sdkParam
+	Query      *sdkQuery
+	// Body is the Go type of the body: a JSON value with JSONBody, or an
+	// io.Reader sent as BodyContentType
+	Body            string
+	JSONBody        bool
+	BodyContentType string
+	// Result is the Go type the response is decoded into, returned as a
+	// pointer with ResultPointer
+	Result        string
+	ResultPointer bool
+	Signature     string
+	Returns       string
+	Pager         *sdkPager
+	// Request is the request literal the method sends, and Comment its doc
+	// comment
+	Request string
+	Comment string
+}
+
+// sdkParam is a path parameter
+type sdkParam struct {
+	Name string
+	Type string
+}
+
+// sdkQuery is the struct of the query parameters of an operation
+type sdkQuery struct {
+	Type    string
+	Fields  []sdkQueryField
+	Comment string
+}
+
+// sdkQueryField is a query parameter
+type sdkQueryField struct {
+	Name    string
+	Key     string
+	Type    string
+	Doc     string
+	List    bool
+	Comment string
+}
+
+// sdkPager is the method that returns a Pager over a list operation
+type sdkPager struct {
+	Name        string
+	Signature   string
+	Call        string
+	Item        string
+	CursorField string
+	CursorKey   string
+	ItemsField  string
+	ItemsKey    string
+	NextField   string
+	Comment     string
+}
+
+// sdkReservedNames are the package-level names of the client that schemas and
+// operations must not take
+var sdkReservedNames = []string{
+	"Client", "Option", "New", "WithBaseURL", "WithHTTPClient", "WithAuthToken", "WithHeader", "WithUserAgent",
+	"WithRetryPolicy", "APIError", "RetryPolicy", "DefaultRetryPolicy", "DefaultBaseURL", "Pager", "NewPager",
+}
+
+// sdkReservedVars are the names the generated methods use, which parameters
+// must not take
+var sdkReservedVars = map[string]bool{
+	"body": true, "c": true, "ctx": true, "cursor": true, "err": true, "out": true, "p": true, "page": true,
+	"params": true, "q": true, "req": true, "s": true,
+	// and the packages they use
+	"context": true, "fmt": true, "http": true, "io": true, "url": true,
+}
+
+// sdkCursorKeys are the query parameters that take the cursor of the next
+// page, and sdkNextKeys the response properties that hold it
+var (
+	sdkCursorKeys = map[string]bool{"cursor": true, "page_token": true, "pageToken": true, "after": true,
+		"starting_after": true, "next_token": true, "nextToken": true}
+	sdkNextKeys = map[string]bool{"next_cursor": true, "nextCursor": true, "next_page_token": true,
+		"nextPageToken": true, "next_token": true, "nextToken": true, "next": true}
+)
+
+// sdkMethods are the operation keys of a path item, in output order
+var sdkMethods = []string{"get", "post", "put", "patch", "delete", "head", "options"}
+
+// sdkBuilder turns an OpenAPI document into an sdkModel, naming the types it
+// declares for inline schemas after where they appear
+type sdkBuilder struct {
+	doc   *openAPIDocument
+	model *sdkModel
+	// names are the package-level names taken, refs the Go names of the
+	// component schemas, and structs the types that are structs
+	names   map[string]bool
+	refs    map[string]string
+	structs map[string]*sdkType
+}
+
+// buildSDKModel builds the client of an OpenAPI document
+func buildSDKModel(doc *openAPIDocument) (*sdkModel, error) {
+	b := &sdkBuilder{
+		doc:     doc,
+		model:   &sdkModel{Title: collapse(doc.Info.Title), Description: collapse(doc.Info.Description)},
+		names:   make(map[string]bool),
+		refs:    make(map[string]string),
+		structs: make(map[string]*sdkType),
+	}
+	if len(doc.Servers) > 0 {
+		b.model.BaseURL = doc.Servers[0].URL
+	}
+	for _, name := range sdkReservedNames {
+		b.names[name] = true
+	}
+
+	// Name the component schemas first, so they keep their names
+	components := sortedKeys(doc.Components.Schemas)
+	for _, name := range components {
+		b.refs[name] = b.uniqueName(goName(name))
+	}
+	for _, name := range components {
+		if err := b.declareComponent(b.refs[name], doc.Components.Schemas[name]); err != nil {
+			return nil, fmt.Errorf("schema %s: %w", name, err)
+		}
+	}
+
+	if err := b.buildOperations(); err != nil {
+		return nil, err
+	}
+	for _, op := range b.model.allOperations() {
+		if op.Method == "GET" && len(op.PathParams) == 0 && op.Body == "" {
+			b.model.Example = op
+			break
+		}
+	}
+	return b.model, nil
+}
+
+// buildOperations builds the methods of the operations, grouped into a
+// service by their first tag
+func (b *sdkBuilder) buildOperations() error {
+	tagDocs := make(map[string]string)
+	for _, tag := range b.doc.Tags {
+		tagDocs[tag.Name] = collapse(tag.Description)
+	}
+	services := make(map[string]*sdkService)
+	members := map[string]map[string]bool{"": {}}
+
+	for _, path := range sortedKeys(b.doc.Paths) {
+		item := b.doc.Paths[path]
+		var shared []*openAPIParameter
+		if node, ok := item["parameters"]; ok {
+			if err := node.Decode(&shared); err != nil {
+				return fmt.Errorf("invalid parameters of %s: %w", path, err)
+			}
+		}
+		for _, method := range sdkMethods {
+			node, ok := item[method]
+			if !ok {
+				continue
+			}
+			var op openAPIOperation
+			if err := node.Decode(&op); err != nil {
+				return fmt.Errorf("invalid operation %s %s: %w", strings.ToUpper(method), path, err)
+			}
+
+			tag := ""
+			if len(op.Tags) > 0 {
+				tag = op.Tags[0]
+			}
+			svc, ok := services[tag]
+			if !ok && tag != "" {
+				field := goName(tag)
+				for members[""][field] {
+					field += "API"
+				}
+				members[""][field] = true
+				svc = &sdkService{
+					Name:  b.uniqueName(field + "Service"),
+					Field: field,
+					Tag:   tag,
+					Doc:   tagDocs[tag],
+					File:  strings.ReplaceAll(templates.FuncMap()["snakeCase"].(func(string) string)(field), "_", "") + ".go",
+				}
+				services[tag] = svc
+				members[tag] = make(map[string]bool)
+				b.model.Services = append(b.model.Services, svc)
+			}
+
+			o, err := b.operation(path, method, &op, shared, svc, members[tag])
+			if err != nil {
+				return fmt.Errorf("operation %s %s: %w", strings.ToUpper(method), path, err)
+			}
+			if svc != nil {
+				svc.Operations = append(svc.Operations, o)
+			} else {
+				b.model.Operations = append(b.model.Operations, o)
+			}
+		}
+	}
+
+	// Service files must not replace the files of the client
+	for _, svc := range b.model.Services {
+		switch svc.File {
+		case "client.go", "retry.go", "pagination.go", "types.go", "operations.go":
+			svc.File = strings.TrimSuffix(svc.File, ".go") + "service.go"
+		}
+	}
+	return nil
+}
+
+// operation builds the method of an operation of svc, or of the Client when
+// svc is nil. members are the names taken on the receiver.
+func (b *sdkBuilder) operation(path, method string, op *openAPIOperation, shared []*openAPIParameter, svc *sdkService, members map[string]bool) (*sdkOperation, error) {
+	name := op.OperationID
+	if name == "" {
+		name = method + " " + strings.NewReplacer("{", "", "}", "").Replace(path)
+	}
+	o := &sdkOperation{
+		Name:        uniqueMember(goName(name), members),
+		Method:      strings.ToUpper(method),
+		MethodConst: "http.Method" + goName(method),
+		Path:        path,
+		Doc:         collapse(op.Summary),
+		Deprecated:  op.Deprecated,
+		Receiver:    "c *Client",
+		ReceiverVar: "c",
+		Client:      "c",
+	}
+	if o.Doc == "" {
+		o.Doc = firstSentence(op.Description)
+	}
+	o.Call = o.Name
+	if svc != nil {
+		o.Receiver, o.ReceiverVar, o.Client = "s *"+svc.Name, "s", "s.client"
+		o.Call = svc.Field + "." + o.Name
+	}
+
+	params, err := b.parameters(shared, op.Parameters)
+	if err != nil {
+		return nil, err
+	}
+	if err := b.pathParameters(o, params); err != nil {
+		return nil, err
+	}
+	if err := b.queryParameters(o, params); err != nil {
+		return nil, err
+	}
+	if err := b.requestBody(o, op.RequestBody); err != nil {
+		return nil, err
+	}
+	if err := b.result(o, op.Responses); err != nil {
+		return nil, err
+	}
+
+	args := []string{"ctx context.Context"}
+	for _, p := range o.PathParams {
+		args = append(args, p.Name+" "+p.Type)
+	}
+	if o.Query != nil {
+		args = append(args, "params *"+o.Query.Type)
+	}
+	if o.Body != "" {
+		args = append(args, "body "+o.Body)
+	}
+	o.Signature = strings.Join(args, ", ")
+	req := []string{"method: " + o.MethodConst, "path: " + o.PathExpr}
+	if o.Query != nil {
+		req = append(req, "query: params.values()")
+	}
+	if o.JSONBody {
+		req = append(req, "json: body")
+	} else if o.Body != "" {
+		req = append(req, "body: body", "contentType: "+strconv.Quote(o.BodyContentType))
+	}
+	o.Request = "request{" + strings.Join(req, ", ") + "}"
+	switch {
+	case o.Result == "":
+		o.Returns = "error"
+	case o.ResultPointer:
+		o.Returns = "(*" + o.Result + ", error)"
+	default:
+		o.Returns = "(" + o.Result + ", error)"
+	}
+	o.Pager = b.pager(o, members)
+	return o, nil
+}
+
+// parameters merges the parameters of a path item with those of its
+// operation, which override them, resolving references
+func (b *sdkBuilder) parameters(shared, own []*openAPIParameter) ([]*openAPIParameter, error) {
+	var params []*openAPIParameter
+	index := make(map[string]int)
+	for _, p := range append(append([]*openAPIParameter(nil), shared...), own...) {
+		if p.Ref != "" {
+			ref, ok := b.doc.Components.Parameters[strings.TrimPrefix(p.Ref, "#/components/parameters/")]
+			if !ok || !strings.HasPrefix(p.Ref, "#/components/parameters/") {
+				return nil, fmt.Errorf("unsupported $ref %q", p.Ref)
+			}
+			p = ref
+		}
+		key := p.In + " " + p.Name
+		if i, ok := index[key]; ok {
+			params[i] = p
+			continue
+		}
+		index[key] = len(params)
+		params = append(params, p)
+	}
+	return params, nil
+}
+
+// pathParameters builds the path expression of o and its path parameters, in
+// the order of the path
+func (b *sdkBuilder) pathParameters(o *sdkOperation, params []*openAPIParameter) error {
+	types := make(map[string]string)
+	for _, p := range params {
+		if p.In == "path" {
+			t, _, err := b.paramType(p.Schema)
+			if err != nil {
+				return fmt.Errorf("parameter %s: %w", p.Name, err)
+			}
+			types[p.Name] = t
+		}
+	}
+
+	var parts []string
+	vars := make(map[string]bool)
+	rest := o.Path
+	for rest != "" {
+		start := strings.Index(rest, "{")
+		end := strings.Index(rest, "}")
+		if start < 0 || end < start {
+			parts = append(parts, strconv.Quote(rest))
+			break
+		}
+		if start > 0 {
+			parts = append(parts, strconv.Quote(rest[:start]))
+		}
+		param := rest[start+1 : end]
+		rest = rest[end+1:]
+
+		t := types[param]
+		if t == "" {
+			t = "string"
+		}
+		name := goVar(param)
+		for vars[name] {
+			name += "2"
+		}
+		vars[name] = true
+		o.PathParams = append(o.PathParams, sdkParam{Name: name, Type: t})
+		if t == "string" {
+			parts = append(parts, "url.PathEscape("+name+")")
+		} else {
+			parts = append(parts, "url.PathEscape(fmt.Sprint("+name+"))")
+		}
+	}
+	o.PathExpr = strings.Join(parts, " + ")
+	if o.PathExpr == "" {
+		o.PathExpr = `""`
+	}
+	return nil
+}
+
+// queryParameters declares the struct of the query parameters of o. Query
+// parameters of object types are left out; header and cookie parameters are
+// set with WithHeader.
+func (b *sdkBuilder) queryParameters(o *sdkOperation, params []*openAPIParameter) error {
+	query := &sdkQuery{}
+	fields := make(map[string]bool)
+	for _, p := range params {
+		if p.In != "query" {
+			continue
+		}
+		t, ok, err := b.paramType(p.Schema)
+		if err != nil {
+			return fmt.Errorf("parameter %s: %w", p.Name, err)
+		}
+		if !ok {
+			continue
+		}
+		f := sdkQueryField{Name: uniqueMember(goName(p.Name), fields), Key: p.Name, Type: t, Doc: collapse(p.Description)}
+		f.List = strings.HasPrefix(t, "[]")
+		if p.Required {
+			f.Doc = strings.TrimSpace(f.Doc + " (required)")
+		}
+		query.Fields = append(query.Fields, f)
+	}
+	if len(query.Fields) > 0 {
+		query.Type = b.uniqueName(o.Name + "Params")
+		o.Query = query
+	}
+	return nil
+}
+
+// requestBody sets the body of o: a JSON value, or an io.Reader for other
+// media types
+func (b *sdkBuilder) requestBody(o *sdkOperation, body *openAPIRequestBody) error {
+	if body == nil {
+		return nil
+	}
+	if body.Ref != "" {
+		ref, ok := b.doc.Components.RequestBodies[strings.TrimPrefix(body.Ref, "#/components/requestBodies/")]
+		if !ok || !strings.HasPrefix(body.Ref, "#/components/requestBodies/") {
+			return fmt.Errorf("unsupported $ref %q", body.Ref)
+		}
+		body = ref
+	}
+	if len(body.Content) == 0 {
+		return nil
+	}
+	if schema, ok := jsonSchema(body.Content); ok {
+		t, err := b.operationType(schema, o.Name+"Request", "the request body of "+o.Name)
+		if err != nil {
+			return fmt.Errorf("request body: %w", err)
+		}
+		if b.structs[t] != nil {
+			t = "*" + t
+		}
+		o.Body, o.JSONBody = t, true
+		return nil
+	}
+	o.Body, o.BodyContentType = "io.Reader", sortedKeys(body.Content)[0]
+	return nil
+}
+
+// result sets the type the first 2xx response with a JSON body of o is
+// decoded into
+func (b *sdkBuilder) result(o *sdkOperation, responses map[string]*openAPIResponse) error {
+	for _, code := range sortedKeys(responses) {
+		if !strings.HasPrefix(code, "2") {
+			continue
+		}
+		resp := responses[code]
+		if resp.Ref != "" {
+			ref, ok := b.doc.Components.Responses[strings.TrimPrefix(resp.Ref, "#/components/responses/")]
+			if !ok || !strings.HasPrefix(resp.Ref, "#/components/responses/") {
+				return fmt.Errorf("unsupported $ref %q", resp.Ref)
+			}
+			resp = ref
+		}
+		schema, ok := jsonSchema(resp.Content)
+		if !ok {
+			continue
+		}
+		t, err := b.operationType(schema, o.Name+"Response", "the response of "+o.Name)
+		if err != nil {
+			return fmt.Errorf("response %s: %w", code, err)
+		}
+		o.Result, o.ResultPointer = t, b.structs[t] != nil
+		return nil
+	}
+	return nil
+}
+
+// pager returns the Pager method of a list operation: one with a cursor query
+// parameter whose response holds a list and the cursor of the next page
+func (b *sdkBuilder) pager(o *sdkOperation, members map[string]bool) *sdkPager {
+	if o.Query == nil || !o.ResultPointer || o.Body != "" {
+		return nil
+	}
+	p := &sdkPager{}
+	for _, f := range o.Query.Fields {
+		if sdkCursorKeys[f.Key] && f.Type == "string" {
+			p.CursorField, p.CursorKey = f.Name, f.Key
+		}
+	}
+	lists := 0
+	for _, f := range b.structs[o.Result].Fields {
+		switch {
+		case strings.HasPrefix(f.Type, "[]"):
+			lists++
+			p.ItemsField, p.ItemsKey, p.Item = f.Name, f.Key, strings.TrimPrefix(f.Type, "[]")
+		case sdkNextKeys[f.Key] && f.Type == "string":
+			p.NextField = f.Name
+		}
+	}
+	if p.CursorField == "" || p.NextField == "" || lists != 1 {
+		return nil
+	}
+
+	p.Name = uniqueMember(o.Name+"Pager", members)
+	var args, call []string
+	for _, param := range o.PathParams {
+		args = append(args, param.Name+" "+param.Type)
+		call = append(call, param.Name)
+	}
+	p.Signature = strings.Join(append(args, "params *"+o.Query.Type), ", ")
+	p.Call = strings.Join(append(append([]string{"ctx"}, call...), "&p"), ", ")
+	return p
+}
+
+// jsonSchema returns the schema of the JSON media type of content
+func jsonSchema(content map[string]openAPIMediaType) (*openAPISchema, bool) {
+	for _, mediaType := range sortedKeys(content) {
+		if mediaType == "application/json" || strings.HasSuffix(mediaType, "+json") {
+			return content[mediaType].Schema, true
+		}
+	}
+	return nil, false
+}
+
+// operationType returns the Go type of a body. Types from other packages,
+// such as json.RawMessage, are declared in types.go so that the service files
+// need not import them.
+func (b *sdkBuilder) operationType(s *openAPISchema, hint, origin string) (string, error) {
+	t, err := b.goType(s, hint, origin)
+	if err != nil || !strings.Contains(t, ".") {
+		return t, err
+	}
+	name := b.uniqueName(hint)
+	b.model.Types = append(b.model.Types, &sdkType{Name: name, Origin: origin, Underlying: t, Alias: true})
+	return name, nil
+}
+
+// paramType returns the Go type of a path or query parameter: a scalar, a
+// string enum, or a list of them. It reports false for object parameters.
+func (b *sdkBuilder) paramType(s *openAPISchema) (string, bool, error) {
+	if s == nil {
+		return "string", true, nil
+	}
+	if s.Ref != "" {
+		name, target, err := b.resolve(s.Ref)
+		if err != nil {
+			return "", false, err
+		}
+		if t := b.structs[name]; t != nil || target.Type.name == "object" || target.Type.name == "array" {
+			return "", false, nil
+		}
+		return name, true, nil
+	}
+	switch s.Type.name {
+	case "array":
+		item, ok, err := b.paramType(s.Items)
+		if err != nil || !ok || strings.HasPrefix(item, "[]") {
+			return "", false, err
+		}
+		return "[]" + item, true, nil
+	case "integer":
+		if s.Format == "int32" {
+			return "int32", true, nil
+		}
+		return "int64", true, nil
+	case "number":
+		return "float64", true, nil
+	case "boolean":
+		return "bool", true, nil
+	case "object":
+		return "", false, nil
+	default:
+		return "string", true, nil
+	}
+}
+
+// resolve returns the Go name and the schema of a component schema reference
+func (b *sdkBuilder) resolve(ref string) (string, *openAPISchema, error) {
+	const prefix = "#/components/schemas/"
+	name, ok := b.refs[strings.TrimPrefix(ref, prefix)]
+	if !ok || !strings.HasPrefix(ref, prefix) {
+		return "", nil, fmt.Errorf("unsupported $ref %q: only component schemas of the same document are supported", ref)
+	}
+	return name, b.doc.Components.Schemas[strings.TrimPrefix(ref, prefix)], nil
+}
+
+// declareComponent declares the Go type of a component schema
+func (b *sdkBuilder) declareComponent(name string, s *openAPISchema) error {
+	origin := "the " + name + " schema"
+	if isObjectSchema(s) {
+		return b.declareStruct(name, s, origin)
+	}
+	if s.Type.name == "string" && len(s.Enum) > 0 {
+		t := &sdkType{Name: name, Origin: origin, Doc: collapse(s.Description), Underlying: "string"}
+		b.model.Types = append(b.model.Types, t)
+		for _, v := range s.Enum {
+			value, ok := v.(string)
+			if !ok || goName(value) == "" {
+				continue
+			}
+			t.Enum = append(t.Enum, sdkEnumValue{Name: b.uniqueName(name + goName(value)), Value: value})
+		}
+		return nil
+	}
+
+	t := &sdkType{Name: name, Origin: origin, Doc: collapse(s.Description)}
+	b.model.Types = append(b.model.Types, t)
+	underlying, err := b.goType(s, name+"Item", origin)
+	if err != nil {
+		return err
+	}
+	t.Underlying = underlying
+	t.Alias = strings.Contains(underlying, ".")
+	return nil
+}
+
+// declareStruct declares a struct for an object schema. The properties of
+// the schemas of allOf come first.
+func (b *sdkBuilder) declareStruct(name string, s *openAPISchema, origin string) error {
+	t := &sdkType{Name: name, Origin: origin, Doc: collapse(s.Description)}
+	b.model.Types = append(b.model.Types, t)
+	b.structs[name] = t
+
+	var props []string
+	schemas := make(map[string]*openAPISchema)
+	required := make(map[string]bool)
+	var merge func(s *openAPISchema) error
+	merge = func(s *openAPISchema) error {
+		if s.Ref != "" {
+			_, target, err := b.resolve(s.Ref)
+			if err != nil {
+				return err
+			}
+			s = target
+		}
+		for _, member := range s.AllOf {
+			if err := merge(member); err != nil {
+				return err
+			}
+		}
+		for _, prop := range s.Properties.names {
+			if _, ok := schemas[prop]; !ok {
+				props = append(props, prop)
+			}
+			schemas[prop] = s.Properties.schemas[prop]
+		}
+		for _, prop := range s.Required {
+			required[prop] = true
+		}
+		return nil
+	}
+	if err := merge(s); err != nil {
+		return err
+	}
+
+	fields := make(map[string]bool)
+	for _, prop := range props {
+		ps := schemas[prop]
+		fieldName := uniqueMember(goName(prop), fields)
+		ft, err := b.goType(ps, name+fieldName, "the "+prop+" property of "+name)
+		if err != nil {
+			return fmt.Errorf("property %s: %w", prop, err)
+		}
+		f := sdkField{Name: fieldName, Type: ft, Key: prop, JSON: prop, Doc: collapse(ps.Description)}
+		if !required[prop] {
+			f.JSON += ",omitempty"
+			if b.structs[ft] != nil || ft == "time.Time" || ps.Nullable || ps.Type.nullable {
+				f.Type = "*" + ft
+			}
+		}
+		t.Fields = append(t.Fields, f)
+	}
+	if len(t.Fields) == 0 {
+		t.Underlying = "struct{}"
+	}
+	return nil
+}
+
+// goType returns the Go type of a schema, declaring structs for inline
+// object schemas named after hint
+func (b *sdkBuilder) goType(s *openAPISchema, hint, origin string) (string, error) {
+	if s == nil {
+		return "any", nil
+	}
+	if s.Ref != "" {
+		name, _, err := b.resolve(s.Ref)
+		return name, err
+	}
+	if len(s.AllOf) == 1 && len(s.Properties.names) == 0 {
+		return b.goType(s.AllOf[0], hint, origin)
+	}
+	if len(s.OneOf) > 0 || len(s.AnyOf) > 0 {
+		b.model.UsesJSON = true
+		return "json.RawMessage", nil
+	}
+	if isObjectSchema(s) {
+		name := b.uniqueName(hint)
+		return name, b.declareStruct(name, s, origin)
+	}
+
+	switch s.Type.name {
+	case "array":
+		item, err := b.goType(s.Items, hint+"Item", "an item of "+origin)
+		return "[]" + item, err
+	case "string":
+		if s.Format == "date-time" {
+			b.model.UsesTime = true
+			return "time.Time", nil
+		}
+		return "string", nil
+	case "integer":
+		if s.Format == "int32" {
+			return "int32", nil
+		}
+		return "int64", nil
+	case "number":
+		if s.Format == "float" {
+			return "float32", nil
+		}
+		return "float64", nil
+	case "boolean":
+		return "bool", nil
+	case "object":
+		if s.AdditionalProperties.Kind == yaml.MappingNode {
+			var values openAPISchema
+			if err := s.AdditionalProperties.Decode(&values); err != nil {
+				return "", err
+			}
+			value, err := b.goType(&values, hint+"Value", "a value of "+origin)
+			return "map[string]" + value, err
+		}
+		return "map[string]any", nil
+	default:
+		return "any", nil
+	}
+}
+
+// isObjectSchema reports whether a schema is declared as a struct: an object
+// with properties, or a composition of them with allOf
+func isObjectSchema(s *openAPISchema) bool {
+	if s.Ref != "" || (s.Type.name != "" && s.Type.name != "object") {
+		return false
+	}
+	return len(s.Properties.names) > 0 || len(s.AllOf) > 1 || (len(s.AllOf) == 1 && len(s.Properties.names) > 0)
+}
+
+// uniqueName takes a package-level name, adding a number when it is taken
+func (b *sdkBuilder) uniqueName(name string) string {
+	return uniqueMember(name, b.names)
+}
+
+// uniqueMember takes a name in taken, adding a number when it is already
+// taken
+func uniqueMember(name string, taken map[string]bool) string {
+	unique := name
+	for i := 2; taken[unique]; i++ {
+		unique = name + strconv.Itoa(i)
+	}
+	taken[unique] = true
+	return unique
+}
+
+// goInitialisms are the words written in upper case in Go names
+var goInitialisms = map[string]bool{
+	"api": true, "cpu": true, "dns": true, "html": true, "http": true, "https": true, "id": true, "ip": true,
+	"json": true, "sql": true, "ssh": true, "tls": true, "ttl": true, "ui": true, "uri": true, "url": true,
+	"uuid": true, "xml": true,
+}
+
+// goName turns a name of the document, such as pet_id or list-pets, into an
+// exported Go name, such as PetID or ListPets
+func goName(s string) string {
+	var b strings.Builder
+	for _, word := range strings.Split(templates.FuncMap()["kebabCase"].(func(string) string)(s), "-") {
+		if word == "" {
+			continue
+		}
+		if goInitialisms[word] {
+			b.WriteString(strings.ToUpper(word))
+		} else {
+			r := []rune(word)
+			b.WriteString(string(unicode.ToUpper(r[0])) + string(r[1:]))
+		}
+	}
+	name := b.String()
+	if name != "" && name[0] >= '0' && name[0] <= '9' {
+		name = "N" + name
+	}
+	return name
+}
+
+// goVar turns a name of the document into an unexported Go name that does
+// not shadow the variables of the generated methods
+func goVar(s string) string {
+	name := goName(s)
+	if name == "" {
+		return "param"
+	}
+	// Lower the leading initialism or word: PetID -> petID, IDType -> idType
+	i := 1
+	for i < len(name) && unicode.IsUpper(rune(name[i])) && (i+1 == len(name) || unicode.IsUpper(rune(name[i+1]))) {
+		i++
+	}
+	name = strings.ToLower(name[:i]) + name[i:]
+	if token.IsKeyword(name) || sdkReservedVars[name] {
+		name += "Param"
+	}
+	return name
+}
+
+// collapse joins the lines of a description into one line
+func collapse(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// firstSentence returns the first sentence of a description
+func firstSentence(s string) string {
+	s = collapse(s)
+	if i := strings.Index(s, ". "); i >= 0 {
+		return s[:i+1]
+	}
+	return s
+}
+
+// sortedKeys returns the keys of m in order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
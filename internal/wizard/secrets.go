@@ -0,0 +1,79 @@
+package wizard
+
+import (
+	"github.com/oculus-core/gogo/internal/templates/builtin"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// sopsRecipientPlaceholder stands in for the age recipients in .sops.yaml
+// until make secrets-init replaces it with the public key of the developer
+const sopsRecipientPlaceholder = "age1replacewithyourrecipient"
+
+// hasSecrets reports whether the project gets a secrets management setup
+func hasSecrets(cfg *config.ProjectConfig) bool {
+	return cfg.SecretsManager == config.SecretsManagerSops || cfg.SecretsManager == config.SecretsManagerVault
+}
+
+// secretsData is the data the secrets templates are rendered with
+type secretsData struct {
+	*config.ProjectConfig
+	// CatalogName names the database user and the Vault secret of the project
+	CatalogName string
+	// Recipient stands in for the age recipients in .sops.yaml
+	Recipient string
+}
+
+func newSecretsData(cfg *config.ProjectConfig) secretsData {
+	return secretsData{ProjectConfig: cfg, CatalogName: catalogName(cfg.Name), Recipient: sopsRecipientPlaceholder}
+}
+
+// generateSecrets creates the files of the secrets manager: .sops.yaml and the
+// example secrets for sops, or the agent config and template for Vault
+func generateSecrets(cfg *config.ProjectConfig, projectDir string) error {
+	var managerFiles []struct{ path, text string }
+	switch cfg.SecretsManager {
+	case config.SecretsManagerSops:
+		managerFiles = sopsFiles
+	case config.SecretsManagerVault:
+		managerFiles = vaultFiles
+	default:
+		return nil
+	}
+	files, err := renderFiles(newSecretsData(cfg), managerFiles)
+	if err != nil {
+		return err
+	}
+	return writeFiles(projectDir, files)
+}
+
+var (
+	// sopsFiles are the .sops.yaml that encrypts secrets/*.enc.yaml for the
+	// age recipients of the team, and the plaintext example that make
+	// secrets-init encrypts to secrets/secrets.enc.yaml
+	sopsFiles = []struct{ path, text string }{
+		{".sops.yaml", sopsConfigTemplate},
+		{"secrets/secrets.example.yaml", sopsExampleSecretsTemplate},
+	}
+	// vaultFiles are the Vault Agent config that authenticates with AppRole,
+	// and the consul-template file that it renders to .env from the KV v2
+	// secret of the project
+	vaultFiles = []struct{ path, text string }{
+		{"vault/agent.hcl", vaultAgentTemplate},
+		{"vault/secrets.env.ctmpl", vaultSecretsTemplate},
+	}
+)
+
+var (
+	sopsConfigTemplate         = builtin.MustTemplate("wizard/secrets/sops.tmpl")
+	sopsExampleSecretsTemplate = builtin.MustTemplate("wizard/secrets/example-secrets.tmpl")
+	vaultAgentTemplate         = builtin.MustTemplate("wizard/secrets/vault-agent.tmpl")
+	vaultSecretsTemplate       = builtin.MustTemplate("wizard/secrets/vault-secrets.tmpl")
+	// secretsMakeTargetsTemplate has the Makefile targets of the secrets
+	// manager, secretsIgnoreTemplate the .gitignore entries for decrypted or
+	// rendered secrets, and sopsCIStepTemplate the CI step that fails when a
+	// file meant to be encrypted was committed in plaintext
+	secretsMakeTargetsTemplate = builtin.MustTemplate("wizard/secrets/make-targets.tmpl")
+	secretsMakeHelpTemplate    = builtin.MustTemplate("wizard/secrets/make-help.tmpl")
+	secretsIgnoreTemplate      = builtin.MustTemplate("wizard/secrets/gitignore.tmpl")
+	sopsCIStepTemplate         = builtin.MustTemplate("wizard/secrets/ci-step.tmpl")
+)
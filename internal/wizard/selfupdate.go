@@ -0,0 +1,71 @@
+package wizard
+
+import (
+	"strings"
+
+	"github.com/oculus-core/gogo/internal/templates/builtin"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// hasSelfUpdate reports whether the CLI updates itself from GitHub releases,
+// which CLI projects with Cobra hosted on GitHub with use_self_update do
+func hasSelfUpdate(cfg *config.ProjectConfig) bool {
+	host, owner, repo := splitModule(cfg.Module)
+	return cfg.UseSelfUpdate && cfg.UseCobra && cfg.Type == config.TypeCLI && host == "github.com" && owner != "" && repo != ""
+}
+
+// noUpdateNotifierEnv returns the environment variable that turns off the
+// update notification of the CLI, such as MY_CLI_NO_UPDATE_NOTIFIER
+func noUpdateNotifierEnv(cfg *config.ProjectConfig) string {
+	return envPrefix(cfg.Name) + "_NO_UPDATE_NOTIFIER"
+}
+
+// envPrefix turns the name of a CLI into the prefix of its environment
+// variables, such as TODO_CLI for todo-cli
+func envPrefix(name string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(name))
+}
+
+// selfUpdateData is the data the self-update templates are rendered with
+type selfUpdateData struct {
+	*config.ProjectConfig
+	// Owner and Repo name the GitHub repository of the releases
+	Owner string
+	Repo  string
+	// NoNotifierEnv turns off the update notification
+	NoNotifierEnv string
+}
+
+// selfUpdateFiles maps the paths of the self-update files to their templates
+var selfUpdateFiles = []struct{ path, text string }{
+	{"internal/update/update.go", updatePackageTemplate},
+	{"internal/update/check.go", updateNotifierTemplate},
+	{"internal/update/apply.go", updateApplyTemplate},
+	{"internal/update/update_test.go", updateTestTemplate},
+	{"cmd/{{ .Name }}/cmd/selfupdate.go", selfUpdateCommandTemplate},
+}
+
+// generateSelfUpdate creates the update package, its tests, and the
+// self-update command
+func generateSelfUpdate(cfg *config.ProjectConfig, projectDir string) error {
+	_, owner, repo := splitModule(cfg.Module)
+	data := selfUpdateData{
+		ProjectConfig: cfg,
+		Owner:         owner,
+		Repo:          repo,
+		NoNotifierEnv: noUpdateNotifierEnv(cfg),
+	}
+	files, err := renderFiles(data, selfUpdateFiles)
+	if err != nil {
+		return err
+	}
+	return writeFiles(projectDir, files)
+}
+
+var (
+	updatePackageTemplate     = builtin.MustTemplate("wizard/selfupdate/update.tmpl")
+	updateNotifierTemplate    = builtin.MustTemplate("wizard/selfupdate/check.tmpl")
+	updateApplyTemplate       = builtin.MustTemplate("wizard/selfupdate/apply.tmpl")
+	updateTestTemplate        = builtin.MustTemplate("wizard/selfupdate/update-test.tmpl")
+	selfUpdateCommandTemplate = builtin.MustTemplate("wizard/selfupdate/command.tmpl")
+)
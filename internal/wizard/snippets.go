@@ -0,0 +1,103 @@
+package wizard
+
+import (
+	"strings"
+
+	"github.com/oculus-core/gogo/internal/templates"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// snippet is a part of a generated file that a feature adds, such as a README
+// section or a step of the CI workflow
+type snippet struct {
+	// Enabled reports whether the feature adds the snippet, always when nil
+	Enabled func(cfg *config.ProjectConfig) bool
+	// Text is the template of the snippet
+	Text string
+	// Data returns the data the snippet is rendered with, the project data
+	// when nil
+	Data func(cfg *config.ProjectConfig) interface{}
+}
+
+// renderSnippets renders the enabled snippets in order and joins them, for
+// the file name
+func renderSnippets(cfg *config.ProjectConfig, name string, snippets []snippet) (string, error) {
+	var b strings.Builder
+	for _, s := range snippets {
+		if s.Enabled != nil && !s.Enabled(cfg) {
+			continue
+		}
+		var data interface{} = newProjectData(cfg)
+		if s.Data != nil {
+			data = s.Data(cfg)
+		}
+		text, err := templates.Render(name, s.Text, data)
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(text)
+	}
+	return b.String(), nil
+}
+
+// makeSnippet is what a feature adds to the Makefile: its targets, the lines
+// that describe them in make help, and the names of those that are not files
+type makeSnippet struct {
+	Enabled func(cfg *config.ProjectConfig) bool
+	Phony   string
+	Targets string
+	Help    string
+	Data    func(cfg *config.ProjectConfig) interface{}
+}
+
+// renderMakeSnippets renders the targets and help of the enabled Makefile
+// snippets and lists their phony targets after phony
+func renderMakeSnippets(cfg *config.ProjectConfig, phony string, snippets []makeSnippet) (string, string, string, error) {
+	var targets, help []snippet
+	for _, s := range snippets {
+		if !s.Enabled(cfg) {
+			continue
+		}
+		phony += s.Phony
+		if s.Targets != "" {
+			targets = append(targets, snippet{Text: s.Targets, Data: s.Data})
+			help = append(help, snippet{Text: s.Help, Data: s.Data})
+		}
+	}
+	extraTargets, err := renderSnippets(cfg, "Makefile", targets)
+	if err != nil {
+		return "", "", "", err
+	}
+	extraHelp, err := renderSnippets(cfg, "Makefile", help)
+	if err != nil {
+		return "", "", "", err
+	}
+	return phony, extraTargets, extraHelp, nil
+}
+
+// snippetField is a field of the data of a file, filled in with the snippets
+// of the enabled features
+type snippetField struct {
+	Field    *string
+	Snippets []snippet
+}
+
+// renderSnippetFields renders the snippets of each field into it, for the
+// file name
+func renderSnippetFields(cfg *config.ProjectConfig, name string, fields ...snippetField) error {
+	for _, f := range fields {
+		text, err := renderSnippets(cfg, name, f.Snippets)
+		if err != nil {
+			return err
+		}
+		*f.Field = text
+	}
+	return nil
+}
+
+// ofType returns the Enabled func of the snippets of the project type t
+func ofType(t config.ProjectType) func(cfg *config.ProjectConfig) bool {
+	return func(cfg *config.ProjectConfig) bool {
+		return cfg.Type == t
+	}
+}
@@ -0,0 +1,35 @@
+package wizard
+
+import (
+	"github.com/oculus-core/gogo/internal/templates/builtin"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// hasStarterDocs reports whether the project gets the architecture,
+// development, and deployment pages in docs/
+func hasStarterDocs(cfg *config.ProjectConfig) bool {
+	return cfg.UseDocs
+}
+
+// Starter pages of the documentation
+var (
+	architecturePage = docsPage{Path: "architecture", Title: "Architecture", Weight: 20, Body: architectureTemplate}
+	developmentPage  = docsPage{Path: "development", Title: "Development", Weight: 22, Body: starterDevelopmentTemplate}
+	deploymentPage   = docsPage{Path: "deployment", Title: "Deployment", Weight: 24, Body: starterDeploymentTemplate}
+)
+
+// generateStarterDocs creates the starter pages in docs/, or in the content
+// directory of the documentation site
+func generateStarterDocs(cfg *config.ProjectConfig, projectDir string) error {
+	files := map[string]string{}
+	if err := renderDocsPages(cfg, files, newDocsData(cfg), architecturePage, developmentPage, deploymentPage); err != nil {
+		return err
+	}
+	return writeFiles(projectDir, files)
+}
+
+var (
+	architectureTemplate       = builtin.MustTemplate("wizard/starterdocs/architecture.tmpl")
+	starterDevelopmentTemplate = builtin.MustTemplate("wizard/starterdocs/development.tmpl")
+	starterDeploymentTemplate  = builtin.MustTemplate("wizard/starterdocs/deployment.tmpl")
+)
@@ -0,0 +1,58 @@
+package wizard
+
+import (
+	"github.com/oculus-core/gogo/internal/templates"
+	"github.com/oculus-core/gogo/internal/templates/builtin"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// stdlibRoot returns the root.go of CLI projects without Cobra: the
+// subcommands register themselves in a map and Execute dispatches to them
+// with the flag package. With Viper, a -config flag selects the config file.
+func stdlibRoot(cfg *config.ProjectConfig) (string, error) {
+	data := newProjectData(cfg)
+	if cfg.UseViper {
+		data.ConfigFileDefault = configFileDefault(cfg)
+	}
+	return templates.Render("root.go", stdlibRootTemplate, data)
+}
+
+// stdlibVersion returns the version command of CLI projects without Cobra
+func stdlibVersion(cfg *config.ProjectConfig) (string, error) {
+	data := newProjectData(cfg)
+	data.CommandDescription = commandDescription(cfg)
+	return templates.Render("version.go", stdlibVersionTemplate, data)
+}
+
+// stdlibServer returns the server.go of API projects without Gin, which
+// routes requests with http.ServeMux
+func stdlibServer(cfg *config.ProjectConfig) (string, error) {
+	// The example routes are in internal/api/greeting.go, books.go, and
+	// tasks.go, and the cached routes in cache.go. The server holds the task
+	// queue that tasks.go enqueues to, and the routes that take a request body
+	// need the post helper.
+	data := newProjectData(cfg)
+	data.ServerFields = "\tmux *http.ServeMux\n\tcfg *config.Config\n"
+	if hasHTTPCache(cfg) {
+		data.ExtraRoutes += "\n\ts.registerCachedRoutes()"
+	}
+	if hasValidation(cfg) {
+		data.ExtraRoutes += "\n\ts.mux.HandleFunc(\"/api/v1/greetings\", post(s.createGreeting))"
+	}
+	if hasPagination(cfg) {
+		data.ExtraRoutes += "\n\ts.mux.HandleFunc(\"/api/v1/books\", get(s.listBooks))"
+	}
+	if hasTaskQueue(cfg) {
+		data.ExtraRoutes += "\n\ts.mux.HandleFunc(\"/api/v1/signups\", post(s.createSignup))"
+		data.ServerFields = "\tmux   *http.ServeMux\n\tcfg   *config.Config\n\tqueue TaskQueue\n"
+	}
+	data.PostHelper = hasValidation(cfg) || hasTaskQueue(cfg)
+	data.Banner = serverBanner(cfg)
+	return templates.Render("server.go", stdlibServerTemplate, data)
+}
+
+var (
+	stdlibRootTemplate    = builtin.MustTemplate("wizard/project/stdlib-root.tmpl")
+	stdlibVersionTemplate = builtin.MustTemplate("wizard/project/stdlib-version.tmpl")
+	stdlibServerTemplate  = builtin.MustTemplate("wizard/project/stdlib-server.tmpl")
+)
@@ -0,0 +1,69 @@
+package wizard
+
+import (
+	"github.com/oculus-core/gogo/internal/templates/builtin"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// asynqDependency is the asynq version that still builds with the Go version
+// of API projects
+var asynqDependency = Dependency{"github.com/hibiken/asynq", "v0.24.1"}
+
+// hasTaskQueue reports whether the API runs background tasks
+func hasTaskQueue(cfg *config.ProjectConfig) bool {
+	return cfg.Type == config.TypeAPI && (cfg.TaskQueue == config.TaskQueueMemory || cfg.TaskQueue == config.TaskQueueAsynq)
+}
+
+// taskQueueFiles maps the paths of the task queue files to their templates
+var taskQueueFiles = []struct{ path, text string }{
+	{"internal/tasks/tasks.go", tasksTemplate},
+	{"internal/tasks/welcome.go", welcomeTaskTemplate},
+	{"internal/tasks/welcome_test.go", welcomeTaskTestTemplate},
+	{"internal/api/tasks.go", taskRoutesTemplate},
+	{"internal/api/tasks_test.go", taskRoutesTestTemplate},
+}
+
+// memoryQueueFiles and asynqQueueFiles map the paths of the files of each
+// queue to their templates
+var (
+	memoryQueueFiles = []struct{ path, text string }{
+		{"internal/tasks/queue.go", memoryQueueTemplate},
+		{"internal/tasks/queue_test.go", memoryQueueTestTemplate},
+	}
+	asynqQueueFiles = []struct{ path, text string }{
+		{"internal/tasks/queue.go", asynqQueueTemplate},
+	}
+)
+
+// generateTaskQueue creates the tasks package, the example route that enqueues
+// a task, and their tests
+func generateTaskQueue(cfg *config.ProjectConfig, projectDir string) error {
+	queueFiles := asynqQueueFiles
+	if cfg.TaskQueue == config.TaskQueueMemory {
+		queueFiles = memoryQueueFiles
+	}
+	files, err := renderFiles(newProjectData(cfg), taskQueueFiles, queueFiles)
+	if err != nil {
+		return err
+	}
+	return writeFiles(projectDir, files)
+}
+
+var (
+	tasksTemplate           = builtin.MustTemplate("wizard/tasks/tasks.tmpl")
+	memoryQueueTemplate     = builtin.MustTemplate("wizard/tasks/memory-queue.tmpl")
+	memoryQueueTestTemplate = builtin.MustTemplate("wizard/tasks/memory-queue-test.tmpl")
+	asynqQueueTemplate      = builtin.MustTemplate("wizard/tasks/asynq-queue.tmpl")
+	welcomeTaskTemplate     = builtin.MustTemplate("wizard/tasks/welcome.tmpl")
+	welcomeTaskTestTemplate = builtin.MustTemplate("wizard/tasks/welcome-test.tmpl")
+	taskRoutesTemplate      = builtin.MustTemplate("wizard/tasks/routes.tmpl")
+	taskRoutesTestTemplate  = builtin.MustTemplate("wizard/tasks/routes-test.tmpl")
+	// redisMakeTargetsTemplate runs the Redis that asynq queues the tasks in.
+	// taskQueueSetupTemplate has the statements of main that start the
+	// workers and drain them on signals, and taskQueueServeTemplate gives the
+	// server the queue to enqueue tasks to.
+	redisMakeTargetsTemplate = builtin.MustTemplate("wizard/tasks/make-targets.tmpl")
+	redisMakeHelpTemplate    = builtin.MustTemplate("wizard/tasks/make-help.tmpl")
+	taskQueueSetupTemplate   = builtin.MustTemplate("wizard/tasks/main-setup.tmpl")
+	taskQueueServeTemplate   = builtin.MustTemplate("wizard/tasks/main-serve.tmpl")
+)
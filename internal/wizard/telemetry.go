@@ -0,0 +1,67 @@
+package wizard
+
+import (
+	"github.com/oculus-core/gogo/internal/templates/builtin"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// hasTelemetry reports whether the CLI records anonymous usage for users who
+// opt in, which use_telemetry adds to CLI projects with Cobra
+func hasTelemetry(cfg *config.ProjectConfig) bool {
+	return cfg.UseTelemetry && cfg.UseCobra && cfg.Type == config.TypeCLI
+}
+
+// telemetryEnv returns the environment variable that turns telemetry on or off
+// for one run, such as TODO_CLI_TELEMETRY
+func telemetryEnv(cfg *config.ProjectConfig) string {
+	return envPrefix(cfg.Name) + "_TELEMETRY"
+}
+
+// rootExecute returns the body of Execute in root.go, which records the
+// command used when telemetry is generated
+func rootExecute(cfg *config.ProjectConfig) string {
+	if hasTelemetry(cfg) {
+		return "\treturn executeTracked()\n"
+	}
+	return "\treturn rootCmd.Execute()\n"
+}
+
+// telemetryData is the data the telemetry templates are rendered with
+type telemetryData struct {
+	*config.ProjectConfig
+	// Env turns telemetry on or off for one run, and DocsDir holds the
+	// privacy page
+	Env     string
+	DocsDir string
+}
+
+// telemetryFiles maps the paths of the telemetry files to their templates
+var telemetryFiles = []struct{ path, text string }{
+	{"internal/telemetry/telemetry.go", telemetryTemplate},
+	{"internal/telemetry/telemetry_test.go", telemetryTestTemplate},
+	{"cmd/{{ .Name }}/cmd/telemetry.go", telemetryCommandTemplate},
+}
+
+// telemetryDocsPage documents what is collected and how users control it
+var telemetryDocsPage = docsPage{Path: "telemetry", Title: "Telemetry and privacy", Weight: 50, Body: telemetryDocsTemplate}
+
+// generateTelemetry creates the telemetry package, its tests, the telemetry
+// command, and the privacy page
+func generateTelemetry(cfg *config.ProjectConfig, projectDir string) error {
+	data := telemetryData{ProjectConfig: cfg, Env: telemetryEnv(cfg), DocsDir: docsContentDir(cfg)}
+	files, err := renderFiles(data, telemetryFiles)
+	if err != nil {
+		return err
+	}
+	if err := renderDocsPages(cfg, files, data, telemetryDocsPage); err != nil {
+		return err
+	}
+	return writeFiles(projectDir, files)
+}
+
+var (
+	telemetryTemplate        = builtin.MustTemplate("wizard/telemetry/telemetry.tmpl")
+	telemetryTestTemplate    = builtin.MustTemplate("wizard/telemetry/telemetry-test.tmpl")
+	telemetryCommandTemplate = builtin.MustTemplate("wizard/telemetry/command.tmpl")
+	telemetryDocsTemplate    = builtin.MustTemplate("wizard/telemetry/docs.tmpl")
+)
@@ -0,0 +1,72 @@
+package wizard
+
+import (
+	"strings"
+
+	"github.com/oculus-core/gogo/internal/templates"
+	"github.com/oculus-core/gogo/internal/templates/builtin"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// hasTestHelpers reports whether the project gets the testutil package and
+// the integration tests in test/
+func hasTestHelpers(cfg *config.ProjectConfig) bool {
+	return cfg.UseTest
+}
+
+// testHelpersData is the data the test templates are rendered with
+type testHelpersData struct {
+	Name   string
+	Module string
+	// Main is the import path of the main package, empty for libraries
+	Main string
+	// CLI runs the version command of the binary
+	CLI bool
+	// EnvPrefix prefixes the environment variables the tests read
+	EnvPrefix string
+}
+
+// testHelpersFiles maps the paths of the test files to their templates
+var testHelpersFiles = []struct{ path, text string }{
+	{"test/testutil/testutil.go", testutilTemplate},
+	{"test/testutil/testutil_test.go", testutilTestTemplate},
+	{"test/integration/integration_test.go", integrationTestTemplate},
+}
+
+// generateTestHelpers generates the testutil package with helpers for temporary
+// directories and the environment, and integration tests behind the
+// integration build tag
+func generateTestHelpers(cfg *config.ProjectConfig, projectDir string) error {
+	data := testHelpersData{
+		Name:      cfg.Name,
+		Module:    cfg.Module,
+		CLI:       cfg.Type == config.TypeCLI,
+		EnvPrefix: envPrefix(cfg.Name),
+	}
+	if main := mainPackage(cfg); main != "" {
+		data.Main = strings.TrimSuffix(cfg.Module+"/"+strings.TrimPrefix(main, "./"), "/.")
+	}
+	files := make(map[string]string, len(testHelpersFiles))
+	for _, f := range testHelpersFiles {
+		content, err := templates.Render(f.path, f.text, data)
+		if err != nil {
+			return err
+		}
+		files[f.path] = content
+	}
+	return writeFiles(projectDir, files)
+}
+
+var (
+	testutilTemplate        = builtin.MustTemplate("wizard/testhelpers/testutil.tmpl")
+	testutilTestTemplate    = builtin.MustTemplate("wizard/testhelpers/testutil-test.tmpl")
+	integrationTestTemplate = builtin.MustTemplate("wizard/testhelpers/integration-test.tmpl")
+	// testMakeTargetsTemplate runs the unit tests and the tests behind the
+	// integration build tag separately. Event-driven projects have their own
+	// test-integration target, which also passes the Postgres of
+	// compose.yaml. testCIStepTemplate runs the integration tests in the CI
+	// workflow.
+	testMakeTargetsTemplate = builtin.MustTemplate("wizard/testhelpers/make-targets.tmpl")
+	testMakeHelpTemplate    = builtin.MustTemplate("wizard/testhelpers/make-help.tmpl")
+	testCIStepTemplate      = builtin.MustTemplate("wizard/testhelpers/ci-step.tmpl")
+)
@@ -0,0 +1,148 @@
+package wizard
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/oculus-core/gogo/internal/templates"
+	"github.com/oculus-core/gogo/internal/templates/builtin"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// Tool versions pinned by the tool version file. The Go version matches the
+// go directive of go.mod and golangci-lint matches the pre-commit hook, so
+// dev machines, hooks, and CI run the same tools.
+const (
+	goToolVersion           = "1.19.13"
+	golangciLintToolVersion = "1.64.5"
+	preCommitToolVersion    = "3.6.0"
+)
+
+// Go versions of the project types of needsNewerGo, whose dependencies and
+// standard library packages need a newer Go than the other project types
+const (
+	newerGoVersion     = "1.22"
+	newerGoToolVersion = "1.22.12"
+)
+
+// needsNewerGo reports whether the dependencies of the project type need
+// newerGoVersion
+func needsNewerGo(cfg *config.ProjectConfig) bool {
+	switch cfg.Type {
+	case config.TypeOperator, config.TypeGRPC, config.TypeEventDriven, config.TypeBatch, config.TypeCrawler,
+		config.TypeWorker, config.TypeSDK, config.TypeScript:
+		return true
+	default:
+		return false
+	}
+}
+
+// goVersion returns the go directive of go.mod, which CI installs when no
+// tool version file pins Go
+func goVersion(cfg *config.ProjectConfig) string {
+	if needsNewerGo(cfg) {
+		return newerGoVersion
+	}
+	return "1.19"
+}
+
+// goToolchainVersion returns the Go release pinned by the tool version file
+func goToolchainVersion(cfg *config.ProjectConfig) string {
+	if needsNewerGo(cfg) {
+		return newerGoToolVersion
+	}
+	return goToolVersion
+}
+
+// Tool version files of asdf and mise
+const (
+	toolVersionsFileName = ".tool-versions"
+	miseConfigFileName   = ".mise.toml"
+)
+
+// toolVersion is a tool pinned by the tool version file
+type toolVersion struct {
+	// Name is the asdf plugin and mise tool name
+	Name    string
+	Version string
+}
+
+// pinnedTools returns the tools the project uses, starting with go
+func pinnedTools(cfg *config.ProjectConfig) []toolVersion {
+	tools := []toolVersion{{Name: "golang", Version: goToolchainVersion(cfg)}}
+	if cfg.UseLinters {
+		tools = append(tools, toolVersion{Name: "golangci-lint", Version: golangciLintToolVersion})
+	}
+	if cfg.UsePreCommitHooks {
+		tools = append(tools, toolVersion{Name: "pre-commit", Version: preCommitToolVersion})
+	}
+	return tools
+}
+
+// toolVersionFile returns the tool version file of the configured manager,
+// or an empty string when versions are not pinned
+func toolVersionFile(cfg *config.ProjectConfig) string {
+	switch cfg.ToolVersionManager {
+	case config.ToolVersionAsdf:
+		return toolVersionsFileName
+	case config.ToolVersionMise:
+		return miseConfigFileName
+	default:
+		return ""
+	}
+}
+
+// generateToolVersions creates the .tool-versions or .mise.toml file that pins
+// the versions of go and the chosen tools. Renovate keeps both formats up to date.
+func generateToolVersions(cfg *config.ProjectConfig, projectDir string) error {
+	if !config.IsValidToolVersionManager(cfg.ToolVersionManager) {
+		return fmt.Errorf("unknown tool version manager %q: use %s",
+			cfg.ToolVersionManager, strings.Join(config.ToolVersionManagers, ", "))
+	}
+
+	var b strings.Builder
+	switch cfg.ToolVersionManager {
+	case config.ToolVersionAsdf:
+		for _, t := range pinnedTools(cfg) {
+			fmt.Fprintf(&b, "%s %s\n", t.Name, t.Version)
+		}
+	case config.ToolVersionMise:
+		b.WriteString("[tools]\n")
+		for _, t := range pinnedTools(cfg) {
+			name := t.Name
+			if name == "golang" {
+				name = "go"
+			}
+			fmt.Fprintf(&b, "%s = %q\n", name, t.Version)
+		}
+	default:
+		return nil
+	}
+
+	path := filepath.Join(projectDir, toolVersionFile(cfg))
+	if err := os.WriteFile(path, []byte(b.String()), 0600); err != nil {
+		return fmt.Errorf("failed to create %s: %v", toolVersionFile(cfg), err)
+	}
+	return nil
+}
+
+// setupGoStep returns the CI step that installs Go: from the tool version
+// file when there is one, so CI runs the pinned versions
+func setupGoStep(cfg *config.ProjectConfig) (string, error) {
+	data := newProjectData(cfg)
+	data.GoVersion = goVersion(cfg)
+	return templates.Render("setup-go", setupGoTemplate, data)
+}
+
+// golangciLintVersion returns the golangci-lint version the lint workflow
+// runs: the pinned one when a tool version file is generated
+func golangciLintVersion(cfg *config.ProjectConfig) string {
+	if toolVersionFile(cfg) == "" {
+		return "latest"
+	}
+	return "v" + golangciLintToolVersion
+}
+
+var setupGoTemplate = builtin.MustTemplate("wizard/project/setup-go.tmpl")
@@ -0,0 +1,33 @@
+package wizard
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// readmeTopics returns a badge for each topic of the project, shown below the
+// title of the README
+func readmeTopics(cfg *config.ProjectConfig) string {
+	badges := make([]string, len(cfg.Topics))
+	for i, t := range cfg.Topics {
+		// Shields.io reads single hyphens as separators of the badge fields
+		badges[i] = fmt.Sprintf("![%[1]s](https://img.shields.io/badge/topic-%[2]s-blue)", t, strings.ReplaceAll(t, "-", "--"))
+	}
+	return strings.Join(badges, " ") + "\n\n"
+}
+
+// catalogTags returns the tags of the catalog entry: go, the project type,
+// and the topics, each once
+func catalogTags(cfg *config.ProjectConfig) []string {
+	tags := []string{"go", string(cfg.Type)}
+	seen := map[string]bool{"go": true, string(cfg.Type): true}
+	for _, t := range cfg.Topics {
+		if !seen[t] {
+			seen[t] = true
+			tags = append(tags, t)
+		}
+	}
+	return tags
+}
@@ -0,0 +1,81 @@
+package wizard
+
+import (
+	"github.com/oculus-core/gogo/internal/templates"
+	"github.com/oculus-core/gogo/internal/templates/builtin"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// tuiDependencies lists the direct dependencies of TUI applications: Bubble
+// Tea, Lip Gloss for the styles, and teatest for the tests
+var tuiDependencies = []Dependency{
+	{"github.com/charmbracelet/bubbletea", bubbleteaVersion},
+	{"github.com/charmbracelet/lipgloss", "v1.0.0"},
+	{"github.com/charmbracelet/x/exp/teatest", "v0.0.0-20250311204145-2c3ea96c31dd"},
+}
+
+// tuiData is the data the TUI templates are rendered with
+type tuiData struct {
+	Name   string
+	Module string
+	// Title is the heading of the view
+	Title string
+	// CrashImport and CrashDefer report panics of main with use_crash_handler
+	CrashImport string
+	CrashDefer  string
+}
+
+// tuiMakePhony lists the TUI targets that are not files
+const tuiMakePhony = " run"
+
+// tuiFiles maps the paths of the TUI files to their templates
+var tuiFiles = []struct{ path, text string }{
+	{"cmd/{{ .Name }}/main.go", tuiMainTemplate},
+	{"internal/tui/model.go", tuiModelTemplate},
+	{"internal/tui/update.go", tuiUpdateTemplate},
+	{"internal/tui/update_test.go", tuiUpdateTestTemplate},
+	{"internal/tui/view.go", tuiViewTemplate},
+	{"internal/tui/styles.go", tuiStylesTemplate},
+	{"internal/tui/program_test.go", tuiProgramTestTemplate},
+}
+
+// generateTUICode generates a Bubble Tea application with a root model, its
+// update and view in their own files, Lip Gloss styles, and tests that drive
+// the model directly and through teatest
+func generateTUICode(cfg *config.ProjectConfig, projectDir string) error {
+	data := tuiData{
+		Name:        cfg.Name,
+		Module:      cfg.Module,
+		Title:       displayName(cfg),
+		CrashImport: crashImport(cfg),
+		CrashDefer:  crashDefer(cfg),
+	}
+	files := make(map[string]string, len(tuiFiles))
+	for _, f := range tuiFiles {
+		path, err := templates.Render("path", f.path, data)
+		if err != nil {
+			return err
+		}
+		content, err := templates.Render(path, f.text, data)
+		if err != nil {
+			return err
+		}
+		files[path] = content
+	}
+	return writeFiles(projectDir, files)
+}
+
+var (
+	tuiMainTemplate        = builtin.MustTemplate("wizard/tui/main.tmpl")
+	tuiModelTemplate       = builtin.MustTemplate("wizard/tui/model.tmpl")
+	tuiUpdateTemplate      = builtin.MustTemplate("wizard/tui/update.tmpl")
+	tuiUpdateTestTemplate  = builtin.MustTemplate("wizard/tui/update-test.tmpl")
+	tuiViewTemplate        = builtin.MustTemplate("wizard/tui/view.tmpl")
+	tuiStylesTemplate      = builtin.MustTemplate("wizard/tui/styles.tmpl")
+	tuiProgramTestTemplate = builtin.MustTemplate("wizard/tui/program-test.tmpl")
+	// tuiMakeTargetsTemplate has the target that runs the application, and
+	// tuiReadmeTemplate explains the parts of the application and its tests
+	tuiMakeTargetsTemplate = builtin.MustTemplate("wizard/tui/make-targets.tmpl")
+	tuiMakeHelpTemplate    = builtin.MustTemplate("wizard/tui/make-help.tmpl")
+	tuiReadmeTemplate      = builtin.MustTemplate("wizard/tui/readme-section.tmpl")
+)
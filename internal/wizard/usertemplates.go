@@ -0,0 +1,53 @@
+package wizard
+
+import (
+	"fmt"
+
+	"github.com/AlecAivazis/survey/v2"
+
+	"github.com/oculus-core/gogo/internal/manifest"
+	"github.com/oculus-core/gogo/internal/templates"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// applyUserTemplates renders the template set in cfg.Template over the
+// generated project. Files with the path of a generated file replace it
+// unless their merge strategy says otherwise, and the others are added.
+// Three-way merges start from the files as the generation left them, which
+// are saved in .gogo/original first.
+func applyUserTemplates(cfg *config.ProjectConfig, projectDir string) error {
+	set, err := templates.OpenSet(cfg.Template)
+	if err != nil {
+		return fmt.Errorf("failed to load templates from %s: %w", cfg.Template, err)
+	}
+
+	generated, err := manifest.Build(projectDir)
+	if err != nil {
+		return err
+	}
+	if err := generated.SaveOriginals(projectDir); err != nil {
+		return err
+	}
+	opts := templates.ApplyOptions{
+		Base: func(path string) ([]byte, bool) {
+			return manifest.Original(projectDir, path)
+		},
+	}
+	if _, err := set.Apply(projectDir, cfg, opts); err != nil {
+		return fmt.Errorf("failed to apply templates from %s: %w", cfg.Template, err)
+	}
+	return nil
+}
+
+// ConfirmHook asks whether a hook of the user templates may run in the
+// generated project
+func ConfirmHook(h templates.Hook) (bool, error) {
+	run := false
+	prompt := &survey.Confirm{
+		Message: fmt.Sprintf("Run the template hook %s?", h),
+	}
+	if err := survey.AskOne(prompt, &run); err != nil {
+		return false, err
+	}
+	return run, nil
+}
@@ -0,0 +1,73 @@
+package wizard
+
+import (
+	"github.com/oculus-core/gogo/internal/templates/builtin"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// validatorDependency is the validator that both request validations use;
+// the binding of Gin validates with it too
+var validatorDependency = Dependency{"github.com/go-playground/validator/v10", "v10.14.0"}
+
+// requestValidation returns the request validation of the project: binding
+// for API projects with Gin that use it, validator for the other API
+// projects that validate requests, and an empty string for the others
+func requestValidation(cfg *config.ProjectConfig) string {
+	if cfg.Type != config.TypeAPI {
+		return ""
+	}
+	switch cfg.RequestValidation {
+	case config.RequestValidationBinding:
+		if cfg.UseGin {
+			return config.RequestValidationBinding
+		}
+		return config.RequestValidationValidator
+	case config.RequestValidationValidator:
+		return config.RequestValidationValidator
+	}
+	return ""
+}
+
+// hasValidation reports whether the API validates its requests
+func hasValidation(cfg *config.ProjectConfig) bool {
+	return requestValidation(cfg) != ""
+}
+
+// validationData is the data of the validation templates
+type validationData struct {
+	*config.ProjectConfig
+	// Binding reports whether requests are validated by the binding of Gin
+	Binding bool
+	// Tag is the struct tag that holds the rules of the request fields
+	Tag string
+}
+
+// generateValidation creates the error envelope and validation of the API, an
+// example request that uses it, and their test
+func generateValidation(cfg *config.ProjectConfig, projectDir string) error {
+	data := validationData{ProjectConfig: cfg, Tag: "validate"}
+	if requestValidation(cfg) == config.RequestValidationBinding {
+		data.Binding = true
+		data.Tag = "binding"
+	}
+	files, err := renderFiles(data, validationFiles)
+	if err != nil {
+		return err
+	}
+	return writeFiles(projectDir, files)
+}
+
+// validationFiles are the error envelope of the API and the translation of
+// validation errors into it, an example request that uses them, and the test
+// of the responses to valid and invalid greeting requests
+var validationFiles = []struct{ path, text string }{
+	{"internal/api/validation.go", validationTemplate},
+	{"internal/api/greeting.go", greetingTemplate},
+	{"internal/api/validation_test.go", validationTestTemplate},
+}
+
+var (
+	validationTemplate     = builtin.MustTemplate("wizard/validation/validation.tmpl")
+	greetingTemplate       = builtin.MustTemplate("wizard/validation/greeting.tmpl")
+	validationTestTemplate = builtin.MustTemplate("wizard/validation/validation-test.tmpl")
+)
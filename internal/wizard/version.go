@@ -0,0 +1,86 @@
+package wizard
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/oculus-core/gogo/internal/templates"
+	"github.com/oculus-core/gogo/internal/templates/builtin"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// initialVersion is the version a new project starts at
+const initialVersion = "0.1.0"
+
+// versionPackage returns the package that holds the Version, Commit, and
+// BuildDate variables set by ldflags, relative to the module
+func versionPackage(cfg *config.ProjectConfig) string {
+	if cfg.Type == config.TypeCLI {
+		return "cmd/" + cfg.Name + "/cmd"
+	}
+	return "cmd"
+}
+
+// mainPackage returns the directory of the main package, or an empty string
+// for projects without a binary
+func mainPackage(cfg *config.ProjectConfig) string {
+	switch cfg.Type {
+	case config.TypeCLI, config.TypeAPI, config.TypeOperator, config.TypeGRPC, config.TypeEventDriven, config.TypeBatch,
+		config.TypeCrawler, config.TypeWorker, config.TypeTUI:
+		return "./cmd/" + cfg.Name
+	case config.TypeLibrary, config.TypeSDK:
+		return ""
+	default:
+		return "."
+	}
+}
+
+// goreleaserData is the data of the GoReleaser config
+type goreleaserData struct {
+	*config.ProjectConfig
+	// Main is the main package of the binary, and Package the package that
+	// holds its version variables
+	Main    string
+	Package string
+}
+
+// generateVersionFiles creates the VERSION file and, for projects that build a
+// binary, a GoReleaser config that refuses to release a tag that does not
+// match VERSION
+func generateVersionFiles(cfg *config.ProjectConfig, projectDir string) error {
+	versionPath := filepath.Join(projectDir, "VERSION")
+	if err := os.WriteFile(versionPath, []byte(initialVersion+"\n"), 0600); err != nil {
+		return fmt.Errorf("failed to create VERSION: %v", err)
+	}
+
+	main := mainPackage(cfg)
+	if main == "" {
+		return nil
+	}
+
+	data := goreleaserData{
+		ProjectConfig: cfg,
+		Main:          main,
+		Package:       cfg.Module + "/" + versionPackage(cfg),
+	}
+	goreleaserContent, err := templates.Render(".goreleaser.yaml", goreleaserTemplate, data)
+	if err != nil {
+		return err
+	}
+
+	goreleaserPath := filepath.Join(projectDir, ".goreleaser.yaml")
+	if err := os.WriteFile(goreleaserPath, []byte(goreleaserContent), 0600); err != nil {
+		return fmt.Errorf("failed to create .goreleaser.yaml: %v", err)
+	}
+	return nil
+}
+
+var (
+	goreleaserTemplate = builtin.MustTemplate("wizard/project/goreleaser.tmpl")
+	// versionMakeTargetsTemplate bumps the version in VERSION and tags it for
+	// a release. Each target reads VERSION itself, so they work without the
+	// VERSION variable.
+	versionMakeTargetsTemplate = builtin.MustTemplate("wizard/project/version-make-targets.tmpl")
+	versionMakeHelpTemplate    = builtin.MustTemplate("wizard/project/version-make-help.tmpl")
+)
@@ -0,0 +1,65 @@
+package wizard
+
+import (
+	"strings"
+
+	"github.com/oculus-core/gogo/internal/templates/builtin"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// hasLicense reports whether the project gets a LICENSE file, which internal
+// projects never do
+func hasLicense(cfg *config.ProjectConfig) bool {
+	return cfg.CreateLicense && cfg.License != config.LicenseNone && !cfg.IsInternal()
+}
+
+// hasPublicBadges reports whether the README shows the pkg.go.dev and Go
+// Report Card badges, which cannot read the private module of an internal
+// project
+func hasPublicBadges(cfg *config.ProjectConfig) bool {
+	return hasPackageDocs(cfg) && !cfg.IsInternal()
+}
+
+// goPrivate returns the GOPRIVATE pattern of an internal project: the host and
+// organization of its module, such as github.com/acme, so the other modules of
+// the organization are private too. Modules without a host, which the go
+// command never downloads, have none.
+func goPrivate(cfg *config.ProjectConfig) string {
+	if !cfg.IsInternal() {
+		return ""
+	}
+	elems := strings.Split(cfg.Module, "/")
+	if !strings.Contains(elems[0], ".") {
+		return ""
+	}
+	if len(elems) > 2 {
+		elems = elems[:2]
+	}
+	return strings.Join(elems, "/")
+}
+
+// goPrivateData is the data the GOPRIVATE snippets are rendered with
+type goPrivateData struct {
+	Pattern string
+}
+
+func newGoPrivateData(cfg *config.ProjectConfig) goPrivateData {
+	return goPrivateData{Pattern: goPrivate(cfg)}
+}
+
+// hasGoPrivate reports whether the Makefile and CI workflow set GOPRIVATE
+func hasGoPrivate(cfg *config.ProjectConfig) bool {
+	return goPrivate(cfg) != ""
+}
+
+var (
+	// makefileGoPrivateTemplate makes the go command fetch the module of an
+	// internal project directly instead of through the public proxy and
+	// checksum database, and ciGoPrivateTemplate sets the same in the env of
+	// the CI workflow
+	makefileGoPrivateTemplate = builtin.MustTemplate("wizard/project/makefile-goprivate.tmpl")
+	ciGoPrivateTemplate       = builtin.MustTemplate("wizard/project/ci-goprivate.tmpl")
+	// readmeInternalTemplate is the README section of an internal project
+	// that says who may use it and how to fetch its private module
+	readmeInternalTemplate = builtin.MustTemplate("wizard/project/readme-internal.tmpl")
+)
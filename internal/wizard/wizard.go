@@ -5,9 +5,11 @@ import (
 	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
+	"github.com/AlecAivazis/survey/v2/core"
 	"github.com/AlecAivazis/survey/v2/terminal"
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/oculus-core/gogo/internal/policy"
 	"github.com/oculus-core/gogo/pkg/config"
 )
 
@@ -28,146 +30,396 @@ var (
 			Foreground(lipgloss.Color("#778899")) // Light slate gray
 )
 
-// RunWizard runs the interactive project setup wizard
-func RunWizard(cfg *config.ProjectConfig) error {
+// Config fields backing each multi-select option, used to hide options locked by a policy
+var (
+	structureFields = map[string]string{
+		"cmd (application entrypoints)": "use_cmd",
+		"internal (private packages)":   "use_internal",
+		"pkg (public packages)":         "use_pkg",
+		"test (test utilities)":         "use_test",
+		"docs (documentation)":          "use_docs",
+		"examples (example programs)":   "use_examples",
+	}
+
+	filesFields = map[string]string{
+		"README.md": "create_readme",
+		"LICENSE":   "create_license",
+		"Makefile":  "create_makefile",
+
+		"catalog-info.yaml (Backstage)":      "create_catalog_info",
+		"VERSION (bump targets, GoReleaser)": "create_version_file",
+		"docs/adr (decision records)":        "use_adr",
+	}
+
+	toolsFields = map[string]string{
+		"Linters (golangci-lint)": "use_linters",
+		"Pre-commit hooks":        "use_pre_commit_hooks",
+		"Git hooks":               "use_git_hooks",
+	}
+
+	depsFields = map[string]string{
+		"Cobra (CLI framework)": "use_cobra",
+		"Viper (configuration)": "use_viper",
+		"Gin (HTTP framework)":  "use_gin",
+	}
+)
+
+// RunWizard runs the interactive project setup wizard.
+// Fields locked by the policy are displayed but cannot be edited; pol may be nil.
+// When the project type changes, its defaults and the rules, such as those of
+// the profile and policy, are applied again.
+func RunWizard(cfg *config.ProjectConfig, pol *policy.Policy, rules []config.Rule) error {
 	fmt.Println() // Add blank line before the welcome banner
 	fmt.Println(titleStyle.Render("🚀 Welcome to the Gogo Project Generator Wizard"))
 	fmt.Println("This wizard will help you set up a new Go project with best practices")
+	fmt.Println("Type ? at any prompt to see what the option generates and what it requires")
 	fmt.Println()
 
 	// Project information section
 	fmt.Println(sectionStyle.Render("📋 Project Information"))
 
 	// Project name
-	namePrompt := &survey.Input{
-		Message: "Project name:",
-		Default: cfg.Name,
-	}
-	if err := survey.AskOne(namePrompt, &cfg.Name); err != nil {
-		if err == terminal.InterruptErr {
-			return fmt.Errorf("wizard cancelled")
+	if !showLocked(pol, "name", "Project name:") {
+		namePrompt := &survey.Input{
+			Message: "Project name:",
+			Help:    fieldHelp["name"],
+			Default: cfg.Name,
+		}
+		validate := func(ans interface{}) error {
+			if strings.TrimSpace(ans.(string)) == "" {
+				return fmt.Errorf("project name is required")
+			}
+			_, err := config.ASCIIName(ans.(string))
+			return err
+		}
+		if err := survey.AskOne(namePrompt, &cfg.Name, survey.WithValidator(validate)); err != nil {
+			if err == terminal.InterruptErr {
+				return fmt.Errorf("wizard cancelled")
+			}
+			return err
 		}
-		return err
 	}
 
 	// Module path
-	modulePrompt := &survey.Input{
-		Message: "Module path:",
-		Default: cfg.Module,
-	}
-	if err := survey.AskOne(modulePrompt, &cfg.Module); err != nil {
-		if err == terminal.InterruptErr {
-			return fmt.Errorf("wizard cancelled")
+	if !showLocked(pol, "module", "Module path:") {
+		modulePrompt := &survey.Input{
+			Message: "Module path:",
+			Help:    fieldHelp["module"],
+			Default: cfg.Module,
+		}
+		if err := survey.AskOne(modulePrompt, &cfg.Module, survey.WithValidator(moduleValidator(pol))); err != nil {
+			if err == terminal.InterruptErr {
+				return fmt.Errorf("wizard cancelled")
+			}
+			return err
 		}
-		return err
 	}
 
 	// Description
-	descPrompt := &survey.Input{
-		Message: "Description:",
-		Default: cfg.Description,
+	if !showLocked(pol, "description", "Description:") {
+		descPrompt := &survey.Input{
+			Message: "Description:",
+			Help:    fieldHelp["description"],
+			Default: cfg.Description,
+		}
+		if err := survey.AskOne(descPrompt, &cfg.Description); err != nil {
+			if err == terminal.InterruptErr {
+				return fmt.Errorf("wizard cancelled")
+			}
+			return err
+		}
 	}
-	if err := survey.AskOne(descPrompt, &cfg.Description); err != nil {
-		if err == terminal.InterruptErr {
-			return fmt.Errorf("wizard cancelled")
+
+	// Topics
+	if !showLocked(pol, "topics", "Topics:") {
+		topics := strings.Join(cfg.Topics, "\n")
+		topicsPrompt := &survey.Multiline{
+			Message: "Topics (one per line, such as payments or event-driven):",
+			Help:    fieldHelp["topics"],
+			Default: topics,
 		}
-		return err
+		validate := func(ans interface{}) error {
+			return config.ValidateTopics(parseTopics(ans.(string)))
+		}
+		if err := survey.AskOne(topicsPrompt, &topics, survey.WithValidator(validate)); err != nil {
+			if err == terminal.InterruptErr {
+				return fmt.Errorf("wizard cancelled")
+			}
+			return err
+		}
+		cfg.Topics = parseTopics(topics)
 	}
 
-	// Author
-	authorPrompt := &survey.Input{
-		Message: "Author:",
-		Default: cfg.Author,
+	// Authors
+	if !showLocked(pol, "authors", "Authors:") {
+		authorsPrompt := &survey.Input{
+			Message: "Authors (Name <email>, separated by commas):",
+			Help:    fieldHelp["authors"],
+			Default: config.FormatAuthors(cfg.Authors),
+		}
+		validate := func(ans interface{}) error {
+			_, err := config.ParseAuthors(ans.(string))
+			return err
+		}
+		var authors string
+		if err := survey.AskOne(authorsPrompt, &authors, survey.WithValidator(validate)); err != nil {
+			if err == terminal.InterruptErr {
+				return fmt.Errorf("wizard cancelled")
+			}
+			return err
+		}
+		var err error
+		if cfg.Authors, err = config.ParseAuthors(authors); err != nil {
+			return err
+		}
 	}
-	if err := survey.AskOne(authorPrompt, &cfg.Author); err != nil {
-		if err == terminal.InterruptErr {
-			return fmt.Errorf("wizard cancelled")
+
+	// Visibility
+	if !showLocked(pol, "visibility", "Visibility:") {
+		visibilityOptions, err := allowedOptions(pol, "visibility", config.Visibilities)
+		if err != nil {
+			return err
+		}
+		visibilityPrompt := &survey.Select{
+			Message: "Visibility:",
+			Help:    fieldHelp["visibility"],
+			Options: visibilityOptions,
+			Default: config.VisibilityPublic,
+		}
+		if contains(visibilityPrompt.Options, cfg.Visibility) {
+			visibilityPrompt.Default = cfg.Visibility
+		}
+		if err := survey.AskOne(visibilityPrompt, &cfg.Visibility); err != nil {
+			if err == terminal.InterruptErr {
+				return fmt.Errorf("wizard cancelled")
+			}
+			return err
 		}
-		return err
 	}
 
-	// License
-	licensePrompt := &survey.Select{
-		Message: "License:",
-		Options: []string{"MIT", "Apache-2.0", "GPL-3.0", "BSD-3-Clause", "None"},
-		Default: cfg.License,
+	// License, which internal projects do not get
+	if !cfg.IsInternal() && !showLocked(pol, "license", "License:") {
+		licenseOptions, err := allowedOptions(pol, "license", config.Licenses)
+		if err != nil {
+			return err
+		}
+		licensePrompt := &survey.Select{
+			Message: "License:",
+			Help:    fieldHelp["license"],
+			Options: licenseOptions,
+		}
+		if contains(licensePrompt.Options, cfg.License) {
+			licensePrompt.Default = cfg.License
+		}
+		if err := survey.AskOne(licensePrompt, &cfg.License, survey.WithValidator(licenseValidator(licenseOptions))); err != nil {
+			if err == terminal.InterruptErr {
+				return fmt.Errorf("wizard cancelled")
+			}
+			return err
+		}
 	}
-	if err := survey.AskOne(licensePrompt, &cfg.License); err != nil {
-		if err == terminal.InterruptErr {
-			return fmt.Errorf("wizard cancelled")
+
+	// Default branch
+	if !showLocked(pol, "default_branch", "Default branch:") {
+		branchPrompt := &survey.Input{
+			Message: "Default branch:",
+			Help:    fieldHelp["default_branch"],
+			Default: cfg.Branch(),
+		}
+		validate := func(ans interface{}) error {
+			return config.ValidateBranch(ans.(string))
+		}
+		if err := survey.AskOne(branchPrompt, &cfg.DefaultBranch, survey.WithValidator(validate)); err != nil {
+			if err == terminal.InterruptErr {
+				return fmt.Errorf("wizard cancelled")
+			}
+			return err
 		}
-		return err
 	}
 
 	// Now ask for project details using survey
 	fmt.Println(highlightStyle.Render("\nProject Details:"))
 
 	// Project Type
+	var typeOptions []string
+	for _, t := range config.ProjectTypes {
+		typeOptions = append(typeOptions, string(t))
+	}
+
+	typeOptions, err := allowedOptions(pol, "type", typeOptions)
+	if err != nil {
+		return err
+	}
 	appTypePrompt := &survey.Select{
 		Message: "Project Type:",
-		Options: []string{
-			string(config.TypeDefault),
-			string(config.TypeCLI),
-			string(config.TypeAPI),
-			string(config.TypeLibrary),
-		},
-		Default: string(cfg.Type),
+		Help:    fieldHelp["type"],
+		Options: typeOptions,
 		Description: func(value string, _ int) string {
-			switch value {
-			case string(config.TypeCLI):
-				return "Command-line application (includes Cobra and Viper)"
-			case string(config.TypeAPI):
-				return "API/Web service (includes Gin)"
-			case string(config.TypeLibrary):
-				return "Library/Package (no cmd directory)"
-			default:
-				return "Generic Go project"
-			}
+			return config.ProjectType(value).Description()
 		},
 	}
+	if contains(appTypePrompt.Options, string(cfg.Type)) {
+		appTypePrompt.Default = string(cfg.Type)
+	}
 
-	var appTypeStr string
-	if err := survey.AskOne(appTypePrompt, &appTypeStr); err != nil {
-		if err == terminal.InterruptErr {
-			return fmt.Errorf("wizard cancelled")
+	appTypeStr := string(cfg.Type)
+	if !showLocked(pol, "type", "Project Type:") {
+		if err := survey.AskOne(appTypePrompt, &appTypeStr); err != nil {
+			if err == terminal.InterruptErr {
+				return fmt.Errorf("wizard cancelled")
+			}
+			return err
 		}
-		return err
 	}
 
 	// Update the config based on the selected project type
 	prevType := cfg.Type
 	cfg.Type = config.ProjectType(appTypeStr)
 
-	// Only apply type-specific settings if the type has changed
+	// Only apply the defaults of the type and the rules if the type has changed
 	if prevType != cfg.Type {
-		switch cfg.Type {
-		case config.TypeCLI:
-			cfg.UseCobra = true
-			cfg.UseViper = true
-		case config.TypeAPI:
-			cfg.UseGin = true
-		case config.TypeLibrary:
-			cfg.UseCmd = false
+		config.ApplyTypeDefaults(cfg)
+		if _, err := config.ApplyRules(cfg, rules, nil); err != nil {
+			return err
+		}
+	}
+
+	// Operator API
+	if cfg.Type == config.TypeOperator {
+		if !showLocked(pol, "operator_group", "API group:") {
+			groupPrompt := &survey.Input{
+				Message: "API group of the custom resource:",
+				Help:    fieldHelp["operator_group"],
+				Default: operatorGroup(cfg),
+			}
+			validate := func(ans interface{}) error {
+				return config.ValidateOperatorAPI(ans.(string), "")
+			}
+			if err := survey.AskOne(groupPrompt, &cfg.OperatorGroup, survey.WithValidator(validate)); err != nil {
+				return err
+			}
+		}
+		if !showLocked(pol, "operator_kind", "Kind:") {
+			kindPrompt := &survey.Input{
+				Message: "Kind of the custom resource:",
+				Help:    fieldHelp["operator_kind"],
+				Default: operatorKind(cfg),
+			}
+			validate := func(ans interface{}) error {
+				return config.ValidateOperatorAPI("", ans.(string))
+			}
+			if err := survey.AskOne(kindPrompt, &cfg.OperatorKind, survey.WithValidator(validate)); err != nil {
+				return err
+			}
+		}
+		if !showLocked(pol, "use_kubebuilder", "Scaffold with kubebuilder?") {
+			kubebuilderPrompt := &survey.Confirm{
+				Message: "Scaffold with kubebuilder when it is installed, instead of the built-in templates?",
+				Help:    fieldHelp["use_kubebuilder"],
+				Default: cfg.UseKubebuilder,
+			}
+			if err := survey.AskOne(kubebuilderPrompt, &cfg.UseKubebuilder); err != nil {
+				return err
+			}
 		}
 	}
 
+	// GitHub Action runtime
+	if cfg.Type == config.TypeGitHubAction && !showLocked(pol, "action_runtime", "Action runtime:") {
+		runtimePrompt := &survey.Select{
+			Message: "Action runtime:",
+			Help:    fieldHelp["action_runtime"],
+			Options: config.ActionRuntimes,
+			Description: func(value string, _ int) string {
+				if value == config.ActionRuntimeComposite {
+					return "release binary in a composite step, any runner"
+				}
+				return "container built from the Dockerfile, Linux runners"
+			},
+		}
+		if contains(runtimePrompt.Options, cfg.ActionRuntime) {
+			runtimePrompt.Default = cfg.ActionRuntime
+		}
+		if err := survey.AskOne(runtimePrompt, &cfg.ActionRuntime); err != nil {
+			return err
+		}
+	}
+
+	// Event broker
+	if cfg.Type == config.TypeEventDriven && !showLocked(pol, "event_broker", "Event broker:") {
+		brokerPrompt := &survey.Select{
+			Message: "Event broker:",
+			Help:    fieldHelp["event_broker"],
+			Options: config.EventBrokers,
+			Description: func(value string, _ int) string {
+				switch value {
+				case config.EventBrokerNATS:
+					return "NATS JetStream streams and durable consumers"
+				case config.EventBrokerRabbitMQ:
+					return "RabbitMQ topic exchange and durable queues"
+				default:
+					return "Kafka topics and consumer groups"
+				}
+			},
+		}
+		if contains(brokerPrompt.Options, cfg.EventBroker) {
+			brokerPrompt.Default = cfg.EventBroker
+		}
+		if err := survey.AskOne(brokerPrompt, &cfg.EventBroker); err != nil {
+			return err
+		}
+	}
+
+	// OpenAPI spec of an SDK
+	if cfg.Type == config.TypeSDK && !showLocked(pol, "openapi_spec", "OpenAPI spec:") {
+		specPrompt := &survey.Input{
+			Message: "OpenAPI 3 spec to generate the SDK from (YAML or JSON):",
+			Help:    fieldHelp["openapi_spec"],
+			Default: cfg.OpenAPISpec,
+		}
+		validate := func(ans interface{}) error {
+			_, err := loadSDKSpec(ans.(string))
+			return err
+		}
+		if err := survey.AskOne(specPrompt, &cfg.OpenAPISpec, survey.WithValidator(validate)); err != nil {
+			return err
+		}
+	}
+
+	// Library packages
+	if cfg.Type == config.TypeLibrary && !showLocked(pol, "packages", "Packages:") {
+		packages := strings.Join(cfg.Packages, ", ")
+		packagesPrompt := &survey.Input{
+			Message: "Packages (directories such as ., internal/strutil; empty for pkg/" + cfg.Name + "):",
+			Help:    fieldHelp["packages"],
+			Default: packages,
+		}
+		validate := func(ans interface{}) error {
+			return config.ValidatePackages(splitList(ans.(string)))
+		}
+		if err := survey.AskOne(packagesPrompt, &packages, survey.WithValidator(validate)); err != nil {
+			return err
+		}
+		cfg.Packages = splitList(packages)
+	}
+
 	// Project structure section
 	fmt.Println(sectionStyle.Render("📁 Project Structure"))
 
-	structurePrompt := &survey.MultiSelect{
-		Message: "Select project directories to include:",
-		Options: []string{
-			"cmd (application entrypoints)",
-			"internal (private packages)",
-			"pkg (public packages)",
-			"test (test utilities)",
-			"docs (documentation)",
-		},
-		Default: getStructureDefaults(cfg),
+	structureOptions := []string{
+		"cmd (application entrypoints)",
+		"internal (private packages)",
+		"pkg (public packages)",
+		"test (test utilities)",
+		"docs (documentation)",
+	}
+	// Example programs demonstrate a library or an SDK, or call an API
+	if cfg.Type == config.TypeLibrary || cfg.Type == config.TypeAPI || cfg.Type == config.TypeSDK {
+		structureOptions = append(structureOptions, "examples (example programs)")
 	}
 
-	var selectedStructure []string
-	if err := survey.AskOne(structurePrompt, &selectedStructure); err != nil {
+	selectedStructure, err := askMultiSelect(pol, "Select project directories to include:", structureOptions, structureFields, getStructureDefaults(cfg))
+	if err != nil {
 		return err
 	}
 
@@ -177,22 +429,73 @@ func RunWizard(cfg *config.ProjectConfig) error {
 	cfg.UsePkg = contains(selectedStructure, "pkg (public packages)")
 	cfg.UseTest = contains(selectedStructure, "test (test utilities)")
 	cfg.UseDocs = contains(selectedStructure, "docs (documentation)")
+	cfg.UseExamples = contains(selectedStructure, "examples (example programs)")
+
+	if !showLocked(pol, "docs_site", "Documentation site:") {
+		sitePrompt := &survey.Select{
+			Message: "Documentation site:",
+			Help:    fieldHelp["docs_site"],
+			Options: config.DocsSites,
+			Description: func(value string, _ int) string {
+				switch value {
+				case config.DocsSiteMkDocs:
+					return "MkDocs Material, deployed to GitHub Pages"
+				case config.DocsSiteHugo:
+					return "Hugo, deployed to GitHub Pages"
+				default:
+					return "no documentation site"
+				}
+			},
+		}
+		if contains(sitePrompt.Options, cfg.DocsSite) {
+			sitePrompt.Default = cfg.DocsSite
+		}
+		if err := survey.AskOne(sitePrompt, &cfg.DocsSite); err != nil {
+			return err
+		}
+	}
+
+	if !showLocked(pol, "dir_placeholder", "Empty directories:") {
+		placeholderPrompt := &survey.Select{
+			Message: "Empty directories:",
+			Help:    fieldHelp["dir_placeholder"],
+			Options: config.DirPlaceholders,
+			Default: config.DirPlaceholderGitkeep,
+			Description: func(value string, _ int) string {
+				switch value {
+				case config.DirPlaceholderDoc:
+					return "doc.go describing what the directory is for"
+				case config.DirPlaceholderNone:
+					return "leave them empty, untracked by Git"
+				default:
+					return "empty .gitkeep file"
+				}
+			},
+		}
+		if contains(placeholderPrompt.Options, cfg.DirPlaceholder) {
+			placeholderPrompt.Default = cfg.DirPlaceholder
+		}
+		if err := survey.AskOne(placeholderPrompt, &cfg.DirPlaceholder); err != nil {
+			return err
+		}
+	}
 
 	// Files section
 	fmt.Println(sectionStyle.Render("📝 Project Files"))
 
-	filesPrompt := &survey.MultiSelect{
-		Message: "Select files to generate:",
-		Options: []string{
-			"README.md",
-			"LICENSE",
-			"Makefile",
-		},
-		Default: getFilesDefaults(cfg),
+	// Internal projects get no LICENSE
+	fileOptions := []string{"README.md"}
+	if !cfg.IsInternal() {
+		fileOptions = append(fileOptions, "LICENSE")
 	}
-
-	var selectedFiles []string
-	if err := survey.AskOne(filesPrompt, &selectedFiles); err != nil {
+	fileOptions = append(fileOptions,
+		"Makefile",
+		"catalog-info.yaml (Backstage)",
+		"VERSION (bump targets, GoReleaser)",
+		"docs/adr (decision records)",
+	)
+	selectedFiles, err := askMultiSelect(pol, "Select files to generate:", fileOptions, filesFields, getFilesDefaults(cfg))
+	if err != nil {
 		return err
 	}
 
@@ -200,22 +503,87 @@ func RunWizard(cfg *config.ProjectConfig) error {
 	cfg.CreateReadme = contains(selectedFiles, "README.md")
 	cfg.CreateLicense = contains(selectedFiles, "LICENSE")
 	cfg.CreateMakefile = contains(selectedFiles, "Makefile")
+	cfg.CreateCatalogInfo = contains(selectedFiles, "catalog-info.yaml (Backstage)")
+	cfg.CreateVersionFile = contains(selectedFiles, "VERSION (bump targets, GoReleaser)")
+	cfg.UseADR = contains(selectedFiles, "docs/adr (decision records)")
 
-	// Code quality tools section
-	fmt.Println(sectionStyle.Render("🛠️ Code Quality Tools"))
+	// Restore locked file options before asking for their details
+	if _, err := pol.Apply(cfg); err != nil {
+		return err
+	}
 
-	toolsPrompt := &survey.MultiSelect{
-		Message: "Select code quality tools to include:",
-		Options: []string{
-			"Linters (golangci-lint)",
-			"Pre-commit hooks",
-			"Git hooks",
-		},
-		Default: getToolsDefaults(cfg),
+	// Ownership section
+	fmt.Println(sectionStyle.Render("👥 Ownership"))
+
+	for _, q := range []struct {
+		field, label, message string
+		value                 *string
+	}{
+		{"team", "Team:", "Owning team (CODEOWNERS, catalog owner):", &cfg.Team},
+		{"slack_channel", "Slack channel:", "Slack channel (e.g. #payments):", &cfg.SlackChannel},
+		{"on_call", "On-call:", "On-call rotation or schedule URL:", &cfg.OnCall},
+	} {
+		if showLocked(pol, q.field, q.label) {
+			continue
+		}
+		prompt := &survey.Input{
+			Message: q.message,
+			Help:    fieldHelp[q.field],
+			Default: *q.value,
+		}
+		if err := survey.AskOne(prompt, q.value); err != nil {
+			return err
+		}
 	}
 
-	var selectedTools []string
-	if err := survey.AskOne(toolsPrompt, &selectedTools); err != nil {
+	// Catalog metadata
+	if cfg.CreateCatalogInfo {
+		if !showLocked(pol, "owner", "Catalog owner:") {
+			ownerPrompt := &survey.Input{
+				Message: "Catalog owner (team or user):",
+				Help:    fieldHelp["owner"],
+				Default: catalogOwner(cfg),
+			}
+			if err := survey.AskOne(ownerPrompt, &cfg.Owner); err != nil {
+				return err
+			}
+		}
+
+		if !showLocked(pol, "lifecycle", "Lifecycle:") {
+			lifecyclePrompt := &survey.Select{
+				Message: "Lifecycle:",
+				Help:    fieldHelp["lifecycle"],
+				Options: []string{"experimental", "production", "deprecated"},
+			}
+			if contains(lifecyclePrompt.Options, cfg.Lifecycle) {
+				lifecyclePrompt.Default = cfg.Lifecycle
+			}
+			if err := survey.AskOne(lifecyclePrompt, &cfg.Lifecycle); err != nil {
+				return err
+			}
+		}
+	}
+
+	if cfg.Type == config.TypeLibrary && !showLocked(pol, "create_package_docs", "Generate package docs?") {
+		docsPrompt := &survey.Confirm{
+			Message: "Generate doc.go, a runnable example, and pkg.go.dev and Go Report Card badges?",
+			Help:    fieldHelp["create_package_docs"],
+			Default: cfg.CreatePackageDocs,
+		}
+		if err := survey.AskOne(docsPrompt, &cfg.CreatePackageDocs); err != nil {
+			return err
+		}
+	}
+
+	// Code quality tools section
+	fmt.Println(sectionStyle.Render("🛠️ Code Quality Tools"))
+
+	selectedTools, err := askMultiSelect(pol, "Select code quality tools to include:", []string{
+		"Linters (golangci-lint)",
+		"Pre-commit hooks",
+		"Git hooks",
+	}, toolsFields, getToolsDefaults(cfg))
+	if err != nil {
 		return err
 	}
 
@@ -224,35 +592,534 @@ func RunWizard(cfg *config.ProjectConfig) error {
 	cfg.UsePreCommitHooks = contains(selectedTools, "Pre-commit hooks")
 	cfg.UseGitHooks = contains(selectedTools, "Git hooks")
 
+	if cfg.UseLinters && !showLocked(pol, "lint_preset", "Lint preset:") {
+		lintPresetOptions, err := allowedOptions(pol, "lint_preset", config.LintPresets)
+		if err != nil {
+			return err
+		}
+		presetPrompt := &survey.Select{
+			Message: "Lint preset:",
+			Help:    fieldHelp["lint_preset"],
+			Options: lintPresetOptions,
+			Description: func(value string, _ int) string {
+				if value == config.LintPresetStrict {
+					return "errorlint, exhaustive, gocritic, gofumpt, gci, and more"
+				}
+				return "common linters with few false positives"
+			},
+		}
+		if contains(presetPrompt.Options, cfg.LintPreset) {
+			presetPrompt.Default = cfg.LintPreset
+		}
+		if err := survey.AskOne(presetPrompt, &cfg.LintPreset); err != nil {
+			return err
+		}
+	}
+
+	// Without golangci-lint, offer the checks it would have run
+	if !cfg.UseLinters && !showLocked(pol, "use_checks", "Add basic checks?") {
+		checksPrompt := &survey.Confirm{
+			Message: "Add make checks with go vet, staticcheck, and govulncheck?",
+			Help:    fieldHelp["use_checks"],
+			Default: cfg.UseChecks,
+		}
+		if err := survey.AskOne(checksPrompt, &cfg.UseChecks); err != nil {
+			return err
+		}
+	}
+
+	if cfg.UsePreCommitHooks && !showLocked(pol, "commit_convention", "Commit convention:") {
+		commitConventionOptions, err := allowedOptions(pol, "commit_convention", config.CommitConventions)
+		if err != nil {
+			return err
+		}
+		conventionPrompt := &survey.Select{
+			Message: "Check commit messages against:",
+			Help:    fieldHelp["commit_convention"],
+			Options: commitConventionOptions,
+			Description: func(value string, _ int) string {
+				if value == config.CommitConventionNone {
+					return "no commit-msg hook"
+				}
+				return "Conventional Commits, such as feat: and fix:"
+			},
+		}
+		if contains(conventionPrompt.Options, cfg.CommitConvention) {
+			conventionPrompt.Default = cfg.CommitConvention
+		}
+		if err := survey.AskOne(conventionPrompt, &cfg.CommitConvention); err != nil {
+			return err
+		}
+	}
+
+	// The strict linters format with gofumpt
+	if !strictLint(cfg) && !showLocked(pol, "formatting", "Formatting:") {
+		formattingOptions, err := allowedOptions(pol, "formatting", config.Formattings)
+		if err != nil {
+			return err
+		}
+		formattingPrompt := &survey.Select{
+			Message: "Format the code with:",
+			Help:    fieldHelp["formatting"],
+			Options: formattingOptions,
+			Description: func(value string, _ int) string {
+				switch value {
+				case config.FormattingGoimports:
+					return "make fmt with goimports"
+				case config.FormattingGofumpt:
+					return "make fmt with gofumpt and gci"
+				default:
+					return "no make fmt target"
+				}
+			},
+		}
+		if contains(formattingPrompt.Options, cfg.Formatting) {
+			formattingPrompt.Default = cfg.Formatting
+		}
+		if err := survey.AskOne(formattingPrompt, &cfg.Formatting); err != nil {
+			return err
+		}
+	}
+
+	if !showLocked(pol, "tool_version_manager", "Tool version file:") {
+		managerPrompt := &survey.Select{
+			Message: "Pin tool versions with:",
+			Help:    fieldHelp["tool_version_manager"],
+			Options: config.ToolVersionManagers,
+			Description: func(value string, _ int) string {
+				switch value {
+				case config.ToolVersionAsdf:
+					return ".tool-versions"
+				case config.ToolVersionMise:
+					return ".mise.toml"
+				default:
+					return "no tool version file"
+				}
+			},
+		}
+		if contains(managerPrompt.Options, cfg.ToolVersionManager) {
+			managerPrompt.Default = cfg.ToolVersionManager
+		}
+		if err := survey.AskOne(managerPrompt, &cfg.ToolVersionManager); err != nil {
+			return err
+		}
+	}
+
+	if !showLocked(pol, "secrets_manager", "Secrets manager:") {
+		secretsPrompt := &survey.Select{
+			Message: "Manage secrets with:",
+			Help:    fieldHelp["secrets_manager"],
+			Options: config.SecretsManagers,
+			Description: func(value string, _ int) string {
+				switch value {
+				case config.SecretsManagerSops:
+					return "sops and age, encrypted in secrets/"
+				case config.SecretsManagerVault:
+					return "Vault Agent templates in vault/"
+				default:
+					return "no secrets setup"
+				}
+			},
+		}
+		if contains(secretsPrompt.Options, cfg.SecretsManager) {
+			secretsPrompt.Default = cfg.SecretsManager
+		}
+		if err := survey.AskOne(secretsPrompt, &cfg.SecretsManager); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Type == config.TypeAPI && !showLocked(pol, "use_live_reload", "Add live reload?") {
+		liveReloadPrompt := &survey.Confirm{
+			Message: "Add make dev to restart the server with air when the code changes?",
+			Help:    fieldHelp["use_live_reload"],
+			Default: cfg.UseLiveReload,
+		}
+		if err := survey.AskOne(liveReloadPrompt, &cfg.UseLiveReload); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Type == config.TypeAPI && !showLocked(pol, "use_pprof", "Add profiling endpoints?") {
+		pprofPrompt := &survey.Confirm{
+			Message: "Serve pprof profiles on a localhost debug port?",
+			Help:    fieldHelp["use_pprof"],
+			Default: cfg.UsePprof,
+		}
+		if err := survey.AskOne(pprofPrompt, &cfg.UsePprof); err != nil {
+			return err
+		}
+	}
+
 	// Dependencies section
 	fmt.Println(sectionStyle.Render("📦 Dependencies"))
 
-	depsPrompt := &survey.MultiSelect{
-		Message: "Select dependencies to include:",
-		Options: []string{
-			"Cobra (CLI framework)",
-			"Viper (configuration)",
-		},
-		Default: getDepsDefaults(cfg),
+	// Without Cobra or Gin, CLI and API projects use the flag and net/http
+	// packages, and the features built on them are not offered
+	deps := []string{
+		"Cobra (CLI framework)",
+		"Viper (configuration)",
 	}
-
-	var selectedDeps []string
-	if err := survey.AskOne(depsPrompt, &selectedDeps); err != nil {
+	if cfg.Type == config.TypeAPI {
+		deps = append(deps, "Gin (HTTP framework)")
+	}
+	selectedDeps, err := askMultiSelect(pol, "Select dependencies to include:", deps, depsFields, getDepsDefaults(cfg))
+	if err != nil {
 		return err
 	}
 
 	// Update config based on selections
 	cfg.UseCobra = contains(selectedDeps, "Cobra (CLI framework)")
 	cfg.UseViper = contains(selectedDeps, "Viper (configuration)")
+	if cfg.Type == config.TypeAPI {
+		cfg.UseGin = contains(selectedDeps, "Gin (HTTP framework)")
+	}
+
+	if cfg.Type == config.TypeAPI && cfg.UseGin && !showLocked(pol, "feature_flags", "Feature flags:") {
+		flagsPrompt := &survey.Select{
+			Message: "Feature flags (OpenFeature):",
+			Help:    fieldHelp["feature_flags"],
+			Options: config.FeatureFlagBackends,
+			Description: func(value string, _ int) string {
+				switch value {
+				case config.FeatureFlagsMemory:
+					return "flags defined in code"
+				case config.FeatureFlagsFlagd:
+					return "flags served by flagd"
+				default:
+					return "no feature flags"
+				}
+			},
+		}
+		if contains(flagsPrompt.Options, cfg.FeatureFlags) {
+			flagsPrompt.Default = cfg.FeatureFlags
+		}
+		if err := survey.AskOne(flagsPrompt, &cfg.FeatureFlags); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Type == config.TypeAPI && !showLocked(pol, "request_validation", "Request validation:") {
+		// Without Gin there is no binding to validate with
+		options := config.RequestValidations
+		if !cfg.UseGin {
+			options = []string{config.RequestValidationNone, config.RequestValidationValidator}
+		}
+		requestValidationOptions, err := allowedOptions(pol, "request_validation", options)
+		if err != nil {
+			return err
+		}
+		validationPrompt := &survey.Select{
+			Message: "Request validation:",
+			Help:    fieldHelp["request_validation"],
+			Options: requestValidationOptions,
+			Description: func(value string, _ int) string {
+				switch value {
+				case config.RequestValidationValidator:
+					return "go-playground/validator after decoding"
+				case config.RequestValidationBinding:
+					return "binding tags of Gin"
+				default:
+					return "no request validation"
+				}
+			},
+		}
+		if v := requestValidation(cfg); contains(validationPrompt.Options, v) {
+			validationPrompt.Default = v
+		}
+		if err := survey.AskOne(validationPrompt, &cfg.RequestValidation); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Type == config.TypeAPI && !showLocked(pol, "pagination", "Pagination:") {
+		paginationOptions, err := allowedOptions(pol, "pagination", config.Paginations)
+		if err != nil {
+			return err
+		}
+		paginationPrompt := &survey.Select{
+			Message: "Pagination:",
+			Help:    fieldHelp["pagination"],
+			Options: paginationOptions,
+			Description: func(value string, _ int) string {
+				switch value {
+				case config.PaginationCursor:
+					return "opaque cursors to the next page"
+				case config.PaginationOffset:
+					return "limit and offset with a total"
+				default:
+					return "no pagination helpers"
+				}
+			},
+		}
+		if contains(paginationPrompt.Options, cfg.Pagination) {
+			paginationPrompt.Default = cfg.Pagination
+		}
+		if err := survey.AskOne(paginationPrompt, &cfg.Pagination); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Type == config.TypeAPI && !showLocked(pol, "task_queue", "Task queue:") {
+		taskQueueOptions, err := allowedOptions(pol, "task_queue", config.TaskQueues)
+		if err != nil {
+			return err
+		}
+		taskQueuePrompt := &survey.Select{
+			Message: "Task queue:",
+			Help:    fieldHelp["task_queue"],
+			Options: taskQueueOptions,
+			Description: func(value string, _ int) string {
+				switch value {
+				case config.TaskQueueMemory:
+					return "worker pool in the process"
+				case config.TaskQueueAsynq:
+					return "asynq with Redis"
+				default:
+					return "no background tasks"
+				}
+			},
+		}
+		if contains(taskQueuePrompt.Options, cfg.TaskQueue) {
+			taskQueuePrompt.Default = cfg.TaskQueue
+		}
+		if err := survey.AskOne(taskQueuePrompt, &cfg.TaskQueue); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Type == config.TypeAPI && !showLocked(pol, "use_http_cache", "Cache responses?") {
+		httpCachePrompt := &survey.Confirm{
+			Message: "Cache GET responses with ETag and Cache-Control headers?",
+			Help:    fieldHelp["use_http_cache"],
+			Default: cfg.UseHTTPCache,
+		}
+		if err := survey.AskOne(httpCachePrompt, &cfg.UseHTTPCache); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Type == config.TypeAPI && cfg.UseGin && !showLocked(pol, "use_i18n", "Localize messages?") {
+		i18nPrompt := &survey.Confirm{
+			Message: "Add a message catalog with Accept-Language negotiation (golang.org/x/text)?",
+			Help:    fieldHelp["use_i18n"],
+			Default: cfg.UseI18n,
+		}
+		if err := survey.AskOne(i18nPrompt, &cfg.UseI18n); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Type == config.TypeAPI && cfg.UseGin && !showLocked(pol, "use_multi_tenancy", "Multi-tenancy?") {
+		tenancyPrompt := &survey.Confirm{
+			Message: "Scope requests and the store by a tenant ID header (multi-tenancy)?",
+			Help:    fieldHelp["use_multi_tenancy"],
+			Default: cfg.UseMultiTenancy,
+		}
+		if err := survey.AskOne(tenancyPrompt, &cfg.UseMultiTenancy); err != nil {
+			return err
+		}
+	}
+
+	if ((cfg.Type == config.TypeCLI && cfg.UseCobra) || (cfg.Type == config.TypeAPI && cfg.UseGin)) && !showLocked(pol, "use_plugins", "Plugin system?") {
+		pluginsPrompt := &survey.Confirm{
+			Message: "Add a plugin system with a sample plugin (hashicorp/go-plugin)?",
+			Help:    fieldHelp["use_plugins"],
+			Default: cfg.UsePlugins,
+		}
+		if err := survey.AskOne(pluginsPrompt, &cfg.UsePlugins); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Type != config.TypeLibrary && !showLocked(pol, "use_notify", "Add notifications?") {
+		notifyPrompt := &survey.Confirm{
+			Message: "Add an internal/notify package with SMTP and Slack webhook notifiers?",
+			Help:    fieldHelp["use_notify"],
+			Default: cfg.UseNotify,
+		}
+		if err := survey.AskOne(notifyPrompt, &cfg.UseNotify); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Type == config.TypeAPI && cfg.UseViper && !showLocked(pol, "use_config_reload", "Reload config on changes?") {
+		reloadPrompt := &survey.Confirm{
+			Message: "Watch config.yaml and apply its changes while the service runs?",
+			Help:    fieldHelp["use_config_reload"],
+			Default: cfg.UseConfigReload,
+		}
+		if err := survey.AskOne(reloadPrompt, &cfg.UseConfigReload); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Type == config.TypeCLI && cfg.UseCobra && cfg.UseViper && !showLocked(pol, "use_config_command", "Add config commands?") {
+		configCmdPrompt := &survey.Confirm{
+			Message: "Add config get/set/list commands with the config file in ~/.config?",
+			Help:    fieldHelp["use_config_command"],
+			Default: cfg.UseConfigCommand,
+		}
+		if err := survey.AskOne(configCmdPrompt, &cfg.UseConfigCommand); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Type == config.TypeCLI && cfg.UseCobra && !showLocked(pol, "use_self_update", "Add self-update?") {
+		selfUpdatePrompt := &survey.Confirm{
+			Message: "Add a self-update command and new release notifications (GitHub releases)?",
+			Help:    fieldHelp["use_self_update"],
+			Default: cfg.UseSelfUpdate,
+		}
+		if err := survey.AskOne(selfUpdatePrompt, &cfg.UseSelfUpdate); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Type == config.TypeCLI && cfg.UseCobra && !showLocked(pol, "use_output", "Add --output formats?") {
+		outputPrompt := &survey.Confirm{
+			Message: "Add a persistent --output flag with text, JSON, and YAML output?",
+			Help:    fieldHelp["use_output"],
+			Default: cfg.UseOutput,
+		}
+		if err := survey.AskOne(outputPrompt, &cfg.UseOutput); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Type == config.TypeCLI && cfg.UseCobra && !showLocked(pol, "prompt_library", "Prompt library:") {
+		promptLibraryPrompt := &survey.Select{
+			Message: "Interactive prompts:",
+			Help:    fieldHelp["prompt_library"],
+			Options: config.PromptLibraries,
+			Description: func(value string, _ int) string {
+				switch value {
+				case config.PromptLibrarySurvey:
+					return "question prompts with survey"
+				case config.PromptLibraryBubbletea:
+					return "Bubble Tea models"
+				default:
+					return "no interactive prompts"
+				}
+			},
+		}
+		if contains(promptLibraryPrompt.Options, cfg.PromptLibrary) {
+			promptLibraryPrompt.Default = cfg.PromptLibrary
+		}
+		if err := survey.AskOne(promptLibraryPrompt, &cfg.PromptLibrary); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Type == config.TypeCLI && cfg.UseCobra && !showLocked(pol, "use_telemetry", "Add usage telemetry?") {
+		telemetryPrompt := &survey.Confirm{
+			Message: "Add opt-in anonymous usage telemetry (off by default, honors DO_NOT_TRACK)?",
+			Help:    fieldHelp["use_telemetry"],
+			Default: cfg.UseTelemetry,
+		}
+		if err := survey.AskOne(telemetryPrompt, &cfg.UseTelemetry); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Type != config.TypeLibrary && !showLocked(pol, "use_crash_handler", "Report crashes?") {
+		crashPrompt := &survey.Confirm{
+			Message: "Recover panics in main and report them to Sentry or a webhook?",
+			Help:    fieldHelp["use_crash_handler"],
+			Default: cfg.UseCrashHandler,
+		}
+		if err := survey.AskOne(crashPrompt, &cfg.UseCrashHandler); err != nil {
+			return err
+		}
+	}
 
 	// CI/CD section
 	fmt.Println(sectionStyle.Render("🔄 CI/CD"))
 
-	cicdPrompt := &survey.Confirm{
-		Message: "Set up GitHub Actions for CI/CD?",
-		Default: cfg.UseGitHubActions,
+	if !showLocked(pol, "use_github_actions", "Set up GitHub Actions for CI/CD?") {
+		cicdPrompt := &survey.Confirm{
+			Message: "Set up GitHub Actions for CI/CD?",
+			Help:    fieldHelp["use_github_actions"],
+			Default: cfg.UseGitHubActions,
+		}
+		if err := survey.AskOne(cicdPrompt, &cfg.UseGitHubActions); err != nil {
+			return err
+		}
+	}
+
+	if !showLocked(pol, "use_benchmarks", "Add benchmarks with a benchstat comparison?") {
+		benchPrompt := &survey.Confirm{
+			Message: "Add a make bench target and compare pull request benchmarks with benchstat?",
+			Help:    fieldHelp["use_benchmarks"],
+			Default: cfg.UseBenchmarks,
+		}
+		if err := survey.AskOne(benchPrompt, &cfg.UseBenchmarks); err != nil {
+			return err
+		}
 	}
-	if err := survey.AskOne(cicdPrompt, &cfg.UseGitHubActions); err != nil {
+
+	if !showLocked(pol, "use_apidiff", "Check the exported API for breaking changes?") {
+		apidiffPrompt := &survey.Confirm{
+			Message: "Add a make apidiff target and check pull requests for breaking API changes with gorelease?",
+			Help:    fieldHelp["use_apidiff"],
+			Default: cfg.UseAPIDiff,
+		}
+		if err := survey.AskOne(apidiffPrompt, &cfg.UseAPIDiff); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Type != config.TypeGitHubAction && cfg.Type != config.TypeOperator && mainPackage(cfg) != "" {
+		if !showLocked(pol, "use_docker", "Add a Dockerfile?") {
+			dockerPrompt := &survey.Confirm{
+				Message: "Add a multi-arch Dockerfile and make docker-build?",
+				Help:    fieldHelp["use_docker"],
+				Default: cfg.UseDocker,
+			}
+			if err := survey.AskOne(dockerPrompt, &cfg.UseDocker); err != nil {
+				return err
+			}
+		}
+
+		if cfg.UseDocker && !showLocked(pol, "docker_base", "Runtime image:") {
+			dockerBaseOptions, err := allowedOptions(pol, "docker_base", config.DockerBases)
+			if err != nil {
+				return err
+			}
+			basePrompt := &survey.Select{
+				Message: "Runtime image:",
+				Help:    fieldHelp["docker_base"],
+				Options: dockerBaseOptions,
+			}
+			if contains(basePrompt.Options, dockerBase(cfg)) {
+				basePrompt.Default = dockerBase(cfg)
+			}
+			if err := survey.AskOne(basePrompt, &cfg.DockerBase); err != nil {
+				return err
+			}
+		}
+
+		if cfg.UseDocker && cfg.UseGitHubActions && !showLocked(pol, "container_registry", "Container registry:") {
+			containerRegistryOptions, err := allowedOptions(pol, "container_registry", config.ContainerRegistries)
+			if err != nil {
+				return err
+			}
+			registryPrompt := &survey.Select{
+				Message: "Publish the image to:",
+				Help:    fieldHelp["container_registry"],
+				Options: containerRegistryOptions,
+			}
+			if contains(registryPrompt.Options, containerRegistry(cfg)) {
+				registryPrompt.Default = containerRegistry(cfg)
+			}
+			if err := survey.AskOne(registryPrompt, &cfg.ContainerRegistry); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Re-apply locked values that were hidden from the multi-select prompts
+	if _, err := pol.Apply(cfg); err != nil {
 		return err
 	}
 
@@ -261,47 +1128,152 @@ func RunWizard(cfg *config.ProjectConfig) error {
 	fmt.Println(highlightStyle.Render("Project:"), cfg.Name)
 	fmt.Println(highlightStyle.Render("Module:"), cfg.Module)
 	fmt.Println(highlightStyle.Render("Description:"), cfg.Description)
-	fmt.Println(highlightStyle.Render("Author:"), cfg.Author)
-	fmt.Println(highlightStyle.Render("License:"), cfg.License)
+	if len(cfg.Topics) > 0 {
+		fmt.Println(highlightStyle.Render("Topics:"), strings.Join(cfg.Topics, ", "))
+	}
+	fmt.Println(highlightStyle.Render("Authors:"), config.FormatAuthors(cfg.Authors))
+	if cfg.IsInternal() {
+		fmt.Println(highlightStyle.Render("Visibility:"), cfg.Visibility)
+	} else {
+		fmt.Println(highlightStyle.Render("License:"), cfg.License)
+	}
 
 	fmt.Println(highlightStyle.Render("Directories:"))
 	if cfg.UseCmd {
-		fmt.Println("  - cmd")
+		fmt.Println("  -", cfg.Dir("cmd"))
 	}
 	if cfg.UseInternal {
-		fmt.Println("  - internal")
+		fmt.Println("  -", cfg.Dir("internal"))
 	}
 	if cfg.UsePkg {
-		fmt.Println("  - pkg")
+		fmt.Println("  -", cfg.Dir("pkg"))
 	}
 	if cfg.UseTest {
-		fmt.Println("  - test")
+		fmt.Println("  -", cfg.Dir("test"))
 	}
 	if cfg.UseDocs {
-		fmt.Println("  - docs")
+		fmt.Println("  -", cfg.Dir("docs"))
+	}
+	if hasExamples(cfg) {
+		fmt.Println("  - examples")
+	}
+	if hasDocsSite(cfg) {
+		fmt.Printf("  - docs site (%s)\n", cfg.DocsSite)
+	}
+	switch cfg.DirPlaceholder {
+	case config.DirPlaceholderDoc:
+		fmt.Println("  - doc.go in empty directories")
+	case config.DirPlaceholderNone:
+		fmt.Println("  - no placeholders in empty directories")
 	}
 
 	fmt.Println(highlightStyle.Render("Files:"))
 	if cfg.CreateReadme {
 		fmt.Println("  - README.md")
 	}
-	if cfg.CreateLicense {
+	if hasLicense(cfg) {
 		fmt.Println("  - LICENSE")
 	}
 	if cfg.CreateMakefile {
 		fmt.Println("  - Makefile")
 	}
+	if cfg.CreateCatalogInfo {
+		fmt.Printf("  - catalog-info.yaml (owner: %s, lifecycle: %s)\n", catalogOwner(cfg), cfg.Lifecycle)
+	}
+	if cfg.Team != "" {
+		fmt.Printf("  - %s (%s)\n", codeownersPath(cfg), codeownersTeam(cfg))
+	}
+	if hasAlerting(cfg) {
+		fmt.Println("  - alerting/ (Prometheus rules, Alertmanager route)")
+	}
+	if cfg.CreateVersionFile {
+		fmt.Println("  - VERSION")
+	}
+	if cfg.UseADR {
+		fmt.Println("  - docs/adr (decision records)")
+	}
+	if hasPackageDocs(cfg) {
+		fmt.Println("  - doc.go and examples_test.go (pkg.go.dev, Go Report Card)")
+	}
+	if cfg.Type == config.TypeLibrary && len(cfg.Packages) > 0 {
+		fmt.Printf("  - Packages: %s\n", strings.Join(cfg.Packages, ", "))
+	}
+	if cfg.Type == config.TypeGitHubAction {
+		fmt.Printf("  - action.yml (%s)\n", actionRuntime(cfg))
+	}
+	if cfg.Type == config.TypeOperator {
+		fmt.Printf("  - %s/%s %s (CRD, sample reconciler)\n", operatorGroup(cfg), operatorAPIVersion, operatorKind(cfg))
+	}
+	if cfg.Type == config.TypeGRPC {
+		fmt.Printf("  - %s (gRPC, REST gateway, OpenAPI)\n", grpcProtoFile(cfg))
+	}
+	if cfg.Type == config.TypeEventDriven {
+		fmt.Printf("  - Postgres outbox relayed to %s, idempotent consumer\n", eventBroker(cfg))
+	}
+	if cfg.Type == config.TypeBatch {
+		fmt.Println("  - File, S3, and Postgres input and output, worker pool, checkpoints")
+	}
+	if cfg.Type == config.TypeCrawler {
+		fmt.Println("  - robots.txt, per-host rate limits, pluggable parsers, JSON lines storage")
+	}
+	if cfg.Type == config.TypeWorker {
+		fmt.Println("  - Queue interface, worker pool with retries, graceful shutdown, example processor")
+	}
+	if cfg.Type == config.TypeTUI {
+		fmt.Println("  - Bubble Tea model, update/view split, Lip Gloss styles, teatest tests")
+	}
+	if cfg.Type == config.TypeSDK {
+		fmt.Printf("  - Client generated from %s (services, pagination, retries)\n", cfg.OpenAPISpec)
+	}
+	if cfg.Type == config.TypeScript {
+		fmt.Println("  - main.go (flags, log/slog, exit statuses)")
+	}
+
+	if hasOwnership(cfg) {
+		fmt.Println(highlightStyle.Render("Ownership:"))
+		if cfg.Team != "" {
+			fmt.Printf("  - Team: %s\n", cfg.Team)
+		}
+		if cfg.SlackChannel != "" {
+			fmt.Printf("  - Slack: #%s\n", slackChannelName(cfg))
+		}
+		if cfg.OnCall != "" {
+			fmt.Printf("  - On-call: %s\n", cfg.OnCall)
+		}
+	}
 
 	fmt.Println(highlightStyle.Render("Tools:"))
-	if cfg.UseLinters {
+	if strictLint(cfg) {
+		fmt.Println("  - Linters (strict)")
+	} else if cfg.UseLinters {
 		fmt.Println("  - Linters")
 	}
-	if cfg.UsePreCommitHooks {
+	if cfg.ConventionalCommits() {
+		fmt.Println("  - Pre-commit hooks (Conventional Commits)")
+	} else if cfg.UsePreCommitHooks {
 		fmt.Println("  - Pre-commit hooks")
 	}
 	if cfg.UseGitHooks {
 		fmt.Println("  - Git hooks")
 	}
+	if cfg.UseChecks {
+		fmt.Println("  - make checks (go vet, staticcheck, govulncheck)")
+	}
+	if hasFormatting(cfg) {
+		fmt.Printf("  - make fmt (%s)\n", formatter(cfg))
+	}
+	if file := toolVersionFile(cfg); file != "" {
+		fmt.Printf("  - %s (%s)\n", file, cfg.ToolVersionManager)
+	}
+	if hasSecrets(cfg) {
+		fmt.Printf("  - Secrets (%s)\n", cfg.SecretsManager)
+	}
+	if hasLiveReload(cfg) {
+		fmt.Println("  - Live reload (air)")
+	}
+	if hasPprof(cfg) {
+		fmt.Printf("  - pprof (%s)\n", pprofDefaultAddr)
+	}
 
 	fmt.Println(highlightStyle.Render("Dependencies:"))
 	if cfg.UseCobra {
@@ -310,16 +1282,80 @@ func RunWizard(cfg *config.ProjectConfig) error {
 	if cfg.UseViper {
 		fmt.Println("  - Viper")
 	}
+	if cfg.UseGin && cfg.Type == config.TypeAPI {
+		fmt.Println("  - Gin")
+	}
+	if hasFeatureFlags(cfg) {
+		fmt.Printf("  - OpenFeature (%s)\n", cfg.FeatureFlags)
+	}
+	if hasValidation(cfg) {
+		fmt.Printf("  - Request validation (%s)\n", requestValidation(cfg))
+	}
+	if hasPagination(cfg) {
+		fmt.Printf("  - Pagination (%s)\n", cfg.Pagination)
+	}
+	if hasTaskQueue(cfg) {
+		fmt.Printf("  - Task queue (%s)\n", cfg.TaskQueue)
+	}
+	if hasHTTPCache(cfg) {
+		fmt.Println("  - HTTP cache (ETag, Cache-Control, 304 Not Modified)")
+	}
+	if hasI18n(cfg) {
+		fmt.Println("  - golang.org/x/text (message catalog)")
+	}
+	if hasMultiTenancy(cfg) {
+		fmt.Println("  - Multi-tenancy (tenant header, tenant-scoped store)")
+	}
+	if hasPlugins(cfg) {
+		fmt.Println("  - Plugins (hashicorp/go-plugin, sample plugin)")
+	}
+	if hasNotify(cfg) {
+		fmt.Println("  - internal/notify (SMTP, Slack webhook)")
+	}
+	if hasConfigReload(cfg) {
+		fmt.Println("  - Config reload (Viper)")
+	}
+	if hasConfigCommand(cfg) {
+		fmt.Println("  - config get/set/list (XDG config directory)")
+	}
+	if hasSelfUpdate(cfg) {
+		fmt.Println("  - self-update (GitHub releases)")
+	}
+	if hasOutput(cfg) {
+		fmt.Println("  - pkg/output (--output text, json, yaml)")
+	}
+	if hasPrompt(cfg) {
+		fmt.Printf("  - internal/prompt (%s)\n", cfg.PromptLibrary)
+	}
+	if hasTelemetry(cfg) {
+		fmt.Println("  - internal/telemetry (opt-in usage data)")
+	}
+	if hasCrashHandler(cfg) {
+		fmt.Println("  - internal/crash (Sentry, webhook)")
+	}
 
 	fmt.Println(highlightStyle.Render("CI/CD:"))
 	if cfg.UseGitHubActions {
 		fmt.Println("  - GitHub Actions")
 	}
+	if cfg.UseBenchmarks {
+		fmt.Println("  - Benchmarks (benchstat)")
+	}
+	if cfg.UseAPIDiff {
+		fmt.Println("  - API compatibility (gorelease)")
+	}
+	if hasDocker(cfg) {
+		fmt.Printf("  - Dockerfile (%s)\n", dockerBase(cfg))
+	}
+	if hasImageWorkflow(cfg) {
+		fmt.Printf("  - Image workflow (%s)\n", containerRegistry(cfg))
+	}
 
 	// Confirm generation
 	var confirm bool
 	confirmPrompt := &survey.Confirm{
 		Message: "Generate project with these settings?",
+		Help:    "Generate the project with the settings above. Answer no to quit without writing files.",
 		Default: true,
 	}
 	if err := survey.AskOne(confirmPrompt, &confirm); err != nil {
@@ -333,6 +1369,104 @@ func RunWizard(cfg *config.ProjectConfig) error {
 	return nil
 }
 
+// showLocked prints the value of a field locked by the policy and reports whether it is locked
+func showLocked(pol *policy.Policy, field, label string) bool {
+	v, ok := pol.LockedValue(field)
+	if !ok {
+		return false
+	}
+	fmt.Printf("🔒 %s %v %s\n", label, v, highlightStyle.Render("(enforced by policy)"))
+	return true
+}
+
+// askMultiSelect asks a multi-select question, leaving out options locked by the policy
+func askMultiSelect(pol *policy.Policy, message string, options []string, fields map[string]string, defaults []string) ([]string, error) {
+	var unlocked, unlockedDefaults []string
+	for _, opt := range options {
+		if v, ok := pol.LockedValue(fields[opt]); ok {
+			fmt.Printf("🔒 %s: %v %s\n", opt, v, highlightStyle.Render("(enforced by policy)"))
+			continue
+		}
+		unlocked = append(unlocked, opt)
+		if contains(defaults, opt) {
+			unlockedDefaults = append(unlockedDefaults, opt)
+		}
+	}
+
+	var selected []string
+	if len(unlocked) == 0 {
+		return selected, nil
+	}
+
+	prompt := &survey.MultiSelect{
+		Message: message,
+		Help:    optionsHelp(unlocked, fields),
+		Options: unlocked,
+		Default: unlockedDefaults,
+	}
+	if err := survey.AskOne(prompt, &selected); err != nil {
+		return nil, err
+	}
+	return selected, nil
+}
+
+// allowedOptions restricts select options to the values allowed by the
+// policy. It fails when the policy allows none of them, which would leave
+// nothing to select.
+func allowedOptions(pol *policy.Policy, field string, options []string) ([]string, error) {
+	if pol == nil || len(pol.Allowed[field]) == 0 {
+		return options, nil
+	}
+
+	var allowed []string
+	for _, opt := range options {
+		if contains(pol.Allowed[field], opt) {
+			allowed = append(allowed, opt)
+		}
+	}
+	if len(allowed) == 0 {
+		return nil, fmt.Errorf("policy %s allows %s %s, none of which the wizard offers (%s)",
+			pol.Name, field, strings.Join(pol.Allowed[field], ", "), strings.Join(options, ", "))
+	}
+	return allowed, nil
+}
+
+// moduleValidator rejects invalid module paths and those outside the prefix
+// required by the policy
+func moduleValidator(pol *policy.Policy) survey.Validator {
+	return func(ans interface{}) error {
+		module, _ := ans.(string)
+		// Non-ASCII elements are transliterated before generation, so
+		// validate the path they become
+		ascii, err := config.ASCIIModule(module)
+		if err != nil {
+			return err
+		}
+		if err := config.ValidateModule(ascii); err != nil {
+			return err
+		}
+		if pol != nil && pol.ModulePrefix != "" && !strings.HasPrefix(module, pol.ModulePrefix) {
+			return fmt.Errorf("module path must start with %s", pol.ModulePrefix)
+		}
+		return nil
+	}
+}
+
+// licenseValidator rejects licenses other than the given ones, such as the
+// supported licenses allowed by the policy
+func licenseValidator(licenses []string) survey.Validator {
+	return func(ans interface{}) error {
+		license, ok := ans.(string)
+		if option, isOption := ans.(core.OptionAnswer); isOption {
+			license, ok = option.Value, true
+		}
+		if !ok || !contains(licenses, license) {
+			return fmt.Errorf("unknown license %v: use one of %s", ans, strings.Join(licenses, ", "))
+		}
+		return nil
+	}
+}
+
 // Helper functions to set default selections in the wizard
 func getStructureDefaults(cfg *config.ProjectConfig) []string {
 	var defaults []string
@@ -351,6 +1485,9 @@ func getStructureDefaults(cfg *config.ProjectConfig) []string {
 	if cfg.UseDocs {
 		defaults = append(defaults, "docs (documentation)")
 	}
+	if cfg.UseExamples {
+		defaults = append(defaults, "examples (example programs)")
+	}
 	return defaults
 }
 
@@ -365,6 +1502,15 @@ func getFilesDefaults(cfg *config.ProjectConfig) []string {
 	if cfg.CreateMakefile {
 		defaults = append(defaults, "Makefile")
 	}
+	if cfg.CreateCatalogInfo {
+		defaults = append(defaults, "catalog-info.yaml (Backstage)")
+	}
+	if cfg.CreateVersionFile {
+		defaults = append(defaults, "VERSION (bump targets, GoReleaser)")
+	}
+	if cfg.UseADR {
+		defaults = append(defaults, "docs/adr (decision records)")
+	}
 	return defaults
 }
 
@@ -390,6 +1536,9 @@ func getDepsDefaults(cfg *config.ProjectConfig) []string {
 	if cfg.UseViper {
 		defaults = append(defaults, "Viper (configuration)")
 	}
+	if cfg.UseGin {
+		defaults = append(defaults, "Gin (HTTP framework)")
+	}
 	return defaults
 }
 
@@ -402,3 +1551,26 @@ func contains(slice []string, item string) bool {
 	}
 	return false
 }
+
+// splitList splits a comma-separated answer into its trimmed, non-empty items
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseTopics splits the answer of the topics prompt into lowercase topics,
+// one per line, skipping empty lines
+func parseTopics(s string) []string {
+	var topics []string
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.ToLower(strings.TrimSpace(line)); line != "" {
+			topics = append(topics, line)
+		}
+	}
+	return topics
+}
@@ -1,10 +1,17 @@
 package wizard
 
 import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"strings"
 	"testing"
 
+	"github.com/AlecAivazis/survey/v2/core"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/oculus-core/gogo/internal/policy"
 	"github.com/oculus-core/gogo/pkg/config"
 )
 
@@ -199,3 +206,147 @@ func TestGetDepsDefaults(t *testing.T) {
 		})
 	}
 }
+
+// TestResolveNameMismatch tests how a project name that differs from its
+// module is synced without prompting
+func TestResolveNameMismatch(t *testing.T) {
+	assert.Equal(t, "billing", moduleName("github.com/acme/billing/v2"))
+	assert.Equal(t, "v1", moduleName("github.com/acme/v1"))
+	assert.Equal(t, "github.com/acme/payments/v2", renameModule("github.com/acme/billing/v2", "payments"))
+	assert.Equal(t, "payments", renameModule("billing", "payments"))
+
+	newConfig := func() *config.ProjectConfig {
+		cfg := config.NewDefaultProjectConfig()
+		cfg.Name = "payments"
+		cfg.Module = "github.com/acme/billing/v2"
+		return cfg
+	}
+
+	cfg := newConfig()
+	assert.NoError(t, ResolveNameMismatch(cfg, nil, SyncNameToModule, false))
+	assert.Equal(t, "billing", cfg.Name)
+
+	cfg = newConfig()
+	assert.NoError(t, ResolveNameMismatch(cfg, nil, SyncModuleToName, false))
+	assert.Equal(t, "github.com/acme/payments/v2", cfg.Module)
+
+	// Asking without a wizard, keeping both, and locked fields only warn
+	for _, mode := range []string{"", SyncNameAsk, SyncNameKeep} {
+		cfg = newConfig()
+		assert.NoError(t, ResolveNameMismatch(cfg, nil, mode, false))
+		assert.Equal(t, newConfig(), cfg)
+	}
+	cfg = newConfig()
+	pol := &policy.Policy{Locked: map[string]interface{}{"module": "github.com/acme/billing/v2"}}
+	assert.NoError(t, ResolveNameMismatch(cfg, pol, SyncModuleToName, true))
+	assert.Equal(t, newConfig(), cfg)
+
+	assert.Error(t, ResolveNameMismatch(newConfig(), nil, "both", false))
+
+	// The placeholder module and modules made from the name are not checked
+	for _, module := range []string{config.DefaultModule, "payments"} {
+		cfg = newConfig()
+		cfg.Module = module
+		assert.NoError(t, ResolveNameMismatch(cfg, nil, SyncModuleToName, false))
+		assert.Equal(t, module, cfg.Module)
+	}
+}
+
+// TestPromptsHaveHelp checks that every prompt of the wizard shows help when
+// the user types ?, and that the help it names exists
+func TestPromptsHaveHelp(t *testing.T) {
+	fset := token.NewFileSet()
+	for _, file := range []string{"wizard.go", "naming.go"} {
+		f, err := parser.ParseFile(fset, file, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ast.Inspect(f, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.CompositeLit:
+				sel, ok := n.Type.(*ast.SelectorExpr)
+				if !ok {
+					return true
+				}
+				if pkg, ok := sel.X.(*ast.Ident); !ok || pkg.Name != "survey" {
+					return true
+				}
+				for _, elt := range n.Elts {
+					if kv, ok := elt.(*ast.KeyValueExpr); ok && kv.Key.(*ast.Ident).Name == "Help" {
+						return true
+					}
+				}
+				t.Errorf("%s: survey.%s prompt has no Help", fset.Position(n.Pos()), sel.Sel.Name)
+			case *ast.IndexExpr:
+				if id, ok := n.X.(*ast.Ident); ok && id.Name == "fieldHelp" {
+					if lit, ok := n.Index.(*ast.BasicLit); ok {
+						key, _ := strconv.Unquote(lit.Value)
+						if fieldHelp[key] == "" {
+							t.Errorf("%s: no help for %s", fset.Position(n.Pos()), key)
+						}
+					}
+				}
+			}
+			return true
+		})
+	}
+}
+
+func TestFieldHelp(t *testing.T) {
+	for key, help := range fieldHelp {
+		for _, line := range strings.Split(help, "\n") {
+			if len(line) > 90 {
+				t.Errorf("help of %s has a line longer than 90 characters: %q", key, line)
+			}
+		}
+	}
+	for _, fields := range []map[string]string{structureFields, filesFields, toolsFields, depsFields} {
+		for option, field := range fields {
+			if fieldHelp[field] == "" {
+				t.Errorf("no help for the option %q (%s)", option, field)
+			}
+		}
+	}
+
+	help := optionsHelp([]string{"internal (private packages)", "pkg (public packages)"}, structureFields)
+	if !strings.HasPrefix(help, "internal (private packages): Packages in internal/ can only be imported by this module") ||
+		!strings.Contains(help, "\npkg (public packages): ") {
+		t.Errorf("optionsHelp = %q", help)
+	}
+}
+
+func TestModuleValidator(t *testing.T) {
+	validate := moduleValidator(nil)
+	assert.NoError(t, validate("github.com/acme/billing"))
+	assert.NoError(t, validate("github.com/acme/Café"), "non-ASCII elements are transliterated")
+	assert.Error(t, validate(""))
+	assert.Error(t, validate("github.com/acme/"))
+
+	validate = moduleValidator(&policy.Policy{ModulePrefix: "github.com/acme/"})
+	assert.NoError(t, validate("github.com/acme/billing"))
+	assert.ErrorContains(t, validate("github.com/other/billing"), "must start with github.com/acme/")
+}
+
+func TestLicenseValidator(t *testing.T) {
+	validate := licenseValidator(config.Licenses)
+	assert.NoError(t, validate("MIT"))
+	assert.NoError(t, validate(core.OptionAnswer{Value: "Apache-2.0"}))
+	assert.Error(t, validate("WTFPL"))
+
+	licenses, err := allowedOptions(&policy.Policy{Allowed: map[string][]string{"license": {"Apache-2.0"}}}, "license", config.Licenses)
+	assert.NoError(t, err)
+	validate = licenseValidator(licenses)
+	assert.NoError(t, validate(core.OptionAnswer{Value: "Apache-2.0"}))
+	assert.ErrorContains(t, validate(core.OptionAnswer{Value: "MIT"}), "use one of Apache-2.0")
+}
+
+func TestAllowedOptions(t *testing.T) {
+	options, err := allowedOptions(nil, "visibility", config.Visibilities)
+	assert.NoError(t, err)
+	assert.Equal(t, config.Visibilities, options)
+
+	// A policy that allows none of the options leaves nothing to select
+	pol := &policy.Policy{Name: "acme", Allowed: map[string][]string{"visibility": {"secret"}}}
+	_, err = allowedOptions(pol, "visibility", config.Visibilities)
+	assert.ErrorContains(t, err, "policy acme allows visibility secret")
+}
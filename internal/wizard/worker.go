@@ -0,0 +1,73 @@
+package wizard
+
+import (
+	"github.com/oculus-core/gogo/internal/templates"
+	"github.com/oculus-core/gogo/internal/templates/builtin"
+	"github.com/oculus-core/gogo/pkg/config"
+)
+
+// workerData is the data the worker templates are rendered with
+type workerData struct {
+	Name   string
+	Module string
+	// CrashImport and CrashDefer report panics of main with use_crash_handler
+	CrashImport string
+	CrashDefer  string
+}
+
+// workerMakePhony lists the worker targets that are not files
+const workerMakePhony = " run"
+
+// workerFiles maps the paths of the worker files to their templates
+var workerFiles = []struct{ path, text string }{
+	{"cmd/{{ .Name }}/main.go", workerMainTemplate},
+	{"internal/queue/queue.go", workerQueueTemplate},
+	{"internal/queue/memory.go", workerMemoryTemplate},
+	{"internal/queue/memory_test.go", workerMemoryTestTemplate},
+	{"internal/worker/worker.go", workerTemplate},
+	{"internal/worker/worker_test.go", workerTestTemplate},
+	{"internal/processor/processor.go", workerProcessorTemplate},
+	{"internal/processor/processor_test.go", workerProcessorTestTemplate},
+}
+
+// generateWorkerCode generates a long-running worker that consumes a queue
+// on a pool of goroutines, retries failed messages with backoff, and finishes
+// the messages in flight on shutdown
+func generateWorkerCode(cfg *config.ProjectConfig, projectDir string) error {
+	data := workerData{
+		Name:        cfg.Name,
+		Module:      cfg.Module,
+		CrashImport: crashImport(cfg),
+		CrashDefer:  crashDefer(cfg),
+	}
+	files := make(map[string]string, len(workerFiles))
+	for _, f := range workerFiles {
+		path, err := templates.Render("path", f.path, data)
+		if err != nil {
+			return err
+		}
+		content, err := templates.Render(path, f.text, data)
+		if err != nil {
+			return err
+		}
+		files[path] = content
+	}
+	return writeFiles(projectDir, files)
+}
+
+var (
+	workerMainTemplate          = builtin.MustTemplate("wizard/worker/main.tmpl")
+	workerQueueTemplate         = builtin.MustTemplate("wizard/worker/queue.tmpl")
+	workerMemoryTemplate        = builtin.MustTemplate("wizard/worker/memory.tmpl")
+	workerMemoryTestTemplate    = builtin.MustTemplate("wizard/worker/memory-test.tmpl")
+	workerTemplate              = builtin.MustTemplate("wizard/worker/worker.tmpl")
+	workerTestTemplate          = builtin.MustTemplate("wizard/worker/worker-test.tmpl")
+	workerProcessorTemplate     = builtin.MustTemplate("wizard/worker/processor.tmpl")
+	workerProcessorTestTemplate = builtin.MustTemplate("wizard/worker/processor-test.tmpl")
+	// workerMakeTargetsTemplate has the target that runs the worker on the
+	// messages of stdin, and workerReadmeTemplate explains how the worker
+	// consumes, retries, and shuts down, and where to plug in a broker
+	workerMakeTargetsTemplate = builtin.MustTemplate("wizard/worker/make-targets.tmpl")
+	workerMakeHelpTemplate    = builtin.MustTemplate("wizard/worker/make-help.tmpl")
+	workerReadmeTemplate      = builtin.MustTemplate("wizard/worker/readme-section.tmpl")
+)
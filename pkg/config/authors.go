@@ -0,0 +1,157 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/mail"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Author is an author of a project
+type Author struct {
+	Name  string `yaml:"name" json:"name"`
+	Email string `yaml:"email,omitempty" json:"email,omitempty"`
+}
+
+// String formats the author as "Name <email>", or the name alone
+func (a Author) String() string {
+	switch {
+	case a.Email == "":
+		return a.Name
+	case a.Name == "":
+		return "<" + a.Email + ">"
+	}
+	return (&mail.Address{Name: a.Name, Address: a.Email}).String()
+}
+
+// ParseAuthor parses an author of the form "Name" or "Name <email>"
+func ParseAuthor(author string) (Author, error) {
+	author = strings.TrimSpace(author)
+	if !strings.ContainsAny(author, "<>@") {
+		return Author{Name: author}, nil
+	}
+	addr, err := mail.ParseAddress(author)
+	if err != nil {
+		return Author{}, fmt.Errorf("invalid author %q: use Name or Name <email>", author)
+	}
+	return Author{Name: addr.Name, Email: addr.Address}, nil
+}
+
+// ParseAuthors parses a comma-separated list of authors, each of the form
+// "Name" or "Name <email>", such as the author field of older configs
+func ParseAuthors(authors string) ([]Author, error) {
+	var result []Author
+	for _, s := range splitAuthors(authors) {
+		if strings.TrimSpace(s) == "" {
+			continue
+		}
+		a, err := ParseAuthor(s)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, a)
+	}
+	return result, nil
+}
+
+// splitAuthors splits s at the commas outside quotes and angle brackets, so
+// "Doe, Jane" <jane@example.com> stays one author
+func splitAuthors(s string) []string {
+	var parts []string
+	quoted, bracketed, start := false, false, 0
+	for i, r := range s {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case r == '<' && !quoted:
+			bracketed = true
+		case r == '>' && !quoted:
+			bracketed = false
+		case r == ',' && !quoted && !bracketed:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// FormatAuthors formats authors as a comma-separated list that ParseAuthors
+// reads back
+func FormatAuthors(authors []Author) string {
+	s := make([]string, len(authors))
+	for i, a := range authors {
+		s[i] = a.String()
+	}
+	return strings.Join(s, ", ")
+}
+
+// AuthorNames returns the names of the authors as a phrase, such as
+// "Jane Doe and John Roe", using the email of authors without a name
+func (c *ProjectConfig) AuthorNames() string {
+	var names []string
+	for _, a := range c.Authors {
+		if a.Name != "" {
+			names = append(names, a.Name)
+		} else if a.Email != "" {
+			names = append(names, a.Email)
+		}
+	}
+	switch len(names) {
+	case 0:
+		return ""
+	case 1:
+		return names[0]
+	case 2:
+		return names[0] + " and " + names[1]
+	}
+	return strings.Join(names[:len(names)-1], ", ") + ", and " + names[len(names)-1]
+}
+
+// legacyAuthor is the single author field that authors replaced. A config
+// that sets it gets the authors it lists.
+type legacyAuthor struct {
+	Author *string `yaml:"author" json:"author"`
+}
+
+// apply replaces the authors with those of the legacy field when it is set
+func (l legacyAuthor) apply(c *ProjectConfig) error {
+	if l.Author == nil {
+		return nil
+	}
+	authors, err := ParseAuthors(*l.Author)
+	if err != nil {
+		return err
+	}
+	c.Authors = authors
+	return nil
+}
+
+// UnmarshalYAML decodes the config, reading the authors of the legacy author
+// field
+func (c *ProjectConfig) UnmarshalYAML(value *yaml.Node) error {
+	type plain ProjectConfig
+	if err := value.Decode((*plain)(c)); err != nil {
+		return err
+	}
+	var legacy legacyAuthor
+	if err := value.Decode(&legacy); err != nil {
+		return err
+	}
+	return legacy.apply(c)
+}
+
+// UnmarshalJSON decodes the config, reading the authors of the legacy author
+// field
+func (c *ProjectConfig) UnmarshalJSON(data []byte) error {
+	type plain ProjectConfig
+	if err := json.Unmarshal(data, (*plain)(c)); err != nil {
+		return err
+	}
+	var legacy legacyAuthor
+	if err := json.Unmarshal(data, &legacy); err != nil {
+		return err
+	}
+	return legacy.apply(c)
+}
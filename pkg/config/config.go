@@ -2,8 +2,12 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"os"
+	"path"
 	"path/filepath"
+	"regexp"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
@@ -18,20 +22,750 @@ const (
 	TypeAPI ProjectType = "api"
 	// TypeLibrary is for library/package projects
 	TypeLibrary ProjectType = "library"
+	// TypeGitHubAction is for GitHub Actions written in Go
+	TypeGitHubAction ProjectType = "github-action"
+	// TypeOperator is for Kubernetes operators built on controller-runtime
+	TypeOperator ProjectType = "operator"
+	// TypeGRPC is for protobuf-first services that serve gRPC and a
+	// grpc-gateway REST API
+	TypeGRPC ProjectType = "grpc"
+	// TypeEventDriven is for services that publish events through a
+	// transactional outbox and consume them idempotently
+	TypeEventDriven ProjectType = "event-driven"
+	// TypeBatch is for batch and ETL jobs that process records in chunks on
+	// a pool of workers and resume from checkpoints
+	TypeBatch ProjectType = "batch"
+	// TypeCrawler is for polite web crawlers that respect robots.txt and
+	// rate limits
+	TypeCrawler ProjectType = "crawler"
+	// TypeWorker is for long-running workers that consume a queue and shut
+	// down gracefully
+	TypeWorker ProjectType = "worker"
+	// TypeTUI is for terminal user interfaces built on Bubble Tea
+	TypeTUI ProjectType = "tui"
+	// TypeSDK is for client SDK libraries generated from an OpenAPI spec
+	TypeSDK ProjectType = "sdk"
+	// TypeScript is for quick internal tools: a single main.go at the root,
+	// without the directory tree, Makefile, or CI of the other types
+	TypeScript ProjectType = "script"
 	// TypeDefault is the default project type
 	TypeDefault ProjectType = "default"
 )
 
+// ProjectTypes lists all supported project types
+var ProjectTypes = []ProjectType{TypeDefault, TypeCLI, TypeAPI, TypeLibrary, TypeGitHubAction, TypeOperator, TypeGRPC, TypeEventDriven, TypeBatch, TypeCrawler, TypeWorker, TypeTUI, TypeSDK, TypeScript}
+
+// Description returns a short human-readable description of the project type
+func (t ProjectType) Description() string {
+	switch t {
+	case TypeCLI:
+		return "Command-line application (includes Cobra and Viper)"
+	case TypeAPI:
+		return "API/Web service (includes Gin)"
+	case TypeLibrary:
+		return "Library/Package (no cmd directory)"
+	case TypeGitHubAction:
+		return "GitHub Action (Docker or composite, with release and test workflows)"
+	case TypeOperator:
+		return "Kubernetes operator (controller-runtime, CRDs, and a sample reconciler)"
+	case TypeGRPC:
+		return "gRPC service with a REST gateway (protos, grpc-gateway, OpenAPI, telemetry)"
+	case TypeEventDriven:
+		return "Event-driven service (Postgres outbox, relay to a broker, idempotent consumer)"
+	case TypeBatch:
+		return "Batch/ETL job (file, S3, or Postgres input and output, worker pool, checkpoints)"
+	case TypeCrawler:
+		return "Web crawler (robots.txt, per-host rate limits, pluggable parsers, storage)"
+	case TypeWorker:
+		return "Background worker (queue consumer, retries, graceful shutdown, example processor)"
+	case TypeTUI:
+		return "Terminal UI application (Bubble Tea model, update/view split, Lip Gloss styles, teatest)"
+	case TypeSDK:
+		return "Client SDK generated from an OpenAPI spec (typed client, services, pagination, retries)"
+	case TypeScript:
+		return "Internal tool script (single main.go with flags, logging, and error handling)"
+	default:
+		return "Generic Go project"
+	}
+}
+
+// IsValidProjectType reports whether t is a supported project type
+func IsValidProjectType(t ProjectType) bool {
+	for _, pt := range ProjectTypes {
+		if pt == t {
+			return true
+		}
+	}
+	return false
+}
+
+// LicenseNone generates no LICENSE file
+const LicenseNone = "None"
+
+// Licenses lists the licenses the LICENSE file can be generated for
+var Licenses = []string{"MIT", "Apache-2.0", "GPL-3.0", "BSD-3-Clause", LicenseNone}
+
+// IsValidLicense reports whether l is a supported license. The empty string
+// means none.
+func IsValidLicense(l string) bool {
+	if l == "" {
+		return true
+	}
+	for _, v := range Licenses {
+		if v == l {
+			return true
+		}
+	}
+	return false
+}
+
+// Tool version managers whose file pins the tool versions of a project
+const (
+	// ToolVersionNone writes no tool version file
+	ToolVersionNone = "none"
+	// ToolVersionAsdf writes a .tool-versions file, read by asdf and mise
+	ToolVersionAsdf = "asdf"
+	// ToolVersionMise writes a .mise.toml file
+	ToolVersionMise = "mise"
+)
+
+// ToolVersionManagers lists the supported tool version managers
+var ToolVersionManagers = []string{ToolVersionNone, ToolVersionAsdf, ToolVersionMise}
+
+// IsValidToolVersionManager reports whether m is a supported tool version
+// manager. The empty string means none.
+func IsValidToolVersionManager(m string) bool {
+	if m == "" {
+		return true
+	}
+	for _, v := range ToolVersionManagers {
+		if v == m {
+			return true
+		}
+	}
+	return false
+}
+
+// Project visibilities
+const (
+	// VisibilityPublic projects are open source, with a LICENSE and the
+	// badges of the public Go services
+	VisibilityPublic = "public"
+	// VisibilityInternal projects are only used inside an organization:
+	// they get no LICENSE or public badges, and their module is private
+	VisibilityInternal = "internal"
+)
+
+// Visibilities lists the supported project visibilities
+var Visibilities = []string{VisibilityPublic, VisibilityInternal}
+
+// IsValidVisibility reports whether v is a supported visibility. The empty
+// string means public.
+func IsValidVisibility(v string) bool {
+	if v == "" {
+		return true
+	}
+	for _, s := range Visibilities {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+// IsInternal reports whether the project is only used inside an organization
+func (c *ProjectConfig) IsInternal() bool {
+	return c.Visibility == VisibilityInternal
+}
+
+// DefaultModule is the placeholder module path of new configurations, which
+// the wizard or --module replace
+const DefaultModule = "github.com/username/my-project"
+
+// DefaultBranchName is the default branch of projects that do not set one
+const DefaultBranchName = "main"
+
+// Branch returns the default branch of the project
+func (c *ProjectConfig) Branch() string {
+	if c.DefaultBranch == "" {
+		return DefaultBranchName
+	}
+	return c.DefaultBranch
+}
+
+// ConventionalCommits reports whether the pre-commit hooks check commit
+// messages against Conventional Commits
+func (c *ProjectConfig) ConventionalCommits() bool {
+	return c.UsePreCommitHooks && c.CommitConvention != CommitConventionNone
+}
+
+// ValidateBranch checks that branch is a branch name git accepts and that can
+// be written in a workflow without quoting, such as main or release/v2
+func ValidateBranch(branch string) error {
+	if branch == "" {
+		return nil
+	}
+	if !branchPattern.MatchString(branch) || strings.Contains(branch, "..") || strings.Contains(branch, "//") ||
+		strings.HasSuffix(branch, ".lock") {
+		return fmt.Errorf("invalid branch %q: use letters, digits, and ._/- such as main or release/v2", branch)
+	}
+	return nil
+}
+
+// branchPattern matches the branch names ValidateBranch accepts before its
+// checks of sequences git rejects
+var branchPattern = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9._/-]*[A-Za-z0-9_]$|^[A-Za-z0-9_]$`)
+
+// Runtime base images of the Dockerfile
+const (
+	// DockerBaseDistroless runs the binary in distroless/static as nonroot
+	DockerBaseDistroless = "distroless"
+	// DockerBaseScratch runs the binary in an empty image with the CA
+	// certificates of the build image
+	DockerBaseScratch = "scratch"
+)
+
+// DockerBases lists the supported runtime base images
+var DockerBases = []string{DockerBaseDistroless, DockerBaseScratch}
+
+// IsValidDockerBase reports whether b is a supported runtime base image. The
+// empty string means distroless.
+func IsValidDockerBase(b string) bool {
+	if b == "" {
+		return true
+	}
+	for _, v := range DockerBases {
+		if v == b {
+			return true
+		}
+	}
+	return false
+}
+
+// Container registries the image workflow pushes to
+const (
+	// ContainerRegistryNone generates no image workflow
+	ContainerRegistryNone = "none"
+	// ContainerRegistryGHCR pushes to the GitHub Container Registry with the
+	// token of the workflow
+	ContainerRegistryGHCR = "ghcr"
+	// ContainerRegistryECR pushes to Amazon ECR with an IAM role assumed
+	// through OIDC
+	ContainerRegistryECR = "ecr"
+	// ContainerRegistryGAR pushes to Google Artifact Registry with Workload
+	// Identity Federation
+	ContainerRegistryGAR = "gar"
+)
+
+// ContainerRegistries lists the supported container registries
+var ContainerRegistries = []string{ContainerRegistryNone, ContainerRegistryGHCR, ContainerRegistryECR, ContainerRegistryGAR}
+
+// IsValidContainerRegistry reports whether r is a supported container
+// registry. The empty string means none.
+func IsValidContainerRegistry(r string) bool {
+	if r == "" {
+		return true
+	}
+	for _, v := range ContainerRegistries {
+		if v == r {
+			return true
+		}
+	}
+	return false
+}
+
+// Documentation site generators
+const (
+	// DocsSiteNone generates no documentation site
+	DocsSiteNone = "none"
+	// DocsSiteMkDocs generates an MkDocs site with the Material theme
+	DocsSiteMkDocs = "mkdocs"
+	// DocsSiteHugo generates a Hugo site in docs/
+	DocsSiteHugo = "hugo"
+)
+
+// DocsSites lists the supported documentation site generators
+var DocsSites = []string{DocsSiteNone, DocsSiteMkDocs, DocsSiteHugo}
+
+// IsValidDocsSite reports whether s is a supported documentation site
+// generator. The empty string means none.
+func IsValidDocsSite(s string) bool {
+	if s == "" {
+		return true
+	}
+	for _, v := range DocsSites {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Placeholders of the standard directories that no generated file fills
+const (
+	// DirPlaceholderGitkeep adds an empty .gitkeep file so Git tracks the directory
+	DirPlaceholderGitkeep = "gitkeep"
+	// DirPlaceholderDoc adds a doc.go, or a README.md in docs/, that describes
+	// what the directory is for
+	DirPlaceholderDoc = "doc"
+	// DirPlaceholderNone leaves the directories empty, so Git skips them
+	DirPlaceholderNone = "none"
+)
+
+// DirPlaceholders lists the supported directory placeholders
+var DirPlaceholders = []string{DirPlaceholderGitkeep, DirPlaceholderDoc, DirPlaceholderNone}
+
+// IsValidDirPlaceholder reports whether p is a supported directory
+// placeholder. The empty string means gitkeep.
+func IsValidDirPlaceholder(p string) bool {
+	if p == "" {
+		return true
+	}
+	for _, v := range DirPlaceholders {
+		if v == p {
+			return true
+		}
+	}
+	return false
+}
+
+// Secrets managers
+const (
+	// SecretsManagerNone sets up no secrets management
+	SecretsManagerNone = "none"
+	// SecretsManagerSops encrypts secrets in the repository with sops and age
+	SecretsManagerSops = "sops"
+	// SecretsManagerVault renders secrets from HashiCorp Vault with Vault Agent
+	SecretsManagerVault = "vault"
+)
+
+// SecretsManagers lists the supported secrets managers
+var SecretsManagers = []string{SecretsManagerNone, SecretsManagerSops, SecretsManagerVault}
+
+// IsValidSecretsManager reports whether m is a supported secrets manager. The
+// empty string means none.
+func IsValidSecretsManager(m string) bool {
+	if m == "" {
+		return true
+	}
+	for _, v := range SecretsManagers {
+		if v == m {
+			return true
+		}
+	}
+	return false
+}
+
+// Feature flag backends
+const (
+	// FeatureFlagsNone wires no feature flags
+	FeatureFlagsNone = "none"
+	// FeatureFlagsMemory evaluates OpenFeature flags defined in code
+	FeatureFlagsMemory = "memory"
+	// FeatureFlagsFlagd evaluates OpenFeature flags served by flagd
+	FeatureFlagsFlagd = "flagd"
+)
+
+// FeatureFlagBackends lists the supported feature flag backends
+var FeatureFlagBackends = []string{FeatureFlagsNone, FeatureFlagsMemory, FeatureFlagsFlagd}
+
+// IsValidFeatureFlags reports whether b is a supported feature flag backend.
+// The empty string means none.
+func IsValidFeatureFlags(b string) bool {
+	if b == "" {
+		return true
+	}
+	for _, v := range FeatureFlagBackends {
+		if v == b {
+			return true
+		}
+	}
+	return false
+}
+
+// Request validation libraries
+const (
+	// RequestValidationNone validates no requests
+	RequestValidationNone = "none"
+	// RequestValidationValidator validates requests with go-playground/validator
+	// after decoding them
+	RequestValidationValidator = "validator"
+	// RequestValidationBinding validates requests with the binding tags of
+	// Gin while binding them; API projects without Gin use validator
+	RequestValidationBinding = "binding"
+)
+
+// RequestValidations lists the supported request validation libraries
+var RequestValidations = []string{RequestValidationNone, RequestValidationValidator, RequestValidationBinding}
+
+// IsValidRequestValidation reports whether v is a supported request
+// validation library. The empty string means none.
+func IsValidRequestValidation(v string) bool {
+	if v == "" {
+		return true
+	}
+	for _, s := range RequestValidations {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Pagination styles of list endpoints
+const (
+	// PaginationNone adds no pagination helper
+	PaginationNone = "none"
+	// PaginationCursor pages with opaque cursors that point after the last
+	// item of the previous page
+	PaginationCursor = "cursor"
+	// PaginationOffset pages with an offset and a limit, and counts the total
+	PaginationOffset = "offset"
+)
+
+// Paginations lists the supported pagination styles
+var Paginations = []string{PaginationNone, PaginationCursor, PaginationOffset}
+
+// IsValidPagination reports whether p is a supported pagination style. The
+// empty string means none.
+func IsValidPagination(p string) bool {
+	if p == "" {
+		return true
+	}
+	for _, v := range Paginations {
+		if v == p {
+			return true
+		}
+	}
+	return false
+}
+
+// Formatting styles of the make fmt target and the formatters of golangci-lint
+const (
+	// FormattingNone adds no make fmt target; golangci-lint checks the code
+	// with gofmt and goimports
+	FormattingNone = "none"
+	// FormattingGoimports formats with goimports, grouping the imports of the
+	// module after the others
+	FormattingGoimports = "goimports"
+	// FormattingGofumpt formats with gofumpt's stricter rules and orders the
+	// imports in standard, third-party, and module groups with gci
+	FormattingGofumpt = "gofumpt"
+)
+
+// Formattings lists the supported formatting styles
+var Formattings = []string{FormattingNone, FormattingGoimports, FormattingGofumpt}
+
+// IsValidFormatting reports whether f is a supported formatting style. The
+// empty string means none.
+func IsValidFormatting(f string) bool {
+	if f == "" {
+		return true
+	}
+	for _, v := range Formattings {
+		if v == f {
+			return true
+		}
+	}
+	return false
+}
+
+// Commit conventions the commit-msg hook checks commit messages against
+const (
+	// CommitConventionConventional checks commit messages against
+	// Conventional Commits
+	CommitConventionConventional = "conventional"
+	// CommitConventionNone leaves commit messages unchecked
+	CommitConventionNone = "none"
+)
+
+// CommitConventions lists the supported commit conventions
+var CommitConventions = []string{CommitConventionConventional, CommitConventionNone}
+
+// IsValidCommitConvention reports whether c is a supported commit
+// convention. The empty string means conventional.
+func IsValidCommitConvention(c string) bool {
+	if c == "" {
+		return true
+	}
+	for _, v := range CommitConventions {
+		if v == c {
+			return true
+		}
+	}
+	return false
+}
+
+// Lint presets of the golangci-lint configuration
+const (
+	// LintPresetStandard enables the common linters that rarely report false
+	// positives
+	LintPresetStandard = "standard"
+	// LintPresetStrict also enables errorlint, exhaustive, gocritic, and other
+	// stricter linters, and formats with gofumpt and gci
+	LintPresetStrict = "strict"
+)
+
+// LintPresets lists the supported lint presets
+var LintPresets = []string{LintPresetStandard, LintPresetStrict}
+
+// IsValidLintPreset reports whether p is a supported lint preset. The empty
+// string means standard.
+func IsValidLintPreset(p string) bool {
+	if p == "" {
+		return true
+	}
+	for _, v := range LintPresets {
+		if v == p {
+			return true
+		}
+	}
+	return false
+}
+
+// Task queues of API projects
+const (
+	// TaskQueueNone adds no task queue
+	TaskQueueNone = "none"
+	// TaskQueueMemory runs tasks with a pool of workers in the process; tasks
+	// still queued when it exits are lost
+	TaskQueueMemory = "memory"
+	// TaskQueueAsynq queues tasks in Redis with asynq, which retries them and
+	// keeps them across restarts
+	TaskQueueAsynq = "asynq"
+)
+
+// TaskQueues lists the supported task queues
+var TaskQueues = []string{TaskQueueNone, TaskQueueMemory, TaskQueueAsynq}
+
+// IsValidTaskQueue reports whether q is a supported task queue. The empty
+// string means none.
+func IsValidTaskQueue(q string) bool {
+	if q == "" {
+		return true
+	}
+	for _, v := range TaskQueues {
+		if v == q {
+			return true
+		}
+	}
+	return false
+}
+
+// Prompt libraries
+const (
+	// PromptLibraryNone adds no interactive prompts
+	PromptLibraryNone = "none"
+	// PromptLibrarySurvey asks questions with AlecAivazis/survey
+	PromptLibrarySurvey = "survey"
+	// PromptLibraryBubbletea asks questions with charmbracelet/bubbletea
+	PromptLibraryBubbletea = "bubbletea"
+)
+
+// PromptLibraries lists the supported prompt libraries
+var PromptLibraries = []string{PromptLibraryNone, PromptLibrarySurvey, PromptLibraryBubbletea}
+
+// IsValidPromptLibrary reports whether l is a supported prompt library. The
+// empty string means none.
+func IsValidPromptLibrary(l string) bool {
+	if l == "" {
+		return true
+	}
+	for _, v := range PromptLibraries {
+		if v == l {
+			return true
+		}
+	}
+	return false
+}
+
+// Runtimes of GitHub Action projects
+const (
+	// ActionRuntimeDocker runs the action in a container built from its Dockerfile
+	ActionRuntimeDocker = "docker"
+	// ActionRuntimeComposite runs the binary of the action in a composite
+	// step, downloaded from the release or built from the source
+	ActionRuntimeComposite = "composite"
+)
+
+// ActionRuntimes lists the supported runtimes of GitHub Action projects
+var ActionRuntimes = []string{ActionRuntimeDocker, ActionRuntimeComposite}
+
+// IsValidActionRuntime reports whether r is a supported runtime of GitHub
+// Action projects. The empty string means docker.
+func IsValidActionRuntime(r string) bool {
+	if r == "" {
+		return true
+	}
+	for _, v := range ActionRuntimes {
+		if v == r {
+			return true
+		}
+	}
+	return false
+}
+
+// Brokers that event-driven projects publish to and consume from
+const (
+	// EventBrokerKafka uses Kafka topics and consumer groups
+	EventBrokerKafka = "kafka"
+	// EventBrokerNATS uses NATS JetStream streams and durable consumers
+	EventBrokerNATS = "nats"
+	// EventBrokerRabbitMQ uses a RabbitMQ topic exchange and durable queues
+	EventBrokerRabbitMQ = "rabbitmq"
+)
+
+// EventBrokers lists the supported brokers of event-driven projects
+var EventBrokers = []string{EventBrokerKafka, EventBrokerNATS, EventBrokerRabbitMQ}
+
+// IsValidEventBroker reports whether b is a supported broker of event-driven
+// projects. The empty string means kafka.
+func IsValidEventBroker(b string) bool {
+	if b == "" {
+		return true
+	}
+	for _, v := range EventBrokers {
+		if v == b {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	operatorGroupPattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)+$`)
+	operatorKindPattern  = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+)
+
+// ValidateOperatorAPI checks the API of an operator project: the group is a
+// lowercase DNS name of two labels or more, such as cache.example.com, and
+// the kind is an exported Go identifier, such as Memcached. Empty values are
+// valid and replaced by defaults.
+func ValidateOperatorAPI(group, kind string) error {
+	if group != "" && (len(group) > 253 || !operatorGroupPattern.MatchString(group)) {
+		return fmt.Errorf("invalid operator group %q: use a lowercase DNS name such as cache.example.com", group)
+	}
+	if kind != "" && !operatorKindPattern.MatchString(kind) {
+		return fmt.Errorf("invalid operator kind %q: use an exported Go identifier such as Memcached", kind)
+	}
+	return nil
+}
+
+// ValidatePackages checks the package directories of a library project: each
+// is "." for the module root or a clean relative path whose elements are
+// lowercase Go identifiers, such as "internal/strutil", and none repeats
+func ValidatePackages(pkgs []string) error {
+	seen := map[string]bool{}
+	for _, p := range pkgs {
+		if seen[p] {
+			return fmt.Errorf("package %q is listed twice", p)
+		}
+		seen[p] = true
+		if p == "." {
+			continue
+		}
+		if p == "" || path.Clean(p) != p || path.IsAbs(p) {
+			return fmt.Errorf("invalid package directory %q: use a clean path relative to the module root", p)
+		}
+		for _, elem := range strings.Split(p, "/") {
+			if !isPackageName(elem) {
+				return fmt.Errorf("invalid package directory %q: %q is not a lowercase Go identifier", p, elem)
+			}
+		}
+	}
+	return nil
+}
+
+// MaxTopics is the number of topics a GitHub repository can have
+const MaxTopics = 20
+
+// topicPattern matches the topics GitHub accepts, which are also valid
+// Backstage tags
+var topicPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,49}$`)
+
+// ValidateTopics checks the topics of a project: each is lowercase letters,
+// digits, and hyphens, starts with a letter or digit, and has at most 50
+// characters, and none repeats
+func ValidateTopics(topics []string) error {
+	if len(topics) > MaxTopics {
+		return fmt.Errorf("%d topics given: a project has at most %d", len(topics), MaxTopics)
+	}
+	seen := map[string]bool{}
+	for _, t := range topics {
+		if seen[t] {
+			return fmt.Errorf("topic %q is listed twice", t)
+		}
+		seen[t] = true
+		if !topicPattern.MatchString(t) {
+			return fmt.Errorf("invalid topic %q: use up to 50 lowercase letters, digits, and hyphens, such as payments or event-driven", t)
+		}
+	}
+	return nil
+}
+
+// isPackageName reports whether s is a lowercase Go identifier
+func isPackageName(s string) bool {
+	if s == "" || s[0] < 'a' || s[0] > 'z' {
+		return false
+	}
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+// ModuleFromRemote returns the module path of the repository at a git remote
+// URL, such as github.com/acme/billing for git@github.com:acme/billing.git or
+// https://github.com/acme/billing.git, or an empty string for remotes without
+// a host, such as local paths
+func ModuleFromRemote(remote string) string {
+	remote = strings.TrimSpace(remote)
+	var host, repo string
+	if u, err := url.Parse(remote); err == nil && u.Scheme != "" && u.Host != "" {
+		host, repo = u.Hostname(), u.Path
+	} else if i := strings.Index(remote, ":"); i > 0 && !strings.Contains(remote[:i], "/") {
+		// The scp-like syntax of ssh remotes: [user@]host:path
+		host, repo = remote[:i], remote[i+1:]
+		if at := strings.LastIndex(host, "@"); at >= 0 {
+			host = host[at+1:]
+		}
+	}
+	repo = strings.TrimSuffix(strings.Trim(repo, "/"), ".git")
+	if !strings.Contains(host, ".") || repo == "" {
+		return ""
+	}
+	return strings.ToLower(host) + "/" + repo
+}
+
 // ProjectConfig represents the configuration for a gogo project
 type ProjectConfig struct {
 	// General project information
 	Name        string      `yaml:"name" json:"name"`
+	DisplayName string      `yaml:"display_name,omitempty" json:"display_name,omitempty"`
 	Module      string      `yaml:"module" json:"module"`
 	Description string      `yaml:"description" json:"description"`
 	License     string      `yaml:"license" json:"license"`
-	Author      string      `yaml:"author" json:"author"`
 	Type        ProjectType `yaml:"type" json:"type"`
 
+	// Visibility is public for open source projects or internal for
+	// projects only used inside an organization; empty means public
+	Visibility string `yaml:"visibility,omitempty" json:"visibility,omitempty"`
+
+	// DefaultBranch is the branch the workflows run on and git init creates;
+	// empty means main
+	DefaultBranch string `yaml:"default_branch,omitempty" json:"default_branch,omitempty"`
+
+	// Topics classify the project, like the topics of a GitHub repository.
+	// They are shown in the README and tag the catalog entry.
+	Topics []string `yaml:"topics,omitempty" json:"topics,omitempty"`
+
+	// Authors hold the copyright in the LICENSE file and are listed as the
+	// maintainers in the README. The author field of older configs, a
+	// comma-separated list of "Name <email>", is read into it.
+	Authors []Author `yaml:"authors,omitempty" json:"authors,omitempty"`
+
 	// Project structure options
 	UseCmd         bool `yaml:"use_cmd" json:"use_cmd"`
 	UseInternal    bool `yaml:"use_internal" json:"use_internal"`
@@ -42,28 +776,307 @@ type ProjectConfig struct {
 	CreateLicense  bool `yaml:"create_license" json:"create_license"`
 	CreateMakefile bool `yaml:"create_makefile" json:"create_makefile"`
 
+	// Layout moves conventional directories of the project, such as
+	// internal to app; keys are the names in LayoutDirs
+	Layout map[string]string `yaml:"layout,omitempty" json:"layout,omitempty"`
+
+	// DirPlaceholder selects what goes into the standard directories that no
+	// generated file fills: gitkeep (an empty .gitkeep), doc (a doc.go
+	// describing the directory), or none
+	DirPlaceholder string `yaml:"dir_placeholder,omitempty" json:"dir_placeholder,omitempty"`
+
+	// DocsSite selects the documentation site generated in docs/, with a
+	// GitHub Pages deploy workflow: none, mkdocs, or hugo
+	DocsSite string `yaml:"docs_site,omitempty" json:"docs_site,omitempty"`
+
+	// UseExamples adds example programs in examples/ to library and API
+	// projects, built by CI but left out of release builds
+	UseExamples bool `yaml:"use_examples" json:"use_examples"`
+
+	// Packages lists the packages of a library project as directories
+	// relative to the module root, such as "." or "internal/strutil". When
+	// empty, the library is a single package in pkg/<name>.
+	Packages []string `yaml:"packages,omitempty" json:"packages,omitempty"`
+
+	// CreateVersionFile adds a VERSION file, make bump-{patch,minor,major}
+	// and tag targets, and a GoReleaser config that builds from it
+	CreateVersionFile bool `yaml:"create_version_file" json:"create_version_file"`
+
+	// UseADR adds architecture decision records in docs/adr, starting with
+	// the decision to use them, and a make adr target that creates new ones
+	UseADR bool `yaml:"use_adr" json:"use_adr"`
+
+	// CreatePackageDocs adds doc.go, a runnable example, and pkg.go.dev and
+	// Go Report Card badges to library projects
+	CreatePackageDocs bool `yaml:"create_package_docs" json:"create_package_docs"`
+
+	// Developer portal catalog
+	CreateCatalogInfo bool   `yaml:"create_catalog_info" json:"create_catalog_info"`
+	Owner             string `yaml:"owner" json:"owner"`
+	Lifecycle         string `yaml:"lifecycle" json:"lifecycle"`
+
+	// Team, SlackChannel, and OnCall say who owns the project and how to
+	// reach them. They are shown in the README and used by catalog-info.yaml,
+	// CODEOWNERS, and the alerting config of services.
+	Team         string `yaml:"team,omitempty" json:"team,omitempty"`
+	SlackChannel string `yaml:"slack_channel,omitempty" json:"slack_channel,omitempty"`
+	OnCall       string `yaml:"on_call,omitempty" json:"on_call,omitempty"`
+
+	// Additional metadata files for internal developer platforms
+	MetadataFiles []MetadataFile `yaml:"metadata_files,omitempty" json:"metadata_files,omitempty"`
+
 	// Code quality tools
 	UseLinters        bool `yaml:"use_linters" json:"use_linters"`
 	UsePreCommitHooks bool `yaml:"use_pre_commit_hooks" json:"use_pre_commit_hooks"`
 	UseGitHooks       bool `yaml:"use_git_hooks" json:"use_git_hooks"`
 
+	// Formatting adds a make fmt target that formats the code and groups its
+	// imports by the module path, and configures golangci-lint to check the
+	// same: none, goimports, or gofumpt
+	Formatting string `yaml:"formatting,omitempty" json:"formatting,omitempty"`
+
+	// LintPreset selects the linters of .golangci.yml: standard, or strict,
+	// which adds stricter linters and formats with gofumpt and gci whatever
+	// the formatting
+	LintPreset string `yaml:"lint_preset,omitempty" json:"lint_preset,omitempty"`
+
+	// UseChecks adds make targets and a CI step that run go vet,
+	// staticcheck, and govulncheck, for projects without golangci-lint
+	UseChecks bool `yaml:"use_checks" json:"use_checks"`
+
+	// CommitConvention selects what the commit-msg hook of the pre-commit
+	// hooks checks commit messages against: conventional, or none
+	CommitConvention string `yaml:"commit_convention,omitempty" json:"commit_convention,omitempty"`
+
+	// ToolVersionManager selects the file that pins go and the other tools:
+	// none, asdf (.tool-versions), or mise (.mise.toml)
+	ToolVersionManager string `yaml:"tool_version_manager,omitempty" json:"tool_version_manager,omitempty"`
+
+	// SecretsManager selects the secrets management setup: none, sops
+	// (.sops.yaml with age recipients and encrypted files in secrets/), or
+	// vault (Vault Agent config and templates in vault/)
+	SecretsManager string `yaml:"secrets_manager,omitempty" json:"secrets_manager,omitempty"`
+
+	// UseLiveReload adds a make dev target to API projects that rebuilds and
+	// restarts the server with air when the code changes
+	UseLiveReload bool `yaml:"use_live_reload" json:"use_live_reload"`
+
+	// UsePprof serves net/http/pprof on a loopback debug port in API
+	// projects, with make targets to capture profiles and a profiling page
+	UsePprof bool `yaml:"use_pprof" json:"use_pprof"`
+
 	// Dependencies
 	UseCobra bool `yaml:"use_cobra" json:"use_cobra"`
 	UseViper bool `yaml:"use_viper" json:"use_viper"`
 	UseGin   bool `yaml:"use_gin" json:"use_gin"`
 
+	// FeatureFlags wires OpenFeature into API projects with a flag backend:
+	// none, memory (flags defined in code), or flagd
+	FeatureFlags string `yaml:"feature_flags,omitempty" json:"feature_flags,omitempty"`
+
+	// RequestValidation validates the request bodies of API projects and
+	// answers invalid ones with the fields at fault: none, validator
+	// (go-playground/validator), or binding (the binding of Gin)
+	RequestValidation string `yaml:"request_validation,omitempty" json:"request_validation,omitempty"`
+
+	// Pagination adds pkg/pagination to API projects, which parses the
+	// paging, filtering, and sorting parameters of list endpoints, and an
+	// example list endpoint: none, cursor, or offset
+	Pagination string `yaml:"pagination,omitempty" json:"pagination,omitempty"`
+
+	// TaskQueue runs background tasks in API projects, with an example
+	// handler that enqueues one and workers that drain the queue on shutdown:
+	// none, memory, or asynq
+	TaskQueue string `yaml:"task_queue,omitempty" json:"task_queue,omitempty"`
+
+	// UseHTTPCache adds an internal/httpcache package to API projects that
+	// caches GET responses in memory, sets ETag and Cache-Control headers,
+	// and answers matching If-None-Match requests with 304 Not Modified
+	UseHTTPCache bool `yaml:"use_http_cache" json:"use_http_cache"`
+
+	// UseI18n adds a golang.org/x/text message catalog to API projects, with
+	// locale negotiation middleware and a localized example route
+	UseI18n bool `yaml:"use_i18n" json:"use_i18n"`
+
+	// UseMultiTenancy scopes API projects by tenant: a middleware reads the
+	// tenant ID of each request from a header, and the store layer keeps the
+	// data of each tenant apart
+	UseMultiTenancy bool `yaml:"use_multi_tenancy" json:"use_multi_tenancy"`
+
+	// UsePlugins adds a plugin system to CLI and API projects: a versioned
+	// plugin interface, a host that discovers plugin executables and runs them
+	// with hashicorp/go-plugin, and a sample plugin
+	UsePlugins bool `yaml:"use_plugins" json:"use_plugins"`
+
+	// UseNotify adds an internal/notify package to applications, with a
+	// Notifier interface, SMTP and Slack webhook implementations configured
+	// from the environment, and a recorder for tests
+	UseNotify bool `yaml:"use_notify" json:"use_notify"`
+
+	// UseCrashHandler makes main of applications recover panics, write a
+	// structured crash report, and send it to Sentry or a webhook configured
+	// from the environment
+	UseCrashHandler bool `yaml:"use_crash_handler" json:"use_crash_handler"`
+
+	// UseConfigReload makes API projects with Viper watch a config file and
+	// apply its changes while running, with callbacks for each change
+	UseConfigReload bool `yaml:"use_config_reload" json:"use_config_reload"`
+
+	// UseConfigCommand adds config get/set/list commands to CLI projects and
+	// keeps their config file in the XDG config directory instead of $HOME
+	UseConfigCommand bool `yaml:"use_config_command" json:"use_config_command"`
+
+	// UseSelfUpdate adds a self-update command and a daily new release
+	// notification, based on GitHub releases, to CLI projects on GitHub
+	UseSelfUpdate bool `yaml:"use_self_update" json:"use_self_update"`
+
+	// UseOutput adds a pkg/output package and a persistent --output flag to
+	// CLI projects, so commands print text, JSON, or YAML
+	UseOutput bool `yaml:"use_output" json:"use_output"`
+
+	// PromptLibrary adds an internal/prompt package and an interactive setup
+	// command to CLI projects: none, survey, or bubbletea
+	PromptLibrary string `yaml:"prompt_library,omitempty" json:"prompt_library,omitempty"`
+
+	// UseTelemetry adds opt-in anonymous usage telemetry to CLI projects,
+	// queued locally, sent on exit, and off with DO_NOT_TRACK
+	UseTelemetry bool `yaml:"use_telemetry" json:"use_telemetry"`
+
+	// ActionRuntime is how GitHub Action projects run: docker or composite
+	ActionRuntime string `yaml:"action_runtime,omitempty" json:"action_runtime,omitempty"`
+
+	// OperatorGroup is the API group of the custom resource of operator
+	// projects, such as cache.example.com
+	OperatorGroup string `yaml:"operator_group,omitempty" json:"operator_group,omitempty"`
+	// OperatorKind is the kind of the custom resource of operator projects,
+	// such as Memcached
+	OperatorKind string `yaml:"operator_kind,omitempty" json:"operator_kind,omitempty"`
+	// UseKubebuilder scaffolds operator projects with kubebuilder when it is
+	// installed, instead of the built-in templates
+	UseKubebuilder bool `yaml:"use_kubebuilder" json:"use_kubebuilder"`
+
+	// EventBroker is the broker event-driven projects relay their outbox to
+	// and consume from: kafka, nats, or rabbitmq
+	EventBroker string `yaml:"event_broker,omitempty" json:"event_broker,omitempty"`
+
+	// OpenAPISpec is the OpenAPI 3 document, in YAML or JSON, that sdk
+	// projects generate their client from. It is a local path, so it is not
+	// read from JSON requests.
+	OpenAPISpec string `yaml:"openapi_spec,omitempty" json:"-"`
+
+	// Template is a directory of user templates layered over the generated
+	// project, such as the scaffolding conventions of a team, or a git
+	// repository of them such as github.com/acme/gogo-templates@v1.2.0, which
+	// gogo new fetches into a local directory. It is not read from JSON
+	// requests or written to gogo.yaml.
+	Template string `yaml:"template,omitempty" json:"-"`
+
 	// CI/CD
 	UseGitHubActions bool `yaml:"use_github_actions" json:"use_github_actions"`
+	// UseBenchmarks adds a make bench target and, with GitHub Actions, a
+	// workflow that compares the benchmarks of pull requests with benchstat
+	UseBenchmarks bool `yaml:"use_benchmarks" json:"use_benchmarks"`
+	// UseAPIDiff adds a make apidiff target and, with GitHub Actions, a
+	// workflow that checks the exported API against the latest tag with gorelease
+	UseAPIDiff bool `yaml:"use_apidiff" json:"use_apidiff"`
+	// UseDocker adds a Dockerfile that builds the binary for the platforms
+	// given to docker buildx, and a make docker-build target
+	UseDocker bool `yaml:"use_docker" json:"use_docker"`
+	// DockerBase is the base of the runtime image: distroless or scratch
+	DockerBase string `yaml:"docker_base,omitempty" json:"docker_base,omitempty"`
+	// ContainerRegistry is the registry the image workflow pushes the image
+	// of use_docker to: none, ghcr, ecr, or gar
+	ContainerRegistry string `yaml:"container_registry,omitempty" json:"container_registry,omitempty"`
+}
+
+// MetadataFile describes a metadata file (e.g. service.yaml or app.json) whose
+// values are text templates rendered against the project configuration
+type MetadataFile struct {
+	// Path of the file relative to the project root
+	Path string `yaml:"path" json:"path"`
+	// Format is yaml or json; inferred from the file extension when empty
+	Format string `yaml:"format,omitempty" json:"format,omitempty"`
+	// Fields maps keys to template strings such as "{{ .Name }}"; values may be nested maps
+	Fields map[string]interface{} `yaml:"fields" json:"fields"`
+}
+
+// Profile holds the ownership defaults of a team. Profiles are kept under
+// profiles in the user config file and fill in the fields a project leaves empty.
+// A profile may extend another one and only set the fields that differ, and
+// may set defaults with rules.
+type Profile struct {
+	Extends      string `mapstructure:"extends" yaml:"extends,omitempty" json:"extends,omitempty"`
+	Team         string `mapstructure:"team" yaml:"team" json:"team"`
+	SlackChannel string `mapstructure:"slack_channel" yaml:"slack_channel" json:"slack_channel"`
+	OnCall       string `mapstructure:"on_call" yaml:"on_call" json:"on_call"`
+	// Authors are the authors of projects whose git user is not configured
+	Authors []Author `mapstructure:"authors" yaml:"authors,omitempty" json:"authors,omitempty"`
+	Rules   []Rule   `mapstructure:"rules" yaml:"rules,omitempty" json:"rules,omitempty"`
+}
+
+// Apply fills in the empty ownership fields and authors of cfg from the profile
+func (p Profile) Apply(cfg *ProjectConfig) {
+	if cfg.Team == "" {
+		cfg.Team = p.Team
+	}
+	if cfg.SlackChannel == "" {
+		cfg.SlackChannel = p.SlackChannel
+	}
+	if cfg.OnCall == "" {
+		cfg.OnCall = p.OnCall
+	}
+	if len(cfg.Authors) == 0 {
+		cfg.Authors = p.Authors
+	}
+}
+
+// ResolveProfile returns the named profile with the fields it leaves empty
+// filled in from the profiles it extends, whose rules come before its own
+func ResolveProfile(profiles map[string]Profile, name string) (Profile, error) {
+	chain := []string{name}
+	p, ok := profiles[name]
+	if !ok {
+		return Profile{}, fmt.Errorf("unknown profile %q", name)
+	}
+	for parent := p.Extends; parent != ""; {
+		for _, seen := range chain {
+			if seen == parent {
+				return Profile{}, fmt.Errorf("profile %s extends itself: %s", name, strings.Join(append(chain, parent), " -> "))
+			}
+		}
+		base, ok := profiles[parent]
+		if !ok {
+			return Profile{}, fmt.Errorf("profile %q extends unknown profile %q", chain[len(chain)-1], parent)
+		}
+		chain = append(chain, parent)
+		if p.Team == "" {
+			p.Team = base.Team
+		}
+		if p.SlackChannel == "" {
+			p.SlackChannel = base.SlackChannel
+		}
+		if p.OnCall == "" {
+			p.OnCall = base.OnCall
+		}
+		if len(p.Authors) == 0 {
+			p.Authors = base.Authors
+		}
+		if len(base.Rules) > 0 {
+			p.Rules = append(append([]Rule{}, base.Rules...), p.Rules...)
+		}
+		parent = base.Extends
+	}
+	p.Extends = ""
+	return p, nil
 }
 
 // NewDefaultProjectConfig creates a new project config with sensible defaults
 func NewDefaultProjectConfig() *ProjectConfig {
 	return &ProjectConfig{
 		Name:              "my-project",
-		Module:            "github.com/username/my-project",
+		Module:            DefaultModule,
 		Description:       "A Go project",
 		License:           "MIT",
-		Author:            "",
 		Type:              TypeDefault,
 		UseCmd:            true,
 		UseInternal:       true,
@@ -73,6 +1086,9 @@ func NewDefaultProjectConfig() *ProjectConfig {
 		CreateReadme:      true,
 		CreateLicense:     true,
 		CreateMakefile:    true,
+		CreateCatalogInfo: false,
+		Owner:             "",
+		Lifecycle:         "experimental",
 		UseLinters:        true,
 		UsePreCommitHooks: true,
 		UseGitHooks:       true,
@@ -83,29 +1099,86 @@ func NewDefaultProjectConfig() *ProjectConfig {
 	}
 }
 
-// NewCLIProjectConfig creates a new project config for CLI applications
-func NewCLIProjectConfig() *ProjectConfig {
+// newProjectConfig returns the default config with the defaults of the
+// project type applied
+func newProjectConfig(t ProjectType) *ProjectConfig {
 	cfg := NewDefaultProjectConfig()
-	cfg.Type = TypeCLI
-	cfg.UseCobra = true
-	cfg.UseViper = true
+	cfg.Type = t
+	ApplyTypeDefaults(cfg)
 	return cfg
 }
 
+// NewCLIProjectConfig creates a new project config for CLI applications
+func NewCLIProjectConfig() *ProjectConfig {
+	return newProjectConfig(TypeCLI)
+}
+
 // NewAPIProjectConfig creates a new project config for API applications
 func NewAPIProjectConfig() *ProjectConfig {
-	cfg := NewDefaultProjectConfig()
-	cfg.Type = TypeAPI
-	cfg.UseGin = true
-	return cfg
+	return newProjectConfig(TypeAPI)
 }
 
 // NewLibraryProjectConfig creates a new project config for library projects
 func NewLibraryProjectConfig() *ProjectConfig {
-	cfg := NewDefaultProjectConfig()
-	cfg.Type = TypeLibrary
-	cfg.UseCmd = false
-	return cfg
+	return newProjectConfig(TypeLibrary)
+}
+
+// NewGitHubActionProjectConfig creates a new project config for GitHub
+// Actions, whose main package is at the root of the repository
+func NewGitHubActionProjectConfig() *ProjectConfig {
+	return newProjectConfig(TypeGitHubAction)
+}
+
+// NewOperatorProjectConfig creates a new project config for Kubernetes
+// operators
+func NewOperatorProjectConfig() *ProjectConfig {
+	return newProjectConfig(TypeOperator)
+}
+
+// NewGRPCProjectConfig creates a new project config for gRPC services with a
+// REST gateway
+func NewGRPCProjectConfig() *ProjectConfig {
+	return newProjectConfig(TypeGRPC)
+}
+
+// NewEventDrivenProjectConfig creates a new project config for event-driven
+// services
+func NewEventDrivenProjectConfig() *ProjectConfig {
+	return newProjectConfig(TypeEventDriven)
+}
+
+// NewBatchProjectConfig creates a new project config for batch and ETL jobs
+func NewBatchProjectConfig() *ProjectConfig {
+	return newProjectConfig(TypeBatch)
+}
+
+// NewCrawlerProjectConfig creates a new project config for web crawlers
+func NewCrawlerProjectConfig() *ProjectConfig {
+	return newProjectConfig(TypeCrawler)
+}
+
+// NewWorkerProjectConfig creates a new project config for background workers
+func NewWorkerProjectConfig() *ProjectConfig {
+	return newProjectConfig(TypeWorker)
+}
+
+// NewTUIProjectConfig creates a new project config for terminal user
+// interfaces
+func NewTUIProjectConfig() *ProjectConfig {
+	return newProjectConfig(TypeTUI)
+}
+
+// NewSDKProjectConfig creates a new project config for client SDKs, which
+// are libraries released from a VERSION file and checked for breaking changes
+func NewSDKProjectConfig() *ProjectConfig {
+	return newProjectConfig(TypeSDK)
+}
+
+// NewScriptProjectConfig creates a new project config for internal tool
+// scripts, which leave out the directory tree, Makefile, CI, and hooks until
+// they are enabled
+func NewScriptProjectConfig() *ProjectConfig {
+	return newProjectConfig(TypeScript)
 }
 
 // GetProjectConfigForType returns a project config for the specified project type
@@ -117,24 +1190,37 @@ func GetProjectConfigForType(projType ProjectType) *ProjectConfig {
 		return NewAPIProjectConfig()
 	case TypeLibrary:
 		return NewLibraryProjectConfig()
+	case TypeGitHubAction:
+		return NewGitHubActionProjectConfig()
+	case TypeOperator:
+		return NewOperatorProjectConfig()
+	case TypeGRPC:
+		return NewGRPCProjectConfig()
+	case TypeEventDriven:
+		return NewEventDrivenProjectConfig()
+	case TypeBatch:
+		return NewBatchProjectConfig()
+	case TypeCrawler:
+		return NewCrawlerProjectConfig()
+	case TypeWorker:
+		return NewWorkerProjectConfig()
+	case TypeTUI:
+		return NewTUIProjectConfig()
+	case TypeSDK:
+		return NewSDKProjectConfig()
+	case TypeScript:
+		return NewScriptProjectConfig()
 	default:
 		return NewDefaultProjectConfig()
 	}
 }
 
-// LoadConfigFromFile loads a project configuration from a YAML file
+// LoadConfigFromFile loads a project configuration from a YAML file. Both the
+// flat format and the sectioned format of generated gogo.yaml files are accepted.
+// A file may extend a base config, given as a path relative to the file or as
+// a URL, with the extends key; its values are deep-merged over the base.
 func LoadConfigFromFile(filePath string) (*ProjectConfig, error) {
-	data, err := os.ReadFile(filePath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read config file: %v", err)
-	}
-
-	var cfg ProjectConfig
-	if err := yaml.Unmarshal(data, &cfg); err != nil {
-		return nil, fmt.Errorf("failed to parse config file: %v", err)
-	}
-
-	return &cfg, nil
+	return loadConfig(filePath, false)
 }
 
 // SaveConfigToFile saves a project configuration to a YAML file
@@ -1,11 +1,16 @@
 package config
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
 )
 
 func TestLoadConfigFromFile(t *testing.T) {
@@ -55,6 +60,7 @@ use_viper: [broken, array, syntax
 	assert.NoError(t, err)
 	assert.Equal(t, "test-project", cfg.Name)
 	assert.Equal(t, TypeCLI, cfg.Type)
+	assert.Equal(t, []Author{{Name: "Test Author"}}, cfg.Authors)
 	assert.True(t, cfg.UseCobra)
 	assert.True(t, cfg.UseViper)
 
@@ -109,6 +115,12 @@ func TestProjectTypeConfig(t *testing.T) {
 	assert.Equal(t, TypeLibrary, libCfg.Type)
 	assert.False(t, libCfg.UseCmd)
 
+	// Test GitHub Action config
+	actionCfg := GetProjectConfigForType(TypeGitHubAction)
+	assert.Equal(t, TypeGitHubAction, actionCfg.Type)
+	assert.Equal(t, ActionRuntimeDocker, actionCfg.ActionRuntime)
+	assert.False(t, actionCfg.UseCmd)
+
 	// Test GetProjectConfigForType
 	defaultCfg := GetProjectConfigForType(TypeDefault)
 	assert.Equal(t, TypeDefault, defaultCfg.Type)
@@ -118,3 +130,471 @@ func TestProjectTypeConfig(t *testing.T) {
 	unknownCfg := GetProjectConfigForType(unknownType)
 	assert.Equal(t, TypeDefault, unknownCfg.Type)
 }
+
+func TestProjectFileRoundTrip(t *testing.T) {
+	cfg := NewAPIProjectConfig()
+	cfg.Name = "orders"
+	cfg.Module = "github.com/acme/orders"
+	cfg.UseDocs = false
+	cfg.Owner = "team-orders"
+	cfg.MetadataFiles = []MetadataFile{{Path: "service.yaml", Fields: map[string]interface{}{"name": "{{ .Name }}"}}}
+	cfg.Packages = []string{".", "internal/strutil"}
+	cfg.Topics = []string{"orders", "event-driven"}
+
+	path := filepath.Join(t.TempDir(), ProjectFileName)
+	assert.NoError(t, SaveProjectFile(cfg, path))
+
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "project:\n  name: \"orders\"")
+
+	// Sectioned files load like flat ones
+	loaded, err := LoadConfigFromFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, cfg, loaded)
+
+	cfg.Authors = []Author{{Name: "Doe, Jane", Email: "jane@acme.test"}, {Name: "John Roe"}}
+	assert.NoError(t, SaveProjectFile(cfg, path))
+	loaded, err = LoadConfigFromFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, cfg.Authors, loaded.Authors)
+}
+
+func TestLoadConfigExtends(t *testing.T) {
+	dir := t.TempDir()
+	base := NewAPIProjectConfig()
+	base.Name = "base"
+	base.License = "Apache-2.0"
+	base.Owner = "platform"
+	base.Packages = []string{"internal/a", "internal/b"}
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "org"), 0755))
+	assert.NoError(t, SaveProjectFile(base, filepath.Join(dir, "org", "base.yaml")))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "org", "team.yaml"),
+		[]byte("extends: base.yaml\nowner: payments\nquality:\n  use_linters: false\n"), 0600))
+	path := filepath.Join(dir, "gogo.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte("extends: org/team.yaml\nname: orders\npackages: [internal/c]\n"), 0600))
+
+	cfg, err := LoadConfigFromFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "orders", cfg.Name)
+	assert.Equal(t, "payments", cfg.Owner)
+	assert.Equal(t, "Apache-2.0", cfg.License)
+	assert.Equal(t, TypeAPI, cfg.Type)
+	assert.True(t, cfg.UseGin)
+	assert.False(t, cfg.UseLinters)
+	// Lists are replaced rather than appended to
+	assert.Equal(t, []string{"internal/c"}, cfg.Packages)
+
+	// Cycles are reported
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "org", "base.yaml"), []byte("extends: ../gogo.yaml\n"), 0600))
+	_, err = LoadConfigFromFile(path)
+	assert.ErrorContains(t, err, "extends itself")
+}
+
+func TestLoadConfigExtendsRemote(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/configs/team.yaml":
+			_, _ = w.Write([]byte("extends: base.yaml\nowner: payments\n"))
+		case "/configs/base.yaml":
+			_, _ = w.Write([]byte("type: cli\nlicense: MIT\nowner: platform\n"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	path := filepath.Join(t.TempDir(), "gogo.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte("extends: "+srv.URL+"/configs/team.yaml\nname: tool\n"), 0600))
+
+	cfg, err := LoadConfigFromFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "tool", cfg.Name)
+	assert.Equal(t, "payments", cfg.Owner)
+	assert.Equal(t, "MIT", cfg.License)
+
+	// The fetched configs are cached for offline use
+	srv.Close()
+	cfg, err = LoadConfigFromFileCached(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "MIT", cfg.License)
+
+	assert.NoError(t, os.WriteFile(path, []byte("extends: "+srv.URL+"/configs/other.yaml\n"), 0600))
+	_, err = LoadConfigFromFileCached(path)
+	assert.ErrorIs(t, err, ErrBaseNotCached)
+}
+
+func TestValidatePackages(t *testing.T) {
+	assert.NoError(t, ValidatePackages(nil))
+	assert.NoError(t, ValidatePackages([]string{".", "internal/strutil", "codec/v2"}))
+
+	tests := map[string][]string{
+		"twice":     {"codec", "codec"},
+		"empty":     {""},
+		"unclean":   {"internal/../codec"},
+		"absolute":  {"/codec"},
+		"parent":    {"../codec"},
+		"uppercase": {"Codec"},
+		"dash":      {"str-util"},
+	}
+	for name, pkgs := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Error(t, ValidatePackages(pkgs))
+		})
+	}
+}
+
+func TestValidateTopics(t *testing.T) {
+	assert.NoError(t, ValidateTopics(nil))
+	assert.NoError(t, ValidateTopics([]string{"payments", "event-driven", "k8s"}))
+
+	tests := map[string][]string{
+		"twice":     {"payments", "payments"},
+		"empty":     {""},
+		"uppercase": {"Payments"},
+		"space":     {"event driven"},
+		"hyphen":    {"-payments"},
+		"long":      {strings.Repeat("a", 51)},
+		"too many":  strings.Split("a b c d e f g h i j k l m n o p q r s t u", " "),
+	}
+	for name, topics := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Error(t, ValidateTopics(topics))
+		})
+	}
+}
+
+func TestValidateBranch(t *testing.T) {
+	for _, branch := range []string{"", "main", "trunk", "release/v2", "dev_1.x", "x"} {
+		assert.NoError(t, ValidateBranch(branch), branch)
+	}
+	for _, branch := range []string{"-main", "main/", "/main", "release//v2", "a..b", "main.lock", "my branch", "main]", "feat:x"} {
+		assert.Error(t, ValidateBranch(branch), branch)
+	}
+	assert.Equal(t, "main", (&ProjectConfig{}).Branch())
+	assert.Equal(t, "trunk", (&ProjectConfig{DefaultBranch: "trunk"}).Branch())
+}
+
+func TestValidateLayout(t *testing.T) {
+	assert.NoError(t, ValidateLayout(nil))
+	assert.NoError(t, ValidateLayout(map[string]string{"internal": "app", "cmd": "cmd", "scripts": "tools"}))
+
+	tests := map[string]map[string]string{
+		"unknown":      {"src": "source"},
+		"empty":        {"internal": ""},
+		"unclean":      {"internal": "app/../lib"},
+		"absolute":     {"internal": "/app"},
+		"parent":       {"internal": "../app"},
+		"current":      {"internal": "."},
+		"conventional": {"internal": "pkg/private"},
+		"twice":        {"internal": "app", "pkg": "app"},
+	}
+	for name, layout := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Error(t, ValidateLayout(layout))
+		})
+	}
+}
+
+func TestRelayoutText(t *testing.T) {
+	cfg := &ProjectConfig{
+		Module: "github.com/acme/todo",
+		Layout: map[string]string{"internal": "app", "cmd": "commands"},
+	}
+	assert.Equal(t, "app", cfg.Dir("internal"))
+	assert.Equal(t, "pkg", cfg.Dir("pkg"))
+	assert.Equal(t, "commands/todo/main.go", cfg.LayoutPath("cmd/todo/main.go"))
+	assert.Equal(t, "go.mod", cfg.LayoutPath("go.mod"))
+
+	tests := map[string]string{
+		`"github.com/acme/todo/internal/api"`:   `"github.com/acme/todo/app/api"`,
+		`"github.com/acme/todo/internal"`:       `"github.com/acme/todo/app"`,
+		"go build ./cmd/todo":                   "go build ./commands/todo",
+		"internal/api/server.go registers":      "app/api/server.go registers",
+		"- `internal/`: private packages":       "- `app/`: private packages",
+		"github.com/spf13/cobra/cmd/root.go":    "github.com/spf13/cobra/cmd/root.go",
+		"commands/todo/cmd/root.go":             "commands/todo/cmd/root.go",
+		"go test ./internal/... ./pkg/...":      "go test ./app/... ./pkg/...",
+		"the internal package and subcmd/files": "the internal package and subcmd/files",
+	}
+	for in, want := range tests {
+		assert.Equal(t, want, cfg.RelayoutText(in), in)
+	}
+}
+
+func TestValidateOperatorAPI(t *testing.T) {
+	assert.NoError(t, ValidateOperatorAPI("", ""))
+	assert.NoError(t, ValidateOperatorAPI("cache.example.com", "Memcached"))
+	assert.Error(t, ValidateOperatorAPI("cache", ""))
+	assert.Error(t, ValidateOperatorAPI("Cache.example.com", ""))
+	assert.Error(t, ValidateOperatorAPI("cache..example.com", ""))
+	assert.Error(t, ValidateOperatorAPI("", "memcached"))
+	assert.Error(t, ValidateOperatorAPI("", "Mem-cached"))
+}
+
+func TestModuleFromRemote(t *testing.T) {
+	tests := map[string]string{
+		"git@github.com:acme/billing.git":             "github.com/acme/billing",
+		"https://github.com/acme/billing.git":         "github.com/acme/billing",
+		"https://github.com/acme/billing/":            "github.com/acme/billing",
+		"ssh://git@GitLab.example.com:2222/a/b/c.git": "gitlab.example.com/a/b/c",
+		"gitlab.example.com:team/tool\n":              "gitlab.example.com/team/tool",
+		"/srv/git/billing.git":                        "",
+		"file:///srv/git/billing.git":                 "",
+		"../billing":                                  "",
+		"localhost:billing.git":                       "",
+		"":                                            "",
+	}
+	for remote, want := range tests {
+		assert.Equal(t, want, ModuleFromRemote(remote), remote)
+	}
+}
+
+func TestASCIIName(t *testing.T) {
+	tests := map[string]string{
+		"billing":        "billing",
+		"Café Crème":     "Cafe-Creme",
+		"straße_tool":    "strasse_tool",
+		"Łódź  Øresund!": "Lodz-Oresund",
+		"naïve.v2":       "naive.v2",
+		"-señor-":        "senor",
+	}
+	for name, want := range tests {
+		got, err := ASCIIName(name)
+		assert.NoError(t, err, name)
+		assert.Equal(t, want, got, name)
+	}
+
+	_, err := ASCIIName("日本語")
+	assert.Error(t, err)
+}
+
+func TestASCIIModule(t *testing.T) {
+	tests := map[string]string{
+		"github.com/acme/billing":    "github.com/acme/billing",
+		"bücher.example/tool":        "xn--bcher-kva.example/tool",
+		"MÜNCHEN.example/café/v2":    "xn--mnchen-3ya.example/cafe/v2",
+		"github.com/acme/Café Crème": "github.com/acme/Cafe-Creme",
+		"tool":                       "tool",
+	}
+	for module, want := range tests {
+		got, err := ASCIIModule(module)
+		assert.NoError(t, err, module)
+		assert.Equal(t, want, got, module)
+	}
+
+	_, err := ASCIIModule("github.com/acme/日本語")
+	assert.Error(t, err)
+}
+
+func TestValidateModule(t *testing.T) {
+	for _, module := range []string{"github.com/acme/billing", "tool", "example.com/Acme/my_tool.v2", "gopkg.in/yaml.v3"} {
+		assert.NoError(t, ValidateModule(module), module)
+	}
+	for _, module := range []string{"", "github.com/acme/", "/acme", "github.com//billing", "github.com/acme/bill ing",
+		"github.com/./billing", "github.com/acme/.hidden", "GitHub.com/acme/billing", "github.com/acme/billing?x"} {
+		assert.Error(t, ValidateModule(module), module)
+	}
+}
+
+func TestParseAuthor(t *testing.T) {
+	tests := map[string][2]string{
+		"":                             {"", ""},
+		"Jane Doe":                     {"Jane Doe", ""},
+		"Jane Doe <jane@example.com>":  {"Jane Doe", "jane@example.com"},
+		" jane@example.com ":           {"", "jane@example.com"},
+		`"Doe, Jane" <jane@acme.test>`: {"Doe, Jane", "jane@acme.test"},
+	}
+	for author, want := range tests {
+		a, err := ParseAuthor(author)
+		assert.NoError(t, err, author)
+		assert.Equal(t, want, [2]string{a.Name, a.Email}, author)
+	}
+	for _, author := range []string{"Jane <jane>", "Jane <jane@example.com", "Jane @ Acme"} {
+		_, err := ParseAuthor(author)
+		assert.Error(t, err, author)
+	}
+}
+
+func TestParseAuthors(t *testing.T) {
+	authors, err := ParseAuthors(`Jane Doe <jane@example.com>, "Roe, John" <john@acme.test>, Ann,`)
+	assert.NoError(t, err)
+	want := []Author{{Name: "Jane Doe", Email: "jane@example.com"}, {Name: "Roe, John", Email: "john@acme.test"}, {Name: "Ann"}}
+	assert.Equal(t, want, authors)
+
+	again, err := ParseAuthors(FormatAuthors(authors))
+	assert.NoError(t, err)
+	assert.Equal(t, want, again, "FormatAuthors does not read back")
+
+	_, err = ParseAuthors("Jane, Jane <jane>")
+	assert.Error(t, err)
+}
+
+func TestAuthorNames(t *testing.T) {
+	tests := []struct {
+		authors []Author
+		want    string
+	}{
+		{nil, ""},
+		{[]Author{{Name: "Jane"}}, "Jane"},
+		{[]Author{{Name: "Jane"}, {Email: "john@acme.test"}}, "Jane and john@acme.test"},
+		{[]Author{{Name: "Jane"}, {Name: "John"}, {Name: "Ann"}}, "Jane, John, and Ann"},
+	}
+	for _, tt := range tests {
+		cfg := &ProjectConfig{Authors: tt.authors}
+		assert.Equal(t, tt.want, cfg.AuthorNames())
+	}
+}
+
+func TestLegacyAuthor(t *testing.T) {
+	want := []Author{{Name: "Jane Doe", Email: "jane@example.com"}, {Name: "John Roe"}}
+
+	var fromYAML ProjectConfig
+	assert.NoError(t, yaml.Unmarshal([]byte("name: x\nauthor: Jane Doe <jane@example.com>, John Roe\n"), &fromYAML))
+	assert.Equal(t, "x", fromYAML.Name)
+	assert.Equal(t, want, fromYAML.Authors)
+
+	var fromJSON ProjectConfig
+	assert.NoError(t, json.Unmarshal([]byte(`{"name":"x","author":"Jane Doe <jane@example.com>, John Roe"}`), &fromJSON))
+	assert.Equal(t, "x", fromJSON.Name)
+	assert.Equal(t, want, fromJSON.Authors)
+
+	var current ProjectConfig
+	assert.NoError(t, yaml.Unmarshal([]byte("authors:\n  - name: Jane Doe\n    email: jane@example.com\n  - name: John Roe\n"), &current))
+	assert.Equal(t, want, current.Authors)
+
+	var invalid ProjectConfig
+	assert.Error(t, yaml.Unmarshal([]byte("author: Jane <jane>\n"), &invalid))
+}
+
+func TestIsValidLicense(t *testing.T) {
+	assert.True(t, IsValidLicense(""))
+	assert.True(t, IsValidLicense("Apache-2.0"))
+	assert.True(t, IsValidLicense(LicenseNone))
+	assert.False(t, IsValidLicense("WTFPL"))
+}
+
+func TestNormalizeNames(t *testing.T) {
+	cfg := NewDefaultProjectConfig()
+	cfg.Name = "Café"
+	cfg.Module = "bücher.example/café"
+	assert.NoError(t, cfg.NormalizeNames())
+	assert.Equal(t, "Cafe", cfg.Name)
+	assert.Equal(t, "Café", cfg.DisplayName)
+	assert.Equal(t, "xn--bcher-kva.example/cafe", cfg.Module)
+
+	// An explicit display name and an ASCII name are kept
+	cfg = NewDefaultProjectConfig()
+	cfg.Name, cfg.DisplayName = "crème", "Crème Brûlée"
+	assert.NoError(t, cfg.NormalizeNames())
+	assert.Equal(t, "creme", cfg.Name)
+	assert.Equal(t, "Crème Brûlée", cfg.DisplayName)
+}
+
+func TestApplyRules(t *testing.T) {
+	rules := []Rule{
+		{When: map[string]interface{}{"type": "api"}, Set: map[string]interface{}{"use_pprof": true, "secrets_manager": "vault"}},
+		{When: map[string]interface{}{"type": []interface{}{"cli", "api"}, "use_pprof": true}, Set: map[string]interface{}{"use_live_reload": true}},
+		{When: map[string]interface{}{"secrets_manager": ""}, Set: map[string]interface{}{"use_telemetry": true}},
+	}
+
+	// Later rules see the values set by earlier ones
+	cfg := NewAPIProjectConfig()
+	changed, err := ApplyRules(cfg, rules, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"secrets_manager", "use_live_reload", "use_pprof"}, changed)
+	assert.True(t, cfg.UseLiveReload)
+	assert.False(t, cfg.UseTelemetry)
+
+	// Unset fields compare as empty, and kept fields are left alone
+	cfg = NewCLIProjectConfig()
+	changed, err = ApplyRules(cfg, rules, map[string]bool{"use_telemetry": true})
+	assert.NoError(t, err)
+	assert.Empty(t, changed)
+	assert.False(t, cfg.UseLiveReload)
+	assert.False(t, cfg.UseTelemetry)
+
+	_, err = ApplyRules(cfg, []Rule{{Set: map[string]interface{}{"use_otel": true}}}, nil)
+	assert.ErrorContains(t, err, `unknown field "use_otel"`)
+	_, err = ApplyRules(cfg, []Rule{{When: map[string]interface{}{"type": "api"}}}, nil)
+	assert.ErrorContains(t, err, "sets no fields")
+}
+
+func TestApplyTypeDefaults(t *testing.T) {
+	assert.NoError(t, ValidateRules(TypeRules))
+
+	// Changing the type of a config gives the defaults of the new type,
+	// whatever the previous type set
+	for _, from := range ProjectTypes {
+		for _, to := range ProjectTypes {
+			cfg := GetProjectConfigForType(from)
+			cfg.Type = to
+			ApplyTypeDefaults(cfg)
+			assert.Equal(t, GetProjectConfigForType(to), cfg, "%s -> %s", from, to)
+		}
+	}
+
+	cfg := NewLibraryProjectConfig()
+	assert.False(t, cfg.UseCmd)
+	cfg.Type = TypeCLI
+	ApplyTypeDefaults(cfg)
+	assert.True(t, cfg.UseCmd)
+	assert.True(t, cfg.UseCobra)
+	assert.False(t, cfg.UseAPIDiff)
+
+	// Fields the type rules do not set are left alone
+	cfg.UseLiveReload = true
+	cfg.Type = TypeAPI
+	ApplyTypeDefaults(cfg)
+	assert.True(t, cfg.UseLiveReload)
+	assert.False(t, cfg.UseCobra)
+	assert.True(t, cfg.UseGin)
+}
+
+func TestResolveProfile(t *testing.T) {
+	profiles := map[string]Profile{
+		"platform": {Team: "platform", SlackChannel: "#platform", OnCall: "https://example.pagerduty.com/schedules/P1"},
+		"payments": {Extends: "platform", Team: "payments", SlackChannel: "#payments", Rules: []Rule{{Set: map[string]interface{}{"use_gin": true}}}},
+		"refunds":  {Extends: "payments", Team: "refunds", Authors: []Author{{Name: "Refunds", Email: "refunds@acme.test"}}},
+		"disputes": {Extends: "refunds"},
+		"ledger":   {Extends: "payments", Rules: []Rule{{Set: map[string]interface{}{"use_pprof": true}}}},
+		"loop":     {Extends: "loop"},
+		"orphan":   {Extends: "missing"},
+	}
+
+	p, err := ResolveProfile(profiles, "platform")
+	assert.NoError(t, err)
+	assert.Equal(t, profiles["platform"], p)
+
+	p, err = ResolveProfile(profiles, "ledger")
+	assert.NoError(t, err)
+	assert.Equal(t, "payments", p.Team)
+	// The rules of the base come first
+	assert.Equal(t, []Rule{{Set: map[string]interface{}{"use_gin": true}}, {Set: map[string]interface{}{"use_pprof": true}}}, p.Rules)
+
+	p, err = ResolveProfile(profiles, "disputes")
+	assert.NoError(t, err)
+	assert.Equal(t, profiles["refunds"].Authors, p.Authors)
+
+	_, err = ResolveProfile(profiles, "loop")
+	assert.ErrorContains(t, err, "extends itself")
+	_, err = ResolveProfile(profiles, "orphan")
+	assert.ErrorContains(t, err, `unknown profile "missing"`)
+	_, err = ResolveProfile(profiles, "nobody")
+	assert.ErrorContains(t, err, `unknown profile "nobody"`)
+}
+
+func TestProfileApply(t *testing.T) {
+	p := Profile{Team: "platform", SlackChannel: "#platform", OnCall: "https://example.pagerduty.com/schedules/P1",
+		Authors: []Author{{Name: "Platform", Email: "platform@acme.test"}}}
+
+	cfg := NewDefaultProjectConfig()
+	cfg.Team = "payments"
+	p.Apply(cfg)
+	assert.Equal(t, p.Authors, cfg.Authors)
+
+	// Values set by the project win over the profile
+	assert.Equal(t, "payments", cfg.Team)
+	assert.Equal(t, "#platform", cfg.SlackChannel)
+	assert.Equal(t, "https://example.pagerduty.com/schedules/P1", cfg.OnCall)
+}
@@ -0,0 +1,231 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrBaseNotCached is returned in offline mode when a remote base config was
+// never fetched
+var ErrBaseNotCached = errors.New("base config is not cached")
+
+// baseClient fetches remote base configs
+var baseClient = &http.Client{Timeout: 30 * time.Second}
+
+// LoadConfigFromFileCached is like LoadConfigFromFile but never accesses the
+// network. Remote base configs are read from the cache and fail with
+// ErrBaseNotCached when they were never fetched.
+func LoadConfigFromFileCached(filePath string) (*ProjectConfig, error) {
+	return loadConfig(filePath, true)
+}
+
+func loadConfig(filePath string, offline bool) (*ProjectConfig, error) {
+	doc, err := loadConfigDoc(filePath, offline, nil)
+	if err != nil {
+		return nil, err
+	}
+	data, err := yaml.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %v", err)
+	}
+
+	var cfg ProjectConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %v", err)
+	}
+	return &cfg, nil
+}
+
+// loadConfigDoc reads the config at source, a local path or a URL, with its
+// sections flattened and the base configs it extends merged beneath it.
+// chain lists the sources that extend it, to detect cycles.
+func loadConfigDoc(source string, offline bool, chain []string) (map[string]interface{}, error) {
+	for _, s := range chain {
+		if s == source {
+			return nil, fmt.Errorf("config %s extends itself: %s", source, strings.Join(append(chain, source), " -> "))
+		}
+	}
+	chain = append(chain, source)
+
+	data, err := readConfigSource(source, offline)
+	if err != nil {
+		return nil, err
+	}
+	data, err = flattenSections(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %v", source, err)
+	}
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %v", source, err)
+	}
+	if doc == nil {
+		doc = map[string]interface{}{}
+	}
+
+	extends, ok := doc["extends"]
+	if !ok {
+		return doc, nil
+	}
+	delete(doc, "extends")
+	parent, ok := extends.(string)
+	if !ok || parent == "" {
+		return nil, fmt.Errorf("invalid extends in %s: want the path or URL of a base config", source)
+	}
+	base, err := loadConfigDoc(resolveBase(source, parent), offline, chain)
+	if err != nil {
+		return nil, err
+	}
+	return mergeConfigDocs(base, doc), nil
+}
+
+// ConfigFileFields returns the fields set by a config file and the base
+// configs it extends, reading remote bases from the cache filled by
+// LoadConfigFromFile
+func ConfigFileFields(filePath string) (map[string]bool, error) {
+	doc, err := loadConfigDoc(filePath, true, nil)
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string]bool, len(doc))
+	for field := range doc {
+		fields[field] = true
+	}
+	return fields, nil
+}
+
+// mergeConfigDocs merges the keys of override into base: nested maps are
+// merged key by key, while lists and other values are replaced
+func mergeConfigDocs(base, override map[string]interface{}) map[string]interface{} {
+	for k, v := range override {
+		baseMap, baseOK := base[k].(map[string]interface{})
+		overrideMap, overrideOK := v.(map[string]interface{})
+		if baseOK && overrideOK {
+			base[k] = mergeConfigDocs(baseMap, overrideMap)
+			continue
+		}
+		base[k] = v
+	}
+	return base
+}
+
+// isRemoteConfig reports whether source is fetched over HTTP: a URL, or a path
+// such as github.com/acme/gogo-base.yaml that starts with a host name and is
+// not a local file
+func isRemoteConfig(source string) bool {
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		return true
+	}
+	if _, err := os.Stat(source); err == nil || filepath.IsAbs(source) {
+		return false
+	}
+	host, _, found := strings.Cut(source, "/")
+	return found && strings.Contains(host, ".") && !strings.HasPrefix(host, ".")
+}
+
+// configURL returns the URL a remote config is fetched from
+func configURL(source string) string {
+	if strings.Contains(source, "://") {
+		return source
+	}
+	return "https://" + source
+}
+
+// resolveBase returns the source of the base config that child extends. A
+// relative path is relative to the directory or URL of child.
+func resolveBase(child, parent string) string {
+	if isRemoteConfig(parent) {
+		return parent
+	}
+	if isRemoteConfig(child) {
+		base, err := url.Parse(configURL(child))
+		if err != nil {
+			return parent
+		}
+		ref, err := url.Parse(filepath.ToSlash(parent))
+		if err != nil {
+			return parent
+		}
+		return base.ResolveReference(ref).String()
+	}
+	if strings.HasPrefix(parent, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, parent[2:])
+		}
+	}
+	if filepath.IsAbs(parent) {
+		return parent
+	}
+	return filepath.Join(filepath.Dir(child), parent)
+}
+
+// readConfigSource reads a local config file or fetches a remote one
+func readConfigSource(source string, offline bool) ([]byte, error) {
+	if !isRemoteConfig(source) {
+		data, err := os.ReadFile(source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read config file: %v", err)
+		}
+		return data, nil
+	}
+
+	cache, err := BaseCachePath(source)
+	if err != nil {
+		return nil, err
+	}
+	if offline {
+		data, err := os.ReadFile(cache)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrBaseNotCached, source)
+		}
+		return data, nil
+	}
+
+	data, fetchErr := fetchConfig(configURL(source))
+	if fetchErr == nil {
+		if err := os.MkdirAll(filepath.Dir(cache), 0755); err != nil {
+			return nil, fmt.Errorf("failed to cache base config: %v", err)
+		}
+		if err := os.WriteFile(cache, data, 0600); err != nil {
+			return nil, fmt.Errorf("failed to cache base config: %v", err)
+		}
+		return data, nil
+	}
+	if data, err := os.ReadFile(cache); err == nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not update base config %s, using cached copy\n", source)
+		return data, nil
+	}
+	return nil, fmt.Errorf("failed to fetch base config %s: %v", source, fetchErr)
+}
+
+func fetchConfig(rawURL string) ([]byte, error) {
+	resp, err := baseClient.Get(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// BaseCachePath returns the file where a remote base config is cached
+func BaseCachePath(source string) (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate cache directory: %w", err)
+	}
+
+	replacer := strings.NewReplacer("://", "_", "/", "_", ":", "_", "@", "_", "?", "_")
+	return filepath.Join(base, "gogo", "configs", replacer.Replace(source)), nil
+}
@@ -0,0 +1,113 @@
+package config
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// LayoutDirs are the conventional directories of generated projects that
+// Layout can move, such as internal to app
+var LayoutDirs = []string{"cmd", "internal", "pkg", "test", "docs", "scripts"}
+
+// ValidateLayout checks that layout maps conventional directories to clean
+// relative paths that are neither another conventional directory nor the
+// target of another entry
+func ValidateLayout(layout map[string]string) error {
+	conventional := map[string]bool{}
+	for _, dir := range LayoutDirs {
+		conventional[dir] = true
+	}
+
+	seen := map[string]string{}
+	for _, dir := range sortedKeys(layout) {
+		target := layout[dir]
+		if !conventional[dir] {
+			return fmt.Errorf("layout moves unknown directory %q: use %s", dir, strings.Join(LayoutDirs, ", "))
+		}
+		if target == "" || path.Clean(target) != target || path.IsAbs(target) ||
+			target == "." || target == ".." || strings.HasPrefix(target, "../") {
+			return fmt.Errorf("invalid layout directory %q for %s: use a clean path inside the project", target, dir)
+		}
+		first, _, _ := strings.Cut(target, "/")
+		if first != dir && conventional[first] {
+			return fmt.Errorf("layout directory %q for %s is inside the conventional directory %s", target, dir, first)
+		}
+		if other, ok := seen[target]; ok {
+			return fmt.Errorf("layout moves both %s and %s to %q", other, dir, target)
+		}
+		seen[target] = dir
+	}
+	return nil
+}
+
+// Dir returns the directory of the project that takes the place of the
+// conventional directory name, such as "app" for "internal"
+func (cfg *ProjectConfig) Dir(name string) string {
+	if dir, ok := cfg.Layout[name]; ok {
+		return dir
+	}
+	return name
+}
+
+// LayoutPath returns the path of the project that takes the place of rel, a
+// slash-separated path relative to the project in the conventional layout
+func (cfg *ProjectConfig) LayoutPath(rel string) string {
+	first, rest, found := strings.Cut(rel, "/")
+	if !found {
+		return cfg.Dir(first)
+	}
+	return cfg.Dir(first) + "/" + rest
+}
+
+// moved returns the conventional directories the layout moves, sorted
+func (cfg *ProjectConfig) moved() []string {
+	var dirs []string
+	for _, dir := range sortedKeys(cfg.Layout) {
+		if cfg.Layout[dir] != dir {
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
+
+// RelayoutText rewrites the references of generated content to the moved
+// directories: import paths below the module, such as
+// github.com/acme/todo/internal/api, and relative paths that start with them,
+// such as ./cmd/todo or internal/api/server.go
+func (cfg *ProjectConfig) RelayoutText(content string) string {
+	dirs := cfg.moved()
+	if len(dirs) == 0 {
+		return content
+	}
+
+	var pairs []string
+	quoted := make([]string, 0, len(dirs))
+	for _, dir := range dirs {
+		pairs = append(pairs,
+			cfg.Module+"/"+dir+"/", cfg.Module+"/"+cfg.Layout[dir]+"/",
+			cfg.Module+"/"+dir+`"`, cfg.Module+"/"+cfg.Layout[dir]+`"`)
+		quoted = append(quoted, regexp.QuoteMeta(dir))
+	}
+	content = strings.NewReplacer(pairs...).Replace(content)
+
+	// A relative path starts a line or follows a character that cannot be
+	// part of a longer path, optionally with ./
+	relative := regexp.MustCompile(`(?m)(^|[^\w./-])(\./)?(` + strings.Join(quoted, "|") + `)/`)
+	return relative.ReplaceAllStringFunc(content, func(match string) string {
+		m := relative.FindStringSubmatch(match)
+		return m[1] + m[2] + cfg.Layout[m[3]] + "/"
+	})
+}
+
+// sortedKeys returns the keys of m in order
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,137 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/net/idna"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// spelledOut maps the letters that do not decompose into an ASCII letter and
+// diacritics to their usual ASCII spelling
+var spelledOut = strings.NewReplacer(
+	"ß", "ss", "ẞ", "SS",
+	"æ", "ae", "Æ", "AE",
+	"œ", "oe", "Œ", "OE",
+	"ø", "o", "Ø", "O",
+	"ł", "l", "Ł", "L",
+	"đ", "d", "Đ", "D",
+	"ð", "d", "Ð", "D",
+	"þ", "th", "Þ", "TH",
+	"ı", "i",
+)
+
+// transliterate spells the Latin letters of s in ASCII, dropping their
+// diacritics. Other characters are kept.
+func transliterate(s string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	out, _, err := transform.String(t, spelledOut.Replace(s))
+	if err != nil {
+		return s
+	}
+	return out
+}
+
+// asciiElement transliterates s and replaces the characters that are not
+// ASCII letters, digits, or one of allowed with dashes, collapsing them
+func asciiElement(s, allowed string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range transliterate(s) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune(allowed, r)) {
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+		if !dash {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.Trim(b.String(), "-.")
+}
+
+// ASCIIName returns name as the ASCII name of binaries and directories, such
+// as cafe-creme for "Café Crème": Latin letters lose their diacritics or are
+// spelled out, and other characters become dashes. It fails when no letter or
+// digit is left, as for names written in other scripts.
+func ASCIIName(name string) (string, error) {
+	ascii := asciiElement(name, "-_.")
+	if ascii == "" {
+		return "", fmt.Errorf("cannot derive an ASCII project name from %q: set name to an ASCII name and display_name to %q", name, name)
+	}
+	return ascii, nil
+}
+
+// ASCIIModule returns module as a valid module path: an internationalized
+// host, such as bücher.example, is converted to punycode (xn--bcher-kva.example),
+// and the other path elements are transliterated like ASCIIName
+func ASCIIModule(module string) (string, error) {
+	elems := strings.Split(module, "/")
+	for i, elem := range elems {
+		if i == 0 && strings.Contains(elem, ".") {
+			host, err := idna.Lookup.ToASCII(elem)
+			if err != nil {
+				return "", fmt.Errorf("invalid host %q in module %q: %v", elem, module, err)
+			}
+			elems[i] = host
+			continue
+		}
+		ascii := asciiElement(elem, "-_.~")
+		if ascii == "" {
+			return "", fmt.Errorf("cannot derive an ASCII module path from %q: %q has no ASCII letters or digits", module, elem)
+		}
+		elems[i] = ascii
+	}
+	return strings.Join(elems, "/"), nil
+}
+
+// moduleElementPattern matches an element of a module path: ASCII letters,
+// digits, and -._~, not starting or ending with a dot
+var moduleElementPattern = regexp.MustCompile(`^[A-Za-z0-9_~-]([A-Za-z0-9._~-]*[A-Za-z0-9_~-])?$`)
+
+// ValidateModule checks that module is a module path such as
+// github.com/acme/todo: slash-separated elements of ASCII letters, digits,
+// and -._~, the first of which is lowercase
+func ValidateModule(module string) error {
+	if module == "" {
+		return fmt.Errorf("module path is required")
+	}
+	elems := strings.Split(module, "/")
+	for _, elem := range elems {
+		if !moduleElementPattern.MatchString(elem) {
+			return fmt.Errorf("invalid module path %q: %q is not a valid path element; use letters, digits, and -._~ such as github.com/acme/todo", module, elem)
+		}
+	}
+	if elems[0] != strings.ToLower(elems[0]) {
+		return fmt.Errorf("invalid module path %q: the first element %q must be lowercase", module, elems[0])
+	}
+	return nil
+}
+
+// NormalizeNames makes the name and module of the config valid for binaries,
+// directories, and import paths. A name that changes is kept as the display
+// name of the README and documentation, unless one is set.
+func (c *ProjectConfig) NormalizeNames() error {
+	name, err := ASCIIName(c.Name)
+	if err != nil {
+		return err
+	}
+	if name != c.Name {
+		if c.DisplayName == "" {
+			c.DisplayName = c.Name
+		}
+		c.Name = name
+	}
+	if c.Module != "" {
+		if c.Module, err = ASCIIModule(c.Module); err != nil {
+			return err
+		}
+	}
+	return nil
+}
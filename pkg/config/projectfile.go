@@ -0,0 +1,273 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ProjectFileName is the name of the configuration file written to generated projects
+const ProjectFileName = "gogo.yaml"
+
+// projectFileSections are the top-level sections of a generated gogo.yaml
+var projectFileSections = []string{"project", "structure", "files", "catalog", "quality", "dependencies", "cicd"}
+
+// FormatProjectFile renders the configuration in the sectioned gogo.yaml format
+func FormatProjectFile(cfg *ProjectConfig) []byte {
+	content := fmt.Sprintf(`# Gogo Project Configuration
+# Generated on: %s
+
+# Project Information
+project:
+  name: %q
+  display_name: %q
+  module: %q
+  description: %q
+  license: %q
+%s%s  type: %q
+  visibility: %q
+  default_branch: %q
+  action_runtime: %q
+  operator_group: %q
+  operator_kind: %q
+  use_kubebuilder: %t
+  event_broker: %q
+
+# Project Structure
+structure:
+  use_cmd: %t
+  use_internal: %t
+  use_pkg: %t
+  use_test: %t
+  use_docs: %t
+  dir_placeholder: %q
+  use_examples: %t
+  docs_site: %q
+
+# Generated Files
+files:
+  create_readme: %t
+  create_license: %t
+  create_makefile: %t
+  create_catalog_info: %t
+  create_version_file: %t
+  create_package_docs: %t
+  use_adr: %t
+
+# Catalog
+catalog:
+  owner: %q
+  lifecycle: %q
+  team: %q
+  slack_channel: %q
+  on_call: %q
+
+# Code Quality
+quality:
+  use_linters: %t
+  use_pre_commit_hooks: %t
+  use_git_hooks: %t
+  formatting: %q
+  lint_preset: %q
+  use_checks: %t
+  commit_convention: %q
+  tool_version_manager: %q
+  secrets_manager: %q
+  use_live_reload: %t
+  use_pprof: %t
+
+# Dependencies
+dependencies:
+  use_cobra: %t
+  use_viper: %t
+  use_gin: %t
+  feature_flags: %q
+  request_validation: %q
+  pagination: %q
+  task_queue: %q
+  use_http_cache: %t
+  use_i18n: %t
+  use_multi_tenancy: %t
+  use_plugins: %t
+  use_notify: %t
+  use_crash_handler: %t
+  use_config_reload: %t
+  use_config_command: %t
+  use_self_update: %t
+  use_output: %t
+  prompt_library: %q
+  use_telemetry: %t
+
+# CI/CD
+cicd:
+  use_github_actions: %t
+  use_benchmarks: %t
+  use_apidiff: %t
+  use_docker: %t
+  docker_base: %q
+  container_registry: %q
+`,
+		time.Now().Format(time.RFC3339),
+		cfg.Name,
+		cfg.DisplayName,
+		cfg.Module,
+		cfg.Description,
+		cfg.License,
+		projectFileTopics(cfg.Topics),
+		projectFileAuthors(cfg.Authors),
+		cfg.Type,
+		cfg.Visibility,
+		cfg.DefaultBranch,
+		cfg.ActionRuntime,
+		cfg.OperatorGroup,
+		cfg.OperatorKind,
+		cfg.UseKubebuilder,
+		cfg.EventBroker,
+		cfg.UseCmd,
+		cfg.UseInternal,
+		cfg.UsePkg,
+		cfg.UseTest,
+		cfg.UseDocs,
+		cfg.DirPlaceholder,
+		cfg.UseExamples,
+		cfg.DocsSite,
+		cfg.CreateReadme,
+		cfg.CreateLicense,
+		cfg.CreateMakefile,
+		cfg.CreateCatalogInfo,
+		cfg.CreateVersionFile,
+		cfg.CreatePackageDocs,
+		cfg.UseADR,
+		cfg.Owner,
+		cfg.Lifecycle,
+		cfg.Team,
+		cfg.SlackChannel,
+		cfg.OnCall,
+		cfg.UseLinters,
+		cfg.UsePreCommitHooks,
+		cfg.UseGitHooks,
+		cfg.Formatting,
+		cfg.LintPreset,
+		cfg.UseChecks,
+		cfg.CommitConvention,
+		cfg.ToolVersionManager,
+		cfg.SecretsManager,
+		cfg.UseLiveReload,
+		cfg.UsePprof,
+		cfg.UseCobra,
+		cfg.UseViper,
+		cfg.UseGin,
+		cfg.FeatureFlags,
+		cfg.RequestValidation,
+		cfg.Pagination,
+		cfg.TaskQueue,
+		cfg.UseHTTPCache,
+		cfg.UseI18n,
+		cfg.UseMultiTenancy,
+		cfg.UsePlugins,
+		cfg.UseNotify,
+		cfg.UseCrashHandler,
+		cfg.UseConfigReload,
+		cfg.UseConfigCommand,
+		cfg.UseSelfUpdate,
+		cfg.UseOutput,
+		cfg.PromptLibrary,
+		cfg.UseTelemetry,
+		cfg.UseGitHubActions,
+		cfg.UseBenchmarks,
+		cfg.UseAPIDiff,
+		cfg.UseDocker,
+		cfg.DockerBase,
+		cfg.ContainerRegistry,
+	)
+
+	if len(cfg.Packages) > 0 {
+		data, err := yaml.Marshal(map[string]interface{}{"packages": cfg.Packages})
+		if err == nil {
+			content += "\n# Library Packages\n" + string(data)
+		}
+	}
+
+	if len(cfg.Layout) > 0 {
+		data, err := yaml.Marshal(map[string]interface{}{"layout": cfg.Layout})
+		if err == nil {
+			content += "\n# Directory Layout\n" + string(data)
+		}
+	}
+
+	if len(cfg.MetadataFiles) > 0 {
+		data, err := yaml.Marshal(map[string]interface{}{"metadata_files": cfg.MetadataFiles})
+		if err == nil {
+			content += "\n# Metadata Files\n" + string(data)
+		}
+	}
+
+	return []byte(content)
+}
+
+// projectFileTopics renders the topics entry of the project section, or
+// nothing without topics
+func projectFileTopics(topics []string) string {
+	if len(topics) == 0 {
+		return ""
+	}
+	quoted := make([]string, len(topics))
+	for i, t := range topics {
+		quoted[i] = strconv.Quote(t)
+	}
+	return "  topics: [" + strings.Join(quoted, ", ") + "]\n"
+}
+
+// projectFileAuthors renders the authors entry of the project section, or
+// nothing without authors
+func projectFileAuthors(authors []Author) string {
+	if len(authors) == 0 {
+		return ""
+	}
+	s := "  authors:\n"
+	for _, a := range authors {
+		s += fmt.Sprintf("    - name: %q\n", a.Name)
+		if a.Email != "" {
+			s += fmt.Sprintf("      email: %q\n", a.Email)
+		}
+	}
+	return s
+}
+
+// SaveProjectFile writes the configuration in the sectioned gogo.yaml format
+func SaveProjectFile(cfg *ProjectConfig, filePath string) error {
+	if err := os.WriteFile(filePath, FormatProjectFile(cfg), 0600); err != nil {
+		return fmt.Errorf("failed to write config file: %v", err)
+	}
+	return nil
+}
+
+// flattenSections moves the fields of the gogo.yaml sections to the top level,
+// so sectioned and flat files decode into the same ProjectConfig
+func flattenSections(data []byte) ([]byte, error) {
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	sectioned := false
+	for _, name := range projectFileSections {
+		section, ok := doc[name].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		sectioned = true
+		delete(doc, name)
+		for k, v := range section {
+			doc[k] = v
+		}
+	}
+	if !sectioned {
+		return data, nil
+	}
+	return yaml.Marshal(doc)
+}
@@ -0,0 +1,216 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Rule sets configuration values when the configuration matches its
+// conditions, such as "when type is api, set use_pprof to true". Keys of When
+// and Set use the YAML field names of ProjectConfig.
+type Rule struct {
+	// When maps fields to the value they must have for the rule to apply; a
+	// list matches any of its values. A rule without conditions always applies.
+	When map[string]interface{} `mapstructure:"when" yaml:"when,omitempty" json:"when,omitempty"`
+
+	// Set maps fields to the values they take when the rule applies
+	Set map[string]interface{} `mapstructure:"set" yaml:"set" json:"set"`
+}
+
+// TypeRules are the defaults of each project type, applied by
+// ApplyTypeDefaults
+var TypeRules = []Rule{
+	{When: map[string]interface{}{"type": TypeCLI}, Set: map[string]interface{}{
+		"use_cobra": true,
+		"use_viper": true,
+	}},
+	{When: map[string]interface{}{"type": TypeAPI}, Set: map[string]interface{}{
+		"use_gin":            true,
+		"request_validation": RequestValidationBinding,
+	}},
+	{When: map[string]interface{}{"type": TypeLibrary}, Set: map[string]interface{}{
+		"use_cmd":             false,
+		"use_benchmarks":      true,
+		"use_apidiff":         true,
+		"create_package_docs": true,
+	}},
+	{When: map[string]interface{}{"type": TypeGitHubAction}, Set: map[string]interface{}{
+		"action_runtime": ActionRuntimeDocker,
+		"use_cmd":        false,
+	}},
+	{When: map[string]interface{}{"type": TypeEventDriven}, Set: map[string]interface{}{
+		"event_broker": EventBrokerKafka,
+	}},
+	{When: map[string]interface{}{"type": TypeSDK}, Set: map[string]interface{}{
+		"use_cmd":             false,
+		"use_examples":        true,
+		"use_apidiff":         true,
+		"create_version_file": true,
+	}},
+	{When: map[string]interface{}{"type": []interface{}{TypeGitHubAction, TypeOperator, TypeGRPC, TypeEventDriven, TypeBatch, TypeCrawler, TypeWorker, TypeTUI, TypeSDK}}, Set: map[string]interface{}{
+		"use_pkg": false,
+	}},
+	{When: map[string]interface{}{"type": TypeScript}, Set: map[string]interface{}{
+		"use_cmd":              false,
+		"use_internal":         false,
+		"use_pkg":              false,
+		"use_test":             false,
+		"use_docs":             false,
+		"create_license":       false,
+		"create_makefile":      false,
+		"use_pre_commit_hooks": false,
+		"use_git_hooks":        false,
+		"use_github_actions":   false,
+	}},
+}
+
+// ApplyTypeDefaults sets the fields that depend on the project type to the
+// defaults of cfg.Type, as GetProjectConfigForType does. The fields set by
+// TypeRules first get the value of NewDefaultProjectConfig back, so that a
+// library that becomes a CLI gets its cmd directory again.
+func ApplyTypeDefaults(cfg *ProjectConfig) {
+	if _, err := ApplyRules(cfg, append([]Rule{typeFieldDefaults()}, TypeRules...), nil); err != nil {
+		// TypeRules only set known fields to values of their type
+		panic(err)
+	}
+}
+
+// typeFieldDefaults returns a rule that sets the fields set by TypeRules to
+// their value in NewDefaultProjectConfig
+func typeFieldDefaults() Rule {
+	defaults := reflect.ValueOf(NewDefaultProjectConfig()).Elem()
+	reset := Rule{Set: map[string]interface{}{}}
+	for i := 0; i < defaults.NumField(); i++ {
+		name, _, _ := strings.Cut(defaults.Type().Field(i).Tag.Get("yaml"), ",")
+		for _, r := range TypeRules {
+			if _, ok := r.Set[name]; ok {
+				reset.Set[name] = defaults.Field(i).Interface()
+			}
+		}
+	}
+	return reset
+}
+
+// ValidateRules checks that rules only reference configuration fields and
+// that each rule sets at least one
+func ValidateRules(rules []Rule) error {
+	known := FieldNames()
+	for i, r := range rules {
+		if len(r.Set) == 0 {
+			return fmt.Errorf("rule %d sets no fields", i+1)
+		}
+		for field := range r.When {
+			if !known[field] {
+				return fmt.Errorf("rule %d tests unknown field %q", i+1, field)
+			}
+		}
+		for field := range r.Set {
+			if !known[field] {
+				return fmt.Errorf("rule %d sets unknown field %q", i+1, field)
+			}
+		}
+	}
+	return nil
+}
+
+// ApplyRules applies the rules in order, each seeing the values set by the
+// previous ones, and returns the fields whose value changed. Fields in keep,
+// such as those given explicitly in a configuration file, are left alone.
+func ApplyRules(cfg *ProjectConfig, rules []Rule, keep map[string]bool) ([]string, error) {
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	if err := ValidateRules(rules); err != nil {
+		return nil, err
+	}
+
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %v", err)
+	}
+	values := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %v", err)
+	}
+
+	changed := map[string]bool{}
+	for _, r := range rules {
+		if !r.matches(values) {
+			continue
+		}
+		for field, v := range r.Set {
+			if keep[field] {
+				continue
+			}
+			if ruleValue(values[field]) != ruleValue(v) {
+				changed[field] = true
+			}
+			values[field] = v
+		}
+	}
+	if len(changed) == 0 {
+		return nil, nil
+	}
+
+	if data, err = yaml.Marshal(values); err != nil {
+		return nil, fmt.Errorf("failed to apply rules: %v", err)
+	}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to apply rules: %v", err)
+	}
+
+	fields := make([]string, 0, len(changed))
+	for field := range changed {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields, nil
+}
+
+// matches reports whether values satisfy all the conditions of the rule
+func (r Rule) matches(values map[string]interface{}) bool {
+	for field, want := range r.When {
+		got := ruleValue(values[field])
+		options, ok := want.([]interface{})
+		if !ok {
+			options = []interface{}{want}
+		}
+		found := false
+		for _, option := range options {
+			if ruleValue(option) == got {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// ruleValue returns v as compared by rules, with unset fields empty
+func ruleValue(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+// FieldNames returns the set of YAML field names of ProjectConfig, including
+// the omitempty ones that a marshaled config leaves out
+func FieldNames() map[string]bool {
+	t := reflect.TypeOf(ProjectConfig{})
+	names := make(map[string]bool, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("yaml"), ",")
+		if name != "" && name != "-" {
+			names[name] = true
+		}
+	}
+	return names
+}